@@ -32,6 +32,17 @@ type createProjectCmdParams struct {
 	GitProxyPassword  *string
 	GitPemCertificate *string
 	InsecureSkipTLS   *bool
+	StorageBackend    *string
+}
+
+// createProjectPath is the endpoint of the shipyard-controller used for creating projects with a storage backend,
+// which is not yet supported by the API set of go-utils
+const createProjectPath = "/controlPlane/v1/project"
+
+// createProjectWithStorageBackend extends the payload for creating a project with its storage backend
+type createProjectWithStorageBackend struct {
+	apimodels.CreateProject
+	StorageBackend string `json:"storageBackend"`
 }
 
 var createProjectParams *createProjectCmdParams
@@ -70,6 +81,9 @@ For using proxy please specify proxy IP address together with port (*--git-proxy
 used scheme (*--git-proxy-scheme=*) to connect to proxy. Please be aware that authentication with public/private key and via proxy is 
 supported only when using resource-service.
 
+The resources of a project are stored in Git by default. With *--storage-backend=objectstore*, they are stored in the
+object store of the resource-service instead. Such projects cannot have a Git upstream repository.
+
 For more information about Shipyard, creating projects, or upstream repositories, please go to [Manage Keptn](https://keptn.sh/docs/` + getReleaseDocsURL() + `/manage/)
 `,
 	Example: `keptn create project PROJECTNAME --shipyard=FILEPATH
@@ -82,6 +96,10 @@ keptn create project PROJECTNAME --shipyard=FILEPATH --git-user=GIT_USER --git-r
 or (only for resource-service)
 
 keptn create project PROJECTNAME --shipyard=FILEPATH --git-user=GIT_USER --git-remote-url=GIT_REMOTE_URL --git-token=GIT_TOKEN --git-proxy-url=PROXY_IP --git-proxy-scheme=SCHEME --git-proxy-user=PROXY_USER --git-proxy-password=PROXY_PASS --insecure-skip-tls
+
+or (only for resource-service with an object store)
+
+keptn create project PROJECTNAME --shipyard=FILEPATH --storage-backend=objectstore
 `,
 	SilenceUsage: true,
 	Args: func(cmd *cobra.Command, args []string) error {
@@ -106,7 +124,12 @@ keptn create project PROJECTNAME --shipyard=FILEPATH --git-user=GIT_USER --git-r
 			return fmt.Errorf("Failed to read and parse shipyard file - %s", err.Error())
 		}
 
-		if err := checkGitCredentials(); err != nil {
+		if isStringFlagSet(createProjectParams.StorageBackend) && *createProjectParams.StorageBackend != "git" {
+			if isStringFlagSet(createProjectParams.GitUser) || isStringFlagSet(createProjectParams.RemoteURL) ||
+				isStringFlagSet(createProjectParams.GitToken) || isStringFlagSet(createProjectParams.GitPrivateKey) {
+				return errors.New("A Git upstream repository can only be configured for projects stored in Git")
+			}
+		} else if err := checkGitCredentials(); err != nil {
 			return err
 		}
 
@@ -192,8 +215,12 @@ keptn create project PROJECTNAME --shipyard=FILEPATH --git-user=GIT_USER --git-r
 		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
 
 		if !mocking {
-			_, err := api.APIV1().CreateProject(project)
-			if err != nil {
+			if isStringFlagSet(createProjectParams.StorageBackend) {
+				payload := createProjectWithStorageBackend{CreateProject: project, StorageBackend: *createProjectParams.StorageBackend}
+				if err := internal.NewRESTClient(api).Post(createProjectPath, payload, nil); err != nil {
+					return fmt.Errorf("Create project was unsuccessful.\n%v", err)
+				}
+			} else if _, err := api.APIV1().CreateProject(project); err != nil {
 				return fmt.Errorf("Create project was unsuccessful.\n%s", *err.Message)
 			}
 
@@ -264,4 +291,6 @@ func init() {
 
 	createProjectParams.GitPemCertificate = crProjectCmd.Flags().StringP("git-pem-certificate", "g", "", "The git PEM Certificate file")
 
+	createProjectParams.StorageBackend = crProjectCmd.Flags().StringP("storage-backend", "", "", "The storage backend of the project resources, either git (default) or objectstore")
+
 }
@@ -109,6 +109,42 @@ func TestCreateProjectCmdWithoutGitUser(t *testing.T) {
 	}
 }
 
+// TestCreateProjectCmdWithStorageBackend tests a successful create project
+// command for a project stored in the object store
+func TestCreateProjectCmdWithStorageBackend(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	shipyardFilePath := "./shipyard.yaml"
+	defer testShipyard(t, shipyardFilePath, "")()
+	defer func() { *createProjectParams.StorageBackend = "" }()
+
+	cmd := fmt.Sprintf("create project sockshop --shipyard=%s --git-user= --git-token= --git-remote-url= --git-private-key= --storage-backend=objectstore --mock",
+		shipyardFilePath)
+	_, err := executeActionCommandC(cmd)
+
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+// TestCreateProjectCmdWithStorageBackendAndGit tests whether the create project command aborts
+// if a git upstream is configured for a project stored in the object store
+func TestCreateProjectCmdWithStorageBackendAndGit(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	shipyardFilePath := "./shipyard.yaml"
+	defer testShipyard(t, shipyardFilePath, "")()
+	defer func() { *createProjectParams.StorageBackend = "" }()
+
+	cmd := fmt.Sprintf("create project sockshop --shipyard=%s --git-user=%s --git-token=%s --git-remote-url=%s --storage-backend=objectstore --mock",
+		shipyardFilePath, "user", "token", "https://")
+	_, err := executeActionCommandC(cmd)
+
+	if !errorContains(err, "A Git upstream repository can only be configured for projects stored in Git") {
+		t.Errorf("missing expected error, but got %v", err)
+	}
+}
+
 func errorContains(out error, want string) bool {
 	if out == nil {
 		return want == ""
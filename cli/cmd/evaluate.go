@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var evaluateCmd = &cobra.Command{
+	Use:   "evaluate [ what-if | local ]",
+	Short: "Evaluates SLOs without triggering an evaluation in Keptn",
+}
+
+func init() {
+	rootCmd.AddCommand(evaluateCmd)
+}
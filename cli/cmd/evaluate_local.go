@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/common/fileutils"
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+	"github.com/spf13/cobra"
+)
+
+type evaluateLocalStruct struct {
+	sloFile      *string
+	sliFile      *string
+	previousFile *string
+}
+
+var evaluateLocalParams evaluateLocalStruct
+
+var evaluateLocalCmd = &cobra.Command{
+	Use:   "local --slo=FILEPATH --sli=FILEPATH [--previous=FILEPATH]",
+	Short: "Evaluates SLI values against a local SLO file without a Keptn control plane",
+	Long: `Evaluates SLI values against the objectives of a local SLO file with the same scoring as lighthouse-service, and prints the data of the resulting evaluation.finished event.
+
+The SLI file contains either the data of a get-sli.finished event, or a list of SLI results, e.g.:
+[{"metric": "response_time_p95", "value": 480, "success": true}]
+The timeframe of a list of SLI results ends at the current time.
+
+Relative criteria are compared with the previous evaluations, which are provided as a list of evaluation.finished event data, the latest one first.
+The baselines of SLIs with anomaly detection are built from the previous evaluations as well.
+
+The command exits with a non-zero exit code if the evaluation fails, i.e. it can be used as a quality gate in CI pipelines.
+`,
+	Example: `keptn evaluate local --slo=./slo.yaml --sli=./results.json
+keptn evaluate local --slo=./slo.yaml --sli=./results.json --previous=./previous-evaluations.json`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sloContent, err := fileutils.ReadFile(*evaluateLocalParams.sloFile)
+		if err != nil {
+			return fmt.Errorf("Could not read SLO file: %s", err.Error())
+		}
+		sliContent, err := fileutils.ReadFile(*evaluateLocalParams.sliFile)
+		if err != nil {
+			return fmt.Errorf("Could not read SLI file: %s", err.Error())
+		}
+		var previousContent []byte
+		if *evaluateLocalParams.previousFile != "" {
+			previousContent, err = fileutils.ReadFile(*evaluateLocalParams.previousFile)
+			if err != nil {
+				return fmt.Errorf("Could not read previous evaluations: %s", err.Error())
+			}
+		}
+
+		data, err := evaluateLocally(sloContent, sliContent, previousContent, time.Now())
+		if err != nil {
+			return err
+		}
+		return printLocalEvaluation(os.Stdout, data)
+	},
+}
+
+// evaluateLocally evaluates the SLI values of the SLI file against the SLO file, comparing them with the given previous
+// evaluations
+func evaluateLocally(sloContent, sliContent, previousContent []byte, now time.Time) (*evaluation.EvaluationFinishedEventData, error) {
+	slo, err := evaluation.ParseSLOFile(sloContent)
+	if err != nil {
+		return nil, err
+	}
+	getSLI, samples, err := parseLocalSLIResults(sliContent, now)
+	if err != nil {
+		return nil, err
+	}
+	previousEvaluations, previousSamples, err := parseLocalPreviousEvaluations(previousContent, slo)
+	if err != nil {
+		return nil, err
+	}
+
+	input := evaluation.Input{
+		GetSLI:          getSLI,
+		Samples:         samples,
+		PreviousSamples: map[string][]float64{},
+		AnomalyModels:   map[string]*evaluation.AnomalyModel{},
+	}
+	for i, previousEvaluation := range previousEvaluations {
+		if i == slo.NumberOfPreviousResults() {
+			break
+		}
+		input.PreviousEvaluations = append(input.PreviousEvaluations, previousEvaluation)
+		previousSamples[i].AddEvaluationSamples(input.PreviousSamples)
+	}
+	for sli := range slo.AnomalyPolicies {
+		input.AnomalyModels[sli] = evaluation.NewAnomalyModel(getSLI.Project, getSLI.Stage, getSLI.Service, sli)
+		input.AnomalyModels[sli].Update(previousEvaluations)
+	}
+
+	data, err := slo.Evaluate(input)
+	if err != nil {
+		return nil, err
+	}
+	data.Evaluation.SLOFileContent = base64.StdEncoding.EncodeToString(sloContent)
+	return data, nil
+}
+
+// parseLocalSLIResults parses either the data of a get-sli.finished event or a list of SLI results
+func parseLocalSLIResults(content []byte, now time.Time) (*keptnv2.GetSLIFinishedEventData, map[string][]float64, error) {
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '[' {
+		var results []*keptnv2.SLIResult
+		if err := json.Unmarshal(content, &results); err != nil {
+			return nil, nil, fmt.Errorf("Could not parse SLI results: %s", err.Error())
+		}
+		timestamp := timeutils.GetKeptnTimeStamp(now)
+		content, _ = json.Marshal(map[string]interface{}{
+			"get-sli": map[string]interface{}{
+				"start":           timestamp,
+				"end":             timestamp,
+				"indicatorValues": results,
+			},
+		})
+	}
+
+	getSLI := &keptnv2.GetSLIFinishedEventData{}
+	if err := json.Unmarshal(content, getSLI); err != nil {
+		return nil, nil, fmt.Errorf("Could not parse get-sli.finished event data: %s", err.Error())
+	}
+	if len(getSLI.GetSLI.IndicatorValues) == 0 {
+		return nil, nil, errors.New("The SLI file does not contain any SLI results")
+	}
+	samples := evaluation.SLISamplesEventData{}
+	if err := json.Unmarshal(content, &samples); err != nil {
+		return nil, nil, fmt.Errorf("Could not parse get-sli.finished event data: %s", err.Error())
+	}
+	return getSLI, samples.GetSLISamples(), nil
+}
+
+// parseLocalPreviousEvaluations parses a list of evaluation.finished event data and returns the evaluations that are
+// compared with according to the SLO file, along with the raw samples of their SLIs
+func parseLocalPreviousEvaluations(content []byte, slo *evaluation.SLO) ([]*keptnv2.EvaluationFinishedEventData, []evaluation.SLISamplesEventData, error) {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, nil, nil
+	}
+	var events []json.RawMessage
+	if err := json.Unmarshal(content, &events); err != nil {
+		return nil, nil, fmt.Errorf("Could not parse previous evaluations: %s", err.Error())
+	}
+
+	var previousEvaluations []*keptnv2.EvaluationFinishedEventData
+	var previousSamples []evaluation.SLISamplesEventData
+	for _, event := range events {
+		previousEvaluation := &keptnv2.EvaluationFinishedEventData{}
+		if err := json.Unmarshal(event, previousEvaluation); err != nil {
+			return nil, nil, fmt.Errorf("Could not parse previous evaluations: %s", err.Error())
+		}
+		if !slo.IncludesPreviousResult(previousEvaluation.Result) {
+			continue
+		}
+		samples := evaluation.SLISamplesEventData{}
+		if err := json.Unmarshal(event, &samples); err != nil {
+			return nil, nil, fmt.Errorf("Could not parse previous evaluations: %s", err.Error())
+		}
+		previousEvaluations = append(previousEvaluations, previousEvaluation)
+		previousSamples = append(previousSamples, samples)
+	}
+	return previousEvaluations, previousSamples, nil
+}
+
+// printLocalEvaluation prints the evaluation.finished event data and returns an error if the evaluation has failed
+func printLocalEvaluation(out io.Writer, data *evaluation.EvaluationFinishedEventData) error {
+	payload, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, string(payload))
+	if data.Result == keptnv2.ResultFailed {
+		return fmt.Errorf("Evaluation failed with a score of %.2f", data.Evaluation.Score)
+	}
+	return nil
+}
+
+func init() {
+	evaluateCmd.AddCommand(evaluateLocalCmd)
+
+	evaluateLocalParams.sloFile = evaluateLocalCmd.Flags().StringP("slo", "", "", "The SLO file")
+	evaluateLocalCmd.MarkFlagRequired("slo")
+	evaluateLocalParams.sliFile = evaluateLocalCmd.Flags().StringP("sli", "", "", "The SLI results, either as get-sli.finished event data or as a list of SLI results")
+	evaluateLocalCmd.MarkFlagRequired("sli")
+	evaluateLocalParams.previousFile = evaluateLocalCmd.Flags().StringP("previous", "", "", "A list of previous evaluation.finished event data, the latest one first")
+}
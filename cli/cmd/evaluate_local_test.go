@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+const localEvaluationSLO = `---
+spec_version: '1.0'
+comparison:
+  compare_with: "single_result"
+  include_result_with_score: "pass"
+  aggregate_function: avg
+objectives:
+  - sli: response_time_p95
+    pass:
+      - criteria:
+          - "<=+10%"
+          - "<600"
+    warning:
+      - criteria:
+          - "<=800"
+total_score:
+  pass: "90%"
+  warning: "75%"
+`
+
+func resetEvaluateLocalParams() {
+	*evaluateLocalParams.sloFile = ""
+	*evaluateLocalParams.sliFile = ""
+	*evaluateLocalParams.previousFile = ""
+}
+
+func TestEvaluateLocal(t *testing.T) {
+	resetEvaluateLocalParams()
+	defer testResource(t, "slo.yaml", localEvaluationSLO)()
+	defer testResource(t, "results.json", `[{"metric": "response_time_p95", "value": 500, "success": true}]`)()
+
+	_, err := executeActionCommandC("evaluate local --slo=slo.yaml --sli=results.json")
+	require.Nil(t, err)
+}
+
+func TestEvaluateLocal_Fail(t *testing.T) {
+	resetEvaluateLocalParams()
+	defer testResource(t, "slo.yaml", localEvaluationSLO)()
+	defer testResource(t, "results.json", `[{"metric": "response_time_p95", "value": 900, "success": true}]`)()
+
+	_, err := executeActionCommandC("evaluate local --slo=slo.yaml --sli=results.json")
+	require.EqualError(t, err, "Evaluation failed with a score of 0.00")
+}
+
+func TestEvaluateLocal_MissingSLIFile(t *testing.T) {
+	resetEvaluateLocalParams()
+	defer testResource(t, "slo.yaml", localEvaluationSLO)()
+
+	_, err := executeActionCommandC("evaluate local --slo=slo.yaml --sli=unknown.json")
+	require.NotNil(t, err)
+}
+
+func TestEvaluateLocally(t *testing.T) {
+	now := time.Date(2022, 7, 1, 12, 5, 0, 0, time.UTC)
+	sli := []byte(`{
+  "project": "sockshop",
+  "stage": "staging",
+  "service": "carts",
+  "labels": {"buildnr": "17"},
+  "get-sli": {
+    "start": "2022-07-01T12:00:00.000Z",
+    "end": "2022-07-01T12:05:00.000Z",
+    "indicatorValues": [{"metric": "response_time_p95", "value": 500, "success": true, "samples": [480, 500, 520]}]
+  }
+}`)
+	// the failed evaluation is not compared with, since only passed results are included
+	previous := []byte(`[
+  {"result": "fail", "evaluation": {"timeEnd": "2022-06-30T12:05:00.000Z", "indicatorResults": [{"value": {"metric": "response_time_p95", "value": 300, "success": true}}]}},
+  {"result": "pass", "evaluation": {"timeEnd": "2022-06-29T12:05:00.000Z", "indicatorResults": [{"value": {"metric": "response_time_p95", "value": 400, "success": true}, "samples": [390, 400, 410]}]}}
+]`)
+
+	data, err := evaluateLocally([]byte(localEvaluationSLO), sli, previous, now)
+	require.Nil(t, err)
+	require.Equal(t, "sockshop", data.Project)
+	require.Equal(t, map[string]string{"buildnr": "17"}, data.Labels)
+	// the value exceeds the previous passed value by more than 10%, i.e. only the warning criteria are satisfied
+	require.Equal(t, keptnv2.ResultFailed, data.Result)
+	require.Equal(t, float64(50), data.Evaluation.Score)
+	require.Len(t, data.Evaluation.IndicatorResults, 1)
+	require.Equal(t, "warning", data.Evaluation.IndicatorResults[0].Status)
+	require.Equal(t, []float64{480, 500, 520}, data.Evaluation.IndicatorResults[0].Samples)
+	require.NotNil(t, data.Evaluation.Explanation)
+	require.NotEmpty(t, data.Evaluation.SLOFileContent)
+
+	// without previous evaluations, the relative criteria are satisfied
+	data, err = evaluateLocally([]byte(localEvaluationSLO), sli, nil, now)
+	require.Nil(t, err)
+	require.Equal(t, keptnv2.ResultPass, data.Result)
+}
+
+func TestEvaluateLocally_SLIResults(t *testing.T) {
+	now := time.Date(2022, 7, 1, 12, 5, 0, 0, time.UTC)
+
+	data, err := evaluateLocally([]byte(localEvaluationSLO), []byte(`[{"metric": "response_time_p95", "value": 500, "success": true}]`), nil, now)
+	require.Nil(t, err)
+	require.Equal(t, keptnv2.ResultPass, data.Result)
+	require.Equal(t, "2022-07-01T12:05:00.000Z", data.Evaluation.TimeEnd)
+
+	_, err = evaluateLocally([]byte(localEvaluationSLO), []byte(`[]`), nil, now)
+	require.EqualError(t, err, "The SLI file does not contain any SLI results")
+
+	_, err = evaluateLocally([]byte("objectives: {}"), []byte(`[{"metric": "response_time_p95", "value": 500, "success": true}]`), nil, now)
+	require.NotNil(t, err)
+}
+
+func TestPrintLocalEvaluation(t *testing.T) {
+	data, err := evaluateLocally([]byte(localEvaluationSLO), []byte(`[{"metric": "response_time_p95", "value": 900, "success": true}]`), nil, time.Now())
+	require.Nil(t, err)
+
+	out := &bytes.Buffer{}
+	err = printLocalEvaluation(out, data)
+	require.EqualError(t, err, "Evaluation failed with a score of 0.00")
+	require.Contains(t, out.String(), `"result": "fail"`)
+}
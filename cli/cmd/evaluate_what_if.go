@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/keptn/go-utils/pkg/common/fileutils"
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const whatIfPath = "/lighthouse-service/v1/what-if"
+
+type evaluateWhatIfStruct struct {
+	project     *string
+	stage       *string
+	service     *string
+	sloFile     *string
+	evaluations *int
+}
+
+type whatIfRequest struct {
+	Project     string `json:"project"`
+	Stage       string `json:"stage"`
+	Service     string `json:"service"`
+	SLO         string `json:"slo"`
+	Evaluations int    `json:"evaluations,omitempty"`
+}
+
+type whatIfResult struct {
+	Evaluations []whatIfEvaluation `json:"evaluations"`
+}
+
+type whatIfEvaluation struct {
+	EventID   string  `json:"eventId"`
+	TimeStart string  `json:"timeStart"`
+	TimeEnd   string  `json:"timeEnd"`
+	OldScore  float64 `json:"oldScore"`
+	OldResult string  `json:"oldResult"`
+	NewScore  float64 `json:"newScore"`
+	NewResult string  `json:"newResult"`
+	Message   string  `json:"message,omitempty"`
+}
+
+var evaluateWhatIfParams evaluateWhatIfStruct
+
+var evaluateWhatIfCmd = &cobra.Command{
+	Use:   "what-if --project=PROJECT --stage=STAGE --service=SERVICE --slo=FILEPATH [--evaluations=10]",
+	Short: "Shows how a candidate SLO file would have scored the latest evaluations of a service",
+	Long: `Replays the SLI values of the latest evaluations of a service through the objectives of a local SLO file, and compares the resulting scores and results with the original ones.
+
+No evaluation is triggered and the SLI provider is not queried, i.e. the candidate SLO file can only score SLIs that have been part of the original evaluations.
+Relative criteria of an evaluation are compared with the evaluations preceding it.
+`,
+	Example: `keptn evaluate what-if --project=sockshop --stage=hardening --service=carts --slo=./slo.yaml
+EVENT ID                               END                        OLD SCORE   OLD RESULT   NEW SCORE   NEW RESULT
+7f0c5ea6-3f4e-4d5c-9d0c-1f5a1e8f5b7e   2022-07-01T12:05:00.000Z   100.00      pass         50.00       fail
+0a9e2b71-9c1d-4f3e-8d6a-2b4c6d8e0f1a   2022-06-30T12:05:00.000Z   100.00      pass         100.00      pass
+`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sloContent, err := fileutils.ReadFile(*evaluateWhatIfParams.sloFile)
+		if err != nil {
+			return fmt.Errorf("Could not read SLO file: %s", err.Error())
+		}
+
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		api, err := internal.APIProvider(endPoint.String(), apiToken)
+		if err != nil {
+			return internal.OnAPIError(err)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			fmt.Println("Skipping evaluate what-if due to mocking flag set to true")
+			return nil
+		}
+
+		request := whatIfRequest{
+			Project:     *evaluateWhatIfParams.project,
+			Stage:       *evaluateWhatIfParams.stage,
+			Service:     *evaluateWhatIfParams.service,
+			SLO:         string(sloContent),
+			Evaluations: *evaluateWhatIfParams.evaluations,
+		}
+		result := &whatIfResult{}
+		if err := internal.NewRESTClient(api).Post(whatIfPath, request, result); err != nil {
+			return fmt.Errorf("Evaluations could not be replayed: %v", err)
+		}
+		if len(result.Evaluations) == 0 {
+			fmt.Println("No evaluations found for service " + request.Service + " in stage " + request.Stage + " of project " + request.Project)
+			return nil
+		}
+		return printWhatIfResult(os.Stdout, *result)
+	},
+}
+
+func printWhatIfResult(out io.Writer, result whatIfResult) error {
+	w := new(tabwriter.Writer)
+	w.Init(out, 10, 8, 3, ' ', 0)
+	fmt.Fprintln(w, "EVENT ID\tEND\tOLD SCORE\tOLD RESULT\tNEW SCORE\tNEW RESULT")
+	changed := 0
+	var notes []string
+	for _, evaluation := range result.Evaluations {
+		if evaluation.NewResult == "" {
+			// the evaluation could not be replayed
+			fmt.Fprintf(w, "%s\t%s\t%.2f\t%s\t-\t-\n", evaluation.EventID, evaluation.TimeEnd, evaluation.OldScore, evaluation.OldResult)
+			notes = append(notes, fmt.Sprintf("Evaluation %s could not be replayed: %s", evaluation.EventID, evaluation.Message))
+			continue
+		}
+		if evaluation.NewResult != evaluation.OldResult {
+			changed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%.2f\t%s\t%.2f\t%s\n", evaluation.EventID, evaluation.TimeEnd, evaluation.OldScore, evaluation.OldResult, evaluation.NewScore, evaluation.NewResult)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\n%d of %d evaluations would have a different result\n", changed, len(result.Evaluations))
+	for _, note := range notes {
+		fmt.Fprintln(out, note)
+	}
+	return nil
+}
+
+func init() {
+	evaluateCmd.AddCommand(evaluateWhatIfCmd)
+
+	evaluateWhatIfParams.project = evaluateWhatIfCmd.Flags().StringP("project", "", "", "The project containing the service")
+	evaluateWhatIfCmd.MarkFlagRequired("project")
+	evaluateWhatIfParams.stage = evaluateWhatIfCmd.Flags().StringP("stage", "", "", "The stage containing the service")
+	evaluateWhatIfCmd.MarkFlagRequired("stage")
+	evaluateWhatIfParams.service = evaluateWhatIfCmd.Flags().StringP("service", "", "", "The service whose evaluations are replayed")
+	evaluateWhatIfCmd.MarkFlagRequired("service")
+	evaluateWhatIfParams.sloFile = evaluateWhatIfCmd.Flags().StringP("slo", "", "", "The candidate SLO file")
+	evaluateWhatIfCmd.MarkFlagRequired("slo")
+	evaluateWhatIfParams.evaluations = evaluateWhatIfCmd.Flags().IntP("evaluations", "", 10, "The number of latest evaluations to replay (at most 100)")
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/require"
+)
+
+func resetEvaluateWhatIfParams(t *testing.T) {
+	t.Setenv("MOCK_SERVER", "http://some-valid-url.com")
+	credentialmanager.MockAuthCreds = true
+
+	*evaluateWhatIfParams.project = ""
+	*evaluateWhatIfParams.stage = ""
+	*evaluateWhatIfParams.service = ""
+	*evaluateWhatIfParams.sloFile = ""
+	*evaluateWhatIfParams.evaluations = 10
+}
+
+func TestEvaluateWhatIf(t *testing.T) {
+	resetEvaluateWhatIfParams(t)
+	sloFileName := "slo.yaml"
+	defer testResource(t, sloFileName, "spec_version: '1.0'\n")()
+
+	_, err := executeActionCommandC("evaluate what-if --project=sockshop --stage=dev --service=carts --slo=" + sloFileName + " --mock")
+	require.Nil(t, err)
+}
+
+func TestEvaluateWhatIf_MissingSLOFile(t *testing.T) {
+	resetEvaluateWhatIfParams(t)
+
+	_, err := executeActionCommandC("evaluate what-if --project=sockshop --stage=dev --service=carts --slo=unknown.yaml --mock")
+	require.NotNil(t, err)
+}
+
+func TestPrintWhatIfResult(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := printWhatIfResult(out, whatIfResult{Evaluations: []whatIfEvaluation{
+		{EventID: "id-1", TimeEnd: "2022-07-01T12:05:00.000Z", OldScore: 100, OldResult: "pass", NewScore: 50, NewResult: "fail"},
+		{EventID: "id-2", TimeEnd: "2022-06-30T12:05:00.000Z", OldScore: 100, OldResult: "pass", NewScore: 100, NewResult: "pass"},
+		{EventID: "id-3", TimeEnd: "2022-06-29T12:05:00.000Z", OldScore: 0, OldResult: "fail", Message: "evaluation does not contain any SLI values"},
+	}})
+	require.Nil(t, err)
+	require.Equal(t, `EVENT ID   END                        OLD SCORE   OLD RESULT   NEW SCORE   NEW RESULT
+id-1       2022-07-01T12:05:00.000Z   100.00      pass         50.00       fail
+id-2       2022-06-30T12:05:00.000Z   100.00      pass         100.00      pass
+id-3       2022-06-29T12:05:00.000Z   0.00        fail         -           -
+
+1 of 3 evaluations would have a different result
+Evaluation id-3 could not be replayed: evaluation does not contain any SLI values
+`, out.String())
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+)
+
+const (
+	explanationFormatMarkdown = "markdown"
+	explanationFormatHTML     = "html"
+)
+
+var explanationFuncs = map[string]interface{}{
+	"number": func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	},
+	"percentage": func(value float64) string {
+		return strconv.FormatFloat(value, 'f', 2, 64)
+	},
+	"join": strings.Join,
+	"inc": func(i int) int {
+		return i + 1
+	},
+	"deref": func(value *float64) float64 {
+		if value == nil {
+			return 0
+		}
+		return *value
+	},
+}
+
+const markdownExplanationTemplate = `# Evaluation of service {{.Service}} in stage {{.Stage}} of project {{.Project}}
+
+{{with .Evaluation.Explanation -}}
+**Result:** {{.Result}} | **Score:** {{percentage .Score}} | **Pass target:** {{.PassTarget}}{{if .WarningTarget}} | **Warning target:** {{.WarningTarget}}{{end}}
+
+{{.Summary}}.
+
+| SLI | Value | Status | Weight | Score | Contribution | Key SLI |
+|-----|-------|--------|--------|-------|--------------|---------|
+{{range .Indicators}}| {{.SLI}} | {{if .Success}}{{number .Value}}{{else}}-{{end}} | {{.Status}} | {{.Weight}} | {{number .Score}} | {{percentage .Contribution}} of {{percentage .MaximumContribution}} | {{if .KeySLI}}yes{{end}} |
+{{end}}{{range .Indicators}}
+## {{if .DisplayName}}{{.DisplayName}} ({{.SLI}}){{else}}{{.SLI}}{{end}}
+
+{{range .Reasons}}- {{.}}
+{{end}}{{if .Pass}}
+Pass criteria:
+{{range $i, $set := .Pass}}- Set {{inc $i}}{{if $set.Satisfied}} (satisfied){{else}} (violated){{end}}:{{range $set.Criteria}} ` + "`{{.Criteria}}`" + ` (target {{number .TargetValue}}{{if .Violated}}, violated{{end}}){{end}}
+{{end}}{{end}}{{if .Warning}}
+Warning criteria:
+{{range $i, $set := .Warning}}- Set {{inc $i}}{{if $set.Satisfied}} (satisfied){{else}} (violated){{end}}:{{range $set.Criteria}} ` + "`{{.Criteria}}`" + ` (target {{number .TargetValue}}{{if .Violated}}, violated{{end}}){{end}}
+{{end}}{{end}}{{if or .SourceEvent .ComparedEvents}}
+{{if .SourceEvent}}- Source event: ` + "`{{.SourceEvent}}`" + `
+{{end}}{{if .ComparedEvents}}- Compared with the value {{number (deref .ComparedValue)}} of the evaluations ` + "`{{join .ComparedEvents \"`, `\"}}`" + `
+{{end}}{{end}}{{end}}{{end}}`
+
+const htmlExplanationTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Evaluation of service {{.Service}} in stage {{.Stage}} of project {{.Project}}</title>
+</head>
+<body>
+<h1>Evaluation of service {{.Service}} in stage {{.Stage}} of project {{.Project}}</h1>
+{{with .Evaluation.Explanation -}}
+<p><b>Result:</b> {{.Result}} | <b>Score:</b> {{percentage .Score}} | <b>Pass target:</b> {{.PassTarget}}{{if .WarningTarget}} | <b>Warning target:</b> {{.WarningTarget}}{{end}}</p>
+<p>{{.Summary}}.</p>
+<table>
+<tr><th>SLI</th><th>Value</th><th>Status</th><th>Weight</th><th>Score</th><th>Contribution</th><th>Key SLI</th></tr>
+{{range .Indicators}}<tr><td>{{.SLI}}</td><td>{{if .Success}}{{number .Value}}{{else}}-{{end}}</td><td>{{.Status}}</td><td>{{.Weight}}</td><td>{{number .Score}}</td><td>{{percentage .Contribution}} of {{percentage .MaximumContribution}}</td><td>{{if .KeySLI}}yes{{end}}</td></tr>
+{{end}}</table>
+{{range .Indicators}}<h2>{{if .DisplayName}}{{.DisplayName}} ({{.SLI}}){{else}}{{.SLI}}{{end}}</h2>
+<ul>
+{{range .Reasons}}<li>{{.}}</li>
+{{end}}</ul>
+{{if .Pass}}<p>Pass criteria:</p>
+<ul>
+{{range $i, $set := .Pass}}<li>Set {{inc $i}}{{if $set.Satisfied}} (satisfied){{else}} (violated){{end}}:{{range $set.Criteria}} <code>{{.Criteria}}</code> (target {{number .TargetValue}}{{if .Violated}}, violated{{end}}){{end}}</li>
+{{end}}</ul>
+{{end}}{{if .Warning}}<p>Warning criteria:</p>
+<ul>
+{{range $i, $set := .Warning}}<li>Set {{inc $i}}{{if $set.Satisfied}} (satisfied){{else}} (violated){{end}}:{{range $set.Criteria}} <code>{{.Criteria}}</code> (target {{number .TargetValue}}{{if .Violated}}, violated{{end}}){{end}}</li>
+{{end}}</ul>
+{{end}}{{if .SourceEvent}}<p>Source event: <code>{{.SourceEvent}}</code></p>
+{{end}}{{if .ComparedEvents}}<p>Compared with the value {{number (deref .ComparedValue)}} of the evaluations <code>{{join .ComparedEvents ", "}}</code></p>
+{{end}}{{end}}{{end}}</body>
+</html>
+`
+
+// printEvaluationExplanation renders the explanation of an evaluation.finished event as Markdown or HTML
+func printEvaluationExplanation(out io.Writer, event *apimodels.KeptnContextExtendedCE, format string) error {
+	data := &evaluation.EvaluationFinishedEventData{}
+	if err := event.DataAs(data); err != nil {
+		return fmt.Errorf("could not parse evaluation.finished event: %w", err)
+	}
+	if data.Evaluation.Explanation == nil {
+		return errors.New("the evaluation.finished event " + event.ID + " does not contain an explanation")
+	}
+
+	switch format {
+	case explanationFormatMarkdown:
+		tmpl, err := texttemplate.New("explanation").Funcs(explanationFuncs).Parse(markdownExplanationTemplate)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(out, data)
+	case explanationFormatHTML:
+		tmpl, err := htmltemplate.New("explanation").Funcs(explanationFuncs).Parse(htmlExplanationTemplate)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(out, data)
+	default:
+		return fmt.Errorf("invalid explanation format %s: must be %s or %s", format, explanationFormatMarkdown, explanationFormatHTML)
+	}
+}
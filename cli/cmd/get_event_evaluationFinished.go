@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/cli/internal"
@@ -30,22 +31,33 @@ import (
 
 type evaluationDoneStruct struct {
 	KeptnContext *string `json:"keptnContext"`
+	Explain      *string `json:"explain"`
 }
 
 var evaluationDone evaluationDoneStruct
 
 // getEvaluationFinishedCmd represents the evaluation.finished command
 var getEvaluationFinishedCmd = &cobra.Command{
-	Use:          "evaluation.finished",
-	Args:         cobra.NoArgs,
-	Short:        "Returns the latest Keptn sh.keptn.event.evaluation.finished event from a specific Keptn context",
-	Long:         `Returns the latest Keptn sh.keptn.event.evaluation.finished event from a specific Keptn context.`,
-	Example:      `keptn get event evaluation.finished --keptn-context=1234-5678-90ab-cdef`,
+	Use:   "evaluation.finished",
+	Args:  cobra.NoArgs,
+	Short: "Returns the latest Keptn sh.keptn.event.evaluation.finished event from a specific Keptn context",
+	Long:  `Returns the latest Keptn sh.keptn.event.evaluation.finished event from a specific Keptn context.`,
+	Example: `keptn get event evaluation.finished --keptn-context=1234-5678-90ab-cdef
+
+keptn get event evaluation.finished --keptn-context=1234-5678-90ab-cdef --explain > explanation.md
+keptn get event evaluation.finished --keptn-context=1234-5678-90ab-cdef --explain=html > explanation.html`,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println(`NOTE: The "keptn get event evaluation.finished" command is DEPRECATED and will be removed in a future release`)
-		fmt.Println(`Use "keptn get event evaluation.finished" instead`)
-		fmt.Println()
+		explain := *evaluationDone.Explain
+		if explain != "" && explain != explanationFormatMarkdown && explain != explanationFormatHTML {
+			return fmt.Errorf("invalid explanation format %s: must be %s or %s", explain, explanationFormatMarkdown, explanationFormatHTML)
+		}
+		// the explanation is printed on its own, so that it can be redirected to a file
+		if explain == "" {
+			fmt.Println(`NOTE: The "keptn get event evaluation.finished" command is DEPRECATED and will be removed in a future release`)
+			fmt.Println(`Use "keptn get event evaluation.finished" instead`)
+			fmt.Println()
+		}
 
 		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
 		if err != nil {
@@ -74,6 +86,8 @@ var getEvaluationFinishedCmd = &cobra.Command{
 			if len(evaluationDoneEvts) == 0 {
 				logging.PrintLog("No event returned", logging.QuietLevel)
 				return nil
+			} else if explain != "" {
+				return printEvaluationExplanation(os.Stdout, evaluationDoneEvts[0], explain)
 			} else if len(evaluationDoneEvts) == 1 {
 				eventsJSON, _ := json.MarshalIndent(evaluationDoneEvts[0], "", "	")
 				fmt.Println(string(eventsJSON))
@@ -94,4 +108,8 @@ func init() {
 	evaluationDone.KeptnContext = getEvaluationFinishedCmd.Flags().StringP("keptn-context", "", "",
 		"The ID of a Keptn context from which to retrieve an evaluation.finished event")
 	getEvaluationFinishedCmd.MarkFlagRequired("keptn-context")
+
+	evaluationDone.Explain = getEvaluationFinishedCmd.Flags().StringP("explain", "", "",
+		"Prints the explanation of the score and the result of the evaluation as markdown or html")
+	getEvaluationFinishedCmd.Flags().Lookup("explain").NoOptDefVal = explanationFormatMarkdown
 }
@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"testing"
 
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/require"
 
 	"github.com/keptn/keptn/cli/pkg/logging"
 )
@@ -35,3 +38,86 @@ func TestGetEventEvaluationFinishedUnknownCommand(t *testing.T) {
 func TestGetEventEvaluationFinishedUnknownParmeter(t *testing.T) {
 	testInvalidInputHelper("get event evaluation.finished --projectt=sockshop", "unknown flag: --projectt", t)
 }
+
+func TestGetEventEvaluationFinishedInvalidExplanationFormat(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	defer func() { *evaluationDone.Explain = "" }()
+
+	testInvalidInputHelper("get event evaluation.finished --keptn-context=8929e5e5-3826-488f-9257-708bfa974909 --explain=pdf --mock", "invalid explanation format pdf: must be markdown or html", t)
+}
+
+func newExplainedEvaluationFinishedEvent() *apimodels.KeptnContextExtendedCE {
+	return &apimodels.KeptnContextExtendedCE{
+		ID: "evaluation-finished-id",
+		Data: map[string]interface{}{
+			"project": "sockshop",
+			"stage":   "staging",
+			"service": "carts",
+			"evaluation": map[string]interface{}{
+				"explanation": map[string]interface{}{
+					"summary":        "The SLIs achieved 2 of a maximum weight of 3, which is a score of 66.6667%, which misses the pass target of 90%",
+					"score":          66.66666666,
+					"achievedWeight": 2,
+					"maximumWeight":  3,
+					"passTarget":     "90%",
+					"result":         "fail",
+					"indicators": []map[string]interface{}{
+						{
+							"sli": "response_time_p95", "status": "fail", "value": 700, "success": true, "sourceEvent": "get-sli-finished-id",
+							"weight": 1, "score": 0, "contribution": 0, "maximumContribution": 33.3333,
+							"pass": []map[string]interface{}{
+								{"criteria": []map[string]interface{}{{"criteria": "<=600", "targetValue": 600, "violated": true}}, "satisfied": false},
+							},
+							"reasons": []string{"the value 700 satisfies none of the pass criteria sets: set 1 violates <=600, target 600", "scored 0 of weight 1"},
+						},
+						{
+							"sli": "error_rate", "status": "pass", "value": 1.5, "success": true, "sourceEvent": "get-sli-finished-id",
+							"comparedValue": 1.4, "comparedEvents": []string{"previous-id"}, "keySLI": true,
+							"weight": 2, "score": 2, "contribution": 66.6667, "maximumContribution": 66.6667,
+							"pass": []map[string]interface{}{
+								{"criteria": []map[string]interface{}{{"criteria": "<=+10%", "targetValue": 1.54, "violated": false}}, "satisfied": true},
+							},
+							"reasons": []string{"the value 1.5 satisfies pass criteria set 1 (<=+10%, target 1.54)", "scored the full weight of 2"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPrintEvaluationExplanation(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := printEvaluationExplanation(out, newExplainedEvaluationFinishedEvent(), explanationFormatMarkdown)
+	require.Nil(t, err)
+	require.Equal(t, "# Evaluation of service carts in stage staging of project sockshop\n\n"+
+		"**Result:** fail | **Score:** 66.67 | **Pass target:** 90%\n\n"+
+		"The SLIs achieved 2 of a maximum weight of 3, which is a score of 66.6667%, which misses the pass target of 90%.\n\n"+
+		"| SLI | Value | Status | Weight | Score | Contribution | Key SLI |\n"+
+		"|-----|-------|--------|--------|-------|--------------|---------|\n"+
+		"| response_time_p95 | 700 | fail | 1 | 0 | 0.00 of 33.33 |  |\n"+
+		"| error_rate | 1.5 | pass | 2 | 2 | 66.67 of 66.67 | yes |\n\n"+
+		"## response_time_p95\n\n"+
+		"- the value 700 satisfies none of the pass criteria sets: set 1 violates <=600, target 600\n"+
+		"- scored 0 of weight 1\n\n"+
+		"Pass criteria:\n"+
+		"- Set 1 (violated): `<=600` (target 600, violated)\n\n"+
+		"- Source event: `get-sli-finished-id`\n\n"+
+		"## error_rate\n\n"+
+		"- the value 1.5 satisfies pass criteria set 1 (<=+10%, target 1.54)\n"+
+		"- scored the full weight of 2\n\n"+
+		"Pass criteria:\n"+
+		"- Set 1 (satisfied): `<=+10%` (target 1.54)\n\n"+
+		"- Source event: `get-sli-finished-id`\n"+
+		"- Compared with the value 1.4 of the evaluations `previous-id`\n", out.String())
+
+	out.Reset()
+	err = printEvaluationExplanation(out, newExplainedEvaluationFinishedEvent(), explanationFormatHTML)
+	require.Nil(t, err)
+	require.Contains(t, out.String(), "<tr><td>error_rate</td><td>1.5</td><td>pass</td><td>2</td><td>2</td><td>66.67 of 66.67</td><td>yes</td></tr>")
+	require.Contains(t, out.String(), "<li>Set 1 (violated): <code>&lt;=600</code> (target 600, violated)</li>")
+	require.Contains(t, out.String(), "<p>Compared with the value 1.4 of the evaluations <code>previous-id</code></p>")
+
+	err = printEvaluationExplanation(out, &apimodels.KeptnContextExtendedCE{ID: "evaluation-finished-id", Data: map[string]interface{}{}}, explanationFormatMarkdown)
+	require.EqualError(t, err, "the evaluation.finished event evaluation-finished-id does not contain an explanation")
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/spf13/cobra"
+)
+
+type getResourceHistoryStruct struct {
+	project     *string
+	stage       *string
+	service     *string
+	resourceURI *string
+}
+
+type resourceRevision struct {
+	CommitID  string    `json:"commitID"`
+	Author    string    `json:"author"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type resourceHistory struct {
+	ResourceURI string             `json:"resourceURI"`
+	Revisions   []resourceRevision `json:"revisions"`
+}
+
+var getResourceHistoryParams getResourceHistoryStruct
+
+var getResourceHistoryCmd = &cobra.Command{
+	Use:   "resource-history --project=PROJECT [--stage=STAGE] [--service=SERVICE] --resourceUri=RESOURCE_URI",
+	Short: "Lists the revisions of a resource",
+	Long: `Lists the commits that changed a resource of a project, stage or service, starting with the most recent one.
+
+The commit IDs can be used to revert the resource to a previous revision using *keptn revert resource*.
+`,
+	Example: `keptn get resource-history --project=sockshop --stage=production --service=carts --resourceUri=slo.yaml
+COMMIT ID                                  AUTHOR   DATE                   MESSAGE
+4f2d0ed5a2b5c6f3f4d2e1c9bd0f18f5bd5e0a17   keptn    2022-07-01T12:00:00Z   Updated resource
+9b1c3c2a1e43f7a4f5d9e8c2ab4f6c9d1e2f3a4b   keptn    2022-06-28T09:30:00Z   Added resource
+`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if isStringFlagSet(getResourceHistoryParams.service) && !isStringFlagSet(getResourceHistoryParams.stage) {
+			return errors.New("Flag 'stage' is missing")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		api, err := internal.APIProvider(endPoint.String(), apiToken)
+		if err != nil {
+			return internal.OnAPIError(err)
+		}
+
+		if mocking {
+			fmt.Println("Skipping get resource-history due to mocking flag set to true")
+			return nil
+		}
+
+		history := &resourceHistory{}
+		path := getResourcePath(*getResourceHistoryParams.project, *getResourceHistoryParams.stage, *getResourceHistoryParams.service, *getResourceHistoryParams.resourceURI) + "/history"
+		if err := internal.NewRESTClient(api).Get(path, nil, history); err != nil {
+			return fmt.Errorf("Failed to retrieve history of resource %s: %v", *getResourceHistoryParams.resourceURI, err)
+		}
+
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 10, 8, 3, ' ', 0)
+		fmt.Fprintln(w, "COMMIT ID\tAUTHOR\tDATE\tMESSAGE")
+		for _, revision := range history.Revisions {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", revision.CommitID, revision.Author, revision.Timestamp.Format(time.RFC3339), revision.Message)
+		}
+		return w.Flush()
+	},
+}
+
+// getResourcePath returns the path of a resource in the resource-service API, relative to the Keptn API endpoint
+func getResourcePath(project, stage, service, resourceURI string) string {
+	path := "/resource-service/v1/project/" + url.PathEscape(project)
+	if stage != "" {
+		path += "/stage/" + url.PathEscape(stage)
+	}
+	if service != "" {
+		path += "/service/" + url.PathEscape(service)
+	}
+	return path + "/resource/" + url.QueryEscape(resourceURI)
+}
+
+func init() {
+	getCmd.AddCommand(getResourceHistoryCmd)
+
+	getResourceHistoryParams.project = getResourceHistoryCmd.Flags().StringP("project", "p", "", "The name of the project")
+	getResourceHistoryCmd.MarkFlagRequired("project")
+	getResourceHistoryParams.stage = getResourceHistoryCmd.Flags().StringP("stage", "s", "", "The name of the stage")
+	getResourceHistoryParams.service = getResourceHistoryCmd.Flags().StringP("service", "", "", "The name of the service within the project")
+	getResourceHistoryParams.resourceURI = getResourceHistoryCmd.Flags().StringP("resourceUri", "", "", "The URI of the resource within the config repo")
+	getResourceHistoryCmd.MarkFlagRequired("resourceUri")
+}
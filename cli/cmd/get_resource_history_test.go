@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/require"
+)
+
+func resetGetResourceHistoryParams(t *testing.T) {
+	t.Setenv("MOCK_SERVER", "http://some-valid-url.com")
+	credentialmanager.MockAuthCreds = true
+
+	*getResourceHistoryParams.project = ""
+	*getResourceHistoryParams.stage = ""
+	*getResourceHistoryParams.service = ""
+	*getResourceHistoryParams.resourceURI = ""
+}
+
+func TestGetResourceHistory(t *testing.T) {
+	resetGetResourceHistoryParams(t)
+
+	_, err := executeActionCommandC("get resource-history --project=sockshop --stage=dev --service=carts --resourceUri=slo.yaml --mock")
+	require.Nil(t, err)
+}
+
+func TestGetResourceHistory_MissingStage(t *testing.T) {
+	resetGetResourceHistoryParams(t)
+
+	_, err := executeActionCommandC("get resource-history --project=sockshop --service=carts --resourceUri=slo.yaml --mock")
+	require.EqualError(t, err, "Flag 'stage' is missing")
+}
+
+func Test_getResourcePath(t *testing.T) {
+	require.Equal(t, "/resource-service/v1/project/sockshop/resource/metadata.yaml", getResourcePath("sockshop", "", "", "metadata.yaml"))
+	require.Equal(t, "/resource-service/v1/project/sockshop/stage/dev/resource/shipyard.yaml", getResourcePath("sockshop", "dev", "", "shipyard.yaml"))
+	require.Equal(t, "/resource-service/v1/project/sockshop/stage/dev/service/carts/resource/jmeter%2Fload.jmx", getResourcePath("sockshop", "dev", "carts", "jmeter/load.jmx"))
+}
@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var revertCmd = &cobra.Command{
+	Use:   "revert [ resource ]",
+	Short: "Reverts a Keptn entity to a previous revision",
+}
+
+func init() {
+	rootCmd.AddCommand(revertCmd)
+}
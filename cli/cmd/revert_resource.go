@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+type revertResourceStruct struct {
+	project     *string
+	stage       *string
+	service     *string
+	resourceURI *string
+	revision    *string
+}
+
+type revertResourceResult struct {
+	CommitID string `json:"commitID"`
+}
+
+var revertResourceParams revertResourceStruct
+
+var revertResourceCmd = &cobra.Command{
+	Use:   "resource --project=PROJECT [--stage=STAGE] [--service=SERVICE] --resourceUri=RESOURCE_URI --revision=COMMIT_ID",
+	Short: "Reverts a resource to a previous revision",
+	Long: `Restores the content a resource had at the given revision. The restored content is stored in a new commit, i.e., the history of the resource is preserved.
+
+The available revisions of a resource can be listed using *keptn get resource-history*.
+`,
+	Example:      `keptn revert resource --project=sockshop --stage=production --service=carts --resourceUri=slo.yaml --revision=9b1c3c2a1e43f7a4f5d9e8c2ab4f6c9d1e2f3a4b`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if isStringFlagSet(revertResourceParams.service) && !isStringFlagSet(revertResourceParams.stage) {
+			return errors.New("Flag 'stage' is missing")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		api, err := internal.APIProvider(endPoint.String(), apiToken)
+		if err != nil {
+			return internal.OnAPIError(err)
+		}
+
+		logging.PrintLog("Reverting resource "+*revertResourceParams.resourceURI+" to revision "+*revertResourceParams.revision, logging.InfoLevel)
+
+		if mocking {
+			fmt.Println("Skipping revert resource due to mocking flag set to true")
+			return nil
+		}
+
+		path := getResourcePath(*revertResourceParams.project, *revertResourceParams.stage, *revertResourceParams.service, *revertResourceParams.resourceURI) + "/revert"
+		result := &revertResourceResult{}
+		payload := map[string]string{"gitCommitID": *revertResourceParams.revision}
+		if err := internal.NewRESTClient(api).Post(path, payload, result); err != nil {
+			return fmt.Errorf("Resource %s could not be reverted: %v", *revertResourceParams.resourceURI, err)
+		}
+
+		logging.PrintLog("Resource has been reverted in commit "+result.CommitID, logging.InfoLevel)
+		return nil
+	},
+}
+
+func init() {
+	revertCmd.AddCommand(revertResourceCmd)
+
+	revertResourceParams.project = revertResourceCmd.Flags().StringP("project", "p", "", "The name of the project")
+	revertResourceCmd.MarkFlagRequired("project")
+	revertResourceParams.stage = revertResourceCmd.Flags().StringP("stage", "s", "", "The name of the stage")
+	revertResourceParams.service = revertResourceCmd.Flags().StringP("service", "", "", "The name of the service within the project")
+	revertResourceParams.resourceURI = revertResourceCmd.Flags().StringP("resourceUri", "", "", "The URI of the resource within the config repo")
+	revertResourceCmd.MarkFlagRequired("resourceUri")
+	revertResourceParams.revision = revertResourceCmd.Flags().StringP("revision", "", "", "The commit ID of the revision to restore")
+	revertResourceCmd.MarkFlagRequired("revision")
+}
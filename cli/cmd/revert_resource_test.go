@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/require"
+)
+
+func resetRevertResourceParams(t *testing.T) {
+	t.Setenv("MOCK_SERVER", "http://some-valid-url.com")
+	credentialmanager.MockAuthCreds = true
+
+	*revertResourceParams.project = ""
+	*revertResourceParams.stage = ""
+	*revertResourceParams.service = ""
+	*revertResourceParams.resourceURI = ""
+	*revertResourceParams.revision = ""
+}
+
+func TestRevertResource(t *testing.T) {
+	resetRevertResourceParams(t)
+
+	_, err := executeActionCommandC("revert resource --project=sockshop --stage=dev --service=carts --resourceUri=slo.yaml --revision=my-commit-id --mock")
+	require.Nil(t, err)
+}
+
+func TestRevertResource_UnknownCommand(t *testing.T) {
+	testInvalidInputHelper("revert resource someUnknownCommand --project=sockshop --resourceUri=slo.yaml --revision=my-commit-id", "unknown command \"someUnknownCommand\" for \"keptn revert resource\"", t)
+}
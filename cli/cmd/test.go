@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var testCmd = &cobra.Command{
+	Use:   "test [ webhook ]",
+	Short: "Tests a Keptn entity without affecting any sequence",
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/go-utils/pkg/common/fileutils"
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const webhookDryRunPath = "/webhook-service/v1/dry-run"
+
+type testWebhookStruct struct {
+	subscriptionID *string
+	webhookFile    *string
+	eventFile      *string
+	execute        *bool
+}
+
+type webhookDryRunRequest struct {
+	SubscriptionID string                           `json:"subscriptionID,omitempty"`
+	WebhookConfig  string                           `json:"webhookConfig,omitempty"`
+	Event          apimodels.KeptnContextExtendedCE `json:"event"`
+	Execute        bool                             `json:"execute,omitempty"`
+}
+
+type webhookDryRunResult struct {
+	Type           string                       `json:"type"`
+	SubscriptionID string                       `json:"subscriptionID"`
+	Skipped        string                       `json:"skipped,omitempty"`
+	Requests       []webhookDryRunRequestResult `json:"requests"`
+	FinishedEvent  map[string]interface{}       `json:"finishedEvent,omitempty"`
+}
+
+type webhookDryRunRequestResult struct {
+	Curl    string `json:"curl,omitempty"`
+	Method  string `json:"method,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Headers []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	} `json:"headers,omitempty"`
+	Payload  string `json:"payload,omitempty"`
+	Skipped  string `json:"skipped,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Executed bool   `json:"executed"`
+	Response string `json:"response,omitempty"`
+}
+
+var testWebhookParams testWebhookStruct
+
+var testWebhookCmd = &cobra.Command{
+	Use:   "webhook (--subscription-id=SUBSCRIPTION_ID | --webhook-file=FILEPATH) --event=FILEPATH [--execute]",
+	Short: "Renders and validates the requests of a webhook for a sample event",
+	Long: `Renders the requests of a webhook with the data of a sample event, and validates them against the deny list of the webhook service. Secrets of the webhook are masked within the output.
+
+The webhook is either taken from the webhook.yaml files of the project/stage/service of the event, by providing the ID of its subscription, or from a local webhook.yaml file.
+If a local file contains multiple webhooks, the webhook matching the subscription ID, or the type of the event, is tested.
+
+With --execute, the requests are also sent to their targets, and the responses as well as the data of the .finished event that would be sent by the webhook service are shown.
+`,
+	Example: `keptn test webhook --subscription-id=my-subscription-id --event=./webhook.triggered.json
+keptn test webhook --webhook-file=./webhook.yaml --event=./webhook.triggered.json --execute`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if !isStringFlagSet(testWebhookParams.subscriptionID) && !isStringFlagSet(testWebhookParams.webhookFile) {
+			return errors.New("Either flag 'subscription-id' or 'webhook-file' must be set")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		dryRunRequest, err := newWebhookDryRunRequest()
+		if err != nil {
+			return err
+		}
+
+		api, err := internal.APIProvider(endPoint.String(), apiToken)
+		if err != nil {
+			return internal.OnAPIError(err)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			fmt.Println("Skipping test webhook due to mocking flag set to true")
+			return nil
+		}
+
+		result := &webhookDryRunResult{}
+		if err := internal.NewRESTClient(api).Post(webhookDryRunPath, dryRunRequest, result); err != nil {
+			return fmt.Errorf("Webhook could not be tested: %v", err)
+		}
+		printWebhookDryRunResult(*result)
+
+		for _, request := range result.Requests {
+			if request.Error != "" {
+				return errors.New("Webhook test failed")
+			}
+		}
+		return nil
+	},
+}
+
+func newWebhookDryRunRequest() (*webhookDryRunRequest, error) {
+	eventContent, err := fileutils.ReadFile(*testWebhookParams.eventFile)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read event file: %s", err.Error())
+	}
+	dryRunRequest := &webhookDryRunRequest{
+		SubscriptionID: *testWebhookParams.subscriptionID,
+		Execute:        *testWebhookParams.execute,
+	}
+	if err := json.Unmarshal(eventContent, &dryRunRequest.Event); err != nil {
+		return nil, fmt.Errorf("Failed to map event to API event model. %s", err.Error())
+	}
+	if isStringFlagSet(testWebhookParams.webhookFile) {
+		webhookContent, err := fileutils.ReadFile(*testWebhookParams.webhookFile)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read webhook file: %s", err.Error())
+		}
+		dryRunRequest.WebhookConfig = string(webhookContent)
+	}
+	return dryRunRequest, nil
+}
+
+func printWebhookDryRunResult(result webhookDryRunResult) {
+	fmt.Printf("Webhook for event type %s (subscription %s)\n", result.Type, result.SubscriptionID)
+	if result.Skipped != "" {
+		fmt.Printf("Skipped: %s\n", result.Skipped)
+	}
+	for i, request := range result.Requests {
+		fmt.Printf("\nRequest %d:\n", i+1)
+		if request.Curl != "" {
+			fmt.Printf("  Command:  %s\n", request.Curl)
+		} else {
+			fmt.Printf("  Request:  %s %s\n", request.Method, request.URL)
+		}
+		for _, header := range request.Headers {
+			fmt.Printf("  Header:   %s: %s\n", header.Key, header.Value)
+		}
+		if request.Payload != "" {
+			fmt.Printf("  Payload:  %s\n", request.Payload)
+		}
+		if request.Skipped != "" {
+			fmt.Printf("  Skipped:  %s\n", request.Skipped)
+		}
+		if request.Executed && request.Error == "" {
+			fmt.Printf("  Response: %s\n", strings.TrimSpace(request.Response))
+		}
+		if request.Error != "" {
+			fmt.Printf("  Error:    %s\n", request.Error)
+		}
+	}
+	if result.FinishedEvent != nil {
+		finishedEvent, _ := json.MarshalIndent(result.FinishedEvent, "", "  ")
+		fmt.Printf("\nFinished event data:\n%s\n", string(finishedEvent))
+	}
+}
+
+func init() {
+	testCmd.AddCommand(testWebhookCmd)
+
+	testWebhookParams.subscriptionID = testWebhookCmd.Flags().StringP("subscription-id", "", "", "The ID of the subscription of the webhook")
+	testWebhookParams.webhookFile = testWebhookCmd.Flags().StringP("webhook-file", "", "", "A local webhook.yaml file containing the webhook")
+	testWebhookParams.eventFile = testWebhookCmd.Flags().StringP("event", "e", "", "The file containing the sample event in JSON")
+	testWebhookCmd.MarkFlagRequired("event")
+	testWebhookParams.execute = testWebhookCmd.Flags().BoolP("execute", "", false, "Sends the rendered requests to their targets")
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookEvent = `{
+  "type": "sh.keptn.event.webhook.triggered",
+  "specversion": "1.0",
+  "source": "test",
+  "data": {
+    "project": "sockshop",
+    "stage": "dev",
+    "service": "carts"
+  },
+  "shkeptncontext": "5403dc38-dc42-4218-a587-1b5973ac32fc"
+}`
+
+func resetTestWebhookParams(t *testing.T) {
+	t.Setenv("MOCK_SERVER", "http://some-valid-url.com")
+	credentialmanager.MockAuthCreds = true
+
+	*testWebhookParams.subscriptionID = ""
+	*testWebhookParams.webhookFile = ""
+	*testWebhookParams.eventFile = ""
+	*testWebhookParams.execute = false
+}
+
+func TestTestWebhook(t *testing.T) {
+	resetTestWebhookParams(t)
+	eventFileName := "webhook.triggered.json"
+	defer testResource(t, eventFileName, testWebhookEvent)()
+
+	_, err := executeActionCommandC(fmt.Sprintf("test webhook --subscription-id=my-subscription-id --event=%s --execute --mock", eventFileName))
+	require.Nil(t, err)
+}
+
+func TestTestWebhook_MissingWebhook(t *testing.T) {
+	resetTestWebhookParams(t)
+	eventFileName := "webhook.triggered.json"
+	defer testResource(t, eventFileName, testWebhookEvent)()
+
+	_, err := executeActionCommandC(fmt.Sprintf("test webhook --event=%s --mock", eventFileName))
+	require.EqualError(t, err, "Either flag 'subscription-id' or 'webhook-file' must be set")
+}
+
+func TestTestWebhook_InvalidEventFile(t *testing.T) {
+	resetTestWebhookParams(t)
+
+	_, err := executeActionCommandC("test webhook --subscription-id=my-subscription-id --event=unknown.json --mock")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	apiutils "github.com/keptn/go-utils/pkg/api/utils"
+)
+
+// RESTClient is used to send requests to Keptn API endpoints which are not (yet) covered by the API set of go-utils.
+// It uses the same endpoint, credentials and HTTP client as the API set it has been created from
+type RESTClient struct {
+	baseURL    string
+	authHeader string
+	authToken  string
+	httpClient *http.Client
+}
+
+// NewRESTClient creates a new RESTClient based on the configuration of the given API set
+func NewRESTClient(api *apiutils.APISet) *RESTClient {
+	client := &RESTClient{
+		baseURL:    strings.TrimRight(api.Endpoint().String(), "/"),
+		authHeader: "x-token",
+		authToken:  api.Token(),
+		httpClient: &http.Client{},
+	}
+	// re-use the (possibly OAuth enabled) HTTP client of the API set
+	if resourceHandler, ok := api.ResourcesV1().(*apiutils.ResourceHandler); ok {
+		if resourceHandler.HTTPClient != nil {
+			client.httpClient = resourceHandler.HTTPClient
+		}
+		if resourceHandler.AuthHeader != "" {
+			client.authHeader = resourceHandler.AuthHeader
+		}
+	}
+	return client
+}
+
+// Get sends a GET request to the given path and decodes the response into result
+func (c *RESTClient) Get(path string, query url.Values, result interface{}) error {
+	return c.do(http.MethodGet, path, query, nil, result)
+}
+
+// Post sends a POST request with the given payload to the given path and decodes the response into result
+func (c *RESTClient) Post(path string, payload interface{}, result interface{}) error {
+	return c.do(http.MethodPost, path, nil, payload, result)
+}
+
+func (c *RESTClient) do(method string, path string, query url.Values, payload interface{}, result interface{}) error {
+	requestURL := c.baseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	var body []byte
+	if payload != nil {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("could not encode request payload: %w", err)
+		}
+	}
+
+	req, err := http.NewRequest(method, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.authToken != "" {
+		req.Header.Set(c.authHeader, c.authToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return OnAPIError(newRESTError(resp.StatusCode, respBody))
+	}
+
+	if result == nil || len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, result)
+}
+
+func newRESTError(statusCode int, body []byte) error {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError:
+		return fmt.Errorf(ErrWithStatusCode, statusCode)
+	}
+	apiErr := &apimodels.Error{}
+	if err := json.Unmarshal(body, apiErr); err == nil && apiErr.Message != nil && *apiErr.Message != "" {
+		return fmt.Errorf("%s", *apiErr.Message)
+	}
+	return fmt.Errorf(ErrWithStatusCode, statusCode)
+}
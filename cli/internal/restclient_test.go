@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	apiutils "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRESTClient_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/api/resource-service/v1/project/my-project/resource/file1/history", r.URL.Path)
+		require.Equal(t, "bar", r.URL.Query().Get("foo"))
+		require.Equal(t, "my-token", r.Header.Get("x-token"))
+		w.Write([]byte(`{"value": "ok"}`))
+	}))
+	defer server.Close()
+
+	api, err := apiutils.New(server.URL+"/api", apiutils.WithAuthToken("my-token"))
+	require.Nil(t, err)
+
+	result := map[string]string{}
+	err = NewRESTClient(api).Get("/resource-service/v1/project/my-project/resource/file1/history", url.Values{"foo": []string{"bar"}}, &result)
+	require.Nil(t, err)
+	require.Equal(t, "ok", result["value"])
+}
+
+func TestRESTClient_Post(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		body, _ := ioutil.ReadAll(r.Body)
+		payload := map[string]string{}
+		require.Nil(t, json.Unmarshal(body, &payload))
+		require.Equal(t, "commit-id", payload["gitCommitID"])
+		w.Write([]byte(`{"commitID": "new-commit-id"}`))
+	}))
+	defer server.Close()
+
+	api, err := apiutils.New(server.URL)
+	require.Nil(t, err)
+
+	result := map[string]string{}
+	err = NewRESTClient(api).Post("/revert", map[string]string{"gitCommitID": "commit-id"}, &result)
+	require.Nil(t, err)
+	require.Equal(t, "new-commit-id", result["commitID"])
+}
+
+func TestRESTClient_ErrorResponses(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantErr    string
+	}{
+		{
+			name:       "error message is returned",
+			statusCode: http.StatusNotFound,
+			body:       `{"code": 404, "message": "Resource not found"}`,
+			wantErr:    "Resource not found",
+		},
+		{
+			name:       "status code is returned if body can not be decoded",
+			statusCode: http.StatusBadGateway,
+			body:       `oops`,
+			wantErr:    "error with status code 502",
+		},
+		{
+			name:       "unauthorized",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"code": 401, "message": "unauthorized"}`,
+			wantErr:    ErrNotAuthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			api, err := apiutils.New(server.URL)
+			require.Nil(t, err)
+
+			err = NewRESTClient(api).Get("/", nil, nil)
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package event_handler
+
+import (
+	"errors"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+	logger "github.com/sirupsen/logrus"
+)
+
+// AnomalyModelStore persists the baselines of the SLIs
+type AnomalyModelStore interface {
+	// GetAnomalyModel returns the baseline of the SLI, or ErrAnomalyModelNotFound
+	GetAnomalyModel(project, stage, service, sli string) (*evaluation.AnomalyModel, error)
+	SaveAnomalyModel(model *evaluation.AnomalyModel) error
+}
+
+// ErrAnomalyModelNotFound is returned if no baseline has been learned for an SLI yet
+var ErrAnomalyModelNotFound = errors.New("anomaly model not found")
+
+// anomalyBootstrapEvaluations is the number of previous evaluations a new baseline is built from
+const anomalyBootstrapEvaluations = maxDatastoreEvaluations
+
+// getAnomalyModels returns the baselines of the SLIs with anomaly detection, updated with the given previous
+// evaluations. Baselines that do not exist yet are built from the latest evaluations of the service. Errors of the
+// stores are logged, since the evaluation can be conducted without a baseline
+func getAnomalyModels(modelStore AnomalyModelStore, evaluationStore EvaluationStore, e *keptnv2.GetSLIFinishedEventData, policies map[string]*evaluation.AnomalyPolicy, previousEvaluations []*keptnv2.EvaluationFinishedEventData, includeResult string) map[string]*evaluation.AnomalyModel {
+	models := map[string]*evaluation.AnomalyModel{}
+	if len(policies) == 0 {
+		return models
+	}
+	var latestEvaluations []*keptnv2.EvaluationFinishedEventData
+	latestEvaluationsRetrieved := false
+	for sli := range policies {
+		created := false
+		model, err := modelStore.GetAnomalyModel(e.Project, e.Stage, e.Service, sli)
+		if errors.Is(err, ErrAnomalyModelNotFound) {
+			if !latestEvaluationsRetrieved {
+				latestEvaluations = getLatestEvaluations(evaluationStore, e, includeResult)
+				latestEvaluationsRetrieved = true
+			}
+			model = evaluation.NewAnomalyModel(e.Project, e.Stage, e.Service, sli)
+			model.Update(latestEvaluations)
+			created = true
+		} else if err != nil {
+			logger.Warnf("Could not retrieve baseline of SLI %s: %v", sli, err)
+			continue
+		}
+		if updated := model.Update(previousEvaluations); updated || created {
+			if err := modelStore.SaveAnomalyModel(model); err != nil {
+				logger.Warnf("Could not store baseline of SLI %s: %v", sli, err)
+			}
+		}
+		models[sli] = model
+	}
+	return models
+}
+
+func getLatestEvaluations(store EvaluationStore, e *keptnv2.GetSLIFinishedEventData, includeResult string) []*keptnv2.EvaluationFinishedEventData {
+	stored, err := store.GetEvaluations(e.Project, e.Stage, e.Service, anomalyBootstrapEvaluations)
+	if err != nil {
+		logger.Warnf("Could not retrieve evaluations to build baselines from: %v", err)
+		return nil
+	}
+	evaluations, _ := getPrecedingEvaluations(stored, anomalyBootstrapEvaluations, includeResult)
+	return evaluations
+}
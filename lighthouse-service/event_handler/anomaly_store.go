@@ -0,0 +1,69 @@
+package event_handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const anomalyModelCollectionName = "lighthouse-anomaly-models"
+
+// MongoDBAnomalyModelStore persists the baselines of the SLIs in MongoDB
+type MongoDBAnomalyModelStore struct {
+	connection *MongoDBConnection
+}
+
+func NewMongoDBAnomalyModelStore(connection *MongoDBConnection) *MongoDBAnomalyModelStore {
+	return &MongoDBAnomalyModelStore{connection: connection}
+}
+
+func (s *MongoDBAnomalyModelStore) GetAnomalyModel(project, stage, service, sli string) (*evaluation.AnomalyModel, error) {
+	collection, err := s.getCollection()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+
+	model := &evaluation.AnomalyModel{}
+	err = collection.FindOne(ctx, anomalyModelFilter(project, stage, service, sli)).Decode(model)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrAnomalyModelNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("could not retrieve anomaly model: %w", err)
+	}
+	return model, nil
+}
+
+func (s *MongoDBAnomalyModelStore) SaveAnomalyModel(model *evaluation.AnomalyModel) error {
+	collection, err := s.getCollection()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+
+	filter := anomalyModelFilter(model.Project, model.Stage, model.Service, model.SLI)
+	if _, err := collection.ReplaceOne(ctx, filter, model, options.Replace().SetUpsert(true)); err != nil {
+		return fmt.Errorf("could not store anomaly model: %w", err)
+	}
+	return nil
+}
+
+func anomalyModelFilter(project, stage, service, sli string) bson.M {
+	return bson.M{"project": project, "stage": stage, "service": service, "sli": sli}
+}
+
+func (s *MongoDBAnomalyModelStore) getCollection() (*mongo.Collection, error) {
+	database, err := s.connection.GetDatabase()
+	if err != nil {
+		return nil, err
+	}
+	return database.Collection(anomalyModelCollectionName), nil
+}
@@ -0,0 +1,119 @@
+package event_handler
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+	"github.com/stretchr/testify/require"
+)
+
+func newAnomalyTestEvaluation(timeEnd string, throughput float64) *keptnv2.EvaluationFinishedEventData {
+	return &keptnv2.EvaluationFinishedEventData{
+		EventData: keptnv2.EventData{Result: keptnv2.ResultPass},
+		Evaluation: keptnv2.EvaluationDetails{
+			TimeEnd: timeEnd,
+			IndicatorResults: []*keptnv2.SLIEvaluationResult{
+				{Value: &keptnv2.SLIResult{Metric: "throughput", Value: throughput, Success: true}},
+			},
+		},
+	}
+}
+
+// newTestAnomalyModel returns a baseline with the values 100, 110, 90, 105 and 95 on Mondays at 13:00 UTC
+func newTestAnomalyModel() *evaluation.AnomalyModel {
+	model := evaluation.NewAnomalyModel("sockshop", "staging", "carts", "throughput")
+	model.Update([]*keptnv2.EvaluationFinishedEventData{
+		newAnomalyTestEvaluation("2022-01-31T13:05:00.000Z", 95),
+		newAnomalyTestEvaluation("2022-01-24T13:05:00.000Z", 105),
+		newAnomalyTestEvaluation("2022-01-17T13:05:00.000Z", 90),
+		newAnomalyTestEvaluation("2022-01-10T13:05:00.000Z", 110),
+		newAnomalyTestEvaluation("2022-01-03T13:05:00.000Z", 100),
+	})
+	return model
+}
+
+type fakeAnomalyModelStore struct {
+	models map[string]*evaluation.AnomalyModel
+	saved  []*evaluation.AnomalyModel
+	err    error
+}
+
+func (s *fakeAnomalyModelStore) GetAnomalyModel(_, _, _, sli string) (*evaluation.AnomalyModel, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if model, ok := s.models[sli]; ok {
+		return model, nil
+	}
+	return nil, ErrAnomalyModelNotFound
+}
+
+func (s *fakeAnomalyModelStore) SaveAnomalyModel(model *evaluation.AnomalyModel) error {
+	s.saved = append(s.saved, model)
+	return nil
+}
+
+func TestGetAnomalyModels(t *testing.T) {
+	policies := map[string]*evaluation.AnomalyPolicy{"throughput": {Sensitivity: evaluation.AnomalySensitivityHigh}}
+	e := &keptnv2.GetSLIFinishedEventData{EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts"}}
+	previousEvaluations := []*keptnv2.EvaluationFinishedEventData{newAnomalyTestEvaluation("2022-02-07T13:05:00.000Z", 102)}
+
+	// a new baseline is built from the latest evaluations, except failed ones
+	evaluationStore := &fakeEvaluationStore{latest: []*StoredEvaluation{
+		{ID: "id-1", Data: []byte(`{"result":"pass","evaluation":{"timeEnd":"2022-02-07T13:05:00.000Z","indicatorResults":[{"value":{"metric":"throughput","value":102,"success":true}}]}}`)},
+		{ID: "id-2", Data: []byte(`{"result":"fail","evaluation":{"timeEnd":"2022-01-31T13:05:00.000Z","indicatorResults":[{"value":{"metric":"throughput","value":10,"success":true}}]}}`)},
+		{ID: "id-3", Data: []byte(`{"result":"pass","evaluation":{"timeEnd":"2022-01-24T13:05:00.000Z","indicatorResults":[{"value":{"metric":"throughput","value":98,"success":true}}]}}`)},
+	}}
+	modelStore := &fakeAnomalyModelStore{}
+	models := getAnomalyModels(modelStore, evaluationStore, e, policies, previousEvaluations, "pass")
+	require.Equal(t, 2, models["throughput"].Overall.Count)
+	require.Equal(t, 100.0, models["throughput"].Overall.Mean)
+	require.Len(t, modelStore.saved, 1)
+
+	// an existing baseline is updated with the previous evaluations
+	modelStore = &fakeAnomalyModelStore{models: map[string]*evaluation.AnomalyModel{"throughput": newTestAnomalyModel()}}
+	models = getAnomalyModels(modelStore, evaluationStore, e, policies, previousEvaluations, "pass")
+	require.Equal(t, 6, models["throughput"].Overall.Count)
+	require.Len(t, modelStore.saved, 1)
+
+	// and not stored again if it is up to date
+	modelStore.saved = nil
+	models = getAnomalyModels(modelStore, evaluationStore, e, policies, previousEvaluations, "pass")
+	require.Equal(t, 6, models["throughput"].Overall.Count)
+	require.Empty(t, modelStore.saved)
+
+	// without a model store, the SLIs are evaluated without baseline
+	modelStore = &fakeAnomalyModelStore{err: errors.New("failed to connect client to MongoDB")}
+	models = getAnomalyModels(modelStore, evaluationStore, e, policies, previousEvaluations, "pass")
+	require.Empty(t, models)
+}
+
+func TestWhatIf_Anomaly(t *testing.T) {
+	var stored []*StoredEvaluation
+	for i, throughput := range []float64{150, 95, 105, 90, 110, 100} {
+		timeEnd := time.Date(2022, 2, 7, 13, 5, 0, 0, time.UTC).AddDate(0, 0, -7*i)
+		stored = append(stored, &StoredEvaluation{
+			ID: fmt.Sprintf("id-%d", i+1),
+			Data: []byte(fmt.Sprintf(`{"project":"sockshop","stage":"staging","service":"carts","result":"pass","evaluation":{
+"timeEnd":"%s","score":100,"result":"pass",
+"indicatorResults":[{"value":{"metric":"throughput","value":%v,"success":true},"score":1,"status":"pass"}]}}`, timeutils.GetKeptnTimeStamp(timeEnd), throughput)),
+		})
+	}
+
+	result, err := WhatIf(&fakeEvaluationStore{latest: stored}, WhatIfRequest{
+		Project:     "sockshop",
+		Stage:       "staging",
+		Service:     "carts",
+		SLO:         "objectives:\n  - sli: throughput\n    anomaly:\n      sensitivity: high\ntotal_score:\n  pass: \"90%\"\n",
+		Evaluations: 2,
+	})
+	require.Nil(t, err)
+	// the latest value is compared with the baseline of the five preceding ones, the second one has no baseline yet
+	require.Equal(t, "fail", result.Evaluations[0].NewResult)
+	require.Equal(t, "pass", result.Evaluations[1].NewResult)
+}
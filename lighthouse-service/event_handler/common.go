@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/base64"
 	"errors"
-	"fmt"
 	"github.com/google/uuid"
 	"net/url"
 	"os"
@@ -14,8 +13,8 @@ import (
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
 	logger "github.com/sirupsen/logrus"
-	"gopkg.in/yaml.v3"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -92,7 +91,7 @@ func (sr *SLOFileRetriever) GetSLOs(project, stage, service, commitID string) (*
 		return nil, nil, ErrSLOFileNotFound
 	}
 
-	slo, err := parseSLO([]byte(sloFile.ResourceContent))
+	slo, err := evaluation.ParseSLO([]byte(sloFile.ResourceContent))
 
 	if err != nil {
 		return nil, nil, errors.New("Could not parse SLO file for service " + service + " in stage " + stage + " in project " + project)
@@ -116,50 +115,6 @@ func checkNotFound(notFound, checkOut error) error {
 	}
 }
 
-func parseSLO(input []byte) (*keptn.ServiceLevelObjectives, error) {
-	slo := &keptn.ServiceLevelObjectives{}
-	err := yaml.Unmarshal([]byte(input), &slo)
-
-	if err != nil {
-		return nil, fmt.Errorf(err.Error())
-	}
-
-	if slo.Comparison == nil {
-		slo.Comparison = &keptn.SLOComparison{
-			CompareWith:               "single_result",
-			IncludeResultWithScore:    "all",
-			NumberOfComparisonResults: 1,
-			AggregateFunction:         "avg",
-		}
-	}
-
-	if slo.Comparison != nil {
-		if slo.Comparison.IncludeResultWithScore == "" {
-			slo.Comparison.IncludeResultWithScore = "all"
-		}
-		if slo.Comparison.NumberOfComparisonResults == 0 {
-			slo.Comparison.NumberOfComparisonResults = 3
-		}
-		if slo.Comparison.AggregateFunction == "" {
-			slo.Comparison.AggregateFunction = "avg"
-		}
-	}
-
-	objectives := []*keptn.SLO{}
-	for _, objective := range slo.Objectives {
-		if objective == nil {
-			continue
-		}
-		if objective.Weight == 0 {
-			objective.Weight = 1
-		}
-		objectives = append(objectives, objective)
-	}
-	slo.Objectives = objectives
-
-	return slo, nil
-}
-
 func sendEvent(shkeptncontext string, triggeredID, eventType, commitID string, keptnHandler *keptnv2.Keptn, data interface{}) error {
 	source, _ := url.Parse("lighthouse-service")
 
@@ -0,0 +1,461 @@
+package event_handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/google/uuid"
+	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+	logger "github.com/sirupsen/logrus"
+)
+
+// compositeSeparator separates the service from the SLI in objectives referencing the SLIs of another service of the
+// stage, e.g. payment:error_rate
+const compositeSeparator = ":"
+
+// compositeJoinTimeout determines how long the SLIs of all services of a composite evaluation are waited for, after
+// the SLIs of a service have been retrieved
+var compositeJoinTimeout = 10 * time.Minute
+
+// compositeSweepInterval determines how often the composite evaluations whose deadline has expired are looked for
+var compositeSweepInterval = time.Minute
+
+// compositeRetrieval is sent within each get-sli.triggered event of a composite evaluation, i.e. an evaluation whose
+// objectives reference SLIs of several services. It contains all get-sli.triggered events of the evaluation, so that
+// their results can be joined
+type compositeRetrieval struct {
+	// Service is the evaluated service
+	Service  string              `json:"service"`
+	Requests []*compositeRequest `json:"requests"`
+	// finishedEventIDs contains the IDs of the get-sli.finished events per get-sli.triggered event, once the results
+	// have been joined
+	finishedEventIDs map[string]string
+}
+
+// compositeRequest is the retrieval of the SLIs of a service within a composite evaluation
+type compositeRequest struct {
+	Service string `json:"service"`
+	// EventID is the ID of the get-sli.triggered event
+	EventID string `json:"eventId,omitempty"`
+	// Indicators are the SLIs as referenced by the SLO file, e.g. payment:error_rate
+	Indicators []string `json:"indicators"`
+}
+
+// indicator returns the name the SLI is retrieved with from the SLI provider of the service
+func (r *compositeRequest) indicator(indicator string) string {
+	return strings.TrimPrefix(indicator, r.Service+compositeSeparator)
+}
+
+// groupIndicatorsByService assigns the indicators to the services they are retrieved from. Indicators referencing
+// another service of the stage, e.g. payment:error_rate, are assigned to this service, all others to the evaluated
+// service. The evaluated service comes first
+func groupIndicatorsByService(serviceHandler ServiceHandler, e *keptnv2.EventData, indicators []string) []*compositeRequest {
+	requests := []*compositeRequest{{Service: e.Service}}
+	existingServices := map[string]bool{e.Service: true}
+	for _, indicator := range indicators {
+		service := e.Service
+		if prefix, _, found := strings.Cut(indicator, compositeSeparator); found && serviceHandler != nil {
+			exists, checked := existingServices[prefix]
+			if !checked {
+				_, err := serviceHandler.GetService(e.Project, e.Stage, prefix)
+				exists = err == nil
+				existingServices[prefix] = exists
+			}
+			if exists {
+				service = prefix
+			}
+		}
+		request := getCompositeRequest(requests, service)
+		if request == nil {
+			request = &compositeRequest{Service: service}
+			requests = append(requests, request)
+		}
+		request.Indicators = append(request.Indicators, indicator)
+	}
+	if len(requests[0].Indicators) == 0 {
+		requests = requests[1:]
+	}
+	return requests
+}
+
+func getCompositeRequest(requests []*compositeRequest, service string) *compositeRequest {
+	for _, request := range requests {
+		if request.Service == service {
+			return request
+		}
+	}
+	return nil
+}
+
+// isCompositeEvaluation returns whether SLIs have to be retrieved from other services than the evaluated one
+func isCompositeEvaluation(requests []*compositeRequest, service string) bool {
+	return len(requests) > 1 || (len(requests) == 1 && requests[0].Service != service)
+}
+
+// sendCompositeGetSLIEvents sends a get-sli.triggered event to the SLI provider of each service referenced by the SLO file
+func (eh *StartEvaluationHandler) sendCompositeGetSLIEvents(keptnContext string, commitID string, e *keptnv2.EvaluationTriggeredEventData, requests []*compositeRequest, sloFileContent []byte, start string, end string, filters []*keptnv2.SLIFilter, windows []*evaluation.SLIWindow) error {
+	composite := &compositeRetrieval{Service: e.Service, Requests: requests}
+	sliProviders := map[string]*ResolvedSLIProvider{}
+	for _, request := range requests {
+		// the SLI provider declared in the SLO file only applies to the evaluated service
+		content := sloFileContent
+		if request.Service != e.Service {
+			content = nil
+		}
+		sliProvider, err := eh.SLIProviderResolver.ResolveSLIProvider(e.Project, e.Stage, request.Service, content)
+		if err != nil {
+			message := fmt.Sprintf("no SLI-provider configured for service %s, which is referenced by the SLO file", request.Service)
+			logger.Error(message)
+			return eh.sendEvaluationFinishedWithErrorEvent(start, end, e, message)
+		}
+		sliProviders[request.Service] = sliProvider
+		request.EventID = uuid.New().String()
+	}
+
+	for _, request := range requests {
+		indicators := []string{}
+		for _, indicator := range request.Indicators {
+			indicators = append(indicators, request.indicator(indicator))
+		}
+		data := newGetSLITriggeredEventData(e, sliProviders[request.Service], indicators, start, end, filters, windows)
+		data.Service = request.Service
+		if request.Service != e.Service {
+			data.Deployment = ""
+		}
+		data.GetSLI.Composite = composite
+
+		logger.Infof("Retrieving SLIs %v of service %s for the evaluation of service %s from %s", indicators, request.Service, e.Service, sliProviders[request.Service].Provider)
+		if err := eh.sendGetSLITriggeredEvent(keptnContext, commitID, request.EventID, data); err != nil {
+			logger.Errorf("Could not send get-sli.triggered event for service %s: %v", request.Service, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// getCompositeRetrieval returns the composite evaluation the get-sli.finished event belongs to, or nil if the SLIs
+// have been retrieved for the evaluation of a single service
+func (eh *EvaluateSLIHandler) getCompositeRetrieval(e *keptnv2.GetSLIFinishedEventData, triggeredID string) *compositeRetrieval {
+	if triggeredID == "" {
+		return nil
+	}
+	events, err := eh.EventStore.GetEvents(&keptnapi.EventFilter{
+		Project:      e.Project,
+		EventType:    keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName),
+		KeptnContext: eh.KeptnHandler.KeptnContext,
+		EventID:      triggeredID,
+	})
+	if err != nil || len(events) == 0 {
+		return nil
+	}
+	data := &getSLITriggeredEventData{}
+	if err := events[0].DataAs(data); err != nil {
+		return nil
+	}
+	return data.GetSLI.Composite
+}
+
+// joinCompositeResults stores the result of the get-sli.finished event and joins the results of all services of the
+// composite evaluation into a single get-sli.finished event for the evaluated service, whose SLIs are named as
+// referenced by the SLO file. Only the event completing the results of all services, whose handler claims the results,
+// returns the joined event. All others return nil. The first result stores the deadline of the composite evaluation,
+// after which the evaluation fails if the SLIs of some services have not been retrieved
+func (eh *EvaluateSLIHandler) joinCompositeResults(ctx context.Context, composite *compositeRetrieval, triggeredID string, e *keptnv2.GetSLIFinishedEventData) (*keptnv2.GetSLIFinishedEventData, map[string][]float64) {
+	event, err := json.Marshal(eh.Event)
+	if err != nil {
+		logger.Errorf("Could not encode the get-sli.finished event of service %s of the evaluation of service %s: %v", e.Service, composite.Service, err)
+		return composite.erroredResult(e, fmt.Sprintf("could not store the SLIs of service %s: %v", e.Service, err)), nil
+	}
+	deadline := CompositeDeadline{ExpiresAt: time.Now().UTC().Add(compositeJoinTimeout), Event: event}
+	results, created, err := eh.CompositeResultStore.AddResult(composite.id(), triggeredID, CompositeResult{FinishedEventID: eh.Event.ID(), Data: eh.Event.Data()}, deadline)
+	if err != nil {
+		logger.Errorf("Could not store the SLIs of service %s of the evaluation of service %s: %v", e.Service, composite.Service, err)
+		return composite.erroredResult(e, fmt.Sprintf("could not store the SLIs of service %s: %v", e.Service, err)), nil
+	}
+	if len(results) < len(composite.Requests) {
+		logger.Infof("Waiting for the SLIs of %d of %d services of the evaluation of service %s", len(composite.Requests)-len(results), len(composite.Requests), composite.Service)
+		if created {
+			// if the lighthouse service is stopped before the deadline expires, the composite evaluation is evaluated
+			// by the sweep of any of its replicas instead
+			time.AfterFunc(compositeJoinTimeout, func() {
+				if ctx.Err() == nil {
+					eh.onCompositeJoinTimeout(ctx, composite, e)
+				}
+			})
+		}
+		return nil, nil
+	}
+	return eh.claimCompositeResults(composite, e)
+}
+
+// onCompositeJoinTimeout evaluates the composite evaluation, unless it has been evaluated in the meantime. If the SLIs
+// of some services are still missing, an errored evaluation.finished event is sent
+func (eh *EvaluateSLIHandler) onCompositeJoinTimeout(ctx context.Context, composite *compositeRetrieval, e *keptnv2.GetSLIFinishedEventData) {
+	if wg, ok := ctx.Value(GracefulShutdownKey).(*sync.WaitGroup); ok {
+		wg.Add(1)
+		defer wg.Done()
+	}
+	joined, samples := eh.claimCompositeResults(composite, e)
+	if joined == nil {
+		return
+	}
+	extensions := eh.Event.Extensions()
+	shkeptncontext, _ := types.ToString(extensions["shkeptncontext"])
+	commitID, _ := types.ToString(extensions["gitcommitid"])
+	if err := eh.evaluateGetSLIFinishedEvent(shkeptncontext, commitID, joined, samples); err != nil {
+		logger.Errorf("Could not send evaluation.finished event of the evaluation of service %s: %v", composite.Service, err)
+	}
+}
+
+// onExpiredComposite evaluates the composite evaluation created by the get-sli.finished event of the handler, whose
+// deadline has expired without it being evaluated
+func (eh *EvaluateSLIHandler) onExpiredComposite(ctx context.Context) error {
+	e := &keptnv2.GetSLIFinishedEventData{}
+	if err := eh.Event.DataAs(e); err != nil {
+		return fmt.Errorf("could not parse get-sli.finished event %s: %w", eh.Event.ID(), err)
+	}
+	triggeredID, _ := types.ToString(eh.Event.Extensions()["triggeredid"])
+	composite := eh.getCompositeRetrieval(e, triggeredID)
+	if composite == nil {
+		return fmt.Errorf("could not retrieve the composite evaluation of get-sli.triggered event %s", triggeredID)
+	}
+	eh.onCompositeJoinTimeout(ctx, composite, e)
+	return nil
+}
+
+// SweepExpiredComposites evaluates the composite evaluations whose deadline has expired without them being evaluated,
+// e.g. because the replica of the lighthouse service that created them has been restarted. It sweeps them on startup
+// and then periodically, until the context, which has to provide the event sender, is cancelled
+func SweepExpiredComposites(ctx context.Context) {
+	ticker := time.NewTicker(compositeSweepInterval)
+	defer ticker.Stop()
+	for {
+		sweepExpiredComposites(ctx, compositeResultStore, NewEventHandler)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func sweepExpiredComposites(ctx context.Context, store CompositeResultStore, newEventHandler func(ctx context.Context, event cloudevents.Event) (EvaluationEventHandler, error)) {
+	deadlines, err := store.ListExpired(time.Now().UTC())
+	if err != nil {
+		logger.Errorf("Could not sweep expired composite evaluations: %v", err)
+		return
+	}
+	for _, deadline := range deadlines {
+		event := cloudevents.NewEvent()
+		if err := json.Unmarshal(deadline.Event, &event); err != nil {
+			logger.Errorf("Could not decode the get-sli.finished event of an expired composite evaluation: %v", err)
+			continue
+		}
+		handler, err := newEventHandler(ctx, event)
+		if err != nil {
+			logger.Errorf("Could not create handler for the expired composite evaluation of get-sli.finished event %s: %v", event.ID(), err)
+			continue
+		}
+		eh, ok := handler.(*EvaluateSLIHandler)
+		if !ok {
+			continue
+		}
+		if err := eh.onExpiredComposite(ctx); err != nil {
+			logger.Errorf("Could not evaluate expired composite evaluation: %v", err)
+		}
+	}
+}
+
+// claimCompositeResults claims the results of the composite evaluation and joins them. It returns nil if the results
+// have already been claimed by another event handler
+func (eh *EvaluateSLIHandler) claimCompositeResults(composite *compositeRetrieval, e *keptnv2.GetSLIFinishedEventData) (*keptnv2.GetSLIFinishedEventData, map[string][]float64) {
+	results, claimed, err := eh.CompositeResultStore.ClaimResults(composite.id())
+	if err != nil {
+		logger.Errorf("Could not claim the SLIs of the evaluation of service %s: %v", composite.Service, err)
+		return composite.erroredResult(e, fmt.Sprintf("could not claim the SLIs of the services: %v", err)), nil
+	}
+	if !claimed {
+		return nil, nil
+	}
+	eh.composite = composite
+
+	finished := map[string]*keptnv2.GetSLIFinishedEventData{}
+	finishedSamples := map[string]map[string][]float64{}
+	composite.finishedEventIDs = map[string]string{}
+	for triggeredID, result := range results {
+		data := &keptnv2.GetSLIFinishedEventData{}
+		eventSamples := evaluation.SLISamplesEventData{}
+		if getCompositeRequestByEventID(composite.Requests, triggeredID) == nil || json.Unmarshal(result.Data, data) != nil || json.Unmarshal(result.Data, &eventSamples) != nil {
+			continue
+		}
+		finished[triggeredID] = data
+		finishedSamples[triggeredID] = eventSamples.GetSLISamples()
+		composite.finishedEventIDs[triggeredID] = result.FinishedEventID
+	}
+
+	missing := []string{}
+	for _, request := range composite.Requests {
+		if _, ok := finished[request.EventID]; !ok {
+			missing = append(missing, request.Service)
+		}
+	}
+	if len(missing) > 0 {
+		logger.Errorf("The SLIs of services %v of the evaluation of service %s have not been retrieved within %s", missing, composite.Service, compositeJoinTimeout)
+		return composite.erroredResult(e, fmt.Sprintf("the SLIs of services %s have not been retrieved within %s", strings.Join(missing, ", "), compositeJoinTimeout)), nil
+	}
+	return composite.join(e, finished, finishedSamples)
+}
+
+func getCompositeRequestByEventID(requests []*compositeRequest, eventID string) *compositeRequest {
+	for _, request := range requests {
+		if request.EventID == eventID {
+			return request
+		}
+	}
+	return nil
+}
+
+// id identifies the composite evaluation by the ID of its first get-sli.triggered event
+func (c *compositeRetrieval) id() string {
+	return c.Requests[0].EventID
+}
+
+// erroredResult returns a failed get-sli.finished event for the evaluated service
+func (c *compositeRetrieval) erroredResult(e *keptnv2.GetSLIFinishedEventData, message string) *keptnv2.GetSLIFinishedEventData {
+	return &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{
+			Project: e.Project,
+			Stage:   e.Stage,
+			Service: c.Service,
+			Labels:  e.Labels,
+			Status:  keptnv2.StatusErrored,
+			Message: message,
+		},
+		GetSLI: keptnv2.GetSLIFinished{Start: e.GetSLI.Start, End: e.GetSLI.End},
+	}
+}
+
+// join combines the results of the services. If the retrieval failed for any service, the joined result fails as well
+func (c *compositeRetrieval) join(e *keptnv2.GetSLIFinishedEventData, results map[string]*keptnv2.GetSLIFinishedEventData, samples map[string]map[string][]float64) (*keptnv2.GetSLIFinishedEventData, map[string][]float64) {
+	joined := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{
+			Project: e.Project,
+			Stage:   e.Stage,
+			Service: c.Service,
+			Labels:  e.Labels,
+			Status:  keptnv2.StatusSucceeded,
+			Result:  keptnv2.ResultPass,
+		},
+		GetSLI: keptnv2.GetSLIFinished{
+			Start:           e.GetSLI.Start,
+			End:             e.GetSLI.End,
+			IndicatorValues: []*keptnv2.SLIResult{},
+		},
+	}
+	joinedSamples := map[string][]float64{}
+	for _, request := range c.Requests {
+		result := results[request.EventID]
+		if request.Service == c.Service && result.Labels != nil {
+			joined.Labels = result.Labels
+		}
+		if result.Status == keptnv2.StatusErrored || result.Status == keptnv2.StatusAborted {
+			joined.Status = result.Status
+			joined.Message = fmt.Sprintf("service %s: %s", request.Service, result.Message)
+		} else if result.Result == keptnv2.ResultFailed && joined.Status == keptnv2.StatusSucceeded {
+			joined.Result = keptnv2.ResultFailed
+			joined.Message = fmt.Sprintf("service %s: %s", request.Service, result.Message)
+		}
+		for _, indicator := range request.Indicators {
+			for _, value := range result.GetSLI.IndicatorValues {
+				if value.Metric != request.indicator(indicator) {
+					continue
+				}
+				joinedValue := *value
+				joinedValue.Metric = indicator
+				joined.GetSLI.IndicatorValues = append(joined.GetSLI.IndicatorValues, &joinedValue)
+				if s, ok := samples[request.EventID][value.Metric]; ok {
+					joinedSamples[indicator] = s
+				}
+			}
+		}
+	}
+	return joined, joinedSamples
+}
+
+// sourceEvent returns the ID of the get-sli.finished event the SLI has been retrieved with
+func (c *compositeRetrieval) sourceEvent(indicator string) string {
+	for _, request := range c.Requests {
+		if containsIndicator(request.Indicators, indicator) {
+			return c.finishedEventIDs[request.EventID]
+		}
+	}
+	return ""
+}
+
+// breakdown returns the results of the objectives of each service
+func (c *compositeRetrieval) breakdown(data *evaluation.EvaluationFinishedEventData, sloConfig *keptn.ServiceLevelObjectives) []*evaluation.ServiceEvaluation {
+	weights := map[string]int{}
+	for _, objective := range sloConfig.Objectives {
+		weights[objective.SLI] = objective.Weight
+	}
+	services := []*evaluation.ServiceEvaluation{}
+	for _, request := range c.Requests {
+		service := &evaluation.ServiceEvaluation{Service: request.Service, Result: string(keptnv2.ResultPass), Indicators: []string{}}
+		achieved, maximum := 0.0, 0.0
+		for _, indicatorResult := range data.Evaluation.IndicatorResults {
+			if indicatorResult.Value == nil || !containsIndicator(request.Indicators, indicatorResult.Value.Metric) {
+				continue
+			}
+			service.Indicators = append(service.Indicators, indicatorResult.Value.Metric)
+			switch indicatorResult.Status {
+			case "info":
+				continue
+			case "fail":
+				service.Result = string(keptnv2.ResultFailed)
+			case "warning":
+				if service.Result == string(keptnv2.ResultPass) {
+					service.Result = string(keptnv2.ResultWarning)
+				}
+			}
+			achieved += indicatorResult.Score
+			maximum += float64(weights[indicatorResult.Value.Metric])
+		}
+		service.Score = 100.0
+		if maximum > 0 {
+			service.Score = 100.0 * achieved / maximum
+		}
+		services = append(services, service)
+	}
+	return services
+}
+
+func containsIndicator(indicators []string, indicator string) bool {
+	for _, i := range indicators {
+		if i == indicator {
+			return true
+		}
+	}
+	return false
+}
+
+// compositeRetrievalFromBreakdown restores the services of a composite evaluation from its breakdown, e.g. to
+// re-evaluate it
+func compositeRetrievalFromBreakdown(service string, breakdown []*evaluation.ServiceEvaluation) *compositeRetrieval {
+	if len(breakdown) == 0 {
+		return nil
+	}
+	composite := &compositeRetrieval{Service: service}
+	for _, serviceEvaluation := range breakdown {
+		composite.Requests = append(composite.Requests, &compositeRequest{Service: serviceEvaluation.Service, Indicators: serviceEvaluation.Indicators})
+	}
+	return composite
+}
@@ -0,0 +1,159 @@
+package event_handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const compositeResultCollectionName = "lighthouse-composite-results"
+
+// compositeResultRetention determines how long the results of composite evaluations are kept
+const compositeResultRetention = 24 * time.Hour
+
+// CompositeResultStore collects the results of the get-sli.finished events of composite evaluations, so that exactly
+// one event handler evaluates them, even if the events are processed concurrently by several replicas
+type CompositeResultStore interface {
+	// AddResult stores the result of a get-sli.triggered event of the composite evaluation, and returns the results
+	// of all get-sli.triggered events that have been stored so far, including the given one. The first result creates
+	// the composite evaluation with the given deadline, which is reported by the returned bool
+	AddResult(compositeID string, triggeredID string, result CompositeResult, deadline CompositeDeadline) (map[string]CompositeResult, bool, error)
+	// ClaimResults marks the composite evaluation as evaluated, and returns the results that have been stored so far.
+	// It returns false if the composite evaluation has already been claimed
+	ClaimResults(compositeID string) (map[string]CompositeResult, bool, error)
+	// ListExpired returns the deadlines of the composite evaluations that have not been claimed before they expired
+	ListExpired(now time.Time) ([]CompositeDeadline, error)
+}
+
+// CompositeDeadline determines when a composite evaluation is evaluated, even if the SLIs of some services are still
+// missing. It is stored with the results, so that any replica of the lighthouse service can evaluate the composite
+// evaluation once it has expired, e.g. if the replica that created it has been restarted in the meantime
+type CompositeDeadline struct {
+	ExpiresAt time.Time `bson:"expiresAt"`
+	// Event is the JSON encoded get-sli.finished event that created the composite evaluation
+	Event []byte `bson:"event"`
+}
+
+// CompositeResult is the get-sli.finished event of a service within a composite evaluation
+type CompositeResult struct {
+	FinishedEventID string `bson:"finishedEventId"`
+	// Data is the JSON encoded data of the get-sli.finished event, including the samples of the SLIs, if any
+	Data []byte `bson:"data"`
+}
+
+type compositeResultDocument struct {
+	Results  map[string]CompositeResult `bson:"results"`
+	Deadline CompositeDeadline          `bson:"deadline"`
+}
+
+// MongoDBCompositeResultStore stores the results of composite evaluations in MongoDB
+type MongoDBCompositeResultStore struct {
+	connection *MongoDBConnection
+	mutex      sync.Mutex
+	collection *mongo.Collection
+}
+
+func NewMongoDBCompositeResultStore(connection *MongoDBConnection) *MongoDBCompositeResultStore {
+	return &MongoDBCompositeResultStore{connection: connection}
+}
+
+func (s *MongoDBCompositeResultStore) AddResult(compositeID string, triggeredID string, result CompositeResult, deadline CompositeDeadline) (map[string]CompositeResult, bool, error) {
+	collection, err := s.getCollection()
+	if err != nil {
+		return nil, false, err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+
+	// the update is atomic, so only the result completing the composite evaluation sees the results of all services,
+	// and only the first result creates the composite evaluation
+	update := bson.M{
+		"$set":         bson.M{"results." + triggeredID: result},
+		"$setOnInsert": bson.M{"createdAt": time.Now().UTC(), "claimed": false, "deadline": deadline},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+	document := &compositeResultDocument{}
+	err = collection.FindOneAndUpdate(ctx, bson.M{"_id": compositeID}, update, opts).Decode(document)
+	created := errors.Is(err, mongo.ErrNoDocuments)
+	if err != nil && !created {
+		return nil, false, fmt.Errorf("could not store result of composite evaluation: %w", err)
+	}
+	if document.Results == nil {
+		document.Results = map[string]CompositeResult{}
+	}
+	document.Results[triggeredID] = result
+	return document.Results, created, nil
+}
+
+func (s *MongoDBCompositeResultStore) ClaimResults(compositeID string) (map[string]CompositeResult, bool, error) {
+	collection, err := s.getCollection()
+	if err != nil {
+		return nil, false, err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": compositeID, "claimed": false}
+	update := bson.M{"$set": bson.M{"claimed": true}}
+	document := &compositeResultDocument{}
+	err = collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("could not claim results of composite evaluation: %w", err)
+	}
+	return document.Results, true, nil
+}
+
+func (s *MongoDBCompositeResultStore) ListExpired(now time.Time) ([]CompositeDeadline, error) {
+	collection, err := s.getCollection()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{"claimed": false, "deadline.expiresAt": bson.M{"$lte": now}}
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"deadline": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve expired composite evaluations: %w", err)
+	}
+	documents := []compositeResultDocument{}
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, fmt.Errorf("could not retrieve expired composite evaluations: %w", err)
+	}
+	deadlines := []CompositeDeadline{}
+	for _, document := range documents {
+		deadlines = append(deadlines, document.Deadline)
+	}
+	return deadlines, nil
+}
+
+func (s *MongoDBCompositeResultStore) getCollection() (*mongo.Collection, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.collection != nil {
+		return s.collection, nil
+	}
+
+	database, err := s.connection.GetDatabase()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	collection := database.Collection(compositeResultCollectionName)
+	// the results are only required until the composite evaluation has been evaluated
+	index := mongo.IndexModel{Keys: bson.M{"createdAt": 1}, Options: options.Index().SetExpireAfterSeconds(int32(compositeResultRetention.Seconds()))}
+	if _, err := collection.Indexes().CreateOne(ctx, index); err != nil {
+		return nil, fmt.Errorf("could not create index of composite results: %w", err)
+	}
+	s.collection = collection
+	return s.collection, nil
+}
@@ -0,0 +1,393 @@
+package event_handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	event_handler_mock "github.com/keptn/keptn/lighthouse-service/event_handler/fake"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+	"github.com/stretchr/testify/require"
+)
+
+const testCompositeSLO = `spec_version: "1.0"
+objectives:
+  - sli: "response_time_p95"
+    pass:
+      - criteria:
+          - "<=600"
+  - sli: "payment:error_rate"
+    weight: 2
+    pass:
+      - criteria:
+          - "<=1"
+  - sli: "job:http_requests:rate5m"
+total_score:
+  pass: "90%"
+`
+
+func newCompositeServiceHandler() *event_handler_mock.ServiceHandlerMock {
+	return &event_handler_mock.ServiceHandlerMock{
+		GetServiceFunc: func(project string, stage string, service string) (*models.Service, error) {
+			if service == "payment" {
+				return &models.Service{ServiceName: service}, nil
+			}
+			return nil, errors.New("service not found")
+		},
+	}
+}
+
+func TestGroupIndicatorsByService(t *testing.T) {
+	e := &keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts"}
+
+	requests := groupIndicatorsByService(newCompositeServiceHandler(), e, []string{"response_time_p95", "payment:error_rate", "job:http_requests:rate5m", "payment:error_rate[5m]"})
+	require.Equal(t, []*compositeRequest{
+		{Service: "carts", Indicators: []string{"response_time_p95", "job:http_requests:rate5m"}},
+		{Service: "payment", Indicators: []string{"payment:error_rate", "payment:error_rate[5m]"}},
+	}, requests)
+	require.True(t, isCompositeEvaluation(requests, "carts"))
+	require.Equal(t, "error_rate[5m]", requests[1].indicator("payment:error_rate[5m]"))
+
+	// SLIs containing the separator are not composite, unless the prefix is a service of the stage
+	requests = groupIndicatorsByService(newCompositeServiceHandler(), e, []string{"response_time_p95", "job:http_requests:rate5m"})
+	require.False(t, isCompositeEvaluation(requests, "carts"))
+
+	requests = groupIndicatorsByService(newCompositeServiceHandler(), e, []string{"payment:error_rate"})
+	require.Equal(t, []*compositeRequest{{Service: "payment", Indicators: []string{"payment:error_rate"}}}, requests)
+	require.True(t, isCompositeEvaluation(requests, "carts"))
+}
+
+func TestStartEvaluationHandler_sendCompositeGetSLIEvents(t *testing.T) {
+	keptnHandler, sender := newFingerprintTestKeptnHandler(t)
+	sloFileRetriever := newSLOFileRetriever(testCompositeSLO)
+	sloFileRetriever.ServiceHandler = newCompositeServiceHandler()
+	eh := &StartEvaluationHandler{
+		Event:        *keptnHandler.CloudEvent,
+		KeptnHandler: keptnHandler,
+		SLIProviderResolver: SLIProviderChain{
+			DefaultSLIProviderSource{Name: SLIProviderSourceConfigMapDefault, Config: &MockSLIProviderConfig{DefaultSLIProvider: struct {
+				val string
+				err error
+			}{val: "prometheus"}}},
+		},
+		SLOFileRetriever: sloFileRetriever,
+		EvaluationStore:  &fakeEvaluationStore{},
+	}
+	e := &keptnv2.EvaluationTriggeredEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts"},
+	}
+
+	err := eh.sendGetSliCloudEvent(context.Background(), "my-context", "", e, "2022-01-01T10:00:00.000Z", "2022-01-01T10:05:00.000Z")
+	require.Nil(t, err)
+	require.Len(t, sender.SentEvents, 2)
+
+	var composite *compositeRetrieval
+	for i, wantService := range []string{"carts", "payment"} {
+		require.Equal(t, keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName), sender.SentEvents[i].Type())
+		data := &getSLITriggeredEventData{}
+		require.Nil(t, sender.SentEvents[i].DataAs(data))
+		require.Equal(t, wantService, data.Service)
+		require.Equal(t, "prometheus", data.GetSLI.SLIProvider)
+		require.NotNil(t, data.GetSLI.Composite)
+		composite = data.GetSLI.Composite
+		require.Equal(t, sender.SentEvents[i].ID(), composite.Requests[i].EventID)
+	}
+	require.Equal(t, "carts", composite.Service)
+
+	data := &getSLITriggeredEventData{}
+	require.Nil(t, sender.SentEvents[1].DataAs(data))
+	require.Equal(t, []string{"error_rate"}, data.GetSLI.Indicators)
+}
+
+// fakeCompositeResultStore keeps the results of composite evaluations in memory
+type fakeCompositeResultStore struct {
+	mutex     sync.Mutex
+	results   map[string]map[string]CompositeResult
+	claimed   map[string]bool
+	deadlines map[string]CompositeDeadline
+}
+
+func newFakeCompositeResultStore() *fakeCompositeResultStore {
+	return &fakeCompositeResultStore{results: map[string]map[string]CompositeResult{}, claimed: map[string]bool{}, deadlines: map[string]CompositeDeadline{}}
+}
+
+func (s *fakeCompositeResultStore) AddResult(compositeID string, triggeredID string, result CompositeResult, deadline CompositeDeadline) (map[string]CompositeResult, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	created := false
+	if s.results[compositeID] == nil {
+		s.results[compositeID] = map[string]CompositeResult{}
+		s.deadlines[compositeID] = deadline
+		created = true
+	}
+	s.results[compositeID][triggeredID] = result
+	return copyCompositeResults(s.results[compositeID]), created, nil
+}
+
+func (s *fakeCompositeResultStore) ClaimResults(compositeID string) (map[string]CompositeResult, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.claimed[compositeID] {
+		return nil, false, nil
+	}
+	s.claimed[compositeID] = true
+	return copyCompositeResults(s.results[compositeID]), true, nil
+}
+
+func (s *fakeCompositeResultStore) ListExpired(now time.Time) ([]CompositeDeadline, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	deadlines := []CompositeDeadline{}
+	for compositeID, deadline := range s.deadlines {
+		if !s.claimed[compositeID] && !deadline.ExpiresAt.After(now) {
+			deadlines = append(deadlines, deadline)
+		}
+	}
+	return deadlines, nil
+}
+
+func copyCompositeResults(results map[string]CompositeResult) map[string]CompositeResult {
+	copied := map[string]CompositeResult{}
+	for triggeredID, result := range results {
+		copied[triggeredID] = result
+	}
+	return copied
+}
+
+func TestEvaluateSLIHandler_compositeEvaluation(t *testing.T) {
+	timeout := compositeJoinTimeout
+	compositeJoinTimeout = 10 * time.Millisecond
+	t.Cleanup(func() { compositeJoinTimeout = timeout })
+	composite := &compositeRetrieval{
+		Service: "carts",
+		Requests: []*compositeRequest{
+			{Service: "carts", EventID: "get-sli-carts", Indicators: []string{"response_time_p95", "job:http_requests:rate5m"}},
+			{Service: "payment", EventID: "get-sli-payment", Indicators: []string{"payment:error_rate"}},
+		},
+	}
+	cartsFinished, err := json.Marshal(keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts", Labels: map[string]string{"buildnr": "18"}, Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultPass},
+		GetSLI: keptnv2.GetSLIFinished{
+			Start: "2022-01-01T10:00:00.000Z",
+			End:   "2022-01-01T10:05:00.000Z",
+			IndicatorValues: []*keptnv2.SLIResult{
+				{Metric: "response_time_p95", Value: 500, Success: true},
+				{Metric: "job:http_requests:rate5m", Value: 20, Success: true},
+			},
+		},
+	})
+	require.Nil(t, err)
+	paymentFinished := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "payment", Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultPass},
+		GetSLI: keptnv2.GetSLIFinished{
+			Start:           "2022-01-01T10:00:00.000Z",
+			End:             "2022-01-01T10:05:00.000Z",
+			IndicatorValues: []*keptnv2.SLIResult{{Metric: "error_rate", Value: 2, Success: true}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		storedResults map[string]CompositeResult
+		claimed       bool
+		wantEvents    int
+		wantStatus    keptnv2.StatusType
+	}{
+		{
+			name:       "SLIs of other services are not retrieved before the deadline",
+			wantEvents: 1,
+			wantStatus: keptnv2.StatusErrored,
+		},
+		{
+			name:          "SLIs of all services have been retrieved",
+			storedResults: map[string]CompositeResult{"get-sli-carts": {FinishedEventID: "get-sli-carts-finished", Data: cartsFinished}},
+			wantEvents:    1,
+			wantStatus:    keptnv2.StatusSucceeded,
+		},
+		{
+			name:          "SLIs of all services have already been evaluated",
+			storedResults: map[string]CompositeResult{"get-sli-carts": {FinishedEventID: "get-sli-carts-finished", Data: cartsFinished}},
+			claimed:       true,
+			wantEvents:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keptnHandler, sender := newFingerprintTestKeptnHandler(t)
+			event := cloudevents.NewEvent()
+			event.SetID("get-sli-payment-finished")
+			event.SetType(keptnv2.GetFinishedEventType(keptnv2.GetSLITaskName))
+			event.SetSource("prometheus-service")
+			event.SetExtension("shkeptncontext", "my-context")
+			event.SetExtension("triggeredid", "get-sli-payment")
+			require.Nil(t, event.SetData(cloudevents.ApplicationJSON, paymentFinished))
+
+			compositeResultStore := newFakeCompositeResultStore()
+			if tt.storedResults != nil {
+				compositeResultStore.results[composite.id()] = tt.storedResults
+			}
+			compositeResultStore.claimed[composite.id()] = tt.claimed
+
+			sloFileRetriever := newSLOFileRetriever(testCompositeSLO)
+			eh := &EvaluateSLIHandler{
+				Event:                event,
+				HTTPClient:           &http.Client{},
+				KeptnHandler:         keptnHandler,
+				SLOFileRetriever:     sloFileRetriever,
+				EvaluationStore:      &fakeEvaluationStore{},
+				CompositeResultStore: compositeResultStore,
+				EventStore: &event_handler_mock.EventStoreMock{GetEventsFunc: func(filter *keptnapi.EventFilter) ([]*models.KeptnContextExtendedCE, *models.Error) {
+					switch filter.EventType {
+					case keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName):
+						require.Equal(t, "get-sli-payment", filter.EventID)
+						return []*models.KeptnContextExtendedCE{{ID: "get-sli-payment", Data: getSLITriggeredEventData{GetSLI: getSLI{Composite: composite}}}}, nil
+					case keptnv2.GetTriggeredEventType(keptnv2.EvaluationTaskName):
+						require.Equal(t, "carts", filter.Service)
+						return []*models.KeptnContextExtendedCE{{ID: "my-triggered-id"}}, nil
+					}
+					return nil, nil
+				}},
+			}
+
+			err := eh.processGetSliFinishedEvent(context.Background(), "my-context", "", paymentFinished)
+			require.Nil(t, err)
+			if tt.storedResults == nil {
+				// the first result stores the deadline, so that the evaluation can be swept by any replica
+				compositeResultStore.mutex.Lock()
+				require.NotEmpty(t, compositeResultStore.deadlines[composite.id()].Event)
+				compositeResultStore.mutex.Unlock()
+				// the evaluation fails once the deadline has passed
+				require.Eventually(t, func() bool {
+					compositeResultStore.mutex.Lock()
+					defer compositeResultStore.mutex.Unlock()
+					return compositeResultStore.claimed[composite.id()]
+				}, time.Second, 5*time.Millisecond)
+				require.Eventually(t, func() bool { return len(sender.SentEvents) == tt.wantEvents }, time.Second, 5*time.Millisecond)
+			}
+			require.Len(t, sender.SentEvents, tt.wantEvents)
+			if tt.wantEvents == 0 {
+				return
+			}
+
+			finished := &evaluation.EvaluationFinishedEventData{}
+			require.Nil(t, sender.SentEvents[0].DataAs(finished))
+			require.Equal(t, "carts", finished.Service)
+			require.Equal(t, tt.wantStatus, finished.Status)
+			require.Equal(t, keptnv2.ResultFailed, finished.Result)
+			if tt.wantStatus == keptnv2.StatusErrored {
+				require.Contains(t, finished.Message, "the SLIs of services carts have not been retrieved within 10ms")
+				return
+			}
+			require.Equal(t, map[string]string{"buildnr": "18"}, finished.Labels)
+			require.Equal(t, "payment:error_rate", finished.Evaluation.IndicatorResults[1].Value.Metric)
+			require.Equal(t, 2.0, finished.Evaluation.IndicatorResults[1].Value.Value)
+			require.Equal(t, []*evaluation.ServiceEvaluation{
+				{Service: "carts", Score: 100, Result: "pass", Indicators: []string{"response_time_p95", "job:http_requests:rate5m"}},
+				{Service: "payment", Score: 0, Result: "fail", Indicators: []string{"payment:error_rate"}},
+			}, finished.Evaluation.Services)
+			require.Equal(t, "get-sli-carts-finished", eh.composite.sourceEvent("response_time_p95"))
+		})
+	}
+}
+
+func TestCompositeRetrieval_join(t *testing.T) {
+	composite := &compositeRetrieval{
+		Service: "carts",
+		Requests: []*compositeRequest{
+			{Service: "carts", EventID: "get-sli-carts", Indicators: []string{"response_time_p95"}},
+			{Service: "payment", EventID: "get-sli-payment", Indicators: []string{"payment:error_rate"}},
+		},
+	}
+	results := map[string]*keptnv2.GetSLIFinishedEventData{
+		"get-sli-carts": {
+			EventData: keptnv2.EventData{Service: "carts", Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultPass},
+			GetSLI:    keptnv2.GetSLIFinished{IndicatorValues: []*keptnv2.SLIResult{{Metric: "response_time_p95", Value: 500, Success: true}}},
+		},
+		"get-sli-payment": {
+			EventData: keptnv2.EventData{Service: "payment", Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultFailed, Message: "could not query prometheus"},
+		},
+	}
+	samples := map[string]map[string][]float64{"get-sli-carts": {"response_time_p95": {480, 520}}}
+
+	joined, joinedSamples := composite.join(results["get-sli-payment"], results, samples)
+	require.Equal(t, "carts", joined.Service)
+	require.Equal(t, keptnv2.ResultFailed, joined.Result)
+	require.Equal(t, "service payment: could not query prometheus", joined.Message)
+	require.Equal(t, []*keptnv2.SLIResult{{Metric: "response_time_p95", Value: 500, Success: true}}, joined.GetSLI.IndicatorValues)
+	require.Equal(t, map[string][]float64{"response_time_p95": {480, 520}}, joinedSamples)
+}
+
+func TestSweepExpiredComposites(t *testing.T) {
+	composite := &compositeRetrieval{
+		Service: "carts",
+		Requests: []*compositeRequest{
+			{Service: "carts", EventID: "get-sli-carts", Indicators: []string{"response_time_p95"}},
+			{Service: "payment", EventID: "get-sli-payment", Indicators: []string{"payment:error_rate"}},
+		},
+	}
+	paymentFinished := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "payment", Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultPass},
+		GetSLI:    keptnv2.GetSLIFinished{IndicatorValues: []*keptnv2.SLIResult{{Metric: "error_rate", Value: 2, Success: true}}},
+	}
+	event := cloudevents.NewEvent()
+	event.SetID("get-sli-payment-finished")
+	event.SetType(keptnv2.GetFinishedEventType(keptnv2.GetSLITaskName))
+	event.SetSource("prometheus-service")
+	event.SetExtension("shkeptncontext", "my-context")
+	event.SetExtension("triggeredid", "get-sli-payment")
+	require.Nil(t, event.SetData(cloudevents.ApplicationJSON, paymentFinished))
+	encodedEvent, err := json.Marshal(event)
+	require.Nil(t, err)
+
+	// the composite evaluation has been created by a replica that has been stopped before its deadline expired
+	compositeResultStore := newFakeCompositeResultStore()
+	compositeResultStore.results[composite.id()] = map[string]CompositeResult{"get-sli-payment": {FinishedEventID: event.ID(), Data: event.Data()}}
+	compositeResultStore.deadlines[composite.id()] = CompositeDeadline{ExpiresAt: time.Now().Add(-time.Minute), Event: encodedEvent}
+	compositeResultStore.results["pending"] = map[string]CompositeResult{}
+	compositeResultStore.deadlines["pending"] = CompositeDeadline{ExpiresAt: time.Now().Add(time.Minute), Event: encodedEvent}
+
+	keptnHandler, sender := newFingerprintTestKeptnHandler(t)
+	newEventHandler := func(ctx context.Context, event cloudevents.Event) (EvaluationEventHandler, error) {
+		require.Equal(t, "get-sli-payment-finished", event.ID())
+		return &EvaluateSLIHandler{
+			Event:                event,
+			HTTPClient:           &http.Client{},
+			KeptnHandler:         keptnHandler,
+			SLOFileRetriever:     newSLOFileRetriever(testCompositeSLO),
+			EvaluationStore:      &fakeEvaluationStore{},
+			CompositeResultStore: compositeResultStore,
+			EventStore: &event_handler_mock.EventStoreMock{GetEventsFunc: func(filter *keptnapi.EventFilter) ([]*models.KeptnContextExtendedCE, *models.Error) {
+				switch filter.EventType {
+				case keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName):
+					return []*models.KeptnContextExtendedCE{{ID: "get-sli-payment", Data: getSLITriggeredEventData{GetSLI: getSLI{Composite: composite}}}}, nil
+				case keptnv2.GetTriggeredEventType(keptnv2.EvaluationTaskName):
+					return []*models.KeptnContextExtendedCE{{ID: "my-triggered-id"}}, nil
+				}
+				return nil, nil
+			}},
+		}, nil
+	}
+
+	sweepExpiredComposites(context.Background(), compositeResultStore, newEventHandler)
+
+	require.True(t, compositeResultStore.claimed[composite.id()])
+	require.False(t, compositeResultStore.claimed["pending"])
+	require.Len(t, sender.SentEvents, 1)
+	finished := &evaluation.EvaluationFinishedEventData{}
+	require.Nil(t, sender.SentEvents[0].DataAs(finished))
+	require.Equal(t, "carts", finished.Service)
+	require.Equal(t, keptnv2.StatusErrored, finished.Status)
+	require.Contains(t, finished.Message, "the SLIs of services carts have not been retrieved")
+
+	// the composite evaluation is only evaluated once
+	sweepExpiredComposites(context.Background(), compositeResultStore, newEventHandler)
+	require.Len(t, sender.SentEvents, 1)
+}
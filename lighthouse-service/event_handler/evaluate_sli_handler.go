@@ -7,11 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"math"
 	"net/http"
-	"regexp"
-	"sort"
-	"strconv"
 	"strings"
 	"sync"
 
@@ -22,6 +18,7 @@ import (
 	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
 	keptn "github.com/keptn/go-utils/pkg/lib"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
 )
 
 type datastoreResult struct {
@@ -34,20 +31,18 @@ type datastoreResult struct {
 	}
 }
 
-type criteriaObject struct {
-	Operator        string
-	Value           float64
-	CheckPercentage bool
-	IsComparison    bool
-	CheckIncrease   bool
-}
-
 type EvaluateSLIHandler struct {
-	Event            cloudevents.Event
-	HTTPClient       *http.Client
-	KeptnHandler     *keptnv2.Keptn
-	SLOFileRetriever SLOFileRetriever `deep:"-"`
-	EventStore       EventStore
+	Event             cloudevents.Event
+	HTTPClient        *http.Client
+	KeptnHandler      *keptnv2.Keptn
+	SLOFileRetriever  SLOFileRetriever `deep:"-"`
+	EventStore        EventStore
+	EvaluationStore   EvaluationStore   `deep:"-"`
+	AnomalyModelStore AnomalyModelStore `deep:"-"`
+	// CompositeResultStore collects the SLIs of the services of composite evaluations
+	CompositeResultStore CompositeResultStore `deep:"-"`
+	// composite contains the services of a composite evaluation, whose SLIs have been joined
+	composite *compositeRetrieval
 }
 
 func (eh *EvaluateSLIHandler) HandleEvent(ctx context.Context) error {
@@ -88,6 +83,25 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 			wg.Done()
 		}
 	}()
+
+	// SLI providers can send the raw samples of the SLIs, which are required for the Mann-Whitney U test
+	samplesData := evaluation.SLISamplesEventData{}
+	_ = eh.Event.DataAs(&samplesData)
+	samples := samplesData.GetSLISamples()
+
+	// the SLIs of a composite evaluation are evaluated once they have been retrieved for all services
+	getSLITriggeredID, _ := types.ToString(eh.Event.Extensions()["triggeredid"])
+	if composite := eh.getCompositeRetrieval(e, getSLITriggeredID); composite != nil {
+		e, samples = eh.joinCompositeResults(ctx, composite, getSLITriggeredID, e)
+		if e == nil {
+			return nil
+		}
+	}
+	return eh.evaluateGetSLIFinishedEvent(shkeptncontext, commitID, e, samples)
+}
+
+// evaluateGetSLIFinishedEvent evaluates the SLIs of the get-sli.finished event and responds to the evaluation.triggered event
+func (eh *EvaluateSLIHandler) evaluateGetSLIFinishedEvent(shkeptncontext string, commitID string, e *keptnv2.GetSLIFinishedEventData, samples map[string][]float64) error {
 	triggeredEvents, err2 := eh.EventStore.GetEvents(&keptnapi.EventFilter{
 		Project:      e.Project,
 		Stage:        e.Stage,
@@ -146,486 +160,120 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 		return sendEvent(shkeptncontext, triggeredID, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), commitID, eh.KeptnHandler, &evalResult)
 	}
 
+	return eh.evaluateSLIs(shkeptncontext, triggeredID, commitID, e, samples, "")
+}
+
+// evaluateSLIs evaluates the SLI values against the SLO file and sends the evaluation.finished event. If the inputs of the
+// evaluation have already been evaluated, the stored result is sent instead. reevaluatedFrom is the ID of the evaluation
+// the SLI values have been taken from, if any
+func (eh *EvaluateSLIHandler) evaluateSLIs(shkeptncontext string, triggeredID string, commitID string, e *keptnv2.GetSLIFinishedEventData, samples map[string][]float64, reevaluatedFrom string) error {
 	// compare the results based on the evaluation strategy
-	sloConfig, sloFileContent, err := eh.SLOFileRetriever.GetSLOs(e.Project, e.Stage, e.Service, commitID)
+	_, sloFileContent, err := eh.SLOFileRetriever.GetSLOs(e.Project, e.Stage, e.Service, commitID)
 
 	if err != nil {
 		if err == ErrSLOFileNotFound {
-			evalResult.EventData.Result = keptnv2.ResultPass
-			evalResult.Message = fmt.Sprintf("no evaluation performed by lighthouse because no SLO file configured for project %s", e.Project)
+			evalResult := keptnv2.EvaluationFinishedEventData{
+				Evaluation: keptnv2.EvaluationDetails{
+					TimeStart: e.GetSLI.Start,
+					TimeEnd:   e.GetSLI.End,
+					Result:    string(keptnv2.ResultPass),
+				},
+				EventData: keptnv2.EventData{
+					Status:  keptnv2.StatusSucceeded,
+					Result:  keptnv2.ResultPass,
+					Project: e.Project,
+					Service: e.Service,
+					Stage:   e.Stage,
+					Labels:  e.Labels,
+					Message: fmt.Sprintf("no evaluation performed by lighthouse because no SLO file configured for project %s", e.Project),
+				},
+			}
 			return sendEvent(shkeptncontext, triggeredID, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), commitID, eh.KeptnHandler, &evalResult)
 		}
 
 		return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, commitID, err.Error(), "", eh.KeptnHandler, e)
 	}
 
-	// get results of previous evaluations from data store (mongodb-datastore)
-	numberOfPreviousResults := 3
-	if sloConfig.Comparison.CompareWith == "single_result" {
-		numberOfPreviousResults = 1
-	} else if sloConfig.Comparison.CompareWith == "several_results" {
-		numberOfPreviousResults = sloConfig.Comparison.NumberOfComparisonResults
+	// the same inputs are not evaluated twice, e.g. if the get-sli.finished event is delivered again
+	fingerprint := evaluationFingerprint(e.Project, e.Stage, e.Service, e.GetSLI.Start, e.GetSLI.End, sloFileContent)
+	if stored, storedData := getStoredEvaluation(eh.EvaluationStore, e.Project, e.Stage, e.Service, fingerprint); stored != nil {
+		return sendStoredEvaluation(shkeptncontext, triggeredID, commitID, eh.KeptnHandler, stored, storedData, e.Labels)
 	}
 
-	previousEvaluationEvents, comparisonEventIDs, err := eh.getPreviousEvaluations(e, numberOfPreviousResults, sloConfig.Comparison.IncludeResultWithScore)
+	slo, err := evaluation.ParseSLOFile(sloFileContent)
 	if err != nil {
 		return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, commitID, err.Error(), string(sloFileContent), eh.KeptnHandler, e)
 	}
 
-	var filteredPreviousEvaluationEvents []*keptnv2.EvaluationFinishedEventData
-
-	// verify that we have enough evaluations
-	for _, val := range previousEvaluationEvents {
-		filteredPreviousEvaluationEvents = append(filteredPreviousEvaluationEvents, val)
-	}
-
-	evaluationResult, maximumAchievableScore, keySLIFailed := evaluateObjectives(e, sloConfig, filteredPreviousEvaluationEvents)
-	evaluationResult.Labels = e.Labels
-	evaluationResult.Evaluation.ComparedEvents = comparisonEventIDs
+	// get results of previous evaluations from data store (mongodb-datastore)
+	numberOfPreviousResults := slo.NumberOfPreviousResults()
 
-	// calculate the total score
-	err = calculateScore(maximumAchievableScore, evaluationResult, sloConfig, keySLIFailed)
+	// a re-evaluation is not compared with the evaluation it has been derived from
+	previousEvaluationEvents, comparisonEventIDs, previousSamples, err := eh.getPreviousEvaluations(e, numberOfPreviousResults, slo.Comparison.IncludeResultWithScore, reevaluatedFrom)
 	if err != nil {
 		return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, commitID, err.Error(), string(sloFileContent), eh.KeptnHandler, e)
 	}
-	logger.Debug("Evaluation result: " + string(evaluationResult.Result))
-
-	evaluationResult.Evaluation.SLOFileContent = base64.StdEncoding.EncodeToString(sloFileContent)
-
-	return sendEvent(shkeptncontext, triggeredEvents[0].ID, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), commitID, eh.KeptnHandler, evaluationResult)
-}
-
-func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *keptn.ServiceLevelObjectives, previousEvaluationEvents []*keptnv2.EvaluationFinishedEventData) (*keptnv2.EvaluationFinishedEventData, float64, bool) {
-	evaluationResult := &keptnv2.EvaluationFinishedEventData{
-		EventData: keptnv2.EventData{
-			Status:  "",
-			Project: e.Project,
-			Service: e.Service,
-			Stage:   e.Stage,
-		},
-		Evaluation: keptnv2.EvaluationDetails{
-			TimeStart: e.GetSLI.Start,
-			TimeEnd:   e.GetSLI.End,
-		},
-	}
-	var sliEvaluationResults []*keptnv2.SLIEvaluationResult
-	maximumAchievableScore := 0.0
-	keySLIFailed := false
-	for _, objective := range sloConfig.Objectives {
-		// only consider the SLI for the total score if pass criteria have been included
-		if len(objective.Pass) > 0 {
-			maximumAchievableScore += float64(objective.Weight)
-		}
-		sliEvaluationResult := &keptnv2.SLIEvaluationResult{}
-		result := getSLIResult(&e.GetSLI.IndicatorValues, objective.SLI)
-
-		if result == nil {
-			// no result available => fail the objective
-			sliEvaluationResult.Value = &keptnv2.SLIResult{
-				Metric:  objective.SLI,
-				Success: false,
-				Message: "no value received from SLI provider",
-			}
-			sliEvaluationResult.Status = "fail"
-			sliEvaluationResult.Score = 0
-			continue
-		}
-		sliEvaluationResult.Value = (*keptnv2.SLIResult)(result)
-
-		// gather the previous results for the current SLI
-		var previousSLIResults []*keptnv2.SLIEvaluationResult
-
-		if previousEvaluationEvents != nil && len(previousEvaluationEvents) > 0 {
-			for _, event := range previousEvaluationEvents {
-				for _, prevSLIResult := range event.Evaluation.IndicatorResults {
-					if strings.Compare(prevSLIResult.Value.Metric, objective.SLI) == 0 {
-						previousSLIResults = append(previousSLIResults, prevSLIResult)
-					}
-				}
-			}
-		}
-
-		var passTargets []*keptnv2.SLITarget
-		var warningTargets []*keptnv2.SLITarget
-		isPassed := true
-		isWarning := true
-		if objective.Pass != nil && len(objective.Pass) > 0 {
-			isPassed, passTargets, _ = evaluateOrCombinedCriteria(sliEvaluationResult.Value, objective.Pass, previousSLIResults, sloConfig.Comparison)
-			if isPassed {
-				sliEvaluationResult.Score = float64(objective.Weight)
-				sliEvaluationResult.Status = "pass"
-			}
-		} else {
-			sliEvaluationResult.Status = "info"
-		}
-
-		if objective.Warning != nil && len(objective.Warning) > 0 {
-			isWarning, warningTargets, _ = evaluateOrCombinedCriteria(sliEvaluationResult.Value, objective.Warning, previousSLIResults, sloConfig.Comparison)
-			if !isPassed && isWarning {
-				sliEvaluationResult.Score = 0.5 * float64(objective.Weight)
-				sliEvaluationResult.Status = "warning"
-			}
-		} else {
-			isWarning = false
-		}
-
-		sliEvaluationResult.PassTargets = passTargets
-		sliEvaluationResult.WarningTargets = warningTargets
-		sliEvaluationResult.KeySLI = objective.KeySLI
-		sliEvaluationResult.DisplayName = objective.DisplayName
-
-		if !isPassed && !isWarning {
-			if objective.KeySLI {
-				keySLIFailed = true
-			}
-			sliEvaluationResult.Status = "fail"
-			sliEvaluationResult.Score = 0
-		}
-
-		sliEvaluationResults = append(sliEvaluationResults, sliEvaluationResult)
-	}
-
-	// now we check if any metric from the SLI has not been handled
-	checkLeftoverSLI(e.GetSLI.IndicatorValues, evaluationResult)
-	evaluationResult.Evaluation.IndicatorResults = sliEvaluationResults
-
-	return evaluationResult, maximumAchievableScore, keySLIFailed
-}
-
-func checkLeftoverSLI(results []*keptnv2.SLIResult, evaluationResult *keptnv2.EvaluationFinishedEventData) {
-	if len(results) > 0 {
-		//collect SLIs that did not have objectives defined
-		sliEvaluations := ""
-		for _, Values := range results {
-			if sliEvaluations != "" {
-				sliEvaluations += ", "
-			}
-			sliEvaluations += Values.Metric
-		}
-		if sliEvaluations != "" {
-			evaluationResult.Message += fmt.Sprintf("Lighthouse received additional SLIs,"+
-				" which are not specified as SLO: %s . "+
-				"Please consider using them as an SLO.", sliEvaluations,
-			)
-		}
-	}
-}
-
-func calculateScore(maximumAchievableScore float64, evaluationResult *keptnv2.EvaluationFinishedEventData, sloConfig *keptn.ServiceLevelObjectives, keySLIFailed bool) error {
-	if maximumAchievableScore == 0 {
-		evaluationResult.Evaluation.Result = "pass"
-		evaluationResult.Result = keptnv2.ResultPass
-		evaluationResult.Status = keptnv2.StatusSucceeded
-		evaluationResult.Evaluation.Score = 100.0
-		return nil
-	}
-	totalScore := 0.0
-	for _, result := range evaluationResult.Evaluation.IndicatorResults {
-		totalScore += result.Score
-	}
-	achievedPercentage := 100.0 * (totalScore / maximumAchievableScore)
-	evaluationResult.Evaluation.Score = achievedPercentage
-	if sloConfig.TotalScore == nil || sloConfig.TotalScore.Pass == "" {
-		return errors.New("no target score defined")
-	}
-	passTargetPercentage, err := strconv.ParseFloat(strings.TrimSuffix(sloConfig.TotalScore.Pass, "%"), 64)
-	if err != nil {
-		return errors.New("could not parse pass target percentage")
-	}
-	if achievedPercentage >= passTargetPercentage && !keySLIFailed {
-		evaluationResult.Evaluation.Result = "pass"
-		evaluationResult.Result = keptnv2.ResultPass
-		evaluationResult.Status = keptnv2.StatusSucceeded
-	} else if sloConfig.TotalScore.Warning != "" && !keySLIFailed {
-		warnTargetPercentage, err := strconv.ParseFloat(strings.TrimSuffix(sloConfig.TotalScore.Warning, "%"), 64)
-
-		if err != nil {
-			return errors.New("could not parse warning target percentage")
-		}
-		if achievedPercentage >= warnTargetPercentage {
-			evaluationResult.Evaluation.Result = "warning"
-			evaluationResult.Result = keptnv2.ResultWarning
-			evaluationResult.Status = keptnv2.StatusSucceeded
-			evaluationResult.Message = fmt.Sprintf("Evaluation returned a warning: the calculated score of %v is close to the warning target value of %v", achievedPercentage, warnTargetPercentage)
-		} else {
-			evaluationResult.Evaluation.Result = "fail"
-			evaluationResult.Result = keptnv2.ResultFailed
-			evaluationResult.Status = keptnv2.StatusSucceeded
-			evaluationResult.Message = fmt.Sprintf("Evaluation failed since the calculated score of %v is below the warning value of %v", achievedPercentage, warnTargetPercentage)
-		}
-	} else {
-		evaluationResult.Evaluation.Result = "fail"
-		evaluationResult.Result = keptnv2.ResultFailed
-		evaluationResult.Status = keptnv2.StatusSucceeded
-		evaluationResult.Message = fmt.Sprintf("Evaluation failed since the calculated score of %v is below the target value of %v", achievedPercentage, passTargetPercentage)
-	}
 
-	return nil
-}
+	// the source events are determined before the SLI values are consumed by the evaluation
+	sourceEvents := eh.getSourceEvents(slo.ServiceLevelObjectives, reevaluatedFrom)
 
-func getSLIResult(results *[]*keptnv2.SLIResult, sli string) *keptnv2.SLIResult {
-	var r = *results
-	for i, sliResult := range *results {
-		if sliResult.Metric == sli {
-			// remove already processed SLI
-			r[i] = r[len(r)-1] // Copy last element to index i.
-			r[len(r)-1] = nil  // Erase last element.
-			r = r[:len(r)-1]   // Truncate slice.
-			*results = r
-			return sliResult
-		}
-	}
-	return nil
-}
-
-func evaluateOrCombinedCriteria(result *keptnv2.SLIResult, sloCriteria []*keptn.SLOCriteria, previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison) (bool, []*keptnv2.SLITarget, error) {
-	var satisfied bool
-	satisfied = false
-	var sliTargets []*keptnv2.SLITarget
-	for _, crit := range sloCriteria {
-		criteriaSatisfied, evaluatedTargets, _ := evaluateCriteriaSet(result, crit, previousResults, comparison)
-		if criteriaSatisfied {
-			// one matching criteria set is sufficient to satisfy the evaluation. Other criteria sets are evaluated nevertheless, to get potential violations
-			satisfied = true
-		}
-		for _, evaluatedTarget := range evaluatedTargets {
-			sliTargets = append(sliTargets, evaluatedTarget)
-		}
-	}
-
-	return satisfied, sliTargets, nil
-}
-
-// evaluateCriteria evaluates a set of criteria strings. Per definition, all criteria clauses within a SLOCriteria object have to be fulfilled to satisfy the SLOCriteria
-func evaluateCriteriaSet(result *keptnv2.SLIResult, sloCriteria *keptn.SLOCriteria, previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison) (bool, []*keptnv2.SLITarget, error) {
-	satisfied := true
-	var sliTargets []*keptnv2.SLITarget
-	for _, criteria := range sloCriteria.Criteria {
-		target := &keptnv2.SLITarget{
-			Criteria: criteria,
-		}
-		criteriaSatisfied, _ := evaluateSingleCriteria(result, criteria, previousResults, comparison, target)
-		if !criteriaSatisfied {
-			target.Violated = true
-			satisfied = false
-		} else {
-			target.Violated = false
-		}
-		sliTargets = append(sliTargets, target)
-	}
-
-	return satisfied, sliTargets, nil
-}
-
-func evaluateSingleCriteria(sliResult *keptnv2.SLIResult, criteria string, previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison, violation *keptnv2.SLITarget) (bool, error) {
-	if !sliResult.Success {
-		return false, errors.New("cannot evaluate invalid SLI result")
-	}
-
-	co, err := parseCriteriaString(criteria)
+	// the baselines are updated with the previous evaluations, the current one is added with the next evaluation
+	anomalyModels := getAnomalyModels(eh.AnomalyModelStore, eh.EvaluationStore, e, slo.AnomalyPolicies, previousEvaluationEvents, slo.Comparison.IncludeResultWithScore)
 
+	finishedEventData, err := slo.Evaluate(evaluation.Input{
+		GetSLI:              e,
+		Samples:             samples,
+		PreviousEvaluations: previousEvaluationEvents,
+		ComparedEvents:      comparisonEventIDs,
+		PreviousSamples:     previousSamples,
+		AnomalyModels:       anomalyModels,
+		SourceEvents:        sourceEvents,
+	})
 	if err != nil {
-		return false, err
-	}
-
-	if !co.IsComparison {
-		//compared value is used only if the criteria is a comparison without fixed threshold,
-		//anyway we calculate it here to allow Bridge to display it
-		sliResult.ComparedValue, _ = aggregateValues(previousResults, comparison)
-
-		// do a fixed threshold comparison
-		return evaluateFixedThreshold(sliResult, co, violation)
-	}
-
-	return evaluateComparison(sliResult, co, previousResults, comparison, violation)
-}
-
-func evaluateComparison(sliResult *keptnv2.SLIResult, co *criteriaObject, previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison, violation *keptnv2.SLITarget) (bool, error) {
-	// aggregate previous results
-	var aggregatedValue float64
-	var targetValue float64
-
-	aggregatedValue, skip := aggregateValues(previousResults, comparison)
-	sliResult.ComparedValue = aggregatedValue
-	if skip {
-		return true, nil
-	}
-	// calculate the comparison value
-	if co.CheckPercentage && co.CheckIncrease {
-		targetValue = (aggregatedValue * (100.0 + co.Value)) / 100.0
-	} else if co.CheckPercentage && !co.CheckIncrease {
-		targetValue = (aggregatedValue * (100.0 - co.Value)) / 100.0
-	} else if !co.CheckPercentage && co.CheckIncrease {
-		targetValue = aggregatedValue + co.Value
-	} else if !co.CheckPercentage && !co.CheckIncrease {
-		targetValue = aggregatedValue - co.Value
-	}
-	violation.TargetValue = targetValue
-	// compare!
-	return evaluateValue(sliResult.Value, targetValue, co.Operator)
-}
-
-//aggregateValues combines the previous values into a single one, based on the aggregation function
-//it returns the aggregated value and a boolean telling if the rest of the evaluation should be skipped
-//(no previous results or no successful previous results)
-func aggregateValues(previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison) (float64, bool) {
-
-	if len(previousResults) == 0 {
-		// if no comparison values are available, the evaluation passes
-		return 0, true
-	}
-	var previousValues []float64
-	for _, val := range previousResults {
-		if val.Value.Success == true {
-			// always include
-			previousValues = append(previousValues, val.Value.Value)
-		}
-	}
-
-	if len(previousValues) == 0 {
-		// if no comparison values are available, the evaluation passes
-		return 0, true
-	}
-	var aggregatedValue float64
-	// aggregate the previous values based on the passed aggregation function
-	switch comparison.AggregateFunction {
-	case "avg":
-		aggregatedValue = calculateAverage(previousValues)
-	case "p50":
-		aggregatedValue = calculatePercentile(sort.Float64Slice(previousValues), 0.5)
-	case "p90":
-		aggregatedValue = calculatePercentile(sort.Float64Slice(previousValues), 0.9)
-	case "p95":
-		aggregatedValue = calculatePercentile(sort.Float64Slice(previousValues), 0.95)
-	default:
-		break
-	}
-	return aggregatedValue, false
-}
-
-func calculateAverage(values []float64) float64 {
-	sum := 0.0
-
-	for _, value := range values {
-		sum += value
-	}
-	if len(values) > 0 {
-		return sum / float64(len(values))
+		return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, commitID, err.Error(), string(sloFileContent), eh.KeptnHandler, e)
 	}
+	logger.Debug("Evaluation result: " + string(finishedEventData.Result))
 
-	return 0.0
-}
-
-func calculatePercentile(values sort.Float64Slice, perc float64) float64 {
-	if len(values) == 0 {
-		return 0.0
-	}
-	ps := []float64{perc}
-
-	scores := make([]float64, len(ps))
-	size := len(values)
-	if size > 0 {
-		sort.Sort(values)
-		for i, p := range ps {
-			pos := p * float64(size+1) //ALTERNATIVELY, DROP THE +1
-			if pos < 1.0 {
-				scores[i] = float64(values[0])
-			} else if pos >= float64(size) {
-				scores[i] = float64(values[size-1])
-			} else {
-				lower := float64(values[int(pos)-1])
-				upper := float64(values[int(pos)])
-				scores[i] = lower + (pos-math.Floor(pos))*(upper-lower)
-			}
-		}
+	finishedEventData.Evaluation.SLOFileContent = base64.StdEncoding.EncodeToString(sloFileContent)
+	finishedEventData.Evaluation.Fingerprint = fingerprint
+	finishedEventData.Evaluation.ReevaluatedFrom = reevaluatedFrom
+	if eh.composite != nil {
+		finishedEventData.Evaluation.Services = eh.composite.breakdown(finishedEventData, slo.ServiceLevelObjectives)
 	}
 
-	return scores[0]
-}
-
-func evaluateFixedThreshold(sliResult *keptnv2.SLIResult, co *criteriaObject, violation *keptnv2.SLITarget) (bool, error) {
-	violation.TargetValue = co.Value
-	return evaluateValue(sliResult.Value, co.Value, co.Operator)
+	return sendEvent(shkeptncontext, triggeredID, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), commitID, eh.KeptnHandler, finishedEventData)
 }
 
-func evaluateValue(measured float64, expected float64, operator string) (bool, error) {
-	switch operator {
-	case "<":
-		return measured < expected, nil
-	case "<=":
-		return measured <= expected, nil
-	case "=":
-		return measured == expected, nil
-	case ">=":
-		return measured >= expected, nil
-	case ">":
-		return measured > expected, nil
-	default:
-		return false, errors.New("no operator set")
-	}
-}
-
-func parseCriteriaString(criteria string) (*criteriaObject, error) {
-	// example values: <+15%, <500, >-8%, =0
-	// possible operators: <, <=, =, >, >=
-	// regex: ^([<|<=|=|>|>=]{1,2})([+|-]{0,1}\\d*\.?\d*)([%]{0,1})
-	regex := `^([<|<=|=|>|>=]{1,2})([+|-]{0,1}\d*\.?\d*)([%]{0,1})`
-	var re *regexp.Regexp
-	re = regexp.MustCompile(regex)
-
-	// remove whitespaces
-	criteria = strings.Replace(criteria, " ", "", -1)
-
-	if !re.MatchString(criteria) {
-		return nil, errors.New("invalid criteria string")
-	}
-
-	c := &criteriaObject{}
-
-	operators := []string{"<=", "<", "=", ">=", ">"}
-
-	for _, operator := range operators {
-		if strings.HasPrefix(criteria, operator) {
-			c.Operator = operator
-			criteria = strings.TrimPrefix(criteria, operator)
-			break
+// getSourceEvents returns the ID of the event the value of each SLI has been taken from
+func (eh *EvaluateSLIHandler) getSourceEvents(sloConfig *keptn.ServiceLevelObjectives, reevaluatedFrom string) map[string]string {
+	sourceEvents := map[string]string{}
+	for _, objective := range sloConfig.Objectives {
+		switch {
+		case reevaluatedFrom != "":
+			sourceEvents[objective.SLI] = reevaluatedFrom
+		case eh.composite != nil:
+			sourceEvents[objective.SLI] = eh.composite.sourceEvent(objective.SLI)
+		default:
+			sourceEvents[objective.SLI] = eh.Event.ID()
 		}
 	}
-
-	if strings.HasSuffix(criteria, "%") {
-		c.CheckPercentage = true
-		c.IsComparison = true // Issue #1498: criteria containing '%' is always a comparison
-		c.CheckIncrease = true
-		criteria = strings.TrimSuffix(criteria, "%")
-	}
-
-	if strings.HasPrefix(criteria, "-") {
-		c.IsComparison = true
-		c.CheckIncrease = false
-		criteria = strings.TrimPrefix(criteria, "-")
-	} else if strings.HasPrefix(criteria, "+") {
-		c.IsComparison = true
-		c.CheckIncrease = true
-		criteria = strings.TrimPrefix(criteria, "+")
-	}
-
-	floatValue, err := strconv.ParseFloat(criteria, 64)
-	if err != nil {
-		return nil, errors.New("could not parse criteria target value")
-	}
-	c.Value = floatValue
-
-	return c, nil
+	return sourceEvents
 }
 
-// gets previous evaluation.finished events from mongodb-datastore
-func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEventData, numberOfPreviousResults int, includeResult string) ([]*keptnv2.EvaluationFinishedEventData, []string, error) {
+// gets previous evaluation.finished events from mongodb-datastore, as well as the raw samples of their SLIs. The event
+// with the ID excludedEventID is skipped
+func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEventData, numberOfPreviousResults int, includeResult string, excludedEventID string) ([]*keptnv2.EvaluationFinishedEventData, []string, map[string][]float64, error) {
 	var evaluationDoneEvents []*keptnv2.EvaluationFinishedEventData
 	var eventIDs []string
+	previousSamples := map[string][]float64{}
 
 	// previous results are fetched from mongodb datastore with source=lighthouse-service
+	limit := numberOfPreviousResults
+	if excludedEventID != "" {
+		limit++
+	}
 	queryString := fmt.Sprintf("source=%s&limit=%d&excludeInvalidated=true&",
-		"lighthouse-service", numberOfPreviousResults)
+		"lighthouse-service", limit)
 
 	includeResult = strings.ToLower(includeResult)
 
@@ -647,22 +295,25 @@ func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEv
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := eh.HTTPClient.Do(req)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	defer resp.Body.Close()
 	body, _ := ioutil.ReadAll(resp.Body)
 
 	if resp.StatusCode != 200 {
-		return nil, nil, errors.New("could not retrieve previous evaluation.finished events")
+		return nil, nil, nil, errors.New("could not retrieve previous evaluation.finished events")
 	}
 	previousEvents := &datastoreResult{}
 	err = json.Unmarshal(body, previousEvents)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// iterate over previous events
 	for _, event := range previousEvents.Events {
+		if excludedEventID != "" && event.ID == excludedEventID {
+			continue
+		}
 		bytes, err := json.Marshal(event.Data)
 		if err != nil {
 			continue
@@ -675,10 +326,14 @@ func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEv
 		}
 		evaluationDoneEvents = append(evaluationDoneEvents, &evaluationDoneEvent)
 		eventIDs = append(eventIDs, event.ID)
+		samples := evaluation.SLISamplesEventData{}
+		if err := json.Unmarshal(bytes, &samples); err == nil {
+			samples.AddEvaluationSamples(previousSamples)
+		}
 		if len(evaluationDoneEvents) == numberOfPreviousResults {
-			return evaluationDoneEvents, eventIDs, nil
+			return evaluationDoneEvents, eventIDs, previousSamples, nil
 		}
 	}
 
-	return evaluationDoneEvents, eventIDs, nil
+	return evaluationDoneEvents, eventIDs, previousSamples, nil
 }
@@ -0,0 +1,173 @@
+package event_handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+	logger "github.com/sirupsen/logrus"
+)
+
+// maxDatastoreEvaluations is the maximum number of events mongodb-datastore returns per request
+const maxDatastoreEvaluations = 100
+
+// ErrEvaluationNotFound is returned if the evaluation to be re-evaluated does not exist
+var ErrEvaluationNotFound = errors.New("evaluation not found")
+
+// EvaluationStore provides the evaluation.finished events sent by lighthouse
+type EvaluationStore interface {
+	// GetEvaluationByFingerprint returns the latest evaluation.finished event with the given fingerprint that has not been
+	// invalidated, or nil if there is none
+	GetEvaluationByFingerprint(project, stage, service, fingerprint string) (*StoredEvaluation, error)
+	// GetEvaluation returns the evaluation.finished event with the given ID, or ErrEvaluationNotFound
+	GetEvaluation(project, stage, service, eventID string) (*StoredEvaluation, error)
+	// GetEvaluations returns the latest evaluation.finished events of the service that have not been invalidated, newest first
+	GetEvaluations(project, stage, service string, limit int) ([]*StoredEvaluation, error)
+}
+
+// StoredEvaluation is an evaluation.finished event sent by lighthouse
+type StoredEvaluation struct {
+	ID          string          `json:"id"`
+	TriggeredID string          `json:"triggeredid"`
+	Data        json.RawMessage `json:"data"`
+}
+
+func (s *StoredEvaluation) eventData() (*evaluation.EvaluationFinishedEventData, error) {
+	data := &evaluation.EvaluationFinishedEventData{}
+	if err := json.Unmarshal(s.Data, data); err != nil {
+		return nil, fmt.Errorf("could not parse evaluation %s: %w", s.ID, err)
+	}
+	return data, nil
+}
+
+// DatastoreEvaluationStore retrieves the evaluation.finished events of lighthouse from mongodb-datastore
+type DatastoreEvaluationStore struct {
+	HTTPClient *http.Client
+}
+
+func (s DatastoreEvaluationStore) GetEvaluationByFingerprint(project, stage, service, fingerprint string) (*StoredEvaluation, error) {
+	evaluations, err := s.getEvaluations(project, stage, service, "data.evaluation.fingerprint:"+fingerprint, 1, true)
+	if err != nil || len(evaluations) == 0 {
+		return nil, err
+	}
+	return evaluations[0], nil
+}
+
+func (s DatastoreEvaluationStore) GetEvaluation(project, stage, service, eventID string) (*StoredEvaluation, error) {
+	evaluations, err := s.getEvaluations(project, stage, service, "id:"+eventID, 1, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(evaluations) == 0 {
+		return nil, fmt.Errorf("%w: no evaluation.finished event with ID %s", ErrEvaluationNotFound, eventID)
+	}
+	return evaluations[0], nil
+}
+
+func (s DatastoreEvaluationStore) GetEvaluations(project, stage, service string, limit int) ([]*StoredEvaluation, error) {
+	if limit > maxDatastoreEvaluations {
+		limit = maxDatastoreEvaluations
+	}
+	return s.getEvaluations(project, stage, service, "", limit, true)
+}
+
+func (s DatastoreEvaluationStore) getEvaluations(project, stage, service, filter string, limit int, excludeInvalidated bool) ([]*StoredEvaluation, error) {
+	filter = "data.project:" + project + " AND data.stage:" + stage + " AND data.service:" + service + " AND " + filter
+	query := url.Values{}
+	query.Set("source", "lighthouse-service")
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("excludeInvalidated", strconv.FormatBool(excludeInvalidated))
+	query.Set("filter", strings.TrimSuffix(filter, " AND "))
+
+	req, err := http.NewRequest("GET", getDatastoreURL()+"/event/type/"+keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName)+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("could not retrieve evaluation.finished events")
+	}
+	result := &struct {
+		Events []*StoredEvaluation `json:"events"`
+	}{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result.Events, nil
+}
+
+// evaluationFingerprint identifies the inputs of an evaluation, i.e. the service, the evaluated timeframe and the
+// revision of the SLO file. Evaluations with the same fingerprint produce the same result
+func evaluationFingerprint(project, stage, service, start, end string, sloFileContent []byte) string {
+	sloHash := sha256.Sum256(sloFileContent)
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		project,
+		stage,
+		service,
+		normalizeTimestamp(start),
+		normalizeTimestamp(end),
+		hex.EncodeToString(sloHash[:]),
+	}, "\n")))
+	return hex.EncodeToString(hash[:])
+}
+
+// normalizeTimestamp formats the timestamp in UTC, since SLI providers do not necessarily return the timeframe in the
+// format it has been requested in
+func normalizeTimestamp(timestamp string) string {
+	parsed, err := timeutils.ParseTimestamp(timestamp)
+	if err != nil {
+		return timestamp
+	}
+	return timeutils.GetKeptnTimeStamp(parsed.UTC())
+}
+
+// getStoredEvaluation returns the stored result of an evaluation with the same fingerprint, or nil if the inputs have
+// not been evaluated yet. Errors of the evaluation store are logged, since the evaluation can be conducted without it
+func getStoredEvaluation(store EvaluationStore, project, stage, service, fingerprint string) (*StoredEvaluation, *evaluation.EvaluationFinishedEventData) {
+	stored, err := store.GetEvaluationByFingerprint(project, stage, service, fingerprint)
+	if err != nil {
+		logger.Warnf("Could not retrieve evaluation with fingerprint %s: %v", fingerprint, err)
+		return nil, nil
+	}
+	if stored == nil {
+		return nil, nil
+	}
+	data, err := stored.eventData()
+	if err != nil {
+		logger.Warn(err.Error())
+		return nil, nil
+	}
+	return stored, data
+}
+
+// sendStoredEvaluation sends the stored result of an evaluation with the same inputs for the evaluation.triggered event.
+// Nothing is sent if the stored result already belongs to this event, i.e. if an event has been delivered twice
+func sendStoredEvaluation(shkeptncontext, triggeredID, commitID string, keptnHandler *keptnv2.Keptn, stored *StoredEvaluation, data *evaluation.EvaluationFinishedEventData, labels map[string]string) error {
+	if stored.TriggeredID == triggeredID {
+		logger.Infof("Evaluation of event %s has already been finished with event %s, ignoring duplicate", triggeredID, stored.ID)
+		return nil
+	}
+	logger.Infof("Inputs of the evaluation of event %s have already been evaluated, sending result of event %s", triggeredID, stored.ID)
+	data.Labels = labels
+	if data.Evaluation.ReusedFrom == "" {
+		data.Evaluation.ReusedFrom = stored.ID
+	}
+	return sendEvent(shkeptncontext, triggeredID, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), commitID, keptnHandler, data)
+}
@@ -0,0 +1,257 @@
+package event_handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn/go-utils/pkg/api/models"
+	api "github.com/keptn/go-utils/pkg/api/utils"
+	keptncommon "github.com/keptn/go-utils/pkg/lib/keptn"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	keptnfake "github.com/keptn/go-utils/pkg/lib/v0_2_0/fake"
+	event_handler_mock "github.com/keptn/keptn/lighthouse-service/event_handler/fake"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+	"github.com/stretchr/testify/require"
+)
+
+const testFingerprintSLO = `spec_version: "1.0"
+objectives:
+  - sli: "response_time_p95"
+    pass:
+      - criteria:
+          - "<=600"
+total_score:
+  pass: "90%"
+`
+
+type fakeEvaluationStore struct {
+	evaluations map[string]*StoredEvaluation
+	latest      []*StoredEvaluation
+	err         error
+}
+
+func (s *fakeEvaluationStore) GetEvaluationByFingerprint(_, _, _, fingerprint string) (*StoredEvaluation, error) {
+	return s.evaluations[fingerprint], s.err
+}
+
+func (s *fakeEvaluationStore) GetEvaluations(_, _, _ string, limit int) ([]*StoredEvaluation, error) {
+	if len(s.latest) > limit {
+		return s.latest[:limit], s.err
+	}
+	return s.latest, s.err
+}
+
+func (s *fakeEvaluationStore) GetEvaluation(_, _, _, eventID string) (*StoredEvaluation, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if evaluation, ok := s.evaluations[eventID]; ok {
+		return evaluation, nil
+	}
+	return nil, ErrEvaluationNotFound
+}
+
+func TestEvaluationFingerprint(t *testing.T) {
+	fingerprint := evaluationFingerprint("sockshop", "staging", "carts", "2022-01-01T10:00:00.000Z", "2022-01-01T10:05:00.000Z", []byte(testFingerprintSLO))
+	require.Len(t, fingerprint, 64)
+
+	// the timeframe is compared regardless of its format
+	require.Equal(t, fingerprint, evaluationFingerprint("sockshop", "staging", "carts", "2022-01-01T11:00:00+01:00", "2022-01-01T10:05:00Z", []byte(testFingerprintSLO)))
+
+	require.NotEqual(t, fingerprint, evaluationFingerprint("sockshop", "production", "carts", "2022-01-01T10:00:00.000Z", "2022-01-01T10:05:00.000Z", []byte(testFingerprintSLO)))
+	require.NotEqual(t, fingerprint, evaluationFingerprint("sockshop", "staging", "carts", "2022-01-01T10:00:00.000Z", "2022-01-01T10:10:00.000Z", []byte(testFingerprintSLO)))
+	require.NotEqual(t, fingerprint, evaluationFingerprint("sockshop", "staging", "carts", "2022-01-01T10:00:00.000Z", "2022-01-01T10:05:00.000Z", []byte(strings.Replace(testFingerprintSLO, "600", "500", 1))))
+}
+
+func TestDatastoreEvaluationStore(t *testing.T) {
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/event/type/sh.keptn.event.evaluation.finished", r.URL.Path)
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Query().Get("filter"), "unknown") {
+			_, _ = w.Write([]byte(`{"events":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"events":[{"id":"evaluation-id","triggeredid":"triggered-id","data":{"project":"sockshop","evaluation":{"score":100,"fingerprint":"abc"}}}]}`))
+	}))
+	defer ts.Close()
+	t.Setenv("MONGODB_DATASTORE", strings.TrimPrefix(ts.URL, "http://"))
+
+	store := DatastoreEvaluationStore{HTTPClient: &http.Client{}}
+
+	stored, err := store.GetEvaluationByFingerprint("sockshop", "staging", "carts", "abc")
+	require.Nil(t, err)
+	require.Equal(t, "evaluation-id", stored.ID)
+	require.Equal(t, "triggered-id", stored.TriggeredID)
+	data, err := stored.eventData()
+	require.Nil(t, err)
+	require.Equal(t, "abc", data.Evaluation.Fingerprint)
+	require.Equal(t, 100.0, data.Evaluation.Score)
+	require.Equal(t, "excludeInvalidated=true&filter=data.project%3Asockshop+AND+data.stage%3Astaging+AND+data.service%3Acarts+AND+data.evaluation.fingerprint%3Aabc&limit=1&source=lighthouse-service", queries[0])
+
+	stored, err = store.GetEvaluationByFingerprint("sockshop", "staging", "carts", "unknown")
+	require.Nil(t, err)
+	require.Nil(t, stored)
+
+	stored, err = store.GetEvaluation("sockshop", "staging", "carts", "evaluation-id")
+	require.Nil(t, err)
+	require.Equal(t, "evaluation-id", stored.ID)
+	// invalidated evaluations can be re-evaluated
+	require.Contains(t, queries[2], "excludeInvalidated=false")
+	require.Contains(t, queries[2], "AND+id%3Aevaluation-id")
+
+	_, err = store.GetEvaluation("sockshop", "staging", "carts", "unknown")
+	require.ErrorIs(t, err, ErrEvaluationNotFound)
+
+	evaluations, err := store.GetEvaluations("sockshop", "staging", "carts", 10)
+	require.Nil(t, err)
+	require.Len(t, evaluations, 1)
+	require.Equal(t, "excludeInvalidated=true&filter=data.project%3Asockshop+AND+data.stage%3Astaging+AND+data.service%3Acarts&limit=10&source=lighthouse-service", queries[4])
+}
+
+func newFingerprintTestKeptnHandler(t *testing.T) (*keptnv2.Keptn, *keptnfake.EventSender) {
+	incomingEvent := cloudevents.NewEvent()
+	incomingEvent.SetID("my-triggered-id")
+	incomingEvent.SetType(keptnv2.GetTriggeredEventType(keptnv2.EvaluationTaskName))
+	incomingEvent.SetSource("my-source")
+	incomingEvent.SetExtension("shkeptncontext", "my-context")
+	sender := &keptnfake.EventSender{}
+	keptnHandler, err := keptnv2.NewKeptn(&incomingEvent, keptncommon.KeptnOpts{EventSender: sender})
+	require.Nil(t, err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"events":[]}`))
+	}))
+	t.Cleanup(ts.Close)
+	t.Setenv("MONGODB_DATASTORE", strings.TrimPrefix(ts.URL, "http://"))
+	return keptnHandler, sender
+}
+
+func newSLOFileRetriever(sloFileContent string) SLOFileRetriever {
+	return SLOFileRetriever{
+		ResourceHandler: &event_handler_mock.ResourceHandlerMock{
+			GetResourceFunc: func(scope api.ResourceScope, options ...api.URIOption) (*models.Resource, error) {
+				return &models.Resource{ResourceContent: sloFileContent}, nil
+			},
+		},
+	}
+}
+
+func TestEvaluateSLIHandler_evaluateSLIsWithStoredEvaluation(t *testing.T) {
+	getSLIFinished := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts", Labels: map[string]string{"buildnr": "18"}},
+		GetSLI: keptnv2.GetSLIFinished{
+			Start:           "2022-01-01T10:00:00.000Z",
+			End:             "2022-01-01T10:05:00.000Z",
+			IndicatorValues: []*keptnv2.SLIResult{{Metric: "response_time_p95", Value: 500, Success: true}},
+		},
+	}
+	fingerprint := evaluationFingerprint("sockshop", "staging", "carts", "2022-01-01T10:00:00.000Z", "2022-01-01T10:05:00.000Z", []byte(testFingerprintSLO))
+	storedData := []byte(`{"project":"sockshop","stage":"staging","service":"carts","result":"pass","labels":{"buildnr":"17"},"evaluation":{"score":100,"fingerprint":"` + fingerprint + `"}}`)
+
+	tests := []struct {
+		name           string
+		stored         *StoredEvaluation
+		wantEvents     int
+		wantReusedFrom string
+	}{
+		{
+			name:       "inputs have not been evaluated",
+			wantEvents: 1,
+		},
+		{
+			name:       "get-sli.finished event has been delivered twice",
+			stored:     &StoredEvaluation{ID: "evaluation-id", TriggeredID: "my-triggered-id", Data: storedData},
+			wantEvents: 0,
+		},
+		{
+			name:           "inputs have been evaluated for another evaluation.triggered event",
+			stored:         &StoredEvaluation{ID: "evaluation-id", TriggeredID: "other-triggered-id", Data: storedData},
+			wantEvents:     1,
+			wantReusedFrom: "evaluation-id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keptnHandler, sender := newFingerprintTestKeptnHandler(t)
+			store := &fakeEvaluationStore{evaluations: map[string]*StoredEvaluation{}}
+			if tt.stored != nil {
+				store.evaluations[fingerprint] = tt.stored
+			}
+			eh := &EvaluateSLIHandler{
+				HTTPClient:       &http.Client{},
+				KeptnHandler:     keptnHandler,
+				SLOFileRetriever: newSLOFileRetriever(testFingerprintSLO),
+				EvaluationStore:  store,
+			}
+
+			err := eh.evaluateSLIs("my-context", "my-triggered-id", "", getSLIFinished, nil, "")
+			require.Nil(t, err)
+			require.Len(t, sender.SentEvents, tt.wantEvents)
+			if tt.wantEvents == 0 {
+				return
+			}
+
+			finished := &evaluation.EvaluationFinishedEventData{}
+			require.Nil(t, sender.SentEvents[0].DataAs(finished))
+			require.Equal(t, fingerprint, finished.Evaluation.Fingerprint)
+			require.Equal(t, tt.wantReusedFrom, finished.Evaluation.ReusedFrom)
+			require.Equal(t, map[string]string{"buildnr": "18"}, finished.Labels)
+			require.Equal(t, keptnv2.ResultPass, finished.Result)
+			require.Equal(t, 100.0, finished.Evaluation.Score)
+		})
+	}
+}
+
+func TestStartEvaluationHandler_reevaluate(t *testing.T) {
+	stored := &StoredEvaluation{
+		ID:          "evaluation-id",
+		TriggeredID: "other-triggered-id",
+		Data: []byte(`{"project":"sockshop","stage":"staging","service":"carts","result":"pass","evaluation":{
+"timeStart":"2022-01-01T10:00:00.000Z","timeEnd":"2022-01-01T10:05:00.000Z","score":100,
+"indicatorResults":[{"value":{"metric":"response_time_p95","value":500,"success":true},"score":1,"status":"pass"}]}}`),
+	}
+	updatedSLO := strings.Replace(testFingerprintSLO, "<=600", "<=400", 1)
+	e := &keptnv2.EvaluationTriggeredEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts", Labels: map[string]string{"buildnr": "18"}},
+	}
+
+	keptnHandler, sender := newFingerprintTestKeptnHandler(t)
+	eh := &StartEvaluationHandler{
+		Event:            *keptnHandler.CloudEvent,
+		KeptnHandler:     keptnHandler,
+		HTTPClient:       &http.Client{},
+		SLOFileRetriever: newSLOFileRetriever(updatedSLO),
+		EvaluationStore:  &fakeEvaluationStore{evaluations: map[string]*StoredEvaluation{"evaluation-id": stored}},
+	}
+
+	err := eh.reevaluate(context.Background(), "my-context", "", e, "evaluation-id")
+	require.Nil(t, err)
+
+	// the SLIs are not retrieved from the SLI provider again
+	require.Len(t, sender.SentEvents, 1)
+	require.Equal(t, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), sender.SentEvents[0].Type())
+	finished := &evaluation.EvaluationFinishedEventData{}
+	require.Nil(t, sender.SentEvents[0].DataAs(finished))
+	require.Equal(t, keptnv2.ResultFailed, finished.Result)
+	require.Equal(t, "evaluation-id", finished.Evaluation.ReevaluatedFrom)
+	require.Equal(t, evaluationFingerprint("sockshop", "staging", "carts", "2022-01-01T10:00:00.000Z", "2022-01-01T10:05:00.000Z", []byte(updatedSLO)), finished.Evaluation.Fingerprint)
+	require.Equal(t, map[string]string{"buildnr": "18"}, finished.Labels)
+	require.Equal(t, "<=400", finished.Evaluation.IndicatorResults[0].PassTargets[0].Criteria)
+
+	// the evaluation to be re-evaluated does not exist
+	sender.SentEvents = nil
+	err = eh.reevaluate(context.Background(), "my-context", "", e, "unknown")
+	require.Nil(t, err)
+	require.Len(t, sender.SentEvents, 1)
+	finished = &evaluation.EvaluationFinishedEventData{}
+	require.Nil(t, sender.SentEvents[0].DataAs(finished))
+	require.Equal(t, keptnv2.StatusErrored, finished.Status)
+	require.Contains(t, finished.Message, "could not retrieve evaluation to re-evaluate")
+}
@@ -17,6 +17,13 @@ import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 )
 
+// the stores are shared by all event handlers, since they hold the connection to MongoDB
+var (
+	mongoDBConnection    = NewMongoDBConnection()
+	anomalyModelStore    = NewMongoDBAnomalyModelStore(mongoDBConnection)
+	compositeResultStore = NewMongoDBCompositeResultStore(mongoDBConnection)
+)
+
 type EvaluationEventHandler interface {
 	HandleEvent(ctx context.Context) error
 }
@@ -43,13 +50,16 @@ func NewEventHandler(ctx context.Context, event cloudevents.Event) (EvaluationEv
 	switch event.Type() {
 	case keptnv2.GetTriggeredEventType(keptnv2.EvaluationTaskName):
 		return &StartEvaluationHandler{
-			Event:             event,
-			KeptnHandler:      keptnHandler,
-			SLIProviderConfig: K8sSLIProviderConfig{},
+			Event:               event,
+			KeptnHandler:        keptnHandler,
+			HTTPClient:          &http.Client{},
+			SLIProviderResolver: NewSLIProviderChain(resourceHandler, K8sSLIProviderConfig{}),
 			SLOFileRetriever: SLOFileRetriever{
 				ResourceHandler: resourceHandler,
 				ServiceHandler:  serviceHandler,
 			},
+			EvaluationStore:   DatastoreEvaluationStore{HTTPClient: &http.Client{}},
+			AnomalyModelStore: anomalyModelStore,
 		}, nil
 	case keptnv2.GetFinishedEventType(keptnv2.GetSLITaskName):
 		return &EvaluateSLIHandler{
@@ -60,7 +70,10 @@ func NewEventHandler(ctx context.Context, event cloudevents.Event) (EvaluationEv
 				ResourceHandler: resourceHandler,
 				ServiceHandler:  serviceHandler,
 			},
-			EventStore: keptnHandler.EventHandler,
+			EventStore:           keptnHandler.EventHandler,
+			EvaluationStore:      DatastoreEvaluationStore{HTTPClient: &http.Client{}},
+			AnomalyModelStore:    anomalyModelStore,
+			CompositeResultStore: compositeResultStore,
 		}, nil
 	case keptn.ConfigureMonitoringEventType:
 		return NewConfigureMonitoringHandler(event, logger.StandardLogger())
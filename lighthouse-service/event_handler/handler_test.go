@@ -51,9 +51,9 @@ func TestNewEventHandler(t *testing.T) {
 			},
 			eventType: keptnv2.GetTriggeredEventType(keptnv2.EvaluationTaskName),
 			want: &StartEvaluationHandler{
-				Event:             incomingEvent,
-				KeptnHandler:      keptnHandler,
-				SLIProviderConfig: K8sSLIProviderConfig{},
+				Event:        incomingEvent,
+				KeptnHandler: keptnHandler,
+				HTTPClient:   &http.Client{},
 			},
 			wantErr: false,
 		},
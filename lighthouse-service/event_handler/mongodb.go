@@ -0,0 +1,51 @@
+package event_handler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	keptnmongoutils "github.com/keptn/go-utils/pkg/common/mongoutils"
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoDBConnection holds the connection to the database of the lighthouse service, which is shared by its MongoDB
+// stores. The connection is established with the first request, using the MONGODB_* env vars of the service
+type MongoDBConnection struct {
+	mutex    sync.Mutex
+	database *mongo.Database
+}
+
+func NewMongoDBConnection() *MongoDBConnection {
+	return &MongoDBConnection{}
+}
+
+// GetDatabase returns the database of the lighthouse service, and connects to MongoDB if this has not been done yet
+func (c *MongoDBConnection) GetDatabase() (*mongo.Database, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.database != nil {
+		return c.database, nil
+	}
+
+	connectionString, databaseName, err := keptnmongoutils.GetMongoConnectionStringFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mongo client: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(connectionString).SetConnectTimeout(30*time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect client to MongoDB: %w", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, fmt.Errorf("failed to connect client to MongoDB: %w", err)
+	}
+	logger.Info("Successfully connected to MongoDB")
+	c.database = client.Database(databaseName)
+	return c.database, nil
+}
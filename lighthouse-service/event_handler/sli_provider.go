@@ -0,0 +1,219 @@
+package event_handler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	utils "github.com/keptn/go-utils/pkg/api/utils"
+	logger "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	SLIProviderSourceSLOFile                 = "slo-file"
+	SLIProviderSourceLighthouseConfigService = "lighthouse.yaml (service)"
+	SLIProviderSourceLighthouseConfigStage   = "lighthouse.yaml (stage)"
+	SLIProviderSourceLighthouseConfigProject = "lighthouse.yaml (project)"
+	SLIProviderSourceEnvProject              = "env (project)"
+	SLIProviderSourceEnvDefault              = "env (default)"
+	SLIProviderSourceConfigMapProject        = "configmap (project)"
+	SLIProviderSourceConfigMapDefault        = "configmap (default)"
+
+	// LighthouseConfigResourceURI is the project resource declaring the SLI providers of the stages and services of a project
+	LighthouseConfigResourceURI = "lighthouse.yaml"
+
+	envVarSLIProvider = "SLI_PROVIDER"
+)
+
+var envVarNameRegex = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// ErrNoSLIProvider is returned if no source declares an SLI provider for a service
+var ErrNoSLIProvider = errors.New("no SLI provider configured")
+
+// SLIProviderResolver resolves the SLI provider of a service
+type SLIProviderResolver interface {
+	ResolveSLIProvider(project, stage, service string, sloFileContent []byte) (*ResolvedSLIProvider, error)
+}
+
+// ResolvedSLIProvider is the SLI provider of a service, along with the source it has been declared in
+type ResolvedSLIProvider struct {
+	Provider string
+	Source   string
+}
+
+// SLIProviderSource is a source the SLI provider of a service can be declared in
+type SLIProviderSource interface {
+	// GetSLIProvider returns the SLI provider declared for the service and the name of the source, or an empty
+	// provider if the source does not declare one
+	GetSLIProvider(project, stage, service string, sloFileContent []byte) (string, string, error)
+}
+
+// SLIProviderChain resolves the SLI provider of a service from the first of its sources declaring one
+type SLIProviderChain []SLIProviderSource
+
+// NewSLIProviderChain creates the chain resolving the SLI provider from the SLO file, the lighthouse.yaml resource of the
+// project, the SLI_PROVIDER_<PROJECT> env variable and the lighthouse-config-<project> ConfigMap. Only if none of these
+// declares an SLI provider for the project, the defaults of the SLI_PROVIDER env variable and the lighthouse-config
+// ConfigMap are used
+func NewSLIProviderChain(resourceHandler ResourceHandler, configMaps SLIProviderConfig) SLIProviderChain {
+	env := EnvSLIProviderConfig{LookupEnv: os.LookupEnv}
+	return SLIProviderChain{
+		SLOFileSLIProviderSource{},
+		LighthouseConfigSLIProviderSource{ResourceHandler: resourceHandler},
+		ProjectSLIProviderSource{Name: SLIProviderSourceEnvProject, Config: env},
+		ProjectSLIProviderSource{Name: SLIProviderSourceConfigMapProject, Config: configMaps},
+		DefaultSLIProviderSource{Name: SLIProviderSourceEnvDefault, Config: env},
+		DefaultSLIProviderSource{Name: SLIProviderSourceConfigMapDefault, Config: configMaps},
+	}
+}
+
+// ResolveSLIProvider returns the SLI provider of the first source declaring one, or ErrNoSLIProvider
+func (c SLIProviderChain) ResolveSLIProvider(project, stage, service string, sloFileContent []byte) (*ResolvedSLIProvider, error) {
+	for _, source := range c {
+		provider, sourceName, err := source.GetSLIProvider(project, stage, service, sloFileContent)
+		if err != nil {
+			logger.Warnf("Could not read SLI provider of service %s in stage %s of project %s from %s: %v", service, stage, project, sourceName, err)
+			continue
+		}
+		if provider != "" {
+			return &ResolvedSLIProvider{Provider: provider, Source: sourceName}, nil
+		}
+	}
+	return nil, ErrNoSLIProvider
+}
+
+// SLOFileSLIProviderSource reads the SLI provider from the sli_provider property of the SLO file
+type SLOFileSLIProviderSource struct{}
+
+func (SLOFileSLIProviderSource) GetSLIProvider(_, _, _ string, sloFileContent []byte) (string, string, error) {
+	if len(sloFileContent) == 0 {
+		return "", SLIProviderSourceSLOFile, nil
+	}
+	slo := struct {
+		SLIProvider string `yaml:"sli_provider"`
+	}{}
+	if err := yaml.Unmarshal(sloFileContent, &slo); err != nil {
+		return "", SLIProviderSourceSLOFile, err
+	}
+	return slo.SLIProvider, SLIProviderSourceSLOFile, nil
+}
+
+// LighthouseConfig is the content of the lighthouse.yaml resource of a project. The SLI provider of a service
+// overrides the one of its stage, which overrides the one of the project
+type LighthouseConfig struct {
+	SLIProvider string                   `yaml:"sli_provider"`
+	Stages      []*LighthouseStageConfig `yaml:"stages"`
+}
+
+type LighthouseStageConfig struct {
+	Name        string                     `yaml:"name"`
+	SLIProvider string                     `yaml:"sli_provider"`
+	Services    []*LighthouseServiceConfig `yaml:"services"`
+}
+
+type LighthouseServiceConfig struct {
+	Name        string `yaml:"name"`
+	SLIProvider string `yaml:"sli_provider"`
+}
+
+// GetSLIProvider returns the SLI provider of the service and the level of the config it has been declared on
+func (lc LighthouseConfig) GetSLIProvider(stage, service string) (string, string) {
+	for _, stageConfig := range lc.Stages {
+		if stageConfig == nil || stageConfig.Name != stage {
+			continue
+		}
+		for _, serviceConfig := range stageConfig.Services {
+			if serviceConfig != nil && serviceConfig.Name == service && serviceConfig.SLIProvider != "" {
+				return serviceConfig.SLIProvider, SLIProviderSourceLighthouseConfigService
+			}
+		}
+		if stageConfig.SLIProvider != "" {
+			return stageConfig.SLIProvider, SLIProviderSourceLighthouseConfigStage
+		}
+	}
+	return lc.SLIProvider, SLIProviderSourceLighthouseConfigProject
+}
+
+// LighthouseConfigSLIProviderSource reads the SLI provider from the lighthouse.yaml resource of the project
+type LighthouseConfigSLIProviderSource struct {
+	ResourceHandler ResourceHandler
+}
+
+func (s LighthouseConfigSLIProviderSource) GetSLIProvider(project, stage, service string, _ []byte) (string, string, error) {
+	resourceScope := *utils.NewResourceScope().Project(project).Resource(LighthouseConfigResourceURI)
+	resource, err := s.ResourceHandler.GetResource(resourceScope)
+	if errors.Is(err, utils.ResourceNotFoundError) || (err == nil && (resource == nil || resource.ResourceContent == "")) {
+		// projects without a lighthouse.yaml resource do not declare an SLI provider
+		return "", SLIProviderSourceLighthouseConfigProject, nil
+	} else if err != nil {
+		return "", SLIProviderSourceLighthouseConfigProject, fmt.Errorf("could not retrieve %s: %w", LighthouseConfigResourceURI, err)
+	}
+	lighthouseConfig := LighthouseConfig{}
+	if err := yaml.Unmarshal([]byte(resource.ResourceContent), &lighthouseConfig); err != nil {
+		return "", SLIProviderSourceLighthouseConfigProject, fmt.Errorf("could not parse %s: %w", LighthouseConfigResourceURI, err)
+	}
+	provider, source := lighthouseConfig.GetSLIProvider(stage, service)
+	return provider, source, nil
+}
+
+// EnvSLIProviderConfig reads the SLI provider of a project from the SLI_PROVIDER_<PROJECT> env variable, and the
+// default SLI provider from the SLI_PROVIDER env variable
+type EnvSLIProviderConfig struct {
+	LookupEnv func(key string) (string, bool)
+}
+
+// GetDefaultSLIProvider returns the SLI provider declared by the SLI_PROVIDER env variable
+func (c EnvSLIProviderConfig) GetDefaultSLIProvider() (string, error) {
+	if provider, ok := c.LookupEnv(envVarSLIProvider); ok && provider != "" {
+		return provider, nil
+	}
+	return "", errors.New("no default SLI provider specified")
+}
+
+// GetSLIProvider returns the SLI provider declared by the SLI_PROVIDER_<PROJECT> env variable
+func (c EnvSLIProviderConfig) GetSLIProvider(project string) (string, error) {
+	if provider, ok := c.LookupEnv(EnvSLIProviderVarName(project)); ok && provider != "" {
+		return provider, nil
+	}
+	return "", errors.New("no SLI provider specified for project " + project)
+}
+
+// EnvSLIProviderVarName returns the name of the env variable declaring the SLI provider of a project, e.g.
+// SLI_PROVIDER_MY_PROJECT for the project my-project
+func EnvSLIProviderVarName(project string) string {
+	return envVarSLIProvider + "_" + envVarNameRegex.ReplaceAllString(strings.ToUpper(project), "_")
+}
+
+// ProjectSLIProviderSource reads the SLI provider of the project from an SLIProviderConfig
+type ProjectSLIProviderSource struct {
+	Name   string
+	Config SLIProviderConfig
+}
+
+func (s ProjectSLIProviderSource) GetSLIProvider(project, _, _ string, _ []byte) (string, string, error) {
+	provider, err := s.Config.GetSLIProvider(project)
+	if err != nil {
+		// SLIProviderConfigs return an error if no SLI provider is specified
+		logger.Debugf("No SLI provider found in %s: %v", s.Name, err)
+		return "", s.Name, nil
+	}
+	return provider, s.Name, nil
+}
+
+// DefaultSLIProviderSource reads the default SLI provider from an SLIProviderConfig
+type DefaultSLIProviderSource struct {
+	Name   string
+	Config SLIProviderConfig
+}
+
+func (s DefaultSLIProviderSource) GetSLIProvider(_, _, _ string, _ []byte) (string, string, error) {
+	provider, err := s.Config.GetDefaultSLIProvider()
+	if err != nil {
+		logger.Debugf("No SLI provider found in %s: %v", s.Name, err)
+		return "", s.Name, nil
+	}
+	return provider, s.Name, nil
+}
@@ -0,0 +1,173 @@
+package event_handler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	api "github.com/keptn/go-utils/pkg/api/utils"
+	event_handler_mock "github.com/keptn/keptn/lighthouse-service/event_handler/fake"
+	"github.com/stretchr/testify/require"
+)
+
+const testLighthouseConfig = `sli_provider: dynatrace
+stages:
+  - name: staging
+    sli_provider: prometheus
+    services:
+      - name: carts
+        sli_provider: datadog
+  - name: production
+    services:
+      - name: carts
+        sli_provider: dynatrace-sre
+`
+
+func newLighthouseConfigResourceHandler(content string, err error) *event_handler_mock.ResourceHandlerMock {
+	return &event_handler_mock.ResourceHandlerMock{
+		GetResourceFunc: func(scope api.ResourceScope, options ...api.URIOption) (*models.Resource, error) {
+			if err != nil {
+				return nil, err
+			}
+			return &models.Resource{ResourceContent: content}, nil
+		},
+	}
+}
+
+func TestLighthouseConfigSLIProviderSource_GetSLIProvider(t *testing.T) {
+	tests := []struct {
+		name         string
+		stage        string
+		service      string
+		wantProvider string
+		wantSource   string
+	}{
+		{
+			name:         "service override",
+			stage:        "staging",
+			service:      "carts",
+			wantProvider: "datadog",
+			wantSource:   SLIProviderSourceLighthouseConfigService,
+		},
+		{
+			name:         "stage override",
+			stage:        "staging",
+			service:      "orders",
+			wantProvider: "prometheus",
+			wantSource:   SLIProviderSourceLighthouseConfigStage,
+		},
+		{
+			name:         "service override in stage without provider",
+			stage:        "production",
+			service:      "carts",
+			wantProvider: "dynatrace-sre",
+			wantSource:   SLIProviderSourceLighthouseConfigService,
+		},
+		{
+			name:         "project",
+			stage:        "production",
+			service:      "orders",
+			wantProvider: "dynatrace",
+			wantSource:   SLIProviderSourceLighthouseConfigProject,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceHandler := newLighthouseConfigResourceHandler(testLighthouseConfig, nil)
+			source := LighthouseConfigSLIProviderSource{ResourceHandler: resourceHandler}
+
+			provider, sourceName, err := source.GetSLIProvider("sockshop", tt.stage, tt.service, nil)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantProvider, provider)
+			require.Equal(t, tt.wantSource, sourceName)
+
+			require.Len(t, resourceHandler.GetResourceCalls(), 1)
+			scope := resourceHandler.GetResourceCalls()[0].Scope
+			require.Equal(t, "/v1/project/sockshop/resource/lighthouse.yaml", scope.GetProjectPath()+scope.GetStagePath()+scope.GetServicePath()+scope.GetResourcePath())
+		})
+	}
+
+	provider, _, err := LighthouseConfigSLIProviderSource{ResourceHandler: newLighthouseConfigResourceHandler("", api.ResourceNotFoundError)}.GetSLIProvider("sockshop", "staging", "carts", nil)
+	require.Nil(t, err)
+	require.Empty(t, provider)
+
+	// errors other than a missing lighthouse.yaml are reported, so that they are logged by the chain
+	_, _, err = LighthouseConfigSLIProviderSource{ResourceHandler: newLighthouseConfigResourceHandler("", errors.New("connection refused"))}.GetSLIProvider("sockshop", "staging", "carts", nil)
+	require.ErrorContains(t, err, "could not retrieve lighthouse.yaml: connection refused")
+
+	_, _, err = LighthouseConfigSLIProviderSource{ResourceHandler: newLighthouseConfigResourceHandler("stages: dev", nil)}.GetSLIProvider("sockshop", "staging", "carts", nil)
+	require.ErrorContains(t, err, "could not parse lighthouse.yaml")
+}
+
+func TestEnvSLIProviderConfig(t *testing.T) {
+	env := map[string]string{"SLI_PROVIDER": "prometheus", "SLI_PROVIDER_MY_PROJECT": "dynatrace"}
+	config := EnvSLIProviderConfig{LookupEnv: func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}}
+
+	provider, err := config.GetSLIProvider("my-project")
+	require.Nil(t, err)
+	require.Equal(t, "dynatrace", provider)
+
+	_, err = config.GetSLIProvider("sockshop")
+	require.EqualError(t, err, "no SLI provider specified for project sockshop")
+
+	provider, err = config.GetDefaultSLIProvider()
+	require.Nil(t, err)
+	require.Equal(t, "prometheus", provider)
+}
+
+func TestSLIProviderChain_ResolveSLIProvider(t *testing.T) {
+	configMaps := &MockSLIProviderConfig{}
+	configMaps.DefaultSLIProvider.val = "dynatrace"
+	env := map[string]string{}
+	envConfig := EnvSLIProviderConfig{LookupEnv: func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}}
+	chain := SLIProviderChain{
+		SLOFileSLIProviderSource{},
+		LighthouseConfigSLIProviderSource{ResourceHandler: newLighthouseConfigResourceHandler("stages:\n  - name: staging\n    sli_provider: prometheus\n", nil)},
+		ProjectSLIProviderSource{Name: SLIProviderSourceEnvProject, Config: envConfig},
+		ProjectSLIProviderSource{Name: SLIProviderSourceConfigMapProject, Config: configMaps},
+		DefaultSLIProviderSource{Name: SLIProviderSourceEnvDefault, Config: envConfig},
+		DefaultSLIProviderSource{Name: SLIProviderSourceConfigMapDefault, Config: configMaps},
+	}
+
+	provider, err := chain.ResolveSLIProvider("sockshop", "staging", "carts", []byte("spec_version: '1.0'\nsli_provider: datadog\n"))
+	require.Nil(t, err)
+	require.Equal(t, &ResolvedSLIProvider{Provider: "datadog", Source: SLIProviderSourceSLOFile}, provider)
+
+	provider, err = chain.ResolveSLIProvider("sockshop", "staging", "carts", []byte("spec_version: '1.0'\n"))
+	require.Nil(t, err)
+	require.Equal(t, &ResolvedSLIProvider{Provider: "prometheus", Source: SLIProviderSourceLighthouseConfigStage}, provider)
+
+	env["SLI_PROVIDER_SOCKSHOP"] = "datadog"
+	env["SLI_PROVIDER"] = "prometheus"
+	configMaps.ProjectSLIProvider.val = "dynatrace-sre"
+	provider, err = chain.ResolveSLIProvider("sockshop", "production", "carts", nil)
+	require.Nil(t, err)
+	require.Equal(t, &ResolvedSLIProvider{Provider: "datadog", Source: SLIProviderSourceEnvProject}, provider)
+
+	// the configmap of the project takes precedence over the default of the env variables
+	delete(env, "SLI_PROVIDER_SOCKSHOP")
+	provider, err = chain.ResolveSLIProvider("sockshop", "production", "carts", nil)
+	require.Nil(t, err)
+	require.Equal(t, &ResolvedSLIProvider{Provider: "dynatrace-sre", Source: SLIProviderSourceConfigMapProject}, provider)
+
+	// the configmap of the project does not declare an SLI provider
+	configMaps.ProjectSLIProvider.val = ""
+	provider, err = chain.ResolveSLIProvider("sockshop", "production", "carts", nil)
+	require.Nil(t, err)
+	require.Equal(t, &ResolvedSLIProvider{Provider: "prometheus", Source: SLIProviderSourceEnvDefault}, provider)
+
+	delete(env, "SLI_PROVIDER")
+	provider, err = chain.ResolveSLIProvider("sockshop", "production", "carts", nil)
+	require.Nil(t, err)
+	require.Equal(t, &ResolvedSLIProvider{Provider: "dynatrace", Source: SLIProviderSourceConfigMapDefault}, provider)
+
+	configMaps.DefaultSLIProvider.err = errors.New("no default SLI provider specified")
+	_, err = chain.ResolveSLIProvider("sockshop", "production", "carts", nil)
+	require.ErrorIs(t, err, ErrNoSLIProvider)
+}
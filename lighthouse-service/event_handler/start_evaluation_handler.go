@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
 	"github.com/google/uuid"
 	"github.com/keptn/go-utils/pkg/common/timeutils"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
 	logger "github.com/sirupsen/logrus"
-	"net/url"
-	"sync"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 
@@ -16,10 +19,28 @@ import (
 )
 
 type StartEvaluationHandler struct {
-	Event             cloudevents.Event
-	KeptnHandler      *keptnv2.Keptn
-	SLIProviderConfig SLIProviderConfig
-	SLOFileRetriever  SLOFileRetriever `deep:"-"`
+	Event               cloudevents.Event
+	KeptnHandler        *keptnv2.Keptn
+	HTTPClient          *http.Client
+	SLIProviderResolver SLIProviderResolver `deep:"-"`
+	SLOFileRetriever    SLOFileRetriever    `deep:"-"`
+	EvaluationStore     EvaluationStore     `deep:"-"`
+	AnomalyModelStore   AnomalyModelStore   `deep:"-"`
+}
+
+// evaluationTriggeredEventData extends the evaluation.triggered event data with the request to re-evaluate a previous
+// evaluation
+type evaluationTriggeredEventData struct {
+	Evaluation struct {
+		Reevaluate *reevaluation `json:"reevaluate,omitempty"`
+	} `json:"evaluation"`
+}
+
+// reevaluation requests to evaluate the SLI values of a previous evaluation against the SLO file, e.g. after the SLO
+// file has been updated, without retrieving them from the SLI provider again
+type reevaluation struct {
+	// EventID is the ID of the evaluation.finished event of the previous evaluation
+	EventID string `json:"eventId"`
 }
 
 func (eh *StartEvaluationHandler) HandleEvent(ctx context.Context) error {
@@ -50,12 +71,25 @@ func (eh *StartEvaluationHandler) HandleEvent(ctx context.Context) error {
 		return err
 	}
 
+	val := ctx.Value(GracefulShutdownKey)
+
+	reevaluationRequest := &evaluationTriggeredEventData{}
+	_ = eh.Event.DataAs(reevaluationRequest)
+	if reevaluationRequest.Evaluation.Reevaluate != nil && reevaluationRequest.Evaluation.Reevaluate.EventID != "" {
+		if val != nil {
+			if wg, ok := val.(*sync.WaitGroup); ok {
+				wg.Add(1)
+			}
+		}
+		go eh.reevaluate(ctx, keptnContext, commitID, e, reevaluationRequest.Evaluation.Reevaluate.EventID)
+		return nil
+	}
+
 	// try to parse timestamps
 	evaluationStartTimestamp, evaluationEndTimestamp, err := getEvaluationTimestamps(e)
 	if err != nil {
 		return eh.sendEvaluationFinishedWithErrorEvent(evaluationStartTimestamp, evaluationEndTimestamp, e, err.Error())
 	}
-	val := ctx.Value(GracefulShutdownKey)
 	if val != nil {
 		if wg, ok := val.(*sync.WaitGroup); ok {
 			wg.Add(1)
@@ -81,57 +115,132 @@ func (eh *StartEvaluationHandler) sendGetSliCloudEvent(ctx context.Context, kept
 
 	indicators := []string{}
 	var filters = []*keptnv2.SLIFilter{}
+	var windows []*evaluation.SLIWindow
+	var sloFileContent []byte
 
-	if err2, end := eh.computeObjectives(e, commitID, &indicators, &filters, evaluationStartTimestamp, evaluationEndTimestamp); end {
+	if err2, end := eh.computeObjectives(e, commitID, &indicators, &filters, &windows, &sloFileContent, evaluationStartTimestamp, evaluationEndTimestamp); end {
 		return err2
 	}
 
-	// get the SLI provider that has been configured for the project (e.g. 'dynatrace' or 'prometheus') from the respective configmap
-	var sliProvider string
-	sliProvider, err := eh.SLIProviderConfig.GetSLIProvider(e.Project)
-	if err != nil {
-		// no provider found - fallback to default SLI provider
-		sliProvider, err = eh.SLIProviderConfig.GetDefaultSLIProvider()
-		if err != nil {
-			// no default SLI provider configured
-			logger.Error("no SLI-provider configured for project " + e.Project + ", no evaluation conducted")
-			evaluationDetails := keptnv2.EvaluationDetails{
-				IndicatorResults: nil,
-				TimeStart:        evaluationStartTimestamp,
-				TimeEnd:          evaluationEndTimestamp,
-				Result:           string(keptnv2.ResultPass),
-			}
+	// the SLIs are not retrieved again if the same timeframe has already been evaluated against this revision of the SLO file
+	if len(sloFileContent) > 0 {
+		fingerprint := evaluationFingerprint(e.Project, e.Stage, e.Service, evaluationStartTimestamp, evaluationEndTimestamp, sloFileContent)
+		if stored, storedData := getStoredEvaluation(eh.EvaluationStore, e.Project, e.Stage, e.Service, fingerprint); stored != nil {
+			return sendStoredEvaluation(keptnContext, eh.Event.ID(), commitID, eh.KeptnHandler, stored, storedData, e.Labels)
+		}
+	}
 
-			evaluationFinishedData := keptnv2.EvaluationFinishedEventData{
-				EventData: keptnv2.EventData{
-					Project: e.Project,
-					Stage:   e.Stage,
-					Service: e.Service,
-					Labels:  e.Labels,
-					Status:  keptnv2.StatusSucceeded,
-					Result:  keptnv2.ResultPass,
-					Message: fmt.Sprintf("no evaluation performed by lighthouse because no SLI-provider configured for project %s", e.Project),
-				},
-				Evaluation: evaluationDetails,
-			}
+	// objectives referencing SLIs of other services are retrieved from the SLI providers of these services
+	requests := groupIndicatorsByService(eh.SLOFileRetriever.ServiceHandler, &e.EventData, indicators)
+	if isCompositeEvaluation(requests, e.Service) {
+		return eh.sendCompositeGetSLIEvents(keptnContext, commitID, e, requests, sloFileContent, evaluationStartTimestamp, evaluationEndTimestamp, filters, windows)
+	}
+
+	// resolve the SLI provider (e.g. 'dynatrace' or 'prometheus') of the service from the SLO file, the lighthouse.yaml resource,
+	// the env variables or the configmaps of lighthouse
+	sliProvider, err := eh.SLIProviderResolver.ResolveSLIProvider(e.Project, e.Stage, e.Service, sloFileContent)
+	if err != nil {
+		// no SLI provider configured
+		logger.Error("no SLI-provider configured for project " + e.Project + ", no evaluation conducted")
+		evaluationDetails := keptnv2.EvaluationDetails{
+			IndicatorResults: nil,
+			TimeStart:        evaluationStartTimestamp,
+			TimeEnd:          evaluationEndTimestamp,
+			Result:           string(keptnv2.ResultPass),
+		}
 
-			return sendEvent(keptnContext, eh.Event.ID(), keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), commitID, eh.KeptnHandler, &evaluationFinishedData)
+		evaluationFinishedData := keptnv2.EvaluationFinishedEventData{
+			EventData: keptnv2.EventData{
+				Project: e.Project,
+				Stage:   e.Stage,
+				Service: e.Service,
+				Labels:  e.Labels,
+				Status:  keptnv2.StatusSucceeded,
+				Result:  keptnv2.ResultPass,
+				Message: fmt.Sprintf("no evaluation performed by lighthouse because no SLI-provider configured for project %s", e.Project),
+			},
+			Evaluation: evaluationDetails,
 		}
+
+		return sendEvent(keptnContext, eh.Event.ID(), keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), commitID, eh.KeptnHandler, &evaluationFinishedData)
 	}
 	// send a new event to trigger the SLI retrieval
-	logger.Debug("SLI provider for project " + e.Project + " is: " + sliProvider)
-	err = eh.sendInternalGetSLIEvent(keptnContext, commitID, e, sliProvider, indicators, evaluationStartTimestamp, evaluationEndTimestamp, filters)
+	logger.Infof("SLI provider of service %s in stage %s of project %s is %s, declared in %s", e.Service, e.Stage, e.Project, sliProvider.Provider, sliProvider.Source)
+	err = eh.sendInternalGetSLIEvent(keptnContext, commitID, e, sliProvider, indicators, evaluationStartTimestamp, evaluationEndTimestamp, filters, windows)
 	return nil
 }
 
-func (eh *StartEvaluationHandler) computeObjectives(e *keptnv2.EvaluationTriggeredEventData, commitID string, indicators *[]string, filters *[]*keptnv2.SLIFilter, evaluationStartTimestamp string, evaluationEndTimestamp string) (error, bool) {
-	objectives, _, err := eh.SLOFileRetriever.GetSLOs(e.Project, e.Stage, e.Service, commitID)
+// reevaluate evaluates the SLI values of a previous evaluation against the SLO file of the commit of the
+// evaluation.triggered event, or its latest revision
+func (eh *StartEvaluationHandler) reevaluate(ctx context.Context, keptnContext string, commitID string, e *keptnv2.EvaluationTriggeredEventData, eventID string) error {
+
+	defer func() {
+		val := ctx.Value(GracefulShutdownKey)
+		if val == nil {
+			return
+		}
+		if wg, ok := val.(*sync.WaitGroup); ok {
+			wg.Done()
+		}
+	}()
+
+	stored, err := eh.EvaluationStore.GetEvaluation(e.Project, e.Stage, e.Service, eventID)
+	if err != nil {
+		message := fmt.Sprintf("could not retrieve evaluation to re-evaluate: %v", err)
+		logger.Error(message)
+		return eh.sendEvaluationFinishedWithErrorEvent("", "", e, message)
+	}
+	data, err := stored.eventData()
+	if err != nil {
+		logger.Error(err.Error())
+		return eh.sendEvaluationFinishedWithErrorEvent("", "", e, err.Error())
+	}
+	if len(data.Evaluation.IndicatorResults) == 0 {
+		message := fmt.Sprintf("evaluation %s does not contain any SLI values to re-evaluate", eventID)
+		logger.Error(message)
+		return eh.sendEvaluationFinishedWithErrorEvent(data.Evaluation.TimeStart, data.Evaluation.TimeEnd, e, message)
+	}
+
+	getSLIFinished, samples := data.GetSLIFinishedEventData()
+	getSLIFinished.Labels = e.Labels
+
+	logger.Infof("Re-evaluating the SLI values of evaluation %s", eventID)
+	evaluator := &EvaluateSLIHandler{
+		Event:             eh.Event,
+		HTTPClient:        eh.HTTPClient,
+		KeptnHandler:      eh.KeptnHandler,
+		SLOFileRetriever:  eh.SLOFileRetriever,
+		EvaluationStore:   eh.EvaluationStore,
+		AnomalyModelStore: eh.AnomalyModelStore,
+		// the re-evaluation of a composite evaluation is broken down by service as well
+		composite: compositeRetrievalFromBreakdown(e.Service, data.Evaluation.Services),
+	}
+	return evaluator.evaluateSLIs(keptnContext, eh.Event.ID(), commitID, getSLIFinished, samples, eventID)
+}
+
+func (eh *StartEvaluationHandler) computeObjectives(e *keptnv2.EvaluationTriggeredEventData, commitID string, indicators *[]string, filters *[]*keptnv2.SLIFilter, windows *[]*evaluation.SLIWindow, sloFile *[]byte, evaluationStartTimestamp string, evaluationEndTimestamp string) (error, bool) {
+	objectives, sloFileContent, err := eh.SLOFileRetriever.GetSLOs(e.Project, e.Stage, e.Service, commitID)
 	if err == nil && objectives != nil {
 		logger.Info("SLO file found")
+		*sloFile = sloFileContent
 		for _, objective := range objectives.Objectives {
 			*indicators = append(*indicators, objective.SLI)
 		}
 
+		// the SLIs of burn rate objectives are additionally requested for each of their windows
+		slo, err := evaluation.ParseSLOFile(sloFileContent)
+		if err != nil {
+			logger.Error(err.Error())
+			return eh.sendEvaluationFinishedWithErrorEvent(evaluationStartTimestamp, evaluationEndTimestamp, e, err.Error()), true
+		}
+		sliWindows, windowIndicators, err := evaluation.GetSLIWindows(slo.BurnRatePolicies, evaluationEndTimestamp)
+		if err != nil {
+			logger.Error(err.Error())
+			return eh.sendEvaluationFinishedWithErrorEvent(evaluationStartTimestamp, evaluationEndTimestamp, e, err.Error()), true
+		}
+		*indicators = append(*indicators, windowIndicators...)
+		*windows = sliWindows
+
 		if objectives.Filter != nil {
 			for key, value := range objectives.Filter {
 				filter := &keptnv2.SLIFilter{
@@ -203,31 +312,59 @@ func getEvaluationTimestamps(e *keptnv2.EvaluationTriggeredEventData) (string, s
 	return "", "", errors.New("evaluation.triggered event does not contain evaluation timeframe")
 }
 
-func (eh *StartEvaluationHandler) sendInternalGetSLIEvent(shkeptncontext string, commitID string, e *keptnv2.EvaluationTriggeredEventData, sliProvider string, indicators []string, start string, end string, filters []*keptnv2.SLIFilter) error {
-	source, _ := url.Parse("lighthouse-service")
+// getSLITriggeredEventData extends the get-sli.triggered event data with the windows burn rates are evaluated for, the
+// source the SLI provider has been resolved from and the services of a composite evaluation
+type getSLITriggeredEventData struct {
+	keptnv2.EventData
+	GetSLI     getSLI `json:"get-sli"`
+	Deployment string `json:"deployment"`
+}
+
+type getSLI struct {
+	keptnv2.GetSLI
+	Windows           []*evaluation.SLIWindow `json:"windows,omitempty"`
+	SLIProviderSource string                  `json:"sliProviderSource,omitempty"`
+	// Composite is set if the SLIs are retrieved for the evaluation of another service
+	Composite *compositeRetrieval `json:"composite,omitempty"`
+}
+
+func (eh *StartEvaluationHandler) sendInternalGetSLIEvent(shkeptncontext string, commitID string, e *keptnv2.EvaluationTriggeredEventData, sliProvider *ResolvedSLIProvider, indicators []string, start string, end string, filters []*keptnv2.SLIFilter, windows []*evaluation.SLIWindow) error {
+	getSLITriggeredEventData := newGetSLITriggeredEventData(e, sliProvider, indicators, start, end, filters, windows)
+	return eh.sendGetSLITriggeredEvent(shkeptncontext, commitID, uuid.New().String(), getSLITriggeredEventData)
+}
 
-	getSLITriggeredEventData := keptnv2.GetSLITriggeredEventData{
+func newGetSLITriggeredEventData(e *keptnv2.EvaluationTriggeredEventData, sliProvider *ResolvedSLIProvider, indicators []string, start string, end string, filters []*keptnv2.SLIFilter, windows []*evaluation.SLIWindow) getSLITriggeredEventData {
+	getSLITriggeredEventData := getSLITriggeredEventData{
 		EventData: keptnv2.EventData{
 			Project: e.Project,
 			Stage:   e.Stage,
 			Service: e.Service,
 			Labels:  e.Labels,
 		},
-		GetSLI: keptnv2.GetSLI{
-			SLIProvider:   sliProvider,
-			Start:         start,
-			End:           end,
-			Indicators:    indicators,
-			CustomFilters: filters,
+		GetSLI: getSLI{
+			GetSLI: keptnv2.GetSLI{
+				SLIProvider:   sliProvider.Provider,
+				Start:         start,
+				End:           end,
+				Indicators:    indicators,
+				CustomFilters: filters,
+			},
+			Windows:           windows,
+			SLIProviderSource: sliProvider.Source,
 		},
 	}
 
 	if e.Deployment.DeploymentNames != nil && len(e.Deployment.DeploymentNames) > 0 {
 		getSLITriggeredEventData.Deployment = e.Deployment.DeploymentNames[0]
 	}
+	return getSLITriggeredEventData
+}
+
+func (eh *StartEvaluationHandler) sendGetSLITriggeredEvent(shkeptncontext string, commitID string, eventID string, getSLITriggeredEventData getSLITriggeredEventData) error {
+	source, _ := url.Parse("lighthouse-service")
 
 	event := cloudevents.NewEvent()
-	event.SetID(uuid.New().String())
+	event.SetID(eventID)
 	event.SetType(keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName))
 	event.SetSource(source.String())
 	event.SetDataContentType(cloudevents.ApplicationJSON)
@@ -294,14 +294,19 @@ func TestStartEvaluationHandler_HandleEvent(t *testing.T) {
 			returnSlo = tt.sloAvailable
 			sloFileContent = tt.sloFileContent
 			returnServiceNotFound = tt.serviceNotAvailable
+			sliProviderConfig := &MockSLIProviderConfig{
+				ProjectSLIProvider: tt.ProjectSLIProvider,
+				DefaultSLIProvider: tt.DefaultSLIProvider,
+			}
 			eh := &StartEvaluationHandler{
 				Event:        tt.fields.Event,
 				KeptnHandler: keptnHandler,
-				SLIProviderConfig: &MockSLIProviderConfig{
-					ProjectSLIProvider: tt.ProjectSLIProvider,
-					DefaultSLIProvider: tt.DefaultSLIProvider,
+				SLIProviderResolver: SLIProviderChain{
+					ProjectSLIProviderSource{Name: SLIProviderSourceConfigMapProject, Config: sliProviderConfig},
+					DefaultSLIProviderSource{Name: SLIProviderSourceConfigMapDefault, Config: sliProviderConfig},
 				},
 				SLOFileRetriever: tt.fields.SLOFileRetriever,
+				EvaluationStore:  &fakeEvaluationStore{},
 			}
 			if err := eh.HandleEvent(ctx); (err != nil) != tt.wantErr {
 				t.Errorf("HandleEvent() error = %v, wantErr %v", err, tt.wantErr)
@@ -0,0 +1,223 @@
+package event_handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+	logger "github.com/sirupsen/logrus"
+)
+
+// WhatIfPath is the path of the endpoint replaying previous evaluations with a candidate SLO file
+const WhatIfPath = "/v1/what-if"
+
+const maxWhatIfPayloadSize = 1024 * 1024
+
+const defaultWhatIfEvaluations = 10
+const maxWhatIfEvaluations = 100
+
+// ErrInvalidWhatIfRequest is returned if the what-if request or its SLO file is invalid
+var ErrInvalidWhatIfRequest = errors.New("invalid what-if request")
+
+// WhatIfRequest contains a candidate SLO file and the service whose previous evaluations are replayed with it
+type WhatIfRequest struct {
+	Project string `json:"project"`
+	Stage   string `json:"stage"`
+	Service string `json:"service"`
+	// SLO is the content of the candidate slo.yaml file
+	SLO string `json:"slo"`
+	// Evaluations is the number of previous evaluations to replay, 10 by default
+	Evaluations int `json:"evaluations,omitempty"`
+}
+
+// WhatIfResult contains the scores and results of the previous evaluations of a service, as well as the scores and
+// results they would have had with the candidate SLO file
+type WhatIfResult struct {
+	Project     string              `json:"project"`
+	Stage       string              `json:"stage"`
+	Service     string              `json:"service"`
+	Evaluations []*WhatIfEvaluation `json:"evaluations"`
+}
+
+type WhatIfEvaluation struct {
+	// EventID is the ID of the evaluation.finished event of the previous evaluation
+	EventID   string  `json:"eventId"`
+	TimeStart string  `json:"timeStart"`
+	TimeEnd   string  `json:"timeEnd"`
+	OldScore  float64 `json:"oldScore"`
+	OldResult string  `json:"oldResult"`
+	NewScore  float64 `json:"newScore"`
+	NewResult string  `json:"newResult"`
+	// Message explains the new result, or why the evaluation could not be replayed
+	Message string `json:"message,omitempty"`
+}
+
+// WhatIf replays the SLI values of the latest evaluations of a service through the objectives of the candidate SLO
+// file. Relative criteria of an evaluation are compared with the evaluations preceding it, as far as they are replayed
+// or have been retrieved for comparison. The baselines for anomaly detection are built from the preceding evaluations
+// as well, the stored baselines are neither used nor changed
+func WhatIf(store EvaluationStore, request WhatIfRequest) (*WhatIfResult, error) {
+	if request.Project == "" || request.Stage == "" || request.Service == "" {
+		return nil, fmt.Errorf("%w: project, stage and service must be set", ErrInvalidWhatIfRequest)
+	}
+	if request.Evaluations == 0 {
+		request.Evaluations = defaultWhatIfEvaluations
+	}
+	if request.Evaluations < 0 || request.Evaluations > maxWhatIfEvaluations {
+		return nil, fmt.Errorf("%w: evaluations must be between 1 and %d", ErrInvalidWhatIfRequest, maxWhatIfEvaluations)
+	}
+	slo, err := evaluation.ParseSLOFile([]byte(request.SLO))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWhatIfRequest, err)
+	}
+
+	// the evaluations preceding the oldest replayed evaluation are retrieved as well, since they are compared with it
+	// or, with anomaly detection, the baselines are built from them
+	numberOfPreviousResults := slo.NumberOfPreviousResults()
+	limit := request.Evaluations + numberOfPreviousResults
+	if len(slo.AnomalyPolicies) > 0 {
+		limit = request.Evaluations + anomalyBootstrapEvaluations
+	}
+	storedEvaluations, err := store.GetEvaluations(request.Project, request.Stage, request.Service, limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve previous evaluations: %w", err)
+	}
+
+	result := &WhatIfResult{Project: request.Project, Stage: request.Stage, Service: request.Service, Evaluations: []*WhatIfEvaluation{}}
+	for i, stored := range storedEvaluations {
+		if i == request.Evaluations {
+			break
+		}
+		whatIfEvaluation := &WhatIfEvaluation{EventID: stored.ID}
+		result.Evaluations = append(result.Evaluations, whatIfEvaluation)
+
+		data, err := stored.eventData()
+		if err != nil {
+			whatIfEvaluation.Message = err.Error()
+			continue
+		}
+		whatIfEvaluation.TimeStart = data.Evaluation.TimeStart
+		whatIfEvaluation.TimeEnd = data.Evaluation.TimeEnd
+		whatIfEvaluation.OldScore = data.Evaluation.Score
+		whatIfEvaluation.OldResult = string(data.Result)
+		if len(data.Evaluation.IndicatorResults) == 0 {
+			whatIfEvaluation.Message = "evaluation does not contain any SLI values"
+			continue
+		}
+
+		previousEvaluations, previousSamples := getPrecedingEvaluations(storedEvaluations[i+1:], numberOfPreviousResults, slo.Comparison.IncludeResultWithScore)
+		e, samples := data.GetSLIFinishedEventData()
+		evaluationResult, err := slo.Evaluate(evaluation.Input{
+			GetSLI:              e,
+			Samples:             samples,
+			PreviousEvaluations: previousEvaluations,
+			PreviousSamples:     previousSamples,
+			AnomalyModels:       buildAnomalyModels(storedEvaluations[i+1:], e, slo.AnomalyPolicies, slo.Comparison.IncludeResultWithScore),
+		})
+		if err != nil {
+			whatIfEvaluation.Message = err.Error()
+			continue
+		}
+		whatIfEvaluation.NewScore = evaluationResult.Evaluation.Score
+		whatIfEvaluation.NewResult = string(evaluationResult.Result)
+		whatIfEvaluation.Message = evaluationResult.Message
+	}
+	return result, nil
+}
+
+// buildAnomalyModels builds the baselines of the SLIs with anomaly detection from the given evaluations, without
+// storing them
+func buildAnomalyModels(storedEvaluations []*StoredEvaluation, e *keptnv2.GetSLIFinishedEventData, policies map[string]*evaluation.AnomalyPolicy, includeResult string) map[string]*evaluation.AnomalyModel {
+	models := map[string]*evaluation.AnomalyModel{}
+	if len(policies) == 0 {
+		return models
+	}
+	evaluations, _ := getPrecedingEvaluations(storedEvaluations, anomalyBootstrapEvaluations, includeResult)
+	for sli := range policies {
+		models[sli] = evaluation.NewAnomalyModel(e.Project, e.Stage, e.Service, sli)
+		models[sli].Update(evaluations)
+	}
+	return models
+}
+
+// getPrecedingEvaluations returns the evaluations a replayed evaluation is compared with, along with the raw samples
+// of their SLIs
+func getPrecedingEvaluations(storedEvaluations []*StoredEvaluation, numberOfPreviousResults int, includeResult string) ([]*keptnv2.EvaluationFinishedEventData, map[string][]float64) {
+	var previousEvaluations []*keptnv2.EvaluationFinishedEventData
+	previousSamples := map[string][]float64{}
+	includeResult = strings.ToLower(includeResult)
+	for _, stored := range storedEvaluations {
+		if len(previousEvaluations) == numberOfPreviousResults {
+			break
+		}
+		previousEvaluation := &keptnv2.EvaluationFinishedEventData{}
+		if err := json.Unmarshal(stored.Data, previousEvaluation); err != nil {
+			continue
+		}
+		if (includeResult == "pass" && previousEvaluation.Result != keptnv2.ResultPass) ||
+			(includeResult == "pass_or_warn" && previousEvaluation.Result != keptnv2.ResultPass && previousEvaluation.Result != keptnv2.ResultWarning) {
+			continue
+		}
+		previousEvaluations = append(previousEvaluations, previousEvaluation)
+		samples := evaluation.SLISamplesEventData{}
+		if err := json.Unmarshal(stored.Data, &samples); err == nil {
+			samples.AddEvaluationSamples(previousSamples)
+		}
+	}
+	return previousEvaluations, previousSamples
+}
+
+// WhatIfHandler serves the what-if endpoint of lighthouse
+type WhatIfHandler struct {
+	evaluationStore EvaluationStore
+}
+
+func NewWhatIfHandler(evaluationStore EvaluationStore) *WhatIfHandler {
+	return &WhatIfHandler{evaluationStore: evaluationStore}
+}
+
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (wh *WhatIfHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWhatIfPayloadSize))
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "could not read request payload")
+		return
+	}
+	request := WhatIfRequest{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "invalid request payload: "+err.Error())
+		return
+	}
+
+	result, err := WhatIf(wh.evaluationStore, request)
+	if errors.Is(err, ErrInvalidWhatIfRequest) {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
+		logger.Errorf("could not replay evaluations: %v", err)
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	logger.Infof("replayed %d evaluations of service %s in stage %s of project %s", len(result.Evaluations), request.Service, request.Stage, request.Project)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func writeErrorResponse(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(errorResponse{Code: code, Message: message})
+}
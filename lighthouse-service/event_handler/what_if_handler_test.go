@@ -0,0 +1,142 @@
+package event_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newStoredTestEvaluation(id string, result string, score float64, responseTime float64) *StoredEvaluation {
+	return &StoredEvaluation{
+		ID: id,
+		Data: []byte(fmt.Sprintf(`{"project":"sockshop","stage":"staging","service":"carts","result":"%s","evaluation":{
+"timeStart":"2022-01-01T10:00:00.000Z","timeEnd":"2022-01-01T10:05:00.000Z","score":%v,"result":"%s",
+"indicatorResults":[{"value":{"metric":"response_time_p95","value":%v,"success":true},"score":1,"status":"%s"}]}}`, result, score, result, responseTime, result)),
+	}
+}
+
+func TestWhatIf(t *testing.T) {
+	store := &fakeEvaluationStore{latest: []*StoredEvaluation{
+		newStoredTestEvaluation("id-1", "pass", 100, 500),
+		newStoredTestEvaluation("id-2", "pass", 100, 300),
+		{ID: "id-3", Data: []byte(`{"result":"fail","evaluation":{"score":0}}`)},
+		newStoredTestEvaluation("id-4", "pass", 100, 400),
+	}}
+
+	result, err := WhatIf(store, WhatIfRequest{
+		Project:     "sockshop",
+		Stage:       "staging",
+		Service:     "carts",
+		SLO:         strings.Replace(testFingerprintSLO, "<=600", "<=400", 1),
+		Evaluations: 3,
+	})
+	require.Nil(t, err)
+	require.Len(t, result.Evaluations, 3)
+
+	require.Equal(t, &WhatIfEvaluation{
+		EventID:   "id-1",
+		TimeStart: "2022-01-01T10:00:00.000Z",
+		TimeEnd:   "2022-01-01T10:05:00.000Z",
+		OldScore:  100,
+		OldResult: "pass",
+		NewScore:  0,
+		NewResult: "fail",
+		Message:   "Evaluation failed since the calculated score of 0 is below the target value of 90",
+	}, result.Evaluations[0])
+	require.Equal(t, "pass", result.Evaluations[1].NewResult)
+	require.Equal(t, 100.0, result.Evaluations[1].NewScore)
+	require.Equal(t, "", result.Evaluations[2].NewResult)
+	require.Equal(t, "evaluation does not contain any SLI values", result.Evaluations[2].Message)
+}
+
+func TestWhatIf_RelativeCriteria(t *testing.T) {
+	store := &fakeEvaluationStore{latest: []*StoredEvaluation{
+		newStoredTestEvaluation("id-1", "pass", 100, 500),
+		newStoredTestEvaluation("id-2", "fail", 0, 300),
+		newStoredTestEvaluation("id-3", "pass", 100, 450),
+	}}
+	slo := `spec_version: "1.0"
+comparison:
+  compare_with: "single_result"
+  include_result_with_score: "pass"
+  aggregate_function: "avg"
+objectives:
+  - sli: "response_time_p95"
+    pass:
+      - criteria:
+          - "<=+10%"
+total_score:
+  pass: "90%"
+`
+	result, err := WhatIf(store, WhatIfRequest{Project: "sockshop", Stage: "staging", Service: "carts", SLO: slo, Evaluations: 2})
+	require.Nil(t, err)
+	require.Len(t, result.Evaluations, 2)
+	// the failed evaluation is skipped, i.e. 500 is compared with 450
+	require.Equal(t, "fail", result.Evaluations[0].NewResult)
+	require.Equal(t, "pass", result.Evaluations[1].NewResult)
+}
+
+func TestWhatIf_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		request WhatIfRequest
+		wantErr string
+	}{
+		{
+			name:    "missing service",
+			request: WhatIfRequest{Project: "sockshop", Stage: "staging", SLO: testFingerprintSLO},
+			wantErr: "invalid what-if request: project, stage and service must be set",
+		},
+		{
+			name:    "too many evaluations",
+			request: WhatIfRequest{Project: "sockshop", Stage: "staging", Service: "carts", SLO: testFingerprintSLO, Evaluations: 101},
+			wantErr: "invalid what-if request: evaluations must be between 1 and 100",
+		},
+		{
+			name:    "invalid strategy",
+			request: WhatIfRequest{Project: "sockshop", Stage: "staging", Service: "carts", SLO: testFingerprintSLO + "comparison:\n  strategy: unknown\n"},
+			wantErr: "invalid what-if request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := WhatIf(&fakeEvaluationStore{}, tt.request)
+			require.ErrorIs(t, err, ErrInvalidWhatIfRequest)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWhatIfHandler_ServeHTTP(t *testing.T) {
+	store := &fakeEvaluationStore{latest: []*StoredEvaluation{newStoredTestEvaluation("id-1", "pass", 100, 500)}}
+	handler := NewWhatIfHandler(store)
+
+	body, _ := json.Marshal(WhatIfRequest{Project: "sockshop", Stage: "staging", Service: "carts", SLO: testFingerprintSLO})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, WhatIfPath, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	result := &WhatIfResult{}
+	require.Nil(t, json.Unmarshal(recorder.Body.Bytes(), result))
+	require.Len(t, result.Evaluations, 1)
+	require.Equal(t, "pass", result.Evaluations[0].NewResult)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, WhatIfPath, nil))
+	require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, WhatIfPath, strings.NewReader(`{"project":"sockshop"}`)))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	store.err = errors.New("mongodb-datastore not available")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, WhatIfPath, bytes.NewReader(body)))
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
@@ -6,7 +6,9 @@ import (
 	eventsource "github.com/keptn/go-utils/pkg/sdk/connector/eventsource/nats"
 	"github.com/keptn/go-utils/pkg/sdk/connector/logforwarder"
 	"github.com/keptn/go-utils/pkg/sdk/connector/subscriptionsource"
+	"github.com/keptn/go-utils/pkg/sdk/connector/types"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
@@ -35,6 +37,7 @@ type envConfig struct {
 	K8SNamespace            string `envconfig:"K8S_NAMESPACE" default:""`
 	K8SNodeName             string `envconfig:"K8S_NODE_NAME" default:""`
 	LogLevel                string `envconfig:"LOG_LEVEL" default:"info"`
+	APIPort                 string `envconfig:"API_PORT" default:"8081"`
 }
 
 func main() {
@@ -72,7 +75,19 @@ func main() {
 		}))
 	}()
 
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle(event_handler.WhatIfPath, event_handler.NewWhatIfHandler(event_handler.DatastoreEvaluationStore{HTTPClient: &http.Client{}}))
+		if err := http.ListenAndServe(":"+env.APIPort, mux); err != nil {
+			log.WithError(err).Error("http endpoint stopped")
+		}
+	}()
+
 	ctx, wg := getGracefulContext()
+
+	// composite evaluations whose deadline has expired while no replica was running are evaluated by the sweep
+	go event_handler.SweepExpiredComposites(context.WithValue(ctx, types.EventSenderKey, eventSource.Sender()))
+
 	err = controlPlane.Register(ctx, LighthouseService{env})
 	if err != nil {
 		log.Fatal(err)
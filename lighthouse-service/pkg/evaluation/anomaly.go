@@ -0,0 +1,266 @@
+package evaluation
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// AnomalySensitivityLow flags values deviating by more than four standard deviations from the baseline
+	AnomalySensitivityLow = "low"
+	// AnomalySensitivityMedium flags values deviating by more than three standard deviations from the baseline
+	AnomalySensitivityMedium = "medium"
+	// AnomalySensitivityHigh flags values deviating by more than two standard deviations from the baseline
+	AnomalySensitivityHigh = "high"
+
+	// minimumBaselineSize is the number of values a bucket of the baseline requires to be used
+	minimumBaselineSize = 5
+)
+
+var anomalySensitivities = map[string]float64{
+	AnomalySensitivityLow:    4,
+	AnomalySensitivityMedium: 3,
+	AnomalySensitivityHigh:   2,
+}
+
+// AnomalyPolicy is configured by the anomaly property of an objective in the SLO file. The value of the SLI is
+// compared with the band of its seasonal baseline, which is learned from the previous evaluations
+type AnomalyPolicy struct {
+	Sensitivity string `yaml:"sensitivity"`
+}
+
+func parseAnomalyPolicies(sloFileContent []byte) (map[string]*AnomalyPolicy, error) {
+	slo := struct {
+		Objectives []struct {
+			SLI     string         `yaml:"sli"`
+			Anomaly *AnomalyPolicy `yaml:"anomaly"`
+		} `yaml:"objectives"`
+	}{}
+	if err := yaml.Unmarshal(sloFileContent, &slo); err != nil {
+		return nil, fmt.Errorf("could not parse anomaly detection: %w", err)
+	}
+	policies := map[string]*AnomalyPolicy{}
+	for _, objective := range slo.Objectives {
+		if objective.Anomaly == nil {
+			continue
+		}
+		if objective.Anomaly.Sensitivity == "" {
+			objective.Anomaly.Sensitivity = AnomalySensitivityMedium
+		}
+		if _, ok := anomalySensitivities[objective.Anomaly.Sensitivity]; !ok {
+			return nil, fmt.Errorf("invalid anomaly detection of SLI %s: sensitivity must be one of %s, %s or %s", objective.SLI,
+				AnomalySensitivityLow, AnomalySensitivityMedium, AnomalySensitivityHigh)
+		}
+		policies[objective.SLI] = objective.Anomaly
+	}
+	return policies, nil
+}
+
+// AnomalyModel is the seasonal baseline of an SLI of a service. The values of the previous evaluations are collected
+// in buckets per hour of the week and per hour of the day, based on the end of the evaluation timeframe in UTC
+type AnomalyModel struct {
+	Project string `json:"project" bson:"project"`
+	Stage   string `json:"stage" bson:"stage"`
+	Service string `json:"service" bson:"service"`
+	SLI     string `json:"sli" bson:"sli"`
+	// LastEvaluation is the end of the timeframe of the latest evaluation included in the baseline
+	LastEvaluation time.Time `json:"lastEvaluation" bson:"lastEvaluation"`
+	// Weekly contains a bucket per hour of the week, starting on Sunday 00:00
+	Weekly []*baselineBucket `json:"weekly" bson:"weekly"`
+	// Daily contains a bucket per hour of the day
+	Daily   []*baselineBucket `json:"daily" bson:"daily"`
+	Overall *baselineBucket   `json:"overall" bson:"overall"`
+}
+
+// baselineBucket keeps the mean and the sum of squared deviations of its values, which are updated using Welford's algorithm
+type baselineBucket struct {
+	Count int     `json:"count" bson:"count"`
+	Mean  float64 `json:"mean" bson:"mean"`
+	M2    float64 `json:"m2" bson:"m2"`
+}
+
+// NewAnomalyModel returns an empty baseline of the SLI
+func NewAnomalyModel(project, stage, service, sli string) *AnomalyModel {
+	model := &AnomalyModel{
+		Project: project,
+		Stage:   stage,
+		Service: service,
+		SLI:     sli,
+		Weekly:  make([]*baselineBucket, 7*24),
+		Daily:   make([]*baselineBucket, 24),
+		Overall: &baselineBucket{},
+	}
+	for i := range model.Weekly {
+		model.Weekly[i] = &baselineBucket{}
+	}
+	for i := range model.Daily {
+		model.Daily[i] = &baselineBucket{}
+	}
+	return model
+}
+
+func (b *baselineBucket) add(value float64) {
+	b.Count++
+	delta := value - b.Mean
+	b.Mean += delta / float64(b.Count)
+	b.M2 += delta * (value - b.Mean)
+}
+
+func (b *baselineBucket) stdDev() float64 {
+	if b.Count < 2 {
+		return 0
+	}
+	return math.Sqrt(b.M2 / float64(b.Count-1))
+}
+
+func (m *AnomalyModel) add(timeEnd time.Time, value float64) {
+	timeEnd = timeEnd.UTC()
+	m.Weekly[int(timeEnd.Weekday())*24+timeEnd.Hour()].add(value)
+	m.Daily[timeEnd.Hour()].add(value)
+	m.Overall.add(value)
+}
+
+// Update adds the values of the evaluations that ended after the latest evaluation included in the baseline. The
+// evaluations are expected to be sorted newest first. It returns whether the baseline has been changed
+func (m *AnomalyModel) Update(evaluations []*keptnv2.EvaluationFinishedEventData) bool {
+	updated := false
+	for i := len(evaluations) - 1; i >= 0; i-- {
+		timeEnd, err := timeutils.ParseTimestamp(evaluations[i].Evaluation.TimeEnd)
+		if err != nil || !timeEnd.After(m.LastEvaluation) {
+			continue
+		}
+		for _, indicatorResult := range evaluations[i].Evaluation.IndicatorResults {
+			if indicatorResult.Value != nil && indicatorResult.Value.Metric == m.SLI && indicatorResult.Value.Success {
+				m.add(*timeEnd, indicatorResult.Value.Value)
+			}
+		}
+		m.LastEvaluation = timeEnd.UTC()
+		updated = true
+	}
+	return updated
+}
+
+// baseline returns the most specific bucket for the given time containing enough values, and its name
+func (m *AnomalyModel) baseline(timeEnd time.Time) (*baselineBucket, string) {
+	timeEnd = timeEnd.UTC()
+	if bucket := m.Weekly[int(timeEnd.Weekday())*24+timeEnd.Hour()]; bucket.Count >= minimumBaselineSize {
+		return bucket, fmt.Sprintf("%s %02d:00", timeEnd.Weekday().String()[:3], timeEnd.Hour())
+	}
+	if bucket := m.Daily[timeEnd.Hour()]; bucket.Count >= minimumBaselineSize {
+		return bucket, fmt.Sprintf("%02d:00", timeEnd.Hour())
+	}
+	if m.Overall.Count >= minimumBaselineSize {
+		return m.Overall, "overall"
+	}
+	return nil, ""
+}
+
+// Anomaly is the result of the anomaly detection of an SLI
+type Anomaly struct {
+	Sensitivity string `json:"sensitivity"`
+	// Bucket is the bucket of the baseline the value has been compared with, e.g. Mon 13:00, 13:00 or overall
+	Bucket     string  `json:"bucket,omitempty"`
+	Mean       float64 `json:"mean"`
+	StdDev     float64 `json:"stdDev"`
+	LowerBound float64 `json:"lowerBound"`
+	UpperBound float64 `json:"upperBound"`
+	SampleSize int     `json:"sampleSize"`
+	Violated   bool    `json:"violated"`
+	// Message explains why the value could not be compared with the baseline. In this case, the anomaly detection passes
+	Message string `json:"message,omitempty"`
+}
+
+// anomalyEvaluation contains the anomaly policies and the baselines of an evaluation. The detected anomalies are
+// collected per SLI
+type anomalyEvaluation struct {
+	policies map[string]*AnomalyPolicy
+	models   map[string]*AnomalyModel
+	timeEnd  string
+	results  map[string]*Anomaly
+}
+
+func newAnomalyEvaluation(policies map[string]*AnomalyPolicy, models map[string]*AnomalyModel, timeEnd string) *anomalyEvaluation {
+	return &anomalyEvaluation{
+		policies: policies,
+		models:   models,
+		timeEnd:  timeEnd,
+		results:  map[string]*Anomaly{},
+	}
+}
+
+// hasPolicy returns whether anomaly detection is configured for the SLI
+func (ae *anomalyEvaluation) hasPolicy(sli string) bool {
+	return ae != nil && ae.policies[sli] != nil
+}
+
+// evaluate compares the value of the SLI with the band of its baseline. It returns nil if no anomaly detection is
+// configured for the SLI
+func (ae *anomalyEvaluation) evaluate(sli string, result *keptnv2.SLIResult) *Anomaly {
+	if !ae.hasPolicy(sli) {
+		return nil
+	}
+	anomaly := &Anomaly{Sensitivity: ae.policies[sli].Sensitivity}
+	ae.results[sli] = anomaly
+
+	timeEnd, err := timeutils.ParseTimestamp(ae.timeEnd)
+	if err != nil {
+		anomaly.Message = "could not parse end of evaluation timeframe"
+		return anomaly
+	}
+	model := ae.models[sli]
+	if model == nil {
+		anomaly.Message = "no baseline available"
+		return anomaly
+	}
+	bucket, name := model.baseline(*timeEnd)
+	if bucket == nil {
+		anomaly.SampleSize = model.Overall.Count
+		anomaly.Message = fmt.Sprintf("baseline contains %d values, at least %d are required", model.Overall.Count, minimumBaselineSize)
+		return anomaly
+	}
+	factor := anomalySensitivities[anomaly.Sensitivity]
+	anomaly.Bucket = name
+	anomaly.SampleSize = bucket.Count
+	anomaly.Mean = roundStatistic(bucket.Mean)
+	anomaly.StdDev = roundStatistic(bucket.stdDev())
+	anomaly.LowerBound = roundStatistic(bucket.Mean - factor*bucket.stdDev())
+	anomaly.UpperBound = roundStatistic(bucket.Mean + factor*bucket.stdDev())
+	anomaly.Violated = result.Value < anomaly.LowerBound || result.Value > anomaly.UpperBound
+	return anomaly
+}
+
+// targets returns the bounds of the band as targets of the SLI
+func (a *Anomaly) targets() []*keptnv2.SLITarget {
+	if a.Message != "" {
+		return []*keptnv2.SLITarget{}
+	}
+	return []*keptnv2.SLITarget{
+		{
+			Criteria:    fmt.Sprintf("anomaly[%s]>=%v", a.Sensitivity, a.LowerBound),
+			TargetValue: a.LowerBound,
+			Violated:    a.Violated,
+		},
+		{
+			Criteria:    fmt.Sprintf("anomaly[%s]<=%v", a.Sensitivity, a.UpperBound),
+			TargetValue: a.UpperBound,
+			Violated:    a.Violated,
+		},
+	}
+}
+
+// addTo adds the detected anomalies to the evaluation.finished event data
+func (ae *anomalyEvaluation) addTo(data *EvaluationFinishedEventData) {
+	if ae == nil {
+		return
+	}
+	for _, indicatorResult := range data.Evaluation.IndicatorResults {
+		if indicatorResult.Value != nil {
+			indicatorResult.Anomaly = ae.results[indicatorResult.Value.Metric]
+		}
+	}
+}
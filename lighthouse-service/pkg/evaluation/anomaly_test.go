@@ -0,0 +1,188 @@
+package evaluation
+
+import (
+	"testing"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+const testAnomalySLO = `spec_version: "1.0"
+comparison:
+  include_result_with_score: "pass"
+objectives:
+  - sli: "response_time_p95"
+    pass:
+      - criteria:
+          - "<=600"
+  - sli: "throughput"
+    anomaly:
+      sensitivity: high
+total_score:
+  pass: "90%"
+`
+
+func newAnomalyTestEvaluation(timeEnd string, throughput float64) *keptnv2.EvaluationFinishedEventData {
+	return &keptnv2.EvaluationFinishedEventData{
+		EventData: keptnv2.EventData{Result: keptnv2.ResultPass},
+		Evaluation: keptnv2.EvaluationDetails{
+			TimeEnd: timeEnd,
+			IndicatorResults: []*keptnv2.SLIEvaluationResult{
+				{Value: &keptnv2.SLIResult{Metric: "throughput", Value: throughput, Success: true}},
+			},
+		},
+	}
+}
+
+// newTestAnomalyModel returns a baseline with the values 100, 110, 90, 105 and 95 on Mondays at 13:00 UTC
+func newTestAnomalyModel() *AnomalyModel {
+	model := NewAnomalyModel("sockshop", "staging", "carts", "throughput")
+	model.Update([]*keptnv2.EvaluationFinishedEventData{
+		newAnomalyTestEvaluation("2022-01-31T13:05:00.000Z", 95),
+		newAnomalyTestEvaluation("2022-01-24T13:05:00.000Z", 105),
+		newAnomalyTestEvaluation("2022-01-17T13:05:00.000Z", 90),
+		newAnomalyTestEvaluation("2022-01-10T13:05:00.000Z", 110),
+		newAnomalyTestEvaluation("2022-01-03T13:05:00.000Z", 100),
+	})
+	return model
+}
+
+func TestParseAnomalyPolicies(t *testing.T) {
+	policies, err := parseAnomalyPolicies([]byte(testAnomalySLO))
+	require.Nil(t, err)
+	require.Len(t, policies, 1)
+	require.Equal(t, AnomalySensitivityHigh, policies["throughput"].Sensitivity)
+
+	policies, err = parseAnomalyPolicies([]byte("objectives:\n  - sli: throughput\n    anomaly: {}\n"))
+	require.Nil(t, err)
+	require.Equal(t, AnomalySensitivityMedium, policies["throughput"].Sensitivity)
+
+	_, err = parseAnomalyPolicies([]byte("objectives:\n  - sli: throughput\n    anomaly:\n      sensitivity: extreme\n"))
+	require.EqualError(t, err, "invalid anomaly detection of SLI throughput: sensitivity must be one of low, medium or high")
+}
+
+func TestAnomalyModel_Update(t *testing.T) {
+	model := newTestAnomalyModel()
+	require.Equal(t, 5, model.Overall.Count)
+	require.Equal(t, 100.0, model.Overall.Mean)
+	require.Equal(t, 5, model.Weekly[1*24+13].Count)
+	require.Equal(t, 5, model.Daily[13].Count)
+	require.Equal(t, time.Date(2022, 1, 31, 13, 5, 0, 0, time.UTC), model.LastEvaluation)
+
+	// evaluations that are already included in the baseline are not added again
+	require.False(t, model.Update([]*keptnv2.EvaluationFinishedEventData{
+		newAnomalyTestEvaluation("2022-01-31T13:05:00.000Z", 95),
+	}))
+	require.Equal(t, 5, model.Overall.Count)
+
+	require.True(t, model.Update([]*keptnv2.EvaluationFinishedEventData{
+		newAnomalyTestEvaluation("2022-02-01T08:05:00.000Z", 200),
+		newAnomalyTestEvaluation("2022-01-31T13:05:00.000Z", 95),
+	}))
+	require.Equal(t, 6, model.Overall.Count)
+	require.Equal(t, 1, model.Weekly[2*24+8].Count)
+	require.Equal(t, 1, model.Daily[8].Count)
+}
+
+func TestAnomalyModel_baseline(t *testing.T) {
+	model := newTestAnomalyModel()
+
+	tests := []struct {
+		name       string
+		timeEnd    time.Time
+		wantBucket string
+	}{
+		{name: "hour of the week", timeEnd: time.Date(2022, 2, 7, 13, 30, 0, 0, time.UTC), wantBucket: "Mon 13:00"},
+		{name: "hour of the day", timeEnd: time.Date(2022, 2, 8, 13, 30, 0, 0, time.UTC), wantBucket: "13:00"},
+		{name: "overall", timeEnd: time.Date(2022, 2, 8, 8, 30, 0, 0, time.UTC), wantBucket: "overall"},
+		{name: "converted to UTC", timeEnd: time.Date(2022, 2, 7, 14, 30, 0, 0, time.FixedZone("CET", 3600)), wantBucket: "Mon 13:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket, name := model.baseline(tt.timeEnd)
+			require.NotNil(t, bucket)
+			require.Equal(t, tt.wantBucket, name)
+		})
+	}
+
+	bucket, _ := NewAnomalyModel("sockshop", "staging", "carts", "throughput").baseline(time.Now())
+	require.Nil(t, bucket)
+}
+
+func TestAnomalyEvaluation_evaluate(t *testing.T) {
+	policies := map[string]*AnomalyPolicy{"throughput": {Sensitivity: AnomalySensitivityHigh}}
+	models := map[string]*AnomalyModel{"throughput": newTestAnomalyModel()}
+
+	ae := newAnomalyEvaluation(policies, models, "2022-02-07T13:05:00.000Z")
+	anomaly := ae.evaluate("throughput", &keptnv2.SLIResult{Metric: "throughput", Value: 120, Success: true})
+	require.Equal(t, &Anomaly{
+		Sensitivity: AnomalySensitivityHigh,
+		Bucket:      "Mon 13:00",
+		Mean:        100,
+		StdDev:      7.9057,
+		LowerBound:  84.1886,
+		UpperBound:  115.8114,
+		SampleSize:  5,
+		Violated:    true,
+	}, anomaly)
+	require.Equal(t, []*keptnv2.SLITarget{
+		{Criteria: "anomaly[high]>=84.1886", TargetValue: 84.1886, Violated: true},
+		{Criteria: "anomaly[high]<=115.8114", TargetValue: 115.8114, Violated: true},
+	}, anomaly.targets())
+
+	// the band is wider with a lower sensitivity
+	policies["throughput"].Sensitivity = AnomalySensitivityLow
+	require.False(t, ae.evaluate("throughput", &keptnv2.SLIResult{Metric: "throughput", Value: 120, Success: true}).Violated)
+
+	// without a baseline, the anomaly detection passes
+	ae = newAnomalyEvaluation(policies, map[string]*AnomalyModel{"throughput": NewAnomalyModel("sockshop", "staging", "carts", "throughput")}, "2022-02-07T13:05:00.000Z")
+	anomaly = ae.evaluate("throughput", &keptnv2.SLIResult{Metric: "throughput", Value: 120, Success: true})
+	require.False(t, anomaly.Violated)
+	require.Equal(t, "baseline contains 0 values, at least 5 are required", anomaly.Message)
+	require.Empty(t, anomaly.targets())
+
+	require.Nil(t, ae.evaluate("response_time_p95", &keptnv2.SLIResult{Metric: "response_time_p95", Value: 120, Success: true}))
+}
+
+func TestEvaluateObjectivesWithAnomaly(t *testing.T) {
+	sloConfig, err := ParseSLO([]byte(testAnomalySLO))
+	require.Nil(t, err)
+	policies, err := parseAnomalyPolicies([]byte(testAnomalySLO))
+	require.Nil(t, err)
+
+	getSLIFinished := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts"},
+		GetSLI: keptnv2.GetSLIFinished{
+			End: "2022-02-07T13:05:00.000Z",
+			IndicatorValues: []*keptnv2.SLIResult{
+				{Metric: "response_time_p95", Value: 400, Success: true},
+				{Metric: "throughput", Value: 120, Success: true},
+			},
+		},
+	}
+	ae := newAnomalyEvaluation(policies, map[string]*AnomalyModel{"throughput": newTestAnomalyModel()}, getSLIFinished.GetSLI.End)
+
+	evaluationResult, maximumAchievableScore, keySLIFailed := evaluateObjectives(getSLIFinished, sloConfig, nil, nil, nil, ae)
+	require.Equal(t, 2.0, maximumAchievableScore)
+	require.False(t, keySLIFailed)
+	throughput := evaluationResult.Evaluation.IndicatorResults[1]
+	require.Equal(t, "fail", throughput.Status)
+	require.Equal(t, 0.0, throughput.Score)
+	require.Len(t, throughput.PassTargets, 2)
+
+	finishedEventData := (*statisticalComparison)(nil).extend(evaluationResult)
+	ae.addTo(finishedEventData)
+	require.Nil(t, finishedEventData.Evaluation.IndicatorResults[0].Anomaly)
+	require.True(t, finishedEventData.Evaluation.IndicatorResults[1].Anomaly.Violated)
+
+	// a value within the band passes the objective
+	getSLIFinished.GetSLI.IndicatorValues = []*keptnv2.SLIResult{
+		{Metric: "response_time_p95", Value: 400, Success: true},
+		{Metric: "throughput", Value: 110, Success: true},
+	}
+	ae = newAnomalyEvaluation(policies, map[string]*AnomalyModel{"throughput": newTestAnomalyModel()}, getSLIFinished.GetSLI.End)
+	evaluationResult, _, _ = evaluateObjectives(getSLIFinished, sloConfig, nil, nil, nil, ae)
+	require.Equal(t, "pass", evaluationResult.Evaluation.IndicatorResults[1].Status)
+	require.Equal(t, 1.0, evaluationResult.Evaluation.IndicatorResults[1].Score)
+}
@@ -0,0 +1,288 @@
+package evaluation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// BurnRatePolicyAll violates the burn rate objective if the burn rates of all windows exceed their thresholds
+	BurnRatePolicyAll = "all"
+	// BurnRatePolicyAny violates the burn rate objective if the burn rate of any window exceeds its threshold
+	BurnRatePolicyAny = "any"
+	// BurnRatePolicyWeighted violates the burn rate objective if the weighted average of the burn rates, relative to
+	// their thresholds, exceeds 1
+	BurnRatePolicyWeighted = "weighted"
+)
+
+// BurnRatePolicy is configured by the burn_rate property of an objective in the SLO file. The SLI of the objective
+// has to be an error rate in percent, which is requested for every window ending at the end of the evaluation timeframe
+type BurnRatePolicy struct {
+	// Objective is the service level objective in percent, e.g. 99.9. It determines the error budget of the SLI
+	Objective float64           `yaml:"objective"`
+	Policy    string            `yaml:"policy"`
+	Windows   []*burnRateWindow `yaml:"windows"`
+}
+
+type burnRateWindow struct {
+	Window    string  `yaml:"window"`
+	Threshold float64 `yaml:"threshold"`
+	Weight    int     `yaml:"weight"`
+	duration  time.Duration
+}
+
+func parseBurnRatePolicies(sloFileContent []byte) (map[string]*BurnRatePolicy, error) {
+	slo := struct {
+		Objectives []struct {
+			SLI      string          `yaml:"sli"`
+			BurnRate *BurnRatePolicy `yaml:"burn_rate"`
+		} `yaml:"objectives"`
+	}{}
+	if err := yaml.Unmarshal(sloFileContent, &slo); err != nil {
+		return nil, fmt.Errorf("could not parse burn rates: %w", err)
+	}
+	policies := map[string]*BurnRatePolicy{}
+	for _, objective := range slo.Objectives {
+		if objective.BurnRate == nil {
+			continue
+		}
+		if err := objective.BurnRate.validate(); err != nil {
+			return nil, fmt.Errorf("invalid burn rate of SLI %s: %w", objective.SLI, err)
+		}
+		policies[objective.SLI] = objective.BurnRate
+	}
+	return policies, nil
+}
+
+func (p *BurnRatePolicy) validate() error {
+	if p.Objective <= 0 || p.Objective >= 100 {
+		return errors.New("objective must be a percentage greater than 0 and less than 100")
+	}
+	switch p.Policy {
+	case "":
+		p.Policy = BurnRatePolicyAll
+	case BurnRatePolicyAll, BurnRatePolicyAny, BurnRatePolicyWeighted:
+	default:
+		return fmt.Errorf("policy must be one of %s, %s or %s", BurnRatePolicyAll, BurnRatePolicyAny, BurnRatePolicyWeighted)
+	}
+	if len(p.Windows) == 0 {
+		return errors.New("at least one window is required")
+	}
+	for _, window := range p.Windows {
+		duration, err := parseWindowDuration(window.Window)
+		if err != nil {
+			return err
+		}
+		window.duration = duration
+		if window.Threshold <= 0 {
+			return fmt.Errorf("threshold of window %s must be greater than 0", window.Window)
+		}
+		if window.Weight < 0 {
+			return fmt.Errorf("weight of window %s must not be negative", window.Window)
+		}
+		if window.Weight == 0 {
+			window.Weight = 1
+		}
+	}
+	return nil
+}
+
+// parseWindowDuration parses windows like 5m, 1h or 30d
+func parseWindowDuration(window string) (time.Duration, error) {
+	var duration time.Duration
+	var err error
+	if days := strings.TrimSuffix(window, "d"); days != window {
+		var d int
+		d, err = strconv.Atoi(days)
+		duration = time.Duration(d) * 24 * time.Hour
+	} else {
+		duration, err = time.ParseDuration(window)
+	}
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid window '%s': must be a duration like 5m, 1h or 30d", window)
+	}
+	return duration, nil
+}
+
+// windowIndicator is the name of the indicator an SLI is requested with for a window
+func windowIndicator(sli, window string) string {
+	return sli + "[" + window + "]"
+}
+
+// SLIWindow is sent within the get-sli.triggered event. SLI providers supporting windows return the value of the
+// indicator <sli>[<window>] for the timeframe of the window
+type SLIWindow struct {
+	Window string `json:"window"`
+	Start  string `json:"start"`
+	End    string `json:"end"`
+}
+
+// GetSLIWindows returns the windows of all burn rate policies ending at the given end of the evaluation timeframe,
+// as well as the indicators to be requested for them
+func GetSLIWindows(policies map[string]*BurnRatePolicy, end string) ([]*SLIWindow, []string, error) {
+	if len(policies) == 0 {
+		return nil, nil, nil
+	}
+	endTime, err := timeutils.ParseTimestamp(end)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse end of evaluation timeframe: %w", err)
+	}
+
+	slis := make([]string, 0, len(policies))
+	for sli := range policies {
+		slis = append(slis, sli)
+	}
+	sort.Strings(slis)
+
+	windows := []*SLIWindow{}
+	indicators := []string{}
+	addedWindows := map[string]bool{}
+	for _, sli := range slis {
+		for _, window := range policies[sli].Windows {
+			indicators = append(indicators, windowIndicator(sli, window.Window))
+			if addedWindows[window.Window] {
+				continue
+			}
+			addedWindows[window.Window] = true
+			windows = append(windows, &SLIWindow{
+				Window: window.Window,
+				Start:  timeutils.GetKeptnTimeStamp(endTime.Add(-window.duration)),
+				End:    end,
+			})
+		}
+	}
+	return windows, indicators, nil
+}
+
+// BurnRate is the result of the evaluation of the burn rate policy of an SLI
+type BurnRate struct {
+	Objective float64 `json:"objective"`
+	Policy    string  `json:"policy"`
+	// WeightedBurnRate is the weighted average of the burn rates relative to their thresholds. It is only set for the weighted policy
+	WeightedBurnRate *float64                `json:"weightedBurnRate,omitempty"`
+	Violated         bool                    `json:"violated"`
+	Windows          []*BurnRateWindowResult `json:"windows"`
+}
+
+type BurnRateWindowResult struct {
+	Window    string  `json:"window"`
+	ErrorRate float64 `json:"errorRate"`
+	BurnRate  float64 `json:"burnRate"`
+	Threshold float64 `json:"threshold"`
+	Weight    int     `json:"weight"`
+	Violated  bool    `json:"violated"`
+	// Message explains why no burn rate could be calculated for the window. In this case, the burn rate objective is violated
+	Message string `json:"message,omitempty"`
+}
+
+// burnRateEvaluation contains the burn rate policies of an evaluation. The evaluated burn rates are collected per SLI
+type burnRateEvaluation struct {
+	policies map[string]*BurnRatePolicy
+	results  map[string]*BurnRate
+}
+
+func newBurnRateEvaluation(policies map[string]*BurnRatePolicy) *burnRateEvaluation {
+	return &burnRateEvaluation{
+		policies: policies,
+		results:  map[string]*BurnRate{},
+	}
+}
+
+// hasPolicy returns whether a burn rate policy is configured for the SLI
+func (be *burnRateEvaluation) hasPolicy(sli string) bool {
+	return be != nil && be.policies[sli] != nil
+}
+
+// evaluate calculates the burn rates of an SLI from the values of its window indicators, which are removed from the
+// given results. It returns nil if no burn rate policy is configured for the SLI
+func (be *burnRateEvaluation) evaluate(sli string, results *[]*keptnv2.SLIResult) *BurnRate {
+	if !be.hasPolicy(sli) {
+		return nil
+	}
+	policy := be.policies[sli]
+	errorBudget := 100 - policy.Objective
+	burnRate := &BurnRate{
+		Objective: policy.Objective,
+		Policy:    policy.Policy,
+		Windows:   []*BurnRateWindowResult{},
+	}
+
+	violatedWindows := 0
+	missingValues := false
+	weightedSum := 0.0
+	weights := 0
+	for _, window := range policy.Windows {
+		windowResult := &BurnRateWindowResult{Window: window.Window, Threshold: window.Threshold, Weight: window.Weight}
+		burnRate.Windows = append(burnRate.Windows, windowResult)
+
+		result := getSLIResult(results, windowIndicator(sli, window.Window))
+		if result == nil || !result.Success {
+			windowResult.Violated = true
+			windowResult.Message = "no value received from SLI provider"
+			if result != nil && result.Message != "" {
+				windowResult.Message = result.Message
+			}
+			missingValues = true
+			continue
+		}
+		windowResult.ErrorRate = result.Value
+		windowResult.BurnRate = roundStatistic(result.Value / errorBudget)
+		windowResult.Violated = windowResult.BurnRate > window.Threshold
+		if windowResult.Violated {
+			violatedWindows++
+		}
+		weightedSum += float64(window.Weight) * windowResult.BurnRate / window.Threshold
+		weights += window.Weight
+	}
+
+	switch {
+	case missingValues:
+		burnRate.Violated = true
+	case policy.Policy == BurnRatePolicyAny:
+		burnRate.Violated = violatedWindows > 0
+	case policy.Policy == BurnRatePolicyWeighted:
+		weightedBurnRate := 0.0
+		if weights > 0 {
+			weightedBurnRate = roundStatistic(weightedSum / float64(weights))
+		}
+		burnRate.WeightedBurnRate = &weightedBurnRate
+		burnRate.Violated = weightedBurnRate > 1
+	default:
+		burnRate.Violated = violatedWindows == len(policy.Windows)
+	}
+	be.results[sli] = burnRate
+	return burnRate
+}
+
+// targets returns the windows of the burn rate as targets of the SLI
+func (br *BurnRate) targets() []*keptnv2.SLITarget {
+	targets := []*keptnv2.SLITarget{}
+	for _, window := range br.Windows {
+		targets = append(targets, &keptnv2.SLITarget{
+			Criteria:    fmt.Sprintf("burn_rate[%s]<=%v", window.Window, window.Threshold),
+			TargetValue: window.Threshold,
+			Violated:    window.Violated,
+		})
+	}
+	return targets
+}
+
+// addTo adds the burn rates to the evaluation.finished event data
+func (be *burnRateEvaluation) addTo(data *EvaluationFinishedEventData) {
+	if be == nil {
+		return
+	}
+	for _, indicatorResult := range data.Evaluation.IndicatorResults {
+		if indicatorResult.Value != nil {
+			indicatorResult.BurnRate = be.results[indicatorResult.Value.Metric]
+		}
+	}
+}
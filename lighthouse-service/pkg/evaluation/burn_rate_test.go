@@ -0,0 +1,243 @@
+package evaluation
+
+import (
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+const testBurnRateSLO = `spec_version: "1.0"
+objectives:
+  - sli: "response_time_p95"
+    pass:
+      - criteria:
+          - "<=600"
+  - sli: "error_rate"
+    weight: 2
+    burn_rate:
+      objective: 99.9
+      windows:
+        - window: "5m"
+          threshold: 14.4
+        - window: "1h"
+          threshold: 14.4
+  - sli: "availability_errors"
+    burn_rate:
+      objective: 99
+      policy: weighted
+      windows:
+        - window: "1h"
+          threshold: 6
+          weight: 3
+        - window: "1d"
+          threshold: 3
+total_score:
+  pass: "90%"
+`
+
+func TestParseBurnRatePolicies(t *testing.T) {
+	policies, err := parseBurnRatePolicies([]byte(testBurnRateSLO))
+	require.Nil(t, err)
+	require.Len(t, policies, 2)
+	require.Equal(t, BurnRatePolicyAll, policies["error_rate"].Policy)
+	require.Equal(t, 1, policies["error_rate"].Windows[0].Weight)
+	require.Equal(t, BurnRatePolicyWeighted, policies["availability_errors"].Policy)
+	require.Equal(t, 3, policies["availability_errors"].Windows[0].Weight)
+
+	tests := []struct {
+		name     string
+		burnRate string
+		wantErr  string
+	}{
+		{
+			name:     "invalid objective",
+			burnRate: "objective: 100\n      windows:\n        - window: 5m\n          threshold: 1",
+			wantErr:  "invalid burn rate of SLI error_rate: objective must be a percentage greater than 0 and less than 100",
+		},
+		{
+			name:     "invalid policy",
+			burnRate: "objective: 99\n      policy: majority\n      windows:\n        - window: 5m\n          threshold: 1",
+			wantErr:  "invalid burn rate of SLI error_rate: policy must be one of all, any or weighted",
+		},
+		{
+			name:     "no windows",
+			burnRate: "objective: 99",
+			wantErr:  "invalid burn rate of SLI error_rate: at least one window is required",
+		},
+		{
+			name:     "invalid window",
+			burnRate: "objective: 99\n      windows:\n        - window: 5 minutes\n          threshold: 1",
+			wantErr:  "invalid burn rate of SLI error_rate: invalid window '5 minutes': must be a duration like 5m, 1h or 30d",
+		},
+		{
+			name:     "missing threshold",
+			burnRate: "objective: 99\n      windows:\n        - window: 30d",
+			wantErr:  "invalid burn rate of SLI error_rate: threshold of window 30d must be greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseBurnRatePolicies([]byte("objectives:\n  - sli: error_rate\n    burn_rate:\n      " + tt.burnRate + "\n"))
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGetSLIWindows(t *testing.T) {
+	policies, err := parseBurnRatePolicies([]byte(testBurnRateSLO))
+	require.Nil(t, err)
+
+	windows, indicators, err := GetSLIWindows(policies, "2022-01-02T00:00:00.000Z")
+	require.Nil(t, err)
+	require.Equal(t, []string{"availability_errors[1h]", "availability_errors[1d]", "error_rate[5m]", "error_rate[1h]"}, indicators)
+	require.Equal(t, []*SLIWindow{
+		{Window: "1h", Start: "2022-01-01T23:00:00.000Z", End: "2022-01-02T00:00:00.000Z"},
+		{Window: "1d", Start: "2022-01-01T00:00:00.000Z", End: "2022-01-02T00:00:00.000Z"},
+		{Window: "5m", Start: "2022-01-01T23:55:00.000Z", End: "2022-01-02T00:00:00.000Z"},
+	}, windows)
+
+	windows, indicators, err = GetSLIWindows(map[string]*BurnRatePolicy{}, "2022-01-02T00:00:00.000Z")
+	require.Nil(t, err)
+	require.Empty(t, windows)
+	require.Empty(t, indicators)
+}
+
+func TestBurnRateEvaluation_evaluate(t *testing.T) {
+	policies, err := parseBurnRatePolicies([]byte(testBurnRateSLO))
+	require.Nil(t, err)
+
+	tests := []struct {
+		name             string
+		sli              string
+		values           map[string]float64
+		wantViolated     bool
+		wantBurnRates    []float64
+		wantWeighted     *float64
+		wantMissingValue bool
+	}{
+		{
+			name:          "all windows exceed their thresholds",
+			sli:           "error_rate",
+			values:        map[string]float64{"error_rate[5m]": 2, "error_rate[1h]": 1.5},
+			wantViolated:  true,
+			wantBurnRates: []float64{20, 15},
+		},
+		{
+			name:          "only the short window exceeds its threshold",
+			sli:           "error_rate",
+			values:        map[string]float64{"error_rate[5m]": 2, "error_rate[1h]": 0.5},
+			wantViolated:  false,
+			wantBurnRates: []float64{20, 5},
+		},
+		{
+			name:          "weighted burn rates below their thresholds",
+			sli:           "availability_errors",
+			values:        map[string]float64{"availability_errors[1h]": 4.5, "availability_errors[1d]": 4.5},
+			wantViolated:  false,
+			wantBurnRates: []float64{4.5, 4.5},
+			wantWeighted:  floatp(0.9375),
+		},
+		{
+			name:          "weighted burn rates above their thresholds",
+			sli:           "availability_errors",
+			values:        map[string]float64{"availability_errors[1h]": 9, "availability_errors[1d]": 4.5},
+			wantViolated:  true,
+			wantBurnRates: []float64{9, 4.5},
+			wantWeighted:  floatp(1.5),
+		},
+		{
+			name:             "missing window value",
+			sli:              "error_rate",
+			values:           map[string]float64{"error_rate[5m]": 0},
+			wantViolated:     true,
+			wantBurnRates:    []float64{0, 0},
+			wantMissingValue: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := []*keptnv2.SLIResult{{Metric: tt.sli, Value: 1, Success: true}}
+			for metric, value := range tt.values {
+				results = append(results, &keptnv2.SLIResult{Metric: metric, Value: value, Success: true})
+			}
+			be := newBurnRateEvaluation(policies)
+
+			burnRate := be.evaluate(tt.sli, &results)
+			require.Equal(t, tt.wantViolated, burnRate.Violated)
+			for i, window := range burnRate.Windows {
+				require.InDelta(t, tt.wantBurnRates[i], window.BurnRate, 0.001)
+			}
+			if tt.wantWeighted != nil {
+				require.InDelta(t, *tt.wantWeighted, *burnRate.WeightedBurnRate, 0.001)
+			}
+			require.Equal(t, tt.wantMissingValue, burnRate.Windows[1].Message != "")
+			// the window indicators are removed from the results
+			require.Len(t, results, 1)
+			require.Equal(t, burnRate, be.results[tt.sli])
+		})
+	}
+
+	require.Nil(t, newBurnRateEvaluation(policies).evaluate("response_time_p95", &[]*keptnv2.SLIResult{}))
+}
+
+func TestEvaluateObjectivesWithBurnRate(t *testing.T) {
+	sloConfig, err := ParseSLO([]byte(testBurnRateSLO))
+	require.Nil(t, err)
+	policies, err := parseBurnRatePolicies([]byte(testBurnRateSLO))
+	require.Nil(t, err)
+	delete(policies, "availability_errors")
+	sloConfig.Objectives = sloConfig.Objectives[:2]
+
+	getSLIFinished := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts"},
+		GetSLI: keptnv2.GetSLIFinished{
+			IndicatorValues: []*keptnv2.SLIResult{
+				{Metric: "response_time_p95", Value: 400, Success: true},
+				{Metric: "error_rate", Value: 0.5, Success: true},
+				{Metric: "error_rate[5m]", Value: 2, Success: true},
+				{Metric: "error_rate[1h]", Value: 1.5, Success: true},
+			},
+		},
+	}
+	br := newBurnRateEvaluation(policies)
+
+	evaluationResult, maximumAchievableScore, keySLIFailed := evaluateObjectives(getSLIFinished, sloConfig, nil, nil, br, nil)
+	require.Equal(t, 3.0, maximumAchievableScore)
+	require.False(t, keySLIFailed)
+	// the window indicators are not reported as SLIs without objectives
+	require.Empty(t, evaluationResult.Message)
+	require.Len(t, evaluationResult.Evaluation.IndicatorResults, 2)
+
+	errorRate := evaluationResult.Evaluation.IndicatorResults[1]
+	require.Equal(t, "fail", errorRate.Status)
+	require.Equal(t, 0.0, errorRate.Score)
+	require.Equal(t, []*keptnv2.SLITarget{
+		{Criteria: "burn_rate[5m]<=14.4", TargetValue: 14.4, Violated: true},
+		{Criteria: "burn_rate[1h]<=14.4", TargetValue: 14.4, Violated: true},
+	}, errorRate.PassTargets)
+
+	err = calculateScore(maximumAchievableScore, evaluationResult, sloConfig, keySLIFailed)
+	require.Nil(t, err)
+	require.InDelta(t, 33.33, evaluationResult.Evaluation.Score, 0.01)
+
+	finishedEventData := (*statisticalComparison)(nil).extend(evaluationResult)
+	br.addTo(finishedEventData)
+	require.Nil(t, finishedEventData.Evaluation.IndicatorResults[0].BurnRate)
+	require.True(t, finishedEventData.Evaluation.IndicatorResults[1].BurnRate.Violated)
+
+	// the burn rate passes, if not all windows exceed their thresholds
+	getSLIFinished.GetSLI.IndicatorValues = []*keptnv2.SLIResult{
+		{Metric: "response_time_p95", Value: 400, Success: true},
+		{Metric: "error_rate", Value: 0.5, Success: true},
+		{Metric: "error_rate[5m]", Value: 2, Success: true},
+		{Metric: "error_rate[1h]", Value: 0.5, Success: true},
+	}
+	evaluationResult, _, _ = evaluateObjectives(getSLIFinished, sloConfig, nil, nil, newBurnRateEvaluation(policies), nil)
+	require.Equal(t, "pass", evaluationResult.Evaluation.IndicatorResults[1].Status)
+	require.Equal(t, 2.0, evaluationResult.Evaluation.IndicatorResults[1].Score)
+}
+
+func floatp(f float64) *float64 {
+	return &f
+}
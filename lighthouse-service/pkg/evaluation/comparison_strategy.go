@@ -0,0 +1,349 @@
+package evaluation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ComparisonStrategyThreshold compares the value of an SLI with the aggregated previous results only
+	ComparisonStrategyThreshold = "threshold"
+	// ComparisonStrategyZScore requires the value to deviate by more than the critical z-score from the mean of the previous results
+	ComparisonStrategyZScore = "zscore"
+	// ComparisonStrategyMAD requires the value to deviate by more than the critical robust z-score,
+	// which is based on the median absolute deviation, from the median of the previous results
+	ComparisonStrategyMAD = "mad"
+	// ComparisonStrategyMannWhitney compares the raw samples of an SLI with the raw samples of the previous results using a Mann-Whitney U test
+	ComparisonStrategyMannWhitney = "mann_whitney"
+
+	defaultConfidenceLevel = 0.95
+	// scales the median absolute deviation to be comparable with the standard deviation of normally distributed values
+	madScaleFactor = 1.4826
+)
+
+// comparisonStrategy determines how relative criteria, e.g. <=+10%, are evaluated. It is configured within the comparison
+// section of the SLO file, in addition to the comparison properties of the SLO spec
+type comparisonStrategy struct {
+	Strategy        string  `yaml:"strategy"`
+	ConfidenceLevel float64 `yaml:"confidence_level"`
+}
+
+func parseComparisonStrategy(sloFileContent []byte) (*comparisonStrategy, error) {
+	slo := struct {
+		Comparison comparisonStrategy `yaml:"comparison"`
+	}{}
+	if err := yaml.Unmarshal(sloFileContent, &slo); err != nil {
+		return nil, fmt.Errorf("could not parse comparison strategy: %w", err)
+	}
+	strategy := slo.Comparison
+	switch strategy.Strategy {
+	case "":
+		strategy.Strategy = ComparisonStrategyThreshold
+	case ComparisonStrategyThreshold, ComparisonStrategyZScore, ComparisonStrategyMAD, ComparisonStrategyMannWhitney:
+	default:
+		return nil, fmt.Errorf("invalid comparison strategy '%s': must be one of %s, %s, %s or %s", strategy.Strategy,
+			ComparisonStrategyThreshold, ComparisonStrategyZScore, ComparisonStrategyMAD, ComparisonStrategyMannWhitney)
+	}
+	if strategy.ConfidenceLevel == 0 {
+		strategy.ConfidenceLevel = defaultConfidenceLevel
+	}
+	if strategy.ConfidenceLevel <= 0.5 || strategy.ConfidenceLevel >= 1 {
+		return nil, fmt.Errorf("invalid confidence level %v: must be greater than 0.5 and less than 1", strategy.ConfidenceLevel)
+	}
+	return &strategy, nil
+}
+
+// ComparisonStatistic is the result of the statistical comparison of an SLI with its previous results
+type ComparisonStatistic struct {
+	Strategy        string  `json:"strategy"`
+	ConfidenceLevel float64 `json:"confidenceLevel"`
+	// Statistic is the z-score of the value, the robust z-score of the value, or the standardized U statistic of the samples.
+	// Positive values indicate an increase compared to the previous results
+	Statistic float64 `json:"statistic"`
+	// U is the Mann-Whitney U statistic of the samples of the SLI
+	U *float64 `json:"u,omitempty"`
+	// CriticalValue is the one-sided critical value of the statistic for the confidence level
+	CriticalValue float64 `json:"criticalValue"`
+	// PValue is the two-sided p-value of the statistic
+	PValue float64 `json:"pValue"`
+	// SampleSize is the number of previous values, or previous samples, the SLI has been compared with
+	SampleSize          int  `json:"sampleSize"`
+	SignificantIncrease bool `json:"significantIncrease"`
+	SignificantDecrease bool `json:"significantDecrease"`
+	// Message explains why no statistic could be calculated. In this case, relative criteria are evaluated as thresholds
+	Message string `json:"message,omitempty"`
+}
+
+// available returns whether the statistic has been calculated
+func (cs *ComparisonStatistic) available() bool {
+	return cs != nil && cs.Message == ""
+}
+
+// isSignificant returns whether the deviation of the value violating the operator of a relative criterion is statistically significant
+func (cs *ComparisonStatistic) isSignificant(operator string) bool {
+	switch operator {
+	case "<", "<=":
+		return cs.SignificantIncrease
+	case ">", ">=":
+		return cs.SignificantDecrease
+	default:
+		return cs.SignificantIncrease || cs.SignificantDecrease
+	}
+}
+
+// compare calculates the statistic of an SLI value, or its raw samples, compared to the previous values, or the raw samples of the previous results
+func (cs comparisonStrategy) compare(value float64, samples []float64, previousValues []float64, previousSamples []float64) *ComparisonStatistic {
+	statistic := &ComparisonStatistic{
+		Strategy:        cs.Strategy,
+		ConfidenceLevel: cs.ConfidenceLevel,
+		CriticalValue:   roundStatistic(math.Sqrt2 * math.Erfinv(2*cs.ConfidenceLevel-1)),
+		SampleSize:      len(previousValues),
+	}
+
+	var z float64
+	switch cs.Strategy {
+	case ComparisonStrategyZScore:
+		if len(previousValues) < 2 {
+			statistic.Message = "at least 2 previous results are required to calculate the z-score"
+			return statistic
+		}
+		mean := calculateAverage(previousValues)
+		stdDev := calculateStandardDeviation(previousValues, mean)
+		if stdDev == 0 {
+			statistic.Message = "the previous results do not vary"
+			return statistic
+		}
+		z = (value - mean) / stdDev
+	case ComparisonStrategyMAD:
+		if len(previousValues) < 3 {
+			statistic.Message = "at least 3 previous results are required to calculate the median absolute deviation"
+			return statistic
+		}
+		median := calculateMedian(previousValues)
+		deviations := make([]float64, len(previousValues))
+		for i, previousValue := range previousValues {
+			deviations[i] = math.Abs(previousValue - median)
+		}
+		mad := calculateMedian(deviations)
+		if mad == 0 {
+			statistic.Message = "the median absolute deviation of the previous results is 0"
+			return statistic
+		}
+		z = (value - median) / (madScaleFactor * mad)
+	case ComparisonStrategyMannWhitney:
+		statistic.SampleSize = len(previousSamples)
+		if len(samples) < 3 || len(previousSamples) < 3 {
+			statistic.Message = "at least 3 raw samples of the SLI and of the previous results are required for the Mann-Whitney U test"
+			return statistic
+		}
+		u, stdDev := mannWhitneyU(samples, previousSamples)
+		if stdDev == 0 {
+			statistic.Message = "the raw samples do not vary"
+			return statistic
+		}
+		z = (u - float64(len(samples)*len(previousSamples))/2) / stdDev
+		roundedU := roundStatistic(u)
+		statistic.U = &roundedU
+	default:
+		return nil
+	}
+
+	statistic.Statistic = roundStatistic(z)
+	statistic.PValue = roundStatistic(math.Erfc(math.Abs(z) / math.Sqrt2))
+	statistic.SignificantIncrease = z > statistic.CriticalValue
+	statistic.SignificantDecrease = z < -statistic.CriticalValue
+	return statistic
+}
+
+// mannWhitneyU returns the U statistic of the samples compared to the baseline samples, as well as its standard deviation corrected for ties
+func mannWhitneyU(samples []float64, baseline []float64) (float64, float64) {
+	type rankedValue struct {
+		value    float64
+		isSample bool
+	}
+	values := make([]rankedValue, 0, len(samples)+len(baseline))
+	for _, sample := range samples {
+		values = append(values, rankedValue{value: sample, isSample: true})
+	}
+	for _, sample := range baseline {
+		values = append(values, rankedValue{value: sample})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].value < values[j].value })
+
+	rankSum := 0.0
+	tieCorrection := 0.0
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j].value == values[i].value {
+			j++
+		}
+		// tied values get the average of their ranks
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if values[k].isSample {
+				rankSum += rank
+			}
+		}
+		ties := float64(j - i)
+		tieCorrection += ties*ties*ties - ties
+		i = j
+	}
+
+	n1 := float64(len(samples))
+	n2 := float64(len(baseline))
+	n := n1 + n2
+	u := rankSum - n1*(n1+1)/2
+	variance := n1 * n2 / 12 * ((n + 1) - tieCorrection/(n*(n-1)))
+	return u, math.Sqrt(math.Max(variance, 0))
+}
+
+func calculateStandardDeviation(values []float64, mean float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)-1))
+}
+
+func calculateMedian(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+func roundStatistic(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
+
+// statisticalComparison contains the comparison strategy of an evaluation, as well as the raw samples of its SLIs and of
+// the previous results. The calculated statistics are collected per SLI
+type statisticalComparison struct {
+	strategy        comparisonStrategy
+	samples         map[string][]float64
+	previousSamples map[string][]float64
+	statistics      map[string]*ComparisonStatistic
+}
+
+func newStatisticalComparison(strategy comparisonStrategy, samples map[string][]float64, previousSamples map[string][]float64) *statisticalComparison {
+	return &statisticalComparison{
+		strategy:        strategy,
+		samples:         samples,
+		previousSamples: previousSamples,
+		statistics:      map[string]*ComparisonStatistic{},
+	}
+}
+
+// compare calculates the statistic of an SLI, or returns nil if relative criteria are evaluated as thresholds
+func (sc *statisticalComparison) compare(result *keptnv2.SLIResult, previousResults []*keptnv2.SLIEvaluationResult) *ComparisonStatistic {
+	if sc == nil || sc.strategy.Strategy == ComparisonStrategyThreshold || !result.Success {
+		return nil
+	}
+	var previousValues []float64
+	for _, previousResult := range previousResults {
+		if previousResult.Value != nil && previousResult.Value.Success {
+			previousValues = append(previousValues, previousResult.Value.Value)
+		}
+	}
+	statistic := sc.strategy.compare(result.Value, sc.samples[result.Metric], previousValues, sc.previousSamples[result.Metric])
+	sc.statistics[result.Metric] = statistic
+	return statistic
+}
+
+// SLISamplesEventData contains the raw samples SLI providers can send along with the values of the SLIs,
+// in get-sli.finished events as well as in evaluation.finished events
+type SLISamplesEventData struct {
+	GetSLI struct {
+		IndicatorValues []struct {
+			Metric  string    `json:"metric"`
+			Samples []float64 `json:"samples"`
+		} `json:"indicatorValues"`
+	} `json:"get-sli"`
+	Evaluation struct {
+		IndicatorResults []struct {
+			Value struct {
+				Metric string `json:"metric"`
+			} `json:"value"`
+			Samples []float64 `json:"samples"`
+		} `json:"indicatorResults"`
+	} `json:"evaluation"`
+}
+
+// GetSLISamples returns the raw samples of the SLIs of a get-sli.finished event
+func (d SLISamplesEventData) GetSLISamples() map[string][]float64 {
+	samples := map[string][]float64{}
+	for _, indicatorValue := range d.GetSLI.IndicatorValues {
+		if len(indicatorValue.Samples) > 0 {
+			samples[indicatorValue.Metric] = indicatorValue.Samples
+		}
+	}
+	return samples
+}
+
+// AddEvaluationSamples adds the samples of a previous evaluation to the given samples
+func (d SLISamplesEventData) AddEvaluationSamples(samples map[string][]float64) {
+	for _, indicatorResult := range d.Evaluation.IndicatorResults {
+		if len(indicatorResult.Samples) > 0 {
+			samples[indicatorResult.Value.Metric] = append(samples[indicatorResult.Value.Metric], indicatorResult.Samples...)
+		}
+	}
+}
+
+// EvaluationFinishedEventData extends the evaluation.finished event data with the raw samples, the comparison statistics
+// and the burn rates of the SLIs, as well as the fingerprint and the explanation of the evaluation
+type EvaluationFinishedEventData struct {
+	keptnv2.EventData
+	Evaluation EvaluationDetails `json:"evaluation,omitempty"`
+}
+
+type EvaluationDetails struct {
+	keptnv2.EvaluationDetails
+	IndicatorResults []*SLIEvaluationResult `json:"indicatorResults"`
+	// Fingerprint identifies the inputs of the evaluation, see evaluationFingerprint
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// ReusedFrom is the ID of the evaluation.finished event the result has been taken from, if the inputs have been evaluated before
+	ReusedFrom string `json:"reusedFrom,omitempty"`
+	// ReevaluatedFrom is the ID of the evaluation.finished event whose SLI values have been evaluated against the SLO file
+	ReevaluatedFrom string `json:"reevaluatedFrom,omitempty"`
+	// Services contains the results of the objectives of each service of a composite evaluation
+	Services []*ServiceEvaluation `json:"services,omitempty"`
+	// Explanation describes how the score and the result have been determined
+	Explanation *Explanation `json:"explanation,omitempty"`
+}
+
+type SLIEvaluationResult struct {
+	*keptnv2.SLIEvaluationResult
+	// Samples are the raw samples of the SLI, which are used by the Mann-Whitney U test of subsequent evaluations
+	Samples             []float64            `json:"samples,omitempty"`
+	ComparisonStatistic *ComparisonStatistic `json:"comparisonStatistic,omitempty"`
+	BurnRate            *BurnRate            `json:"burnRate,omitempty"`
+	Anomaly             *Anomaly             `json:"anomaly,omitempty"`
+}
+
+// extend adds the raw samples and the comparison statistics to the evaluation.finished event data
+func (sc *statisticalComparison) extend(data *keptnv2.EvaluationFinishedEventData) *EvaluationFinishedEventData {
+	extended := &EvaluationFinishedEventData{
+		EventData:  data.EventData,
+		Evaluation: EvaluationDetails{EvaluationDetails: data.Evaluation},
+	}
+	if data.Evaluation.IndicatorResults == nil {
+		return extended
+	}
+	extended.Evaluation.IndicatorResults = []*SLIEvaluationResult{}
+	for _, indicatorResult := range data.Evaluation.IndicatorResults {
+		result := &SLIEvaluationResult{SLIEvaluationResult: indicatorResult}
+		if sc != nil && indicatorResult.Value != nil {
+			result.Samples = sc.samples[indicatorResult.Value.Metric]
+			result.ComparisonStatistic = sc.statistics[indicatorResult.Value.Metric]
+		}
+		extended.Evaluation.IndicatorResults = append(extended.Evaluation.IndicatorResults, result)
+	}
+	return extended
+}
@@ -0,0 +1,263 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"testing"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseComparisonStrategy(t *testing.T) {
+	tests := []struct {
+		name           string
+		sloFileContent string
+		want           *comparisonStrategy
+		wantErr        string
+	}{
+		{
+			name:           "defaults",
+			sloFileContent: "spec_version: \"0.1.0\"\ncomparison:\n  compare_with: \"single_result\"\n",
+			want:           &comparisonStrategy{Strategy: ComparisonStrategyThreshold, ConfidenceLevel: 0.95},
+		},
+		{
+			name:           "mad with confidence level",
+			sloFileContent: "comparison:\n  strategy: mad\n  confidence_level: 0.99\n",
+			want:           &comparisonStrategy{Strategy: ComparisonStrategyMAD, ConfidenceLevel: 0.99},
+		},
+		{
+			name:           "unknown strategy",
+			sloFileContent: "comparison:\n  strategy: t_test\n",
+			wantErr:        "invalid comparison strategy 't_test': must be one of threshold, zscore, mad or mann_whitney",
+		},
+		{
+			name:           "invalid confidence level",
+			sloFileContent: "comparison:\n  strategy: zscore\n  confidence_level: 95\n",
+			wantErr:        "invalid confidence level 95: must be greater than 0.5 and less than 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseComparisonStrategy([]byte(tt.sloFileContent))
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestComparisonStrategy_compare(t *testing.T) {
+	tests := []struct {
+		name            string
+		strategy        string
+		value           float64
+		samples         []float64
+		previousValues  []float64
+		previousSamples []float64
+		wantStatistic   float64
+		wantIncrease    bool
+		wantDecrease    bool
+		wantMessage     string
+		wantSampleSize  int
+	}{
+		{
+			name:           "z-score within band",
+			strategy:       ComparisonStrategyZScore,
+			value:          12,
+			previousValues: []float64{10, 12, 11, 13, 9},
+			wantStatistic:  0.6325,
+			wantSampleSize: 5,
+		},
+		{
+			name:           "z-score significant increase",
+			strategy:       ComparisonStrategyZScore,
+			value:          15,
+			previousValues: []float64{10, 12, 11, 13, 9},
+			wantStatistic:  2.5298,
+			wantIncrease:   true,
+			wantSampleSize: 5,
+		},
+		{
+			name:           "z-score significant decrease",
+			strategy:       ComparisonStrategyZScore,
+			value:          7,
+			previousValues: []float64{10, 12, 11, 13, 9},
+			wantStatistic:  -2.5298,
+			wantDecrease:   true,
+			wantSampleSize: 5,
+		},
+		{
+			name:           "z-score without variance",
+			strategy:       ComparisonStrategyZScore,
+			value:          15,
+			previousValues: []float64{10, 10},
+			wantMessage:    "the previous results do not vary",
+			wantSampleSize: 2,
+		},
+		{
+			name:           "mad is robust against outliers",
+			strategy:       ComparisonStrategyMAD,
+			value:          14,
+			previousValues: []float64{10, 11, 12, 13, 100},
+			wantStatistic:  1.349,
+			wantSampleSize: 5,
+		},
+		{
+			name:           "mad significant increase",
+			strategy:       ComparisonStrategyMAD,
+			value:          20,
+			previousValues: []float64{10, 11, 12, 13, 100},
+			wantStatistic:  5.3959,
+			wantIncrease:   true,
+			wantSampleSize: 5,
+		},
+		{
+			name:           "mad with too few previous results",
+			strategy:       ComparisonStrategyMAD,
+			value:          20,
+			previousValues: []float64{10, 11},
+			wantMessage:    "at least 3 previous results are required to calculate the median absolute deviation",
+			wantSampleSize: 2,
+		},
+		{
+			name:            "mann-whitney significant increase",
+			strategy:        ComparisonStrategyMannWhitney,
+			samples:         []float64{5, 6, 7},
+			previousSamples: []float64{1, 2, 3},
+			wantStatistic:   1.964,
+			wantIncrease:    true,
+			wantSampleSize:  3,
+		},
+		{
+			name:            "mann-whitney with ties",
+			strategy:        ComparisonStrategyMannWhitney,
+			samples:         []float64{2, 3, 3, 4},
+			previousSamples: []float64{1, 2, 3, 3},
+			wantStatistic:   1.0838,
+			wantSampleSize:  4,
+		},
+		{
+			name:            "mann-whitney without samples",
+			strategy:        ComparisonStrategyMannWhitney,
+			previousSamples: []float64{1, 2, 3},
+			wantMessage:     "at least 3 raw samples of the SLI and of the previous results are required for the Mann-Whitney U test",
+			wantSampleSize:  3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := comparisonStrategy{Strategy: tt.strategy, ConfidenceLevel: 0.95}
+			got := strategy.compare(tt.value, tt.samples, tt.previousValues, tt.previousSamples)
+			require.Equal(t, tt.wantMessage, got.Message)
+			require.Equal(t, tt.wantSampleSize, got.SampleSize)
+			require.Equal(t, 1.6449, got.CriticalValue)
+			if tt.wantMessage != "" {
+				require.False(t, got.available())
+				return
+			}
+			require.InDelta(t, tt.wantStatistic, got.Statistic, 0.001)
+			require.Equal(t, tt.wantIncrease, got.SignificantIncrease)
+			require.Equal(t, tt.wantDecrease, got.SignificantDecrease)
+			require.Equal(t, tt.strategy == ComparisonStrategyMannWhitney, got.U != nil)
+		})
+	}
+}
+
+func TestEvaluateComparisonWithStatistic(t *testing.T) {
+	previousResults := []*keptnv2.SLIEvaluationResult{
+		{Value: &keptnv2.SLIResult{Metric: "response_time_p95", Value: 200, Success: true}},
+		{Value: &keptnv2.SLIResult{Metric: "response_time_p95", Value: 220, Success: true}},
+		{Value: &keptnv2.SLIResult{Metric: "response_time_p95", Value: 180, Success: true}},
+	}
+	comparison := &keptn.SLOComparison{CompareWith: "several_results", NumberOfComparisonResults: 3, AggregateFunction: "avg"}
+	sc := newStatisticalComparison(comparisonStrategy{Strategy: ComparisonStrategyZScore, ConfidenceLevel: 0.95}, nil, nil)
+
+	// 225 violates <=+10%, but is within the z-score band of the previous results
+	sliResult := &keptnv2.SLIResult{Metric: "response_time_p95", Value: 225, Success: true}
+	statistic := sc.compare(sliResult, previousResults)
+	target := &keptnv2.SLITarget{}
+	satisfied, err := evaluateComparison(sliResult, &criteriaObject{Operator: "<=", Value: 10, CheckPercentage: true, IsComparison: true, CheckIncrease: true}, previousResults, comparison, statistic, target)
+	require.Nil(t, err)
+	require.True(t, satisfied)
+	require.Equal(t, 220.0, target.TargetValue)
+
+	sliResult = &keptnv2.SLIResult{Metric: "response_time_p95", Value: 250, Success: true}
+	statistic = sc.compare(sliResult, previousResults)
+	satisfied, err = evaluateComparison(sliResult, &criteriaObject{Operator: "<=", Value: 10, CheckPercentage: true, IsComparison: true, CheckIncrease: true}, previousResults, comparison, statistic, target)
+	require.Nil(t, err)
+	require.False(t, satisfied)
+
+	// a significant decrease does not violate a criteria limiting the increase
+	sliResult = &keptnv2.SLIResult{Metric: "response_time_p95", Value: 100, Success: true}
+	statistic = sc.compare(sliResult, previousResults)
+	satisfied, err = evaluateComparison(sliResult, &criteriaObject{Operator: ">=", Value: 10, CheckPercentage: true, IsComparison: true, CheckIncrease: false}, previousResults, comparison, statistic, target)
+	require.Nil(t, err)
+	require.False(t, satisfied)
+	satisfied, err = evaluateComparison(sliResult, &criteriaObject{Operator: "<=", Value: 10, CheckPercentage: true, IsComparison: true, CheckIncrease: true}, previousResults, comparison, statistic, target)
+	require.Nil(t, err)
+	require.True(t, satisfied)
+}
+
+func TestStatisticalComparison_extend(t *testing.T) {
+	sc := newStatisticalComparison(
+		comparisonStrategy{Strategy: ComparisonStrategyMannWhitney, ConfidenceLevel: 0.95},
+		map[string][]float64{"response_time_p95": {5, 6, 7}},
+		map[string][]float64{"response_time_p95": {1, 2, 3}},
+	)
+	sliResult := &keptnv2.SLIResult{Metric: "response_time_p95", Value: 6, Success: true}
+	sc.compare(sliResult, nil)
+
+	extended := sc.extend(&keptnv2.EvaluationFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts"},
+		Evaluation: keptnv2.EvaluationDetails{
+			Score:            100,
+			IndicatorResults: []*keptnv2.SLIEvaluationResult{{Score: 1, Value: sliResult, Status: "pass"}},
+		},
+	})
+
+	marshalled, err := json.Marshal(extended)
+	require.Nil(t, err)
+
+	// the extended event data is still a valid evaluation.finished event
+	finished := &keptnv2.EvaluationFinishedEventData{}
+	require.Nil(t, json.Unmarshal(marshalled, finished))
+	require.Equal(t, "carts", finished.Service)
+	require.Equal(t, 100.0, finished.Evaluation.Score)
+	require.Len(t, finished.Evaluation.IndicatorResults, 1)
+	require.Equal(t, 6.0, finished.Evaluation.IndicatorResults[0].Value.Value)
+
+	samples := SLISamplesEventData{}
+	require.Nil(t, json.Unmarshal(marshalled, &samples))
+	previousSamples := map[string][]float64{}
+	samples.AddEvaluationSamples(previousSamples)
+	require.Equal(t, map[string][]float64{"response_time_p95": {5, 6, 7}}, previousSamples)
+
+	statistic := struct {
+		Evaluation struct {
+			IndicatorResults []struct {
+				ComparisonStatistic *ComparisonStatistic `json:"comparisonStatistic"`
+			} `json:"indicatorResults"`
+		} `json:"evaluation"`
+	}{}
+	require.Nil(t, json.Unmarshal(marshalled, &statistic))
+	require.Equal(t, ComparisonStrategyMannWhitney, statistic.Evaluation.IndicatorResults[0].ComparisonStatistic.Strategy)
+	require.True(t, statistic.Evaluation.IndicatorResults[0].ComparisonStatistic.SignificantIncrease)
+}
+
+func TestSLISamplesEventData_getSLISamples(t *testing.T) {
+	samples := SLISamplesEventData{}
+	require.Nil(t, json.Unmarshal([]byte(`{
+  "project": "sockshop",
+  "get-sli": {
+    "indicatorValues": [
+      {"metric": "response_time_p95", "value": 6, "success": true, "samples": [5, 6, 7]},
+      {"metric": "throughput", "value": 100, "success": true}
+    ]
+  }
+}`), &samples))
+	require.Equal(t, map[string][]float64{"response_time_p95": {5, 6, 7}}, samples.GetSLISamples())
+}
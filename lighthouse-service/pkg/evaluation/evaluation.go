@@ -0,0 +1,159 @@
+// Package evaluation contains the scoring of lighthouse, i.e. the evaluation of SLI values against the objectives of an
+// SLO file. It is used by lighthouse-service as well as by the Keptn CLI to evaluate SLI values locally
+package evaluation
+
+import (
+	"fmt"
+	"strings"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// SLO is a parsed SLO file, including the comparison strategy, the burn rates and the anomaly detection of its objectives
+type SLO struct {
+	*keptn.ServiceLevelObjectives
+	// Content is the content of the SLO file
+	Content          []byte
+	strategy         *comparisonStrategy
+	BurnRatePolicies map[string]*BurnRatePolicy
+	AnomalyPolicies  map[string]*AnomalyPolicy
+}
+
+// ParseSLOFile parses the content of an SLO file
+func ParseSLOFile(content []byte) (*SLO, error) {
+	sloConfig, err := ParseSLO(content)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse SLO file: %w", err)
+	}
+	strategy, err := parseComparisonStrategy(content)
+	if err != nil {
+		return nil, err
+	}
+	burnRatePolicies, err := parseBurnRatePolicies(content)
+	if err != nil {
+		return nil, err
+	}
+	anomalyPolicies, err := parseAnomalyPolicies(content)
+	if err != nil {
+		return nil, err
+	}
+	return &SLO{
+		ServiceLevelObjectives: sloConfig,
+		Content:                content,
+		strategy:               strategy,
+		BurnRatePolicies:       burnRatePolicies,
+		AnomalyPolicies:        anomalyPolicies,
+	}, nil
+}
+
+// NumberOfPreviousResults returns the number of previous evaluations the SLO file compares an evaluation with
+func (s *SLO) NumberOfPreviousResults() int {
+	return getNumberOfPreviousResults(s.ServiceLevelObjectives)
+}
+
+// IncludesPreviousResult returns whether previous evaluations with the given result are compared with, according to
+// the include_result_with_score property of the comparison
+func (s *SLO) IncludesPreviousResult(result keptnv2.ResultType) bool {
+	switch strings.ToLower(s.Comparison.IncludeResultWithScore) {
+	case "pass":
+		return result == keptnv2.ResultPass
+	case "pass_or_warn":
+		return result == keptnv2.ResultPass || result == keptnv2.ResultWarning
+	default:
+		return true
+	}
+}
+
+// Input contains the SLI values of an evaluation, as well as the previous evaluations they are compared with
+type Input struct {
+	GetSLI *keptnv2.GetSLIFinishedEventData
+	// Samples are the raw samples of the SLIs, if provided by the SLI provider
+	Samples map[string][]float64
+	// PreviousEvaluations are the evaluations relative criteria are compared with, the latest one first
+	PreviousEvaluations []*keptnv2.EvaluationFinishedEventData
+	// ComparedEvents are the IDs of the previous evaluations
+	ComparedEvents  []string
+	PreviousSamples map[string][]float64
+	// AnomalyModels are the baselines of the SLIs with anomaly detection
+	AnomalyModels map[string]*AnomalyModel
+	// SourceEvents contains the ID of the event the value of each SLI has been taken from
+	SourceEvents map[string]string
+}
+
+// Evaluate evaluates the SLI values against the objectives of the SLO file and calculates the total score. The SLI
+// values of the input are consumed
+func (s *SLO) Evaluate(input Input) (*EvaluationFinishedEventData, error) {
+	statisticalComparison := newStatisticalComparison(*s.strategy, input.Samples, input.PreviousSamples)
+	burnRateEvaluation := newBurnRateEvaluation(s.BurnRatePolicies)
+	anomalyEvaluation := newAnomalyEvaluation(s.AnomalyPolicies, input.AnomalyModels, input.GetSLI.GetSLI.End)
+
+	evaluationResult, maximumAchievableScore, keySLIFailed := evaluateObjectives(input.GetSLI, s.ServiceLevelObjectives, input.PreviousEvaluations, statisticalComparison, burnRateEvaluation, anomalyEvaluation)
+	evaluationResult.Labels = input.GetSLI.Labels
+	evaluationResult.Evaluation.ComparedEvents = input.ComparedEvents
+
+	if err := calculateScore(maximumAchievableScore, evaluationResult, s.ServiceLevelObjectives, keySLIFailed); err != nil {
+		return nil, err
+	}
+
+	data := statisticalComparison.extend(evaluationResult)
+	burnRateEvaluation.addTo(data)
+	anomalyEvaluation.addTo(data)
+	data.Evaluation.Explanation = explainEvaluation(data, s.ServiceLevelObjectives, maximumAchievableScore, input.SourceEvents)
+	return data, nil
+}
+
+// GetSLIFinishedEventData restores the SLI values, the window indicators of burn rates and the raw samples an
+// evaluation has been conducted with
+func (data *EvaluationFinishedEventData) GetSLIFinishedEventData() (*keptnv2.GetSLIFinishedEventData, map[string][]float64) {
+	e := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{
+			Project: data.Project,
+			Stage:   data.Stage,
+			Service: data.Service,
+			Status:  keptnv2.StatusSucceeded,
+			Result:  keptnv2.ResultPass,
+		},
+		GetSLI: keptnv2.GetSLIFinished{
+			Start:           data.Evaluation.TimeStart,
+			End:             data.Evaluation.TimeEnd,
+			IndicatorValues: []*keptnv2.SLIResult{},
+		},
+	}
+	samples := map[string][]float64{}
+	for _, indicatorResult := range data.Evaluation.IndicatorResults {
+		if indicatorResult == nil || indicatorResult.SLIEvaluationResult == nil || indicatorResult.Value == nil {
+			continue
+		}
+		value := *indicatorResult.Value
+		e.GetSLI.IndicatorValues = append(e.GetSLI.IndicatorValues, &value)
+		if len(indicatorResult.Samples) > 0 {
+			samples[value.Metric] = indicatorResult.Samples
+		}
+		if indicatorResult.BurnRate == nil {
+			continue
+		}
+		for _, window := range indicatorResult.BurnRate.Windows {
+			if window.Message != "" {
+				// the SLI provider did not return a value for this window
+				continue
+			}
+			e.GetSLI.IndicatorValues = append(e.GetSLI.IndicatorValues, &keptnv2.SLIResult{
+				Metric:  windowIndicator(value.Metric, window.Window),
+				Value:   window.ErrorRate,
+				Success: true,
+			})
+		}
+	}
+	return e, samples
+}
+
+// ServiceEvaluation is the result of the objectives of a service within a composite evaluation
+type ServiceEvaluation struct {
+	Service string `json:"service"`
+	// Score is the percentage of the weights of the objectives of the service that have been achieved
+	Score  float64 `json:"score"`
+	Result string  `json:"result"`
+	// Indicators are the SLIs of the service as referenced by the SLO file
+	Indicators []string `json:"indicators"`
+}
@@ -0,0 +1,54 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluationFinishedEventData_GetSLIFinishedEventData(t *testing.T) {
+	data := &EvaluationFinishedEventData{}
+	err := json.Unmarshal([]byte(`{
+  "project": "sockshop",
+  "stage": "staging",
+  "service": "carts",
+  "labels": {"buildnr": "17"},
+  "evaluation": {
+    "timeStart": "2022-01-01T10:00:00.000Z",
+    "timeEnd": "2022-01-01T10:05:00.000Z",
+    "indicatorResults": [
+      {"value": {"metric": "response_time_p95", "value": 500, "success": true}, "samples": [480, 500, 520]},
+      {"value": {"metric": "error_rate", "value": 0.5, "success": true}, "burnRate": {"windows": [
+        {"window": "5m", "errorRate": 2},
+        {"window": "1h", "message": "no value received from SLI provider"}
+      ]}}
+    ]
+  }
+}`), data)
+	require.Nil(t, err)
+
+	e, samples := data.GetSLIFinishedEventData()
+	require.Equal(t, keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts", Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultPass}, e.EventData)
+	require.Equal(t, "2022-01-01T10:00:00.000Z", e.GetSLI.Start)
+	require.Equal(t, "2022-01-01T10:05:00.000Z", e.GetSLI.End)
+	require.Equal(t, []*keptnv2.SLIResult{
+		{Metric: "response_time_p95", Value: 500, Success: true},
+		{Metric: "error_rate", Value: 0.5, Success: true},
+		{Metric: "error_rate[5m]", Value: 2, Success: true},
+	}, e.GetSLI.IndicatorValues)
+	require.Equal(t, map[string][]float64{"response_time_p95": {480, 500, 520}}, samples)
+}
+
+func TestSLO_IncludesPreviousResult(t *testing.T) {
+	slo, err := ParseSLOFile([]byte("comparison:\n  include_result_with_score: pass_or_warn\nobjectives: []\n"))
+	require.Nil(t, err)
+	require.True(t, slo.IncludesPreviousResult(keptnv2.ResultPass))
+	require.True(t, slo.IncludesPreviousResult(keptnv2.ResultWarning))
+	require.False(t, slo.IncludesPreviousResult(keptnv2.ResultFailed))
+
+	slo, err = ParseSLOFile([]byte("objectives: []\n"))
+	require.Nil(t, err)
+	require.True(t, slo.IncludesPreviousResult(keptnv2.ResultFailed))
+}
@@ -0,0 +1,280 @@
+package evaluation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// Explanation describes how the score and the result of an evaluation have been determined
+type Explanation struct {
+	// Summary explains the total score and the result in one sentence
+	Summary string  `json:"summary"`
+	Score   float64 `json:"score"`
+	// AchievedWeight is the sum of the scores of the SLIs, MaximumWeight the sum of the weights of the SLIs considered
+	// for the total score
+	AchievedWeight float64 `json:"achievedWeight"`
+	MaximumWeight  float64 `json:"maximumWeight"`
+	PassTarget     string  `json:"passTarget,omitempty"`
+	WarningTarget  string  `json:"warningTarget,omitempty"`
+	Result         string  `json:"result"`
+	// FailedKeySLIs are the key SLIs that failed the evaluation regardless of its score
+	FailedKeySLIs []string                `json:"failedKeySLIs,omitempty"`
+	Indicators    []*IndicatorExplanation `json:"indicators"`
+}
+
+// IndicatorExplanation describes how the status and the score of an SLI have been determined
+type IndicatorExplanation struct {
+	SLI         string  `json:"sli"`
+	DisplayName string  `json:"displayName,omitempty"`
+	Status      string  `json:"status"`
+	Value       float64 `json:"value"`
+	// Success is false if no valid value has been retrieved for the SLI
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	// SourceEvent is the ID of the get-sli.finished event, or of the re-evaluated evaluation.finished event, the value
+	// has been taken from
+	SourceEvent string `json:"sourceEvent,omitempty"`
+	// ComparedValue is the aggregated value of the previous evaluations relative criteria have been evaluated against
+	ComparedValue *float64 `json:"comparedValue,omitempty"`
+	// ComparedEvents are the IDs of the previous evaluations, if the SLI has relative criteria
+	ComparedEvents []string `json:"comparedEvents,omitempty"`
+	KeySLI         bool     `json:"keySLI"`
+	Weight         int      `json:"weight"`
+	Score          float64  `json:"score"`
+	// Contribution is the share of the total score contributed by the SLI, in percentage points
+	Contribution float64 `json:"contribution"`
+	// MaximumContribution is the share of the total score the SLI contributes if it passes, in percentage points
+	MaximumContribution float64                   `json:"maximumContribution"`
+	Pass                []*CriteriaSetExplanation `json:"pass,omitempty"`
+	Warning             []*CriteriaSetExplanation `json:"warning,omitempty"`
+	// Reasons explain the status and the score of the SLI in plain text
+	Reasons []string `json:"reasons"`
+}
+
+// CriteriaSetExplanation is the result of a set of criteria, which is satisfied if all of its criteria are satisfied
+type CriteriaSetExplanation struct {
+	Criteria  []*keptnv2.SLITarget `json:"criteria"`
+	Satisfied bool                 `json:"satisfied"`
+}
+
+// explainEvaluation builds the explanation of the evaluation. sourceEvents contains the event the value of each SLI
+// has been taken from
+func explainEvaluation(data *EvaluationFinishedEventData, sloConfig *keptn.ServiceLevelObjectives, maximumAchievableScore float64, sourceEvents map[string]string) *Explanation {
+	explanation := &Explanation{
+		Score:         data.Evaluation.Score,
+		MaximumWeight: maximumAchievableScore,
+		Result:        data.Evaluation.Result,
+		Indicators:    []*IndicatorExplanation{},
+	}
+	if sloConfig.TotalScore != nil {
+		explanation.PassTarget = sloConfig.TotalScore.Pass
+		explanation.WarningTarget = sloConfig.TotalScore.Warning
+	}
+
+	for _, objective := range sloConfig.Objectives {
+		indicatorResult := getIndicatorResult(data.Evaluation.IndicatorResults, objective.SLI)
+		indicator := explainIndicator(objective, indicatorResult, sloConfig.Comparison, data.Evaluation.ComparedEvents, maximumAchievableScore)
+		indicator.SourceEvent = sourceEvents[objective.SLI]
+		explanation.AchievedWeight += indicator.Score
+		// SLIs without a value do not fail the evaluation as key SLIs
+		if indicatorResult != nil && indicator.KeySLI && indicator.Status == "fail" {
+			explanation.FailedKeySLIs = append(explanation.FailedKeySLIs, objective.SLI)
+		}
+		explanation.Indicators = append(explanation.Indicators, indicator)
+	}
+
+	explanation.Summary = explanation.summarize()
+	return explanation
+}
+
+func getIndicatorResult(indicatorResults []*SLIEvaluationResult, sli string) *SLIEvaluationResult {
+	for _, indicatorResult := range indicatorResults {
+		if indicatorResult.Value != nil && indicatorResult.Value.Metric == sli {
+			return indicatorResult
+		}
+	}
+	return nil
+}
+
+func explainIndicator(objective *keptn.SLO, indicatorResult *SLIEvaluationResult, comparison *keptn.SLOComparison, comparedEvents []string, maximumAchievableScore float64) *IndicatorExplanation {
+	indicator := &IndicatorExplanation{
+		SLI:         objective.SLI,
+		DisplayName: objective.DisplayName,
+		KeySLI:      objective.KeySLI,
+		Weight:      objective.Weight,
+	}
+	if indicatorResult == nil {
+		// SLIs without a value are not part of the results, but are considered for the total score
+		indicator.Status = "fail"
+		indicator.Message = "no value received from SLI provider"
+		indicator.Reasons = []string{"no value has been received from the SLI provider"}
+		indicator.Pass = explainCriteriaSets(objective.Pass, nil)
+		indicator.Warning = explainCriteriaSets(objective.Warning, nil)
+		if len(objective.Pass) > 0 {
+			indicator.MaximumContribution = contribution(float64(objective.Weight), maximumAchievableScore)
+			indicator.Reasons = append(indicator.Reasons, fmt.Sprintf("scored 0 of weight %d", objective.Weight))
+		}
+		return indicator
+	}
+
+	indicator.Status = indicatorResult.Status
+	indicator.Value = indicatorResult.Value.Value
+	indicator.Success = indicatorResult.Value.Success
+	indicator.Message = indicatorResult.Value.Message
+	indicator.Score = indicatorResult.Score
+	indicator.Pass = explainCriteriaSets(objective.Pass, indicatorResult.PassTargets)
+	indicator.Warning = explainCriteriaSets(objective.Warning, indicatorResult.WarningTargets)
+	if hasRelativeCriteria(objective) {
+		comparedValue := indicatorResult.Value.ComparedValue
+		indicator.ComparedValue = &comparedValue
+		indicator.ComparedEvents = comparedEvents
+	}
+
+	value := strconv.FormatFloat(indicator.Value, 'f', -1, 64)
+	if !indicator.Success {
+		indicator.Reasons = append(indicator.Reasons, fmt.Sprintf("the SLI provider returned no valid value: %s", indicator.Message))
+	}
+	if len(indicator.Pass) > 0 {
+		indicator.Reasons = append(indicator.Reasons, explainCriteriaResult("pass", value, indicator.Pass))
+	}
+	if len(indicator.Warning) > 0 && indicator.Status != "pass" {
+		indicator.Reasons = append(indicator.Reasons, explainCriteriaResult("warning", value, indicator.Warning))
+	}
+	if indicator.ComparedValue != nil {
+		if len(comparedEvents) == 0 {
+			indicator.Reasons = append(indicator.Reasons, "relative criteria are satisfied, since there are no previous evaluations to compare with")
+		} else {
+			indicator.Reasons = append(indicator.Reasons, fmt.Sprintf("relative criteria have been compared with the %s value %s of %d previous evaluations",
+				comparison.AggregateFunction, strconv.FormatFloat(*indicator.ComparedValue, 'f', -1, 64), len(comparedEvents)))
+		}
+	}
+	if statistic := indicatorResult.ComparisonStatistic; statistic.available() {
+		indicator.Reasons = append(indicator.Reasons, fmt.Sprintf("violations of relative criteria only count if the %s statistic %v exceeds the critical value %v",
+			statistic.Strategy, statistic.Statistic, statistic.CriticalValue))
+	}
+	if burnRate := indicatorResult.BurnRate; burnRate != nil {
+		if burnRate.Violated {
+			indicator.Reasons = append(indicator.Reasons, fmt.Sprintf("the burn rate of the error budget of the %v%% objective exceeds its thresholds, which fails the SLI", burnRate.Objective))
+		} else {
+			indicator.Reasons = append(indicator.Reasons, fmt.Sprintf("the burn rate of the error budget of the %v%% objective does not exceed its thresholds", burnRate.Objective))
+		}
+	}
+	if anomaly := indicatorResult.Anomaly; anomaly != nil {
+		switch {
+		case anomaly.Message != "":
+			indicator.Reasons = append(indicator.Reasons, fmt.Sprintf("no anomaly detection: %s", anomaly.Message))
+		case anomaly.Violated:
+			indicator.Reasons = append(indicator.Reasons, fmt.Sprintf("the value is an anomaly outside of %v and %v of the %s baseline, which fails the SLI", anomaly.LowerBound, anomaly.UpperBound, anomaly.Bucket))
+		default:
+			indicator.Reasons = append(indicator.Reasons, fmt.Sprintf("the value is within %v and %v of the %s baseline", anomaly.LowerBound, anomaly.UpperBound, anomaly.Bucket))
+		}
+	}
+
+	switch indicator.Status {
+	case "info":
+		indicator.Reasons = append(indicator.Reasons, "the SLI is informational and not considered for the total score")
+		return indicator
+	case "pass":
+		indicator.Reasons = append(indicator.Reasons, fmt.Sprintf("scored the full weight of %d", objective.Weight))
+	case "warning":
+		indicator.Reasons = append(indicator.Reasons, fmt.Sprintf("scored half of the weight of %d", objective.Weight))
+	default:
+		indicator.Reasons = append(indicator.Reasons, fmt.Sprintf("scored 0 of weight %d", objective.Weight))
+		if objective.KeySLI {
+			indicator.Reasons = append(indicator.Reasons, "the SLI is a key SLI, so the evaluation fails regardless of the total score")
+		}
+	}
+	indicator.Contribution = contribution(indicator.Score, maximumAchievableScore)
+	indicator.MaximumContribution = contribution(float64(objective.Weight), maximumAchievableScore)
+	return indicator
+}
+
+// explainCriteriaSets assigns the evaluated targets to the criteria sets they have been evaluated for. Targets of burn
+// rates and anomaly detection follow the targets of the criteria sets and are not included
+func explainCriteriaSets(criteriaSets []*keptn.SLOCriteria, targets []*keptnv2.SLITarget) []*CriteriaSetExplanation {
+	var explanations []*CriteriaSetExplanation
+	for _, criteriaSet := range criteriaSets {
+		explanation := &CriteriaSetExplanation{Criteria: []*keptnv2.SLITarget{}, Satisfied: len(targets) > 0}
+		for _, criteria := range criteriaSet.Criteria {
+			if len(targets) == 0 {
+				explanation.Criteria = append(explanation.Criteria, &keptnv2.SLITarget{Criteria: criteria})
+				continue
+			}
+			explanation.Criteria = append(explanation.Criteria, targets[0])
+			if targets[0].Violated {
+				explanation.Satisfied = false
+			}
+			targets = targets[1:]
+		}
+		explanations = append(explanations, explanation)
+	}
+	return explanations
+}
+
+func explainCriteriaResult(kind string, value string, criteriaSets []*CriteriaSetExplanation) string {
+	for i, criteriaSet := range criteriaSets {
+		if criteriaSet.Satisfied {
+			return fmt.Sprintf("the value %s satisfies %s criteria set %d (%s)", value, kind, i+1, formatCriteria(criteriaSet.Criteria, false))
+		}
+	}
+	violations := make([]string, 0, len(criteriaSets))
+	for i, criteriaSet := range criteriaSets {
+		violations = append(violations, fmt.Sprintf("set %d violates %s", i+1, formatCriteria(criteriaSet.Criteria, true)))
+	}
+	return fmt.Sprintf("the value %s satisfies none of the %s criteria sets: %s", value, kind, strings.Join(violations, "; "))
+}
+
+// formatCriteria lists the criteria along with their target values. If violatedOnly is set, only violated criteria are listed
+func formatCriteria(targets []*keptnv2.SLITarget, violatedOnly bool) string {
+	criteria := []string{}
+	for _, target := range targets {
+		if violatedOnly && !target.Violated {
+			continue
+		}
+		criteria = append(criteria, fmt.Sprintf("%s, target %s", target.Criteria, strconv.FormatFloat(target.TargetValue, 'f', -1, 64)))
+	}
+	return strings.Join(criteria, " and ")
+}
+
+func hasRelativeCriteria(objective *keptn.SLO) bool {
+	for _, criteriaSet := range append(append([]*keptn.SLOCriteria{}, objective.Pass...), objective.Warning...) {
+		for _, criteria := range criteriaSet.Criteria {
+			if co, err := parseCriteriaString(criteria); err == nil && co.IsComparison {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func contribution(score float64, maximumAchievableScore float64) float64 {
+	if maximumAchievableScore == 0 {
+		return 0
+	}
+	return roundStatistic(100.0 * score / maximumAchievableScore)
+}
+
+func (e *Explanation) summarize() string {
+	if e.MaximumWeight == 0 {
+		return fmt.Sprintf("The evaluation results in %s, since no SLI is considered for the total score", e.Result)
+	}
+	summary := fmt.Sprintf("The SLIs achieved %v of a maximum weight of %v, which is a score of %s%%", e.AchievedWeight, e.MaximumWeight, strconv.FormatFloat(roundStatistic(e.Score), 'f', -1, 64))
+	switch {
+	case len(e.FailedKeySLIs) > 0:
+		summary += fmt.Sprintf(". The evaluation results in %s, since the key SLIs %s failed", e.Result, strings.Join(e.FailedKeySLIs, ", "))
+	case e.Result == string(keptnv2.ResultPass):
+		summary += fmt.Sprintf(" and reaches the pass target of %s", e.PassTarget)
+	case e.Result == string(keptnv2.ResultWarning):
+		summary += fmt.Sprintf(", which misses the pass target of %s but reaches the warning target of %s", e.PassTarget, e.WarningTarget)
+	default:
+		summary += fmt.Sprintf(", which misses the pass target of %s", e.PassTarget)
+		if e.WarningTarget != "" {
+			summary += fmt.Sprintf(" and the warning target of %s", e.WarningTarget)
+		}
+	}
+	return summary
+}
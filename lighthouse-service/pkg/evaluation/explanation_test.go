@@ -0,0 +1,157 @@
+package evaluation
+
+import (
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+const testExplanationSLO = `spec_version: "1.0"
+comparison:
+  compare_with: "single_result"
+  include_result_with_score: "all"
+  number_of_comparison_results: 1
+  aggregate_function: avg
+objectives:
+  - sli: "response_time_p95"
+    pass:
+      - criteria:
+          - "<=600"
+    warning:
+      - criteria:
+          - "<=800"
+  - sli: "error_rate"
+    weight: 2
+    pass:
+      - criteria:
+          - "<=1"
+      - criteria:
+          - "<=2"
+          - "<=+10%"
+  - sli: "throughput"
+    key_sli: true
+    pass:
+      - criteria:
+          - ">=100"
+  - sli: "memory"
+    pass:
+      - criteria:
+          - "<=100"
+  - sli: "cpu"
+total_score:
+  pass: "90%"
+  warning: "75%"
+`
+
+func TestExplainEvaluation(t *testing.T) {
+	sloConfig, err := ParseSLO([]byte(testExplanationSLO))
+	require.Nil(t, err)
+
+	getSLIFinished := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts"},
+		GetSLI: keptnv2.GetSLIFinished{
+			IndicatorValues: []*keptnv2.SLIResult{
+				{Metric: "response_time_p95", Value: 700, Success: true},
+				{Metric: "error_rate", Value: 1.5, Success: true},
+				{Metric: "throughput", Value: 50, Success: true},
+				{Metric: "cpu", Value: 0.5, Success: true},
+			},
+		},
+	}
+	previousEvaluations := []*keptnv2.EvaluationFinishedEventData{
+		{Evaluation: keptnv2.EvaluationDetails{IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			{Value: &keptnv2.SLIResult{Metric: "error_rate", Value: 1.4, Success: true}},
+		}}},
+	}
+
+	evaluationResult, maximumAchievableScore, keySLIFailed := evaluateObjectives(getSLIFinished, sloConfig, previousEvaluations, nil, nil, nil)
+	require.Nil(t, calculateScore(maximumAchievableScore, evaluationResult, sloConfig, keySLIFailed))
+	evaluationResult.Evaluation.ComparedEvents = []string{"previous-evaluation"}
+	data := (*statisticalComparison)(nil).extend(evaluationResult)
+
+	explanation := explainEvaluation(data, sloConfig, maximumAchievableScore, map[string]string{"response_time_p95": "get-sli-finished"})
+	require.Equal(t, 2.5, explanation.AchievedWeight)
+	require.Equal(t, 5.0, explanation.MaximumWeight)
+	require.Equal(t, "fail", explanation.Result)
+	require.Equal(t, []string{"throughput"}, explanation.FailedKeySLIs)
+	require.Equal(t, "The SLIs achieved 2.5 of a maximum weight of 5, which is a score of 50%. The evaluation results in fail, since the key SLIs throughput failed", explanation.Summary)
+	require.Len(t, explanation.Indicators, 5)
+
+	responseTime := explanation.Indicators[0]
+	require.Equal(t, "get-sli-finished", responseTime.SourceEvent)
+	require.Equal(t, "warning", responseTime.Status)
+	require.Equal(t, 10.0, responseTime.Contribution)
+	require.Equal(t, 20.0, responseTime.MaximumContribution)
+	require.False(t, responseTime.Pass[0].Satisfied)
+	require.True(t, responseTime.Warning[0].Satisfied)
+	require.Equal(t, []string{
+		"the value 700 satisfies none of the pass criteria sets: set 1 violates <=600, target 600",
+		"the value 700 satisfies warning criteria set 1 (<=800, target 800)",
+		"scored half of the weight of 1",
+	}, responseTime.Reasons)
+
+	errorRate := explanation.Indicators[1]
+	require.Equal(t, "pass", errorRate.Status)
+	require.Equal(t, 40.0, errorRate.Contribution)
+	require.False(t, errorRate.Pass[0].Satisfied)
+	require.True(t, errorRate.Pass[1].Satisfied)
+	require.Len(t, errorRate.Pass[1].Criteria, 2)
+	require.InDelta(t, 1.54, errorRate.Pass[1].Criteria[1].TargetValue, 0.001)
+	require.Equal(t, 1.4, *errorRate.ComparedValue)
+	require.Equal(t, []string{"previous-evaluation"}, errorRate.ComparedEvents)
+	require.Equal(t, []string{
+		"the value 1.5 satisfies pass criteria set 2 (<=2, target 2 and <=+10%, target 1.54)",
+		"relative criteria have been compared with the avg value 1.4 of 1 previous evaluations",
+		"scored the full weight of 2",
+	}, errorRate.Reasons)
+
+	throughput := explanation.Indicators[2]
+	require.Equal(t, "fail", throughput.Status)
+	require.Contains(t, throughput.Reasons, "the SLI is a key SLI, so the evaluation fails regardless of the total score")
+
+	memory := explanation.Indicators[3]
+	require.Equal(t, "fail", memory.Status)
+	require.False(t, memory.Success)
+	require.Equal(t, 20.0, memory.MaximumContribution)
+	require.Equal(t, []string{"no value has been received from the SLI provider", "scored 0 of weight 1"}, memory.Reasons)
+
+	cpu := explanation.Indicators[4]
+	require.Equal(t, "info", cpu.Status)
+	require.Equal(t, 0.0, cpu.MaximumContribution)
+	require.Equal(t, []string{"the SLI is informational and not considered for the total score"}, cpu.Reasons)
+}
+
+func TestExplanation_summarize(t *testing.T) {
+	tests := []struct {
+		name        string
+		explanation Explanation
+		want        string
+	}{
+		{
+			name:        "pass",
+			explanation: Explanation{Score: 100, AchievedWeight: 3, MaximumWeight: 3, PassTarget: "90%", Result: "pass"},
+			want:        "The SLIs achieved 3 of a maximum weight of 3, which is a score of 100% and reaches the pass target of 90%",
+		},
+		{
+			name:        "warning",
+			explanation: Explanation{Score: 80, AchievedWeight: 4, MaximumWeight: 5, PassTarget: "90%", WarningTarget: "75%", Result: "warning"},
+			want:        "The SLIs achieved 4 of a maximum weight of 5, which is a score of 80%, which misses the pass target of 90% but reaches the warning target of 75%",
+		},
+		{
+			name:        "fail",
+			explanation: Explanation{Score: 66.66666666, AchievedWeight: 2, MaximumWeight: 3, PassTarget: "90%", WarningTarget: "75%", Result: "fail"},
+			want:        "The SLIs achieved 2 of a maximum weight of 3, which is a score of 66.6667%, which misses the pass target of 90% and the warning target of 75%",
+		},
+		{
+			name:        "no SLIs considered for the total score",
+			explanation: Explanation{Score: 100, Result: "pass"},
+			want:        "The evaluation results in pass, since no SLI is considered for the total score",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.explanation.summarize())
+		})
+	}
+}
@@ -0,0 +1,478 @@
+package evaluation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+type criteriaObject struct {
+	Operator        string
+	Value           float64
+	CheckPercentage bool
+	IsComparison    bool
+	CheckIncrease   bool
+}
+
+func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *keptn.ServiceLevelObjectives, previousEvaluationEvents []*keptnv2.EvaluationFinishedEventData, sc *statisticalComparison, br *burnRateEvaluation, ae *anomalyEvaluation) (*keptnv2.EvaluationFinishedEventData, float64, bool) {
+	evaluationResult := &keptnv2.EvaluationFinishedEventData{
+		EventData: keptnv2.EventData{
+			Status:  "",
+			Project: e.Project,
+			Service: e.Service,
+			Stage:   e.Stage,
+		},
+		Evaluation: keptnv2.EvaluationDetails{
+			TimeStart: e.GetSLI.Start,
+			TimeEnd:   e.GetSLI.End,
+		},
+	}
+	var sliEvaluationResults []*keptnv2.SLIEvaluationResult
+	maximumAchievableScore := 0.0
+	keySLIFailed := false
+	for _, objective := range sloConfig.Objectives {
+		// only consider the SLI for the total score if pass criteria, a burn rate or anomaly detection have been included
+		if len(objective.Pass) > 0 || br.hasPolicy(objective.SLI) || ae.hasPolicy(objective.SLI) {
+			maximumAchievableScore += float64(objective.Weight)
+		}
+		sliEvaluationResult := &keptnv2.SLIEvaluationResult{}
+		result := getSLIResult(&e.GetSLI.IndicatorValues, objective.SLI)
+		burnRate := br.evaluate(objective.SLI, &e.GetSLI.IndicatorValues)
+
+		if result == nil {
+			// no result available => fail the objective
+			sliEvaluationResult.Value = &keptnv2.SLIResult{
+				Metric:  objective.SLI,
+				Success: false,
+				Message: "no value received from SLI provider",
+			}
+			sliEvaluationResult.Status = "fail"
+			sliEvaluationResult.Score = 0
+			continue
+		}
+		sliEvaluationResult.Value = (*keptnv2.SLIResult)(result)
+		anomaly := ae.evaluate(objective.SLI, sliEvaluationResult.Value)
+
+		// gather the previous results for the current SLI
+		var previousSLIResults []*keptnv2.SLIEvaluationResult
+
+		if previousEvaluationEvents != nil && len(previousEvaluationEvents) > 0 {
+			for _, event := range previousEvaluationEvents {
+				for _, prevSLIResult := range event.Evaluation.IndicatorResults {
+					if strings.Compare(prevSLIResult.Value.Metric, objective.SLI) == 0 {
+						previousSLIResults = append(previousSLIResults, prevSLIResult)
+					}
+				}
+			}
+		}
+
+		statistic := sc.compare(sliEvaluationResult.Value, previousSLIResults)
+
+		var passTargets []*keptnv2.SLITarget
+		var warningTargets []*keptnv2.SLITarget
+		isPassed := true
+		isWarning := true
+		if objective.Pass != nil && len(objective.Pass) > 0 {
+			isPassed, passTargets, _ = evaluateOrCombinedCriteria(sliEvaluationResult.Value, objective.Pass, previousSLIResults, sloConfig.Comparison, statistic)
+			if isPassed {
+				sliEvaluationResult.Score = float64(objective.Weight)
+				sliEvaluationResult.Status = "pass"
+			}
+		} else if burnRate != nil || anomaly != nil {
+			sliEvaluationResult.Score = float64(objective.Weight)
+			sliEvaluationResult.Status = "pass"
+		} else {
+			sliEvaluationResult.Status = "info"
+		}
+
+		if objective.Warning != nil && len(objective.Warning) > 0 {
+			isWarning, warningTargets, _ = evaluateOrCombinedCriteria(sliEvaluationResult.Value, objective.Warning, previousSLIResults, sloConfig.Comparison, statistic)
+			if !isPassed && isWarning {
+				sliEvaluationResult.Score = 0.5 * float64(objective.Weight)
+				sliEvaluationResult.Status = "warning"
+			}
+		} else {
+			isWarning = false
+		}
+
+		// a violated burn rate fails the SLI, regardless of its pass and warning criteria
+		if burnRate != nil {
+			passTargets = append(passTargets, burnRate.targets()...)
+			if burnRate.Violated {
+				isPassed = false
+				isWarning = false
+			}
+		}
+
+		// so does an anomaly
+		if anomaly != nil {
+			passTargets = append(passTargets, anomaly.targets()...)
+			if anomaly.Violated {
+				isPassed = false
+				isWarning = false
+			}
+		}
+
+		sliEvaluationResult.PassTargets = passTargets
+		sliEvaluationResult.WarningTargets = warningTargets
+		sliEvaluationResult.KeySLI = objective.KeySLI
+		sliEvaluationResult.DisplayName = objective.DisplayName
+
+		if !isPassed && !isWarning {
+			if objective.KeySLI {
+				keySLIFailed = true
+			}
+			sliEvaluationResult.Status = "fail"
+			sliEvaluationResult.Score = 0
+		}
+
+		sliEvaluationResults = append(sliEvaluationResults, sliEvaluationResult)
+	}
+
+	// now we check if any metric from the SLI has not been handled
+	checkLeftoverSLI(e.GetSLI.IndicatorValues, evaluationResult)
+	evaluationResult.Evaluation.IndicatorResults = sliEvaluationResults
+
+	return evaluationResult, maximumAchievableScore, keySLIFailed
+}
+
+func checkLeftoverSLI(results []*keptnv2.SLIResult, evaluationResult *keptnv2.EvaluationFinishedEventData) {
+	if len(results) > 0 {
+		//collect SLIs that did not have objectives defined
+		sliEvaluations := ""
+		for _, Values := range results {
+			if sliEvaluations != "" {
+				sliEvaluations += ", "
+			}
+			sliEvaluations += Values.Metric
+		}
+		if sliEvaluations != "" {
+			evaluationResult.Message += fmt.Sprintf("Lighthouse received additional SLIs,"+
+				" which are not specified as SLO: %s . "+
+				"Please consider using them as an SLO.", sliEvaluations,
+			)
+		}
+	}
+}
+
+func calculateScore(maximumAchievableScore float64, evaluationResult *keptnv2.EvaluationFinishedEventData, sloConfig *keptn.ServiceLevelObjectives, keySLIFailed bool) error {
+	if maximumAchievableScore == 0 {
+		evaluationResult.Evaluation.Result = "pass"
+		evaluationResult.Result = keptnv2.ResultPass
+		evaluationResult.Status = keptnv2.StatusSucceeded
+		evaluationResult.Evaluation.Score = 100.0
+		return nil
+	}
+	totalScore := 0.0
+	for _, result := range evaluationResult.Evaluation.IndicatorResults {
+		totalScore += result.Score
+	}
+	achievedPercentage := 100.0 * (totalScore / maximumAchievableScore)
+	evaluationResult.Evaluation.Score = achievedPercentage
+	if sloConfig.TotalScore == nil || sloConfig.TotalScore.Pass == "" {
+		return errors.New("no target score defined")
+	}
+	passTargetPercentage, err := strconv.ParseFloat(strings.TrimSuffix(sloConfig.TotalScore.Pass, "%"), 64)
+	if err != nil {
+		return errors.New("could not parse pass target percentage")
+	}
+	if achievedPercentage >= passTargetPercentage && !keySLIFailed {
+		evaluationResult.Evaluation.Result = "pass"
+		evaluationResult.Result = keptnv2.ResultPass
+		evaluationResult.Status = keptnv2.StatusSucceeded
+	} else if sloConfig.TotalScore.Warning != "" && !keySLIFailed {
+		warnTargetPercentage, err := strconv.ParseFloat(strings.TrimSuffix(sloConfig.TotalScore.Warning, "%"), 64)
+
+		if err != nil {
+			return errors.New("could not parse warning target percentage")
+		}
+		if achievedPercentage >= warnTargetPercentage {
+			evaluationResult.Evaluation.Result = "warning"
+			evaluationResult.Result = keptnv2.ResultWarning
+			evaluationResult.Status = keptnv2.StatusSucceeded
+			evaluationResult.Message = fmt.Sprintf("Evaluation returned a warning: the calculated score of %v is close to the warning target value of %v", achievedPercentage, warnTargetPercentage)
+		} else {
+			evaluationResult.Evaluation.Result = "fail"
+			evaluationResult.Result = keptnv2.ResultFailed
+			evaluationResult.Status = keptnv2.StatusSucceeded
+			evaluationResult.Message = fmt.Sprintf("Evaluation failed since the calculated score of %v is below the warning value of %v", achievedPercentage, warnTargetPercentage)
+		}
+	} else {
+		evaluationResult.Evaluation.Result = "fail"
+		evaluationResult.Result = keptnv2.ResultFailed
+		evaluationResult.Status = keptnv2.StatusSucceeded
+		evaluationResult.Message = fmt.Sprintf("Evaluation failed since the calculated score of %v is below the target value of %v", achievedPercentage, passTargetPercentage)
+	}
+
+	return nil
+}
+
+func getSLIResult(results *[]*keptnv2.SLIResult, sli string) *keptnv2.SLIResult {
+	var r = *results
+	for i, sliResult := range *results {
+		if sliResult.Metric == sli {
+			// remove already processed SLI
+			r[i] = r[len(r)-1] // Copy last element to index i.
+			r[len(r)-1] = nil  // Erase last element.
+			r = r[:len(r)-1]   // Truncate slice.
+			*results = r
+			return sliResult
+		}
+	}
+	return nil
+}
+
+func evaluateOrCombinedCriteria(result *keptnv2.SLIResult, sloCriteria []*keptn.SLOCriteria, previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison, statistic *ComparisonStatistic) (bool, []*keptnv2.SLITarget, error) {
+	var satisfied bool
+	satisfied = false
+	var sliTargets []*keptnv2.SLITarget
+	for _, crit := range sloCriteria {
+		criteriaSatisfied, evaluatedTargets, _ := evaluateCriteriaSet(result, crit, previousResults, comparison, statistic)
+		if criteriaSatisfied {
+			// one matching criteria set is sufficient to satisfy the evaluation. Other criteria sets are evaluated nevertheless, to get potential violations
+			satisfied = true
+		}
+		for _, evaluatedTarget := range evaluatedTargets {
+			sliTargets = append(sliTargets, evaluatedTarget)
+		}
+	}
+
+	return satisfied, sliTargets, nil
+}
+
+// evaluateCriteria evaluates a set of criteria strings. Per definition, all criteria clauses within a SLOCriteria object have to be fulfilled to satisfy the SLOCriteria
+func evaluateCriteriaSet(result *keptnv2.SLIResult, sloCriteria *keptn.SLOCriteria, previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison, statistic *ComparisonStatistic) (bool, []*keptnv2.SLITarget, error) {
+	satisfied := true
+	var sliTargets []*keptnv2.SLITarget
+	for _, criteria := range sloCriteria.Criteria {
+		target := &keptnv2.SLITarget{
+			Criteria: criteria,
+		}
+		criteriaSatisfied, _ := evaluateSingleCriteria(result, criteria, previousResults, comparison, statistic, target)
+		if !criteriaSatisfied {
+			target.Violated = true
+			satisfied = false
+		} else {
+			target.Violated = false
+		}
+		sliTargets = append(sliTargets, target)
+	}
+
+	return satisfied, sliTargets, nil
+}
+
+func evaluateSingleCriteria(sliResult *keptnv2.SLIResult, criteria string, previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison, statistic *ComparisonStatistic, violation *keptnv2.SLITarget) (bool, error) {
+	if !sliResult.Success {
+		return false, errors.New("cannot evaluate invalid SLI result")
+	}
+
+	co, err := parseCriteriaString(criteria)
+
+	if err != nil {
+		return false, err
+	}
+
+	if !co.IsComparison {
+		//compared value is used only if the criteria is a comparison without fixed threshold,
+		//anyway we calculate it here to allow Bridge to display it
+		sliResult.ComparedValue, _ = aggregateValues(previousResults, comparison)
+
+		// do a fixed threshold comparison
+		return evaluateFixedThreshold(sliResult, co, violation)
+	}
+
+	return evaluateComparison(sliResult, co, previousResults, comparison, statistic, violation)
+}
+
+func evaluateComparison(sliResult *keptnv2.SLIResult, co *criteriaObject, previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison, statistic *ComparisonStatistic, violation *keptnv2.SLITarget) (bool, error) {
+	// aggregate previous results
+	var aggregatedValue float64
+	var targetValue float64
+
+	aggregatedValue, skip := aggregateValues(previousResults, comparison)
+	sliResult.ComparedValue = aggregatedValue
+	if skip {
+		return true, nil
+	}
+	// calculate the comparison value
+	if co.CheckPercentage && co.CheckIncrease {
+		targetValue = (aggregatedValue * (100.0 + co.Value)) / 100.0
+	} else if co.CheckPercentage && !co.CheckIncrease {
+		targetValue = (aggregatedValue * (100.0 - co.Value)) / 100.0
+	} else if !co.CheckPercentage && co.CheckIncrease {
+		targetValue = aggregatedValue + co.Value
+	} else if !co.CheckPercentage && !co.CheckIncrease {
+		targetValue = aggregatedValue - co.Value
+	}
+	violation.TargetValue = targetValue
+	// compare!
+	satisfied, err := evaluateValue(sliResult.Value, targetValue, co.Operator)
+	if err != nil || satisfied {
+		return satisfied, err
+	}
+	// with a statistical comparison strategy, the criteria is only violated if the deviation is significant
+	if statistic.available() && !statistic.isSignificant(co.Operator) {
+		return true, nil
+	}
+	return false, nil
+}
+
+// aggregateValues combines the previous values into a single one, based on the aggregation function
+// it returns the aggregated value and a boolean telling if the rest of the evaluation should be skipped
+// (no previous results or no successful previous results)
+func aggregateValues(previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison) (float64, bool) {
+
+	if len(previousResults) == 0 {
+		// if no comparison values are available, the evaluation passes
+		return 0, true
+	}
+	var previousValues []float64
+	for _, val := range previousResults {
+		if val.Value.Success == true {
+			// always include
+			previousValues = append(previousValues, val.Value.Value)
+		}
+	}
+
+	if len(previousValues) == 0 {
+		// if no comparison values are available, the evaluation passes
+		return 0, true
+	}
+	var aggregatedValue float64
+	// aggregate the previous values based on the passed aggregation function
+	switch comparison.AggregateFunction {
+	case "avg":
+		aggregatedValue = calculateAverage(previousValues)
+	case "p50":
+		aggregatedValue = calculatePercentile(sort.Float64Slice(previousValues), 0.5)
+	case "p90":
+		aggregatedValue = calculatePercentile(sort.Float64Slice(previousValues), 0.9)
+	case "p95":
+		aggregatedValue = calculatePercentile(sort.Float64Slice(previousValues), 0.95)
+	default:
+		break
+	}
+	return aggregatedValue, false
+}
+
+func calculateAverage(values []float64) float64 {
+	sum := 0.0
+
+	for _, value := range values {
+		sum += value
+	}
+	if len(values) > 0 {
+		return sum / float64(len(values))
+	}
+
+	return 0.0
+}
+
+func calculatePercentile(values sort.Float64Slice, perc float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+	ps := []float64{perc}
+
+	scores := make([]float64, len(ps))
+	size := len(values)
+	if size > 0 {
+		sort.Sort(values)
+		for i, p := range ps {
+			pos := p * float64(size+1) //ALTERNATIVELY, DROP THE +1
+			if pos < 1.0 {
+				scores[i] = float64(values[0])
+			} else if pos >= float64(size) {
+				scores[i] = float64(values[size-1])
+			} else {
+				lower := float64(values[int(pos)-1])
+				upper := float64(values[int(pos)])
+				scores[i] = lower + (pos-math.Floor(pos))*(upper-lower)
+			}
+		}
+	}
+
+	return scores[0]
+}
+
+func evaluateFixedThreshold(sliResult *keptnv2.SLIResult, co *criteriaObject, violation *keptnv2.SLITarget) (bool, error) {
+	violation.TargetValue = co.Value
+	return evaluateValue(sliResult.Value, co.Value, co.Operator)
+}
+
+func evaluateValue(measured float64, expected float64, operator string) (bool, error) {
+	switch operator {
+	case "<":
+		return measured < expected, nil
+	case "<=":
+		return measured <= expected, nil
+	case "=":
+		return measured == expected, nil
+	case ">=":
+		return measured >= expected, nil
+	case ">":
+		return measured > expected, nil
+	default:
+		return false, errors.New("no operator set")
+	}
+}
+
+func parseCriteriaString(criteria string) (*criteriaObject, error) {
+	// example values: <+15%, <500, >-8%, =0
+	// possible operators: <, <=, =, >, >=
+	// regex: ^([<|<=|=|>|>=]{1,2})([+|-]{0,1}\\d*\.?\d*)([%]{0,1})
+	regex := `^([<|<=|=|>|>=]{1,2})([+|-]{0,1}\d*\.?\d*)([%]{0,1})`
+	var re *regexp.Regexp
+	re = regexp.MustCompile(regex)
+
+	// remove whitespaces
+	criteria = strings.Replace(criteria, " ", "", -1)
+
+	if !re.MatchString(criteria) {
+		return nil, errors.New("invalid criteria string")
+	}
+
+	c := &criteriaObject{}
+
+	operators := []string{"<=", "<", "=", ">=", ">"}
+
+	for _, operator := range operators {
+		if strings.HasPrefix(criteria, operator) {
+			c.Operator = operator
+			criteria = strings.TrimPrefix(criteria, operator)
+			break
+		}
+	}
+
+	if strings.HasSuffix(criteria, "%") {
+		c.CheckPercentage = true
+		c.IsComparison = true // Issue #1498: criteria containing '%' is always a comparison
+		c.CheckIncrease = true
+		criteria = strings.TrimSuffix(criteria, "%")
+	}
+
+	if strings.HasPrefix(criteria, "-") {
+		c.IsComparison = true
+		c.CheckIncrease = false
+		criteria = strings.TrimPrefix(criteria, "-")
+	} else if strings.HasPrefix(criteria, "+") {
+		c.IsComparison = true
+		c.CheckIncrease = true
+		criteria = strings.TrimPrefix(criteria, "+")
+	}
+
+	floatValue, err := strconv.ParseFloat(criteria, 64)
+	if err != nil {
+		return nil, errors.New("could not parse criteria target value")
+	}
+	c.Value = floatValue
+
+	return c, nil
+}
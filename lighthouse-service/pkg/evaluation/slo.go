@@ -0,0 +1,64 @@
+package evaluation
+
+import (
+	"fmt"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"gopkg.in/yaml.v3"
+)
+
+// ParseSLO parses the SLO file and applies the defaults of the comparison and of the weights of the objectives
+func ParseSLO(input []byte) (*keptn.ServiceLevelObjectives, error) {
+	slo := &keptn.ServiceLevelObjectives{}
+	err := yaml.Unmarshal([]byte(input), &slo)
+
+	if err != nil {
+		return nil, fmt.Errorf(err.Error())
+	}
+
+	if slo.Comparison == nil {
+		slo.Comparison = &keptn.SLOComparison{
+			CompareWith:               "single_result",
+			IncludeResultWithScore:    "all",
+			NumberOfComparisonResults: 1,
+			AggregateFunction:         "avg",
+		}
+	}
+
+	if slo.Comparison != nil {
+		if slo.Comparison.IncludeResultWithScore == "" {
+			slo.Comparison.IncludeResultWithScore = "all"
+		}
+		if slo.Comparison.NumberOfComparisonResults == 0 {
+			slo.Comparison.NumberOfComparisonResults = 3
+		}
+		if slo.Comparison.AggregateFunction == "" {
+			slo.Comparison.AggregateFunction = "avg"
+		}
+	}
+
+	objectives := []*keptn.SLO{}
+	for _, objective := range slo.Objectives {
+		if objective == nil {
+			continue
+		}
+		if objective.Weight == 0 {
+			objective.Weight = 1
+		}
+		objectives = append(objectives, objective)
+	}
+	slo.Objectives = objectives
+
+	return slo, nil
+}
+
+// getNumberOfPreviousResults returns the number of previous evaluations the SLO file compares an evaluation with
+func getNumberOfPreviousResults(sloConfig *keptn.ServiceLevelObjectives) int {
+	numberOfPreviousResults := 3
+	if sloConfig.Comparison.CompareWith == "single_result" {
+		numberOfPreviousResults = 1
+	} else if sloConfig.Comparison.CompareWith == "several_results" {
+		numberOfPreviousResults = sloConfig.Comparison.NumberOfComparisonResults
+	}
+	return numberOfPreviousResults
+}
@@ -0,0 +1,210 @@
+package common
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheMetrics contains the counters of a ResourceCache
+type CacheMetrics struct {
+	// Hits is the number of resource reads that have been served from the cache
+	Hits int64 `json:"hits"`
+	// Misses is the number of resource reads that could not be served from the cache
+	Misses int64 `json:"misses"`
+	// Pulls is the number of times the latest revision of a branch has been pulled from the upstream
+	Pulls int64 `json:"pulls"`
+	// ThrottledPulls is the number of pulls that have been skipped because the latest known revision was still fresh
+	ThrottledPulls int64 `json:"throttledPulls"`
+	// Size is the current size of the cached resource contents in bytes
+	Size int64 `json:"size"`
+	// Entries is the current number of cached resource contents
+	Entries int `json:"entries"`
+}
+
+// CachedResource is a resource content together with the metadata it has been read with
+type CachedResource struct {
+	Content     []byte
+	UpstreamURL string
+	// NotFound is set if the resource does not exist at the revision
+	NotFound bool
+}
+
+type branchRevision struct {
+	revision string
+	syncedAt time.Time
+}
+
+type cacheEntry struct {
+	key      string
+	resource CachedResource
+}
+
+// ResourceCache keeps the contents of resources at a given revision, as well as the latest known revision of each
+// project branch. Since the content of a resource at a specific revision never changes, content entries are only
+// evicted when the cache exceeds its maximum size. The latest revision of a branch is considered to be fresh
+// until the staleness window has passed, which limits the number of pulls against the upstream.
+type ResourceCache struct {
+	mutex           sync.Mutex
+	stalenessWindow time.Duration
+	maxSize         int64
+	size            int64
+	revisions       map[string]branchRevision
+	entries         map[string]*list.Element
+	lru             *list.List
+	metrics         CacheMetrics
+	now             func() time.Time
+}
+
+// NewResourceCache creates a new ResourceCache. A staleness window of 0 disables the pull throttling,
+// a maximum size of 0 disables the caching of resource contents
+func NewResourceCache(stalenessWindow time.Duration, maxSize int64) *ResourceCache {
+	return &ResourceCache{
+		stalenessWindow: stalenessWindow,
+		maxSize:         maxSize,
+		revisions:       map[string]branchRevision{},
+		entries:         map[string]*list.Element{},
+		lru:             list.New(),
+		now:             time.Now,
+	}
+}
+
+// GetLatestRevision returns the latest known revision of the given branch of a project, if it has been synced with
+// the upstream within the staleness window. Otherwise, the branch needs to be pulled again
+func (c *ResourceCache) GetLatestRevision(project, branch string) (string, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.revisions[revisionKey(project, branch)]
+	if !ok || c.now().Sub(entry.syncedAt) >= c.stalenessWindow {
+		return "", false
+	}
+	return entry.revision, true
+}
+
+// GetLatest returns the content of a resource at the latest known revision of the given branch, if this revision is
+// still fresh. In this case, the branch does not need to be pulled from the upstream
+func (c *ResourceCache) GetLatest(project, branch, resourceKey string) (CachedResource, string, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	revision, ok := c.revisions[revisionKey(project, branch)]
+	if !ok || c.now().Sub(revision.syncedAt) >= c.stalenessWindow {
+		c.metrics.Misses++
+		return CachedResource{}, "", false
+	}
+	element, ok := c.entries[contentKey(revision.revision, resourceKey)]
+	if !ok {
+		c.metrics.Misses++
+		return CachedResource{}, "", false
+	}
+	c.metrics.Hits++
+	c.metrics.ThrottledPulls++
+	c.lru.MoveToFront(element)
+	return element.Value.(*cacheEntry).resource, revision.revision, true
+}
+
+// SetLatestRevision stores the revision of a branch that has just been synced with the upstream
+func (c *ResourceCache) SetLatestRevision(project, branch, revision string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.metrics.Pulls++
+	c.revisions[revisionKey(project, branch)] = branchRevision{revision: revision, syncedAt: c.now()}
+}
+
+// InvalidateProject marks the latest revisions of all branches of a project as stale.
+// This needs to be called whenever the project repository has been modified
+func (c *ResourceCache) InvalidateProject(project string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	prefix := revisionKey(project, "")
+	for key := range c.revisions {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.revisions, key)
+		}
+	}
+}
+
+// Get returns the content of a resource at the given revision
+func (c *ResourceCache) Get(revision, resourceKey string) (CachedResource, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[contentKey(revision, resourceKey)]
+	if !ok {
+		c.metrics.Misses++
+		return CachedResource{}, false
+	}
+	c.metrics.Hits++
+	c.lru.MoveToFront(element)
+	return element.Value.(*cacheEntry).resource, true
+}
+
+// Set stores the content of a resource at the given revision. If the cache exceeds its maximum size,
+// the least recently used entries are evicted
+func (c *ResourceCache) Set(revision, resourceKey string, resource CachedResource) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := contentKey(revision, resourceKey)
+	entry := &cacheEntry{key: key, resource: resource}
+	size := entry.size()
+	if c.maxSize <= 0 || size > c.maxSize {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		c.removeElement(element)
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.size += size
+
+	for c.size > c.maxSize {
+		c.removeElement(c.lru.Back())
+	}
+}
+
+// RecordThrottledPull increases the number of pulls that have been skipped due to a fresh revision
+func (c *ResourceCache) RecordThrottledPull() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.metrics.ThrottledPulls++
+}
+
+// Metrics returns a snapshot of the current cache metrics
+func (c *ResourceCache) Metrics() CacheMetrics {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	metrics := c.metrics
+	metrics.Size = c.size
+	metrics.Entries = len(c.entries)
+	return metrics
+}
+
+func (c *ResourceCache) removeElement(element *list.Element) {
+	entry := element.Value.(*cacheEntry)
+	c.lru.Remove(element)
+	delete(c.entries, entry.key)
+	c.size -= entry.size()
+}
+
+// size returns the number of bytes the entry occupies. Resources that do not exist are accounted with the size of
+// their key, so that they are evicted as well
+func (e *cacheEntry) size() int64 {
+	if e.resource.NotFound {
+		return int64(len(e.key))
+	}
+	return int64(len(e.resource.Content))
+}
+
+func revisionKey(project, branch string) string {
+	return project + "/" + branch
+}
+
+func contentKey(revision, resourceKey string) string {
+	return revision + ":" + resourceKey
+}
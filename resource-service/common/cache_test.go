@@ -0,0 +1,125 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceCache_GetLatestRevision(t *testing.T) {
+	now := time.Now()
+	cache := NewResourceCache(5*time.Second, 1024)
+	cache.now = func() time.Time { return now }
+
+	_, ok := cache.GetLatestRevision("my-project", "my-stage")
+	require.False(t, ok)
+
+	cache.SetLatestRevision("my-project", "my-stage", "my-revision")
+
+	revision, ok := cache.GetLatestRevision("my-project", "my-stage")
+	require.True(t, ok)
+	require.Equal(t, "my-revision", revision)
+
+	_, ok = cache.GetLatestRevision("my-project", "other-stage")
+	require.False(t, ok)
+
+	now = now.Add(5 * time.Second)
+	_, ok = cache.GetLatestRevision("my-project", "my-stage")
+	require.False(t, ok)
+}
+
+func TestResourceCache_StalenessWindowDisabled(t *testing.T) {
+	cache := NewResourceCache(0, 1024)
+
+	cache.SetLatestRevision("my-project", "my-stage", "my-revision")
+	cache.Set("my-revision", "my-resource", CachedResource{Content: []byte("content")})
+
+	_, ok := cache.GetLatestRevision("my-project", "my-stage")
+	require.False(t, ok)
+
+	_, _, ok = cache.GetLatest("my-project", "my-stage", "my-resource")
+	require.False(t, ok)
+}
+
+func TestResourceCache_GetLatest(t *testing.T) {
+	cache := NewResourceCache(time.Minute, 1024)
+
+	cache.SetLatestRevision("my-project", "my-stage", "my-revision")
+	cache.Set("my-revision", "my-resource", CachedResource{Content: []byte("content"), UpstreamURL: "my-url"})
+
+	resource, revision, ok := cache.GetLatest("my-project", "my-stage", "my-resource")
+	require.True(t, ok)
+	require.Equal(t, "my-revision", revision)
+	require.Equal(t, CachedResource{Content: []byte("content"), UpstreamURL: "my-url"}, resource)
+
+	_, _, ok = cache.GetLatest("my-project", "my-stage", "other-resource")
+	require.False(t, ok)
+
+	require.Equal(t, CacheMetrics{Hits: 1, Misses: 1, Pulls: 1, ThrottledPulls: 1, Size: 7, Entries: 1}, cache.Metrics())
+}
+
+func TestResourceCache_InvalidateProject(t *testing.T) {
+	cache := NewResourceCache(time.Minute, 1024)
+
+	cache.SetLatestRevision("my-project", "", "my-revision")
+	cache.SetLatestRevision("my-project", "my-stage", "my-revision")
+	cache.SetLatestRevision("my-project-2", "my-stage", "my-revision")
+	cache.Set("my-revision", "my-resource", CachedResource{Content: []byte("content")})
+
+	cache.InvalidateProject("my-project")
+
+	_, ok := cache.GetLatestRevision("my-project", "")
+	require.False(t, ok)
+	_, ok = cache.GetLatestRevision("my-project", "my-stage")
+	require.False(t, ok)
+	_, ok = cache.GetLatestRevision("my-project-2", "my-stage")
+	require.True(t, ok)
+
+	// contents of a specific revision stay valid
+	_, ok = cache.Get("my-revision", "my-resource")
+	require.True(t, ok)
+}
+
+func TestResourceCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewResourceCache(time.Minute, 10)
+
+	cache.Set("rev-1", "resource-1", CachedResource{Content: []byte("1234")})
+	cache.Set("rev-1", "resource-2", CachedResource{Content: []byte("1234")})
+
+	// access resource-1 so that resource-2 becomes the least recently used entry
+	_, ok := cache.Get("rev-1", "resource-1")
+	require.True(t, ok)
+
+	cache.Set("rev-1", "resource-3", CachedResource{Content: []byte("1234")})
+
+	_, ok = cache.Get("rev-1", "resource-2")
+	require.False(t, ok)
+	_, ok = cache.Get("rev-1", "resource-1")
+	require.True(t, ok)
+	_, ok = cache.Get("rev-1", "resource-3")
+	require.True(t, ok)
+
+	metrics := cache.Metrics()
+	require.Equal(t, int64(8), metrics.Size)
+	require.Equal(t, 2, metrics.Entries)
+
+	// resources exceeding the maximum size are not cached at all
+	cache.Set("rev-1", "resource-4", CachedResource{Content: []byte("12345678901")})
+	_, ok = cache.Get("rev-1", "resource-4")
+	require.False(t, ok)
+	require.Equal(t, 2, cache.Metrics().Entries)
+}
+
+func TestResourceCache_NotFound(t *testing.T) {
+	cache := NewResourceCache(time.Minute, 1024)
+
+	cache.Set("my-revision", "my-resource", CachedResource{NotFound: true})
+
+	resource, ok := cache.Get("my-revision", "my-resource")
+	require.True(t, ok)
+	require.True(t, resource.NotFound)
+
+	// the absence of a resource is accounted with the size of its key, so that it can be evicted
+	require.Equal(t, int64(len("my-revision:my-resource")), cache.Metrics().Size)
+}
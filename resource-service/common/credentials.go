@@ -17,6 +17,7 @@ import (
 //go:generate moq -pkg common_mock -skip-ensure -out ./fake/credential_reader_mock.go . CredentialReader
 type CredentialReader interface {
 	GetCredentials(project string) (*common_models.GitCredentials, error)
+	GetWebhookSecret(project string) (string, error)
 }
 
 type K8sCredentialReader struct {
@@ -52,6 +53,27 @@ func (kr K8sCredentialReader) GetCredentials(project string) (*common_models.Git
 	return credentials, nil
 }
 
+// GetWebhookSecret returns the secret that is used to validate push webhooks sent by the upstream of a project
+func (kr K8sCredentialReader) GetWebhookSecret(project string) (string, error) {
+	secretName := fmt.Sprintf("git-webhook-%s", project)
+
+	secret, err := kr.k8sClient.CoreV1().Secrets(GetKeptnNamespace()).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil && k8serrors.IsNotFound(err) {
+		logger.Debug("Could not retrieve webhook secret named: ", secretName)
+		return "", errors2.ErrWebhookSecretNotFound
+	}
+	if err != nil {
+		logger.Debug("Could not retrieve webhook secret named: ", secretName)
+		return "", err
+	}
+
+	webhookSecret := string(secret.Data["secret"])
+	if webhookSecret == "" {
+		return "", errors2.ErrWebhookSecretNotFound
+	}
+	return webhookSecret, nil
+}
+
 func GetKeptnNamespace() string {
 	return os.Getenv("POD_NAMESPACE")
 }
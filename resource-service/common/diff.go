@@ -0,0 +1,116 @@
+package common
+
+import (
+	"bytes"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/utils/binary"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// UnifiedDiff returns the unified diff between two versions of a file, in the same format as produced by git.
+// A nil content indicates that the file does not exist in the respective version
+func UnifiedDiff(path string, fromContent, toContent []byte) (string, error) {
+	patch := &filePatch{}
+	if fromContent != nil {
+		patch.from = &patchFile{path: path, hash: plumbing.ComputeHash(plumbing.BlobObject, fromContent)}
+	}
+	if toContent != nil {
+		patch.to = &patchFile{path: path, hash: plumbing.ComputeHash(plumbing.BlobObject, toContent)}
+	}
+
+	if !isBinary(fromContent) && !isBinary(toContent) {
+		for _, d := range diff.Do(string(fromContent), string(toContent)) {
+			var op fdiff.Operation
+			switch d.Type {
+			case diffmatchpatch.DiffEqual:
+				op = fdiff.Equal
+			case diffmatchpatch.DiffDelete:
+				op = fdiff.Delete
+			case diffmatchpatch.DiffInsert:
+				op = fdiff.Add
+			}
+			patch.chunks = append(patch.chunks, &patchChunk{content: d.Text, op: op})
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := fdiff.NewUnifiedEncoder(buf, fdiff.DefaultContextLines).Encode(filePatches{patch}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func isBinary(content []byte) bool {
+	result, err := binary.IsBinary(bytes.NewReader(content))
+	return err == nil && result
+}
+
+type filePatches []fdiff.FilePatch
+
+func (p filePatches) FilePatches() []fdiff.FilePatch {
+	return p
+}
+
+func (p filePatches) Message() string {
+	return ""
+}
+
+type filePatch struct {
+	from   *patchFile
+	to     *patchFile
+	chunks []fdiff.Chunk
+}
+
+func (p *filePatch) IsBinary() bool {
+	return len(p.chunks) == 0
+}
+
+func (p *filePatch) Files() (fdiff.File, fdiff.File) {
+	// the encoder relies on untyped nil values for added and deleted files
+	var from, to fdiff.File
+	if p.from != nil {
+		from = p.from
+	}
+	if p.to != nil {
+		to = p.to
+	}
+	return from, to
+}
+
+func (p *filePatch) Chunks() []fdiff.Chunk {
+	return p.chunks
+}
+
+type patchFile struct {
+	path string
+	hash plumbing.Hash
+}
+
+func (f *patchFile) Hash() plumbing.Hash {
+	return f.hash
+}
+
+func (f *patchFile) Mode() filemode.FileMode {
+	return filemode.Regular
+}
+
+func (f *patchFile) Path() string {
+	return f.path
+}
+
+type patchChunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+func (c *patchChunk) Content() string {
+	return c.content
+}
+
+func (c *patchChunk) Type() fdiff.Operation {
+	return c.op
+}
@@ -0,0 +1,8 @@
+package common
+
+import apimodels "github.com/keptn/go-utils/pkg/api/models"
+
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/event_publisher_mock.go . EventPublisher
+type EventPublisher interface {
+	Publish(event apimodels.KeptnContextExtendedCE) error
+}
@@ -10,23 +10,29 @@ import (
 
 // CredentialReaderMock is a mock implementation of common.CredentialReader.
 //
-// 	func TestSomethingThatUsesCredentialReader(t *testing.T) {
+//	func TestSomethingThatUsesCredentialReader(t *testing.T) {
 //
-// 		// make and configure a mocked common.CredentialReader
-// 		mockedCredentialReader := &CredentialReaderMock{
-// 			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
-// 				panic("mock out the GetCredentials method")
-// 			},
-// 		}
+//		// make and configure a mocked common.CredentialReader
+//		mockedCredentialReader := &CredentialReaderMock{
+//			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
+//				panic("mock out the GetCredentials method")
+//			},
+//			GetWebhookSecretFunc: func(project string) (string, error) {
+//				panic("mock out the GetWebhookSecret method")
+//			},
+//		}
 //
-// 		// use mockedCredentialReader in code that requires common.CredentialReader
-// 		// and then make assertions.
+//		// use mockedCredentialReader in code that requires common.CredentialReader
+//		// and then make assertions.
 //
-// 	}
+//	}
 type CredentialReaderMock struct {
 	// GetCredentialsFunc mocks the GetCredentials method.
 	GetCredentialsFunc func(project string) (*common_models.GitCredentials, error)
 
+	// GetWebhookSecretFunc mocks the GetWebhookSecret method.
+	GetWebhookSecretFunc func(project string) (string, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// GetCredentials holds details about calls to the GetCredentials method.
@@ -34,8 +40,14 @@ type CredentialReaderMock struct {
 			// Project is the project argument value.
 			Project string
 		}
+		// GetWebhookSecret holds details about calls to the GetWebhookSecret method.
+		GetWebhookSecret []struct {
+			// Project is the project argument value.
+			Project string
+		}
 	}
-	lockGetCredentials sync.RWMutex
+	lockGetCredentials   sync.RWMutex
+	lockGetWebhookSecret sync.RWMutex
 }
 
 // GetCredentials calls GetCredentialsFunc.
@@ -56,7 +68,8 @@ func (mock *CredentialReaderMock) GetCredentials(project string) (*common_models
 
 // GetCredentialsCalls gets all the calls that were made to GetCredentials.
 // Check the length with:
-//     len(mockedCredentialReader.GetCredentialsCalls())
+//
+//	len(mockedCredentialReader.GetCredentialsCalls())
 func (mock *CredentialReaderMock) GetCredentialsCalls() []struct {
 	Project string
 } {
@@ -68,3 +81,35 @@ func (mock *CredentialReaderMock) GetCredentialsCalls() []struct {
 	mock.lockGetCredentials.RUnlock()
 	return calls
 }
+
+// GetWebhookSecret calls GetWebhookSecretFunc.
+func (mock *CredentialReaderMock) GetWebhookSecret(project string) (string, error) {
+	if mock.GetWebhookSecretFunc == nil {
+		panic("CredentialReaderMock.GetWebhookSecretFunc: method is nil but CredentialReader.GetWebhookSecret was just called")
+	}
+	callInfo := struct {
+		Project string
+	}{
+		Project: project,
+	}
+	mock.lockGetWebhookSecret.Lock()
+	mock.calls.GetWebhookSecret = append(mock.calls.GetWebhookSecret, callInfo)
+	mock.lockGetWebhookSecret.Unlock()
+	return mock.GetWebhookSecretFunc(project)
+}
+
+// GetWebhookSecretCalls gets all the calls that were made to GetWebhookSecret.
+// Check the length with:
+//
+//	len(mockedCredentialReader.GetWebhookSecretCalls())
+func (mock *CredentialReaderMock) GetWebhookSecretCalls() []struct {
+	Project string
+} {
+	var calls []struct {
+		Project string
+	}
+	mock.lockGetWebhookSecret.RLock()
+	calls = mock.calls.GetWebhookSecret
+	mock.lockGetWebhookSecret.RUnlock()
+	return calls
+}
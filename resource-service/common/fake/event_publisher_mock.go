@@ -0,0 +1,71 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package common_mock
+
+import (
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"sync"
+)
+
+// EventPublisherMock is a mock implementation of common.EventPublisher.
+//
+//	func TestSomethingThatUsesEventPublisher(t *testing.T) {
+//
+//		// make and configure a mocked common.EventPublisher
+//		mockedEventPublisher := &EventPublisherMock{
+//			PublishFunc: func(event apimodels.KeptnContextExtendedCE) error {
+//				panic("mock out the Publish method")
+//			},
+//		}
+//
+//		// use mockedEventPublisher in code that requires common.EventPublisher
+//		// and then make assertions.
+//
+//	}
+type EventPublisherMock struct {
+	// PublishFunc mocks the Publish method.
+	PublishFunc func(event apimodels.KeptnContextExtendedCE) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Publish holds details about calls to the Publish method.
+		Publish []struct {
+			// Event is the event argument value.
+			Event apimodels.KeptnContextExtendedCE
+		}
+	}
+	lockPublish sync.RWMutex
+}
+
+// Publish calls PublishFunc.
+func (mock *EventPublisherMock) Publish(event apimodels.KeptnContextExtendedCE) error {
+	if mock.PublishFunc == nil {
+		panic("EventPublisherMock.PublishFunc: method is nil but EventPublisher.Publish was just called")
+	}
+	callInfo := struct {
+		Event apimodels.KeptnContextExtendedCE
+	}{
+		Event: event,
+	}
+	mock.lockPublish.Lock()
+	mock.calls.Publish = append(mock.calls.Publish, callInfo)
+	mock.lockPublish.Unlock()
+	return mock.PublishFunc(event)
+}
+
+// PublishCalls gets all the calls that were made to Publish.
+// Check the length with:
+//
+//	len(mockedEventPublisher.PublishCalls())
+func (mock *EventPublisherMock) PublishCalls() []struct {
+	Event apimodels.KeptnContextExtendedCE
+} {
+	var calls []struct {
+		Event apimodels.KeptnContextExtendedCE
+	}
+	mock.lockPublish.RLock()
+	calls = mock.calls.Publish
+	mock.lockPublish.RUnlock()
+	return calls
+}
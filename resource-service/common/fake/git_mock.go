@@ -10,55 +10,64 @@ import (
 
 // IGitMock is a mock implementation of common.IGit.
 //
-// 	func TestSomethingThatUsesIGit(t *testing.T) {
+//	func TestSomethingThatUsesIGit(t *testing.T) {
 //
-// 		// make and configure a mocked common.IGit
-// 		mockedIGit := &IGitMock{
-// 			CheckoutBranchFunc: func(gitContext common_models.GitContext, branch string) error {
-// 				panic("mock out the CheckoutBranch method")
-// 			},
-// 			CloneRepoFunc: func(gitContext common_models.GitContext) (bool, error) {
-// 				panic("mock out the CloneRepo method")
-// 			},
-// 			CreateBranchFunc: func(gitContext common_models.GitContext, branch string, sourceBranch string) error {
-// 				panic("mock out the CreateBranch method")
-// 			},
-// 			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) {
-// 				panic("mock out the GetCurrentRevision method")
-// 			},
-// 			GetDefaultBranchFunc: func(gitContext common_models.GitContext) (string, error) {
-// 				panic("mock out the GetDefaultBranch method")
-// 			},
-// 			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
-// 				panic("mock out the GetFileRevision method")
-// 			},
-// 			MigrateProjectFunc: func(gitContext common_models.GitContext, newMetadatacontent []byte) error {
-// 				panic("mock out the MigrateProject method")
-// 			},
-// 			ProjectExistsFunc: func(gitContext common_models.GitContext) bool {
-// 				panic("mock out the ProjectExists method")
-// 			},
-// 			ProjectRepoExistsFunc: func(projectName string) bool {
-// 				panic("mock out the ProjectRepoExists method")
-// 			},
-// 			PullFunc: func(gitContext common_models.GitContext) error {
-// 				panic("mock out the Pull method")
-// 			},
-// 			PushFunc: func(gitContext common_models.GitContext) error {
-// 				panic("mock out the Push method")
-// 			},
-// 			ResetHardFunc: func(gitContext common_models.GitContext) error {
-// 				panic("mock out the ResetHard method")
-// 			},
-// 			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) {
-// 				panic("mock out the StageAndCommitAll method")
-// 			},
-// 		}
+//		// make and configure a mocked common.IGit
+//		mockedIGit := &IGitMock{
+//			CheckoutBranchFunc: func(gitContext common_models.GitContext, branch string) error {
+//				panic("mock out the CheckoutBranch method")
+//			},
+//			CloneRepoFunc: func(gitContext common_models.GitContext) (bool, error) {
+//				panic("mock out the CloneRepo method")
+//			},
+//			CreateBranchFunc: func(gitContext common_models.GitContext, branch string, sourceBranch string) error {
+//				panic("mock out the CreateBranch method")
+//			},
+//			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) {
+//				panic("mock out the GetCurrentRevision method")
+//			},
+//			GetDefaultBranchFunc: func(gitContext common_models.GitContext) (string, error) {
+//				panic("mock out the GetDefaultBranch method")
+//			},
+//			GetFileDiffFunc: func(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+//				panic("mock out the GetFileDiff method")
+//			},
+//			GetFileHistoryFunc: func(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error) {
+//				panic("mock out the GetFileHistory method")
+//			},
+//			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+//				panic("mock out the GetFileRevision method")
+//			},
+//			MigrateProjectFunc: func(gitContext common_models.GitContext, newMetadatacontent []byte) error {
+//				panic("mock out the MigrateProject method")
+//			},
+//			ProjectExistsFunc: func(gitContext common_models.GitContext) bool {
+//				panic("mock out the ProjectExists method")
+//			},
+//			ProjectRepoExistsFunc: func(projectName string) bool {
+//				panic("mock out the ProjectRepoExists method")
+//			},
+//			PullFunc: func(gitContext common_models.GitContext) error {
+//				panic("mock out the Pull method")
+//			},
+//			PushFunc: func(gitContext common_models.GitContext) error {
+//				panic("mock out the Push method")
+//			},
+//			ResetHardFunc: func(gitContext common_models.GitContext, revision string) error {
+//				panic("mock out the ResetHard method")
+//			},
+//			ResolveRevisionFunc: func(gitContext common_models.GitContext, revision string) (string, error) {
+//				panic("mock out the ResolveRevision method")
+//			},
+//			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) {
+//				panic("mock out the StageAndCommitAll method")
+//			},
+//		}
 //
-// 		// use mockedIGit in code that requires common.IGit
-// 		// and then make assertions.
+//		// use mockedIGit in code that requires common.IGit
+//		// and then make assertions.
 //
-// 	}
+//	}
 type IGitMock struct {
 	// CheckoutBranchFunc mocks the CheckoutBranch method.
 	CheckoutBranchFunc func(gitContext common_models.GitContext, branch string) error
@@ -75,6 +84,12 @@ type IGitMock struct {
 	// GetDefaultBranchFunc mocks the GetDefaultBranch method.
 	GetDefaultBranchFunc func(gitContext common_models.GitContext) (string, error)
 
+	// GetFileDiffFunc mocks the GetFileDiff method.
+	GetFileDiffFunc func(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error)
+
+	// GetFileHistoryFunc mocks the GetFileHistory method.
+	GetFileHistoryFunc func(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error)
+
 	// GetFileRevisionFunc mocks the GetFileRevision method.
 	GetFileRevisionFunc func(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
 
@@ -94,7 +109,10 @@ type IGitMock struct {
 	PushFunc func(gitContext common_models.GitContext) error
 
 	// ResetHardFunc mocks the ResetHard method.
-	ResetHardFunc func(gitContext common_models.GitContext) error
+	ResetHardFunc func(gitContext common_models.GitContext, revision string) error
+
+	// ResolveRevisionFunc mocks the ResolveRevision method.
+	ResolveRevisionFunc func(gitContext common_models.GitContext, revision string) (string, error)
 
 	// StageAndCommitAllFunc mocks the StageAndCommitAll method.
 	StageAndCommitAllFunc func(gitContext common_models.GitContext, message string) (string, error)
@@ -132,6 +150,24 @@ type IGitMock struct {
 			// GitContext is the gitContext argument value.
 			GitContext common_models.GitContext
 		}
+		// GetFileDiff holds details about calls to the GetFileDiff method.
+		GetFileDiff []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// FromRevision is the fromRevision argument value.
+			FromRevision string
+			// ToRevision is the toRevision argument value.
+			ToRevision string
+			// File is the file argument value.
+			File string
+		}
+		// GetFileHistory holds details about calls to the GetFileHistory method.
+		GetFileHistory []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// File is the file argument value.
+			File string
+		}
 		// GetFileRevision holds details about calls to the GetFileRevision method.
 		GetFileRevision []struct {
 			// GitContext is the gitContext argument value.
@@ -172,6 +208,15 @@ type IGitMock struct {
 		ResetHard []struct {
 			// GitContext is the gitContext argument value.
 			GitContext common_models.GitContext
+			// Revision is the revision argument value.
+			Revision string
+		}
+		// ResolveRevision holds details about calls to the ResolveRevision method.
+		ResolveRevision []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Revision is the revision argument value.
+			Revision string
 		}
 		// StageAndCommitAll holds details about calls to the StageAndCommitAll method.
 		StageAndCommitAll []struct {
@@ -186,6 +231,8 @@ type IGitMock struct {
 	lockCreateBranch       sync.RWMutex
 	lockGetCurrentRevision sync.RWMutex
 	lockGetDefaultBranch   sync.RWMutex
+	lockGetFileDiff        sync.RWMutex
+	lockGetFileHistory     sync.RWMutex
 	lockGetFileRevision    sync.RWMutex
 	lockMigrateProject     sync.RWMutex
 	lockProjectExists      sync.RWMutex
@@ -193,6 +240,7 @@ type IGitMock struct {
 	lockPull               sync.RWMutex
 	lockPush               sync.RWMutex
 	lockResetHard          sync.RWMutex
+	lockResolveRevision    sync.RWMutex
 	lockStageAndCommitAll  sync.RWMutex
 }
 
@@ -216,7 +264,8 @@ func (mock *IGitMock) CheckoutBranch(gitContext common_models.GitContext, branch
 
 // CheckoutBranchCalls gets all the calls that were made to CheckoutBranch.
 // Check the length with:
-//     len(mockedIGit.CheckoutBranchCalls())
+//
+//	len(mockedIGit.CheckoutBranchCalls())
 func (mock *IGitMock) CheckoutBranchCalls() []struct {
 	GitContext common_models.GitContext
 	Branch     string
@@ -249,7 +298,8 @@ func (mock *IGitMock) CloneRepo(gitContext common_models.GitContext) (bool, erro
 
 // CloneRepoCalls gets all the calls that were made to CloneRepo.
 // Check the length with:
-//     len(mockedIGit.CloneRepoCalls())
+//
+//	len(mockedIGit.CloneRepoCalls())
 func (mock *IGitMock) CloneRepoCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -284,7 +334,8 @@ func (mock *IGitMock) CreateBranch(gitContext common_models.GitContext, branch s
 
 // CreateBranchCalls gets all the calls that were made to CreateBranch.
 // Check the length with:
-//     len(mockedIGit.CreateBranchCalls())
+//
+//	len(mockedIGit.CreateBranchCalls())
 func (mock *IGitMock) CreateBranchCalls() []struct {
 	GitContext   common_models.GitContext
 	Branch       string
@@ -319,7 +370,8 @@ func (mock *IGitMock) GetCurrentRevision(gitContext common_models.GitContext) (s
 
 // GetCurrentRevisionCalls gets all the calls that were made to GetCurrentRevision.
 // Check the length with:
-//     len(mockedIGit.GetCurrentRevisionCalls())
+//
+//	len(mockedIGit.GetCurrentRevisionCalls())
 func (mock *IGitMock) GetCurrentRevisionCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -350,7 +402,8 @@ func (mock *IGitMock) GetDefaultBranch(gitContext common_models.GitContext) (str
 
 // GetDefaultBranchCalls gets all the calls that were made to GetDefaultBranch.
 // Check the length with:
-//     len(mockedIGit.GetDefaultBranchCalls())
+//
+//	len(mockedIGit.GetDefaultBranchCalls())
 func (mock *IGitMock) GetDefaultBranchCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -363,6 +416,86 @@ func (mock *IGitMock) GetDefaultBranchCalls() []struct {
 	return calls
 }
 
+// GetFileDiff calls GetFileDiffFunc.
+func (mock *IGitMock) GetFileDiff(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+	if mock.GetFileDiffFunc == nil {
+		panic("IGitMock.GetFileDiffFunc: method is nil but IGit.GetFileDiff was just called")
+	}
+	callInfo := struct {
+		GitContext   common_models.GitContext
+		FromRevision string
+		ToRevision   string
+		File         string
+	}{
+		GitContext:   gitContext,
+		FromRevision: fromRevision,
+		ToRevision:   toRevision,
+		File:         file,
+	}
+	mock.lockGetFileDiff.Lock()
+	mock.calls.GetFileDiff = append(mock.calls.GetFileDiff, callInfo)
+	mock.lockGetFileDiff.Unlock()
+	return mock.GetFileDiffFunc(gitContext, fromRevision, toRevision, file)
+}
+
+// GetFileDiffCalls gets all the calls that were made to GetFileDiff.
+// Check the length with:
+//
+//	len(mockedIGit.GetFileDiffCalls())
+func (mock *IGitMock) GetFileDiffCalls() []struct {
+	GitContext   common_models.GitContext
+	FromRevision string
+	ToRevision   string
+	File         string
+} {
+	var calls []struct {
+		GitContext   common_models.GitContext
+		FromRevision string
+		ToRevision   string
+		File         string
+	}
+	mock.lockGetFileDiff.RLock()
+	calls = mock.calls.GetFileDiff
+	mock.lockGetFileDiff.RUnlock()
+	return calls
+}
+
+// GetFileHistory calls GetFileHistoryFunc.
+func (mock *IGitMock) GetFileHistory(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error) {
+	if mock.GetFileHistoryFunc == nil {
+		panic("IGitMock.GetFileHistoryFunc: method is nil but IGit.GetFileHistory was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		File       string
+	}{
+		GitContext: gitContext,
+		File:       file,
+	}
+	mock.lockGetFileHistory.Lock()
+	mock.calls.GetFileHistory = append(mock.calls.GetFileHistory, callInfo)
+	mock.lockGetFileHistory.Unlock()
+	return mock.GetFileHistoryFunc(gitContext, file)
+}
+
+// GetFileHistoryCalls gets all the calls that were made to GetFileHistory.
+// Check the length with:
+//
+//	len(mockedIGit.GetFileHistoryCalls())
+func (mock *IGitMock) GetFileHistoryCalls() []struct {
+	GitContext common_models.GitContext
+	File       string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		File       string
+	}
+	mock.lockGetFileHistory.RLock()
+	calls = mock.calls.GetFileHistory
+	mock.lockGetFileHistory.RUnlock()
+	return calls
+}
+
 // GetFileRevision calls GetFileRevisionFunc.
 func (mock *IGitMock) GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
 	if mock.GetFileRevisionFunc == nil {
@@ -385,7 +518,8 @@ func (mock *IGitMock) GetFileRevision(gitContext common_models.GitContext, revis
 
 // GetFileRevisionCalls gets all the calls that were made to GetFileRevision.
 // Check the length with:
-//     len(mockedIGit.GetFileRevisionCalls())
+//
+//	len(mockedIGit.GetFileRevisionCalls())
 func (mock *IGitMock) GetFileRevisionCalls() []struct {
 	GitContext common_models.GitContext
 	Revision   string
@@ -422,7 +556,8 @@ func (mock *IGitMock) MigrateProject(gitContext common_models.GitContext, newMet
 
 // MigrateProjectCalls gets all the calls that were made to MigrateProject.
 // Check the length with:
-//     len(mockedIGit.MigrateProjectCalls())
+//
+//	len(mockedIGit.MigrateProjectCalls())
 func (mock *IGitMock) MigrateProjectCalls() []struct {
 	GitContext         common_models.GitContext
 	NewMetadatacontent []byte
@@ -455,7 +590,8 @@ func (mock *IGitMock) ProjectExists(gitContext common_models.GitContext) bool {
 
 // ProjectExistsCalls gets all the calls that were made to ProjectExists.
 // Check the length with:
-//     len(mockedIGit.ProjectExistsCalls())
+//
+//	len(mockedIGit.ProjectExistsCalls())
 func (mock *IGitMock) ProjectExistsCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -486,7 +622,8 @@ func (mock *IGitMock) ProjectRepoExists(projectName string) bool {
 
 // ProjectRepoExistsCalls gets all the calls that were made to ProjectRepoExists.
 // Check the length with:
-//     len(mockedIGit.ProjectRepoExistsCalls())
+//
+//	len(mockedIGit.ProjectRepoExistsCalls())
 func (mock *IGitMock) ProjectRepoExistsCalls() []struct {
 	ProjectName string
 } {
@@ -517,7 +654,8 @@ func (mock *IGitMock) Pull(gitContext common_models.GitContext) error {
 
 // PullCalls gets all the calls that were made to Pull.
 // Check the length with:
-//     len(mockedIGit.PullCalls())
+//
+//	len(mockedIGit.PullCalls())
 func (mock *IGitMock) PullCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -548,7 +686,8 @@ func (mock *IGitMock) Push(gitContext common_models.GitContext) error {
 
 // PushCalls gets all the calls that were made to Push.
 // Check the length with:
-//     len(mockedIGit.PushCalls())
+//
+//	len(mockedIGit.PushCalls())
 func (mock *IGitMock) PushCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -568,23 +707,28 @@ func (mock *IGitMock) ResetHard(gitContext common_models.GitContext, revision st
 	}
 	callInfo := struct {
 		GitContext common_models.GitContext
+		Revision   string
 	}{
 		GitContext: gitContext,
+		Revision:   revision,
 	}
 	mock.lockResetHard.Lock()
 	mock.calls.ResetHard = append(mock.calls.ResetHard, callInfo)
 	mock.lockResetHard.Unlock()
-	return mock.ResetHardFunc(gitContext)
+	return mock.ResetHardFunc(gitContext, revision)
 }
 
 // ResetHardCalls gets all the calls that were made to ResetHard.
 // Check the length with:
-//     len(mockedIGit.ResetHardCalls())
+//
+//	len(mockedIGit.ResetHardCalls())
 func (mock *IGitMock) ResetHardCalls() []struct {
 	GitContext common_models.GitContext
+	Revision   string
 } {
 	var calls []struct {
 		GitContext common_models.GitContext
+		Revision   string
 	}
 	mock.lockResetHard.RLock()
 	calls = mock.calls.ResetHard
@@ -592,6 +736,42 @@ func (mock *IGitMock) ResetHardCalls() []struct {
 	return calls
 }
 
+// ResolveRevision calls ResolveRevisionFunc.
+func (mock *IGitMock) ResolveRevision(gitContext common_models.GitContext, revision string) (string, error) {
+	if mock.ResolveRevisionFunc == nil {
+		panic("IGitMock.ResolveRevisionFunc: method is nil but IGit.ResolveRevision was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Revision   string
+	}{
+		GitContext: gitContext,
+		Revision:   revision,
+	}
+	mock.lockResolveRevision.Lock()
+	mock.calls.ResolveRevision = append(mock.calls.ResolveRevision, callInfo)
+	mock.lockResolveRevision.Unlock()
+	return mock.ResolveRevisionFunc(gitContext, revision)
+}
+
+// ResolveRevisionCalls gets all the calls that were made to ResolveRevision.
+// Check the length with:
+//
+//	len(mockedIGit.ResolveRevisionCalls())
+func (mock *IGitMock) ResolveRevisionCalls() []struct {
+	GitContext common_models.GitContext
+	Revision   string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Revision   string
+	}
+	mock.lockResolveRevision.RLock()
+	calls = mock.calls.ResolveRevision
+	mock.lockResolveRevision.RUnlock()
+	return calls
+}
+
 // StageAndCommitAll calls StageAndCommitAllFunc.
 func (mock *IGitMock) StageAndCommitAll(gitContext common_models.GitContext, message string) (string, error) {
 	if mock.StageAndCommitAllFunc == nil {
@@ -612,7 +792,8 @@ func (mock *IGitMock) StageAndCommitAll(gitContext common_models.GitContext, mes
 
 // StageAndCommitAllCalls gets all the calls that were made to StageAndCommitAll.
 // Check the length with:
-//     len(mockedIGit.StageAndCommitAllCalls())
+//
+//	len(mockedIGit.StageAndCommitAllCalls())
 func (mock *IGitMock) StageAndCommitAllCalls() []struct {
 	GitContext common_models.GitContext
 	Message    string
@@ -0,0 +1,69 @@
+package common_mock
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+)
+
+// InMemoryObjectStore is a stand-in for an S3 compatible object store like MinIO, which keeps all objects in memory
+type InMemoryObjectStore struct {
+	mutex   sync.Mutex
+	Objects map[string][]byte
+	// Err is returned when reading an object, if set, e.g. to simulate an object store that is not reachable
+	Err error
+}
+
+func NewInMemoryObjectStore() *InMemoryObjectStore {
+	return &InMemoryObjectStore{Objects: map[string][]byte{}}
+}
+
+func (s *InMemoryObjectStore) PutObject(key string, content []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.Objects[key] = append([]byte{}, content...)
+	return nil
+}
+
+func (s *InMemoryObjectStore) GetObject(key string) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	content, ok := s.Objects[key]
+	if !ok {
+		return nil, kerrors.ErrObjectNotFound
+	}
+	return append([]byte{}, content...), nil
+}
+
+func (s *InMemoryObjectStore) ListObjects(prefix string) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	keys := []string{}
+	for key := range s.Objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *InMemoryObjectStore) DeleteObjects(prefix string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for key := range s.Objects {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.Objects, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package common_mock
+
+import (
+	"github.com/keptn/keptn/resource-service/models"
+	"sync"
+)
+
+// IResourceValidatorMock is a mock implementation of common.IResourceValidator.
+//
+//	func TestSomethingThatUsesIResourceValidator(t *testing.T) {
+//
+//		// make and configure a mocked common.IResourceValidator
+//		mockedIResourceValidator := &IResourceValidatorMock{
+//			ValidateFunc: func(resources []models.Resource) error {
+//				panic("mock out the Validate method")
+//			},
+//		}
+//
+//		// use mockedIResourceValidator in code that requires common.IResourceValidator
+//		// and then make assertions.
+//
+//	}
+type IResourceValidatorMock struct {
+	// ValidateFunc mocks the Validate method.
+	ValidateFunc func(resources []models.Resource) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Validate holds details about calls to the Validate method.
+		Validate []struct {
+			// Resources is the resources argument value.
+			Resources []models.Resource
+		}
+	}
+	lockValidate sync.RWMutex
+}
+
+// Validate calls ValidateFunc.
+func (mock *IResourceValidatorMock) Validate(resources []models.Resource) error {
+	if mock.ValidateFunc == nil {
+		panic("IResourceValidatorMock.ValidateFunc: method is nil but IResourceValidator.Validate was just called")
+	}
+	callInfo := struct {
+		Resources []models.Resource
+	}{
+		Resources: resources,
+	}
+	mock.lockValidate.Lock()
+	mock.calls.Validate = append(mock.calls.Validate, callInfo)
+	mock.lockValidate.Unlock()
+	return mock.ValidateFunc(resources)
+}
+
+// ValidateCalls gets all the calls that were made to Validate.
+// Check the length with:
+//
+//	len(mockedIResourceValidator.ValidateCalls())
+func (mock *IResourceValidatorMock) ValidateCalls() []struct {
+	Resources []models.Resource
+} {
+	var calls []struct {
+		Resources []models.Resource
+	}
+	mock.lockValidate.RLock()
+	calls = mock.calls.Validate
+	mock.lockValidate.RUnlock()
+	return calls
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"bytes"
 	"crypto/tls"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/client"
@@ -38,6 +40,9 @@ type IGit interface {
 	CreateBranch(gitContext common_models.GitContext, branch string, sourceBranch string) error
 	CheckoutBranch(gitContext common_models.GitContext, branch string) error
 	GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
+	ResolveRevision(gitContext common_models.GitContext, revision string) (string, error)
+	GetFileHistory(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error)
+	GetFileDiff(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error)
 	GetCurrentRevision(gitContext common_models.GitContext) (string, error)
 	GetDefaultBranch(gitContext common_models.GitContext) (string, error)
 	MigrateProject(gitContext common_models.GitContext, newMetadatacontent []byte) error
@@ -499,6 +504,22 @@ func (g *Git) fetch(gitContext common_models.GitContext, r *git.Repository) erro
 	return nil
 }
 
+// ResolveRevision returns the hash of the commit a revision, e.g. a branch, an abbreviated hash or HEAD~1, refers to in
+// the local repository of the project. The upstream is not contacted, hence revisions that have not been fetched yet
+// cannot be resolved
+func (g *Git) ResolveRevision(gitContext common_models.GitContext, revision string) (string, error) {
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	h, err := r.ResolveRevision(plumbing.Revision(revision))
+	if err != nil || h == nil {
+		logger.Debugf("Could not resolve revision %s: %v", revision, err)
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "resolve revision in", gitContext.Project, kerrors.ErrResolveRevision)
+	}
+	return h.String(), nil
+}
+
 func (g *Git) GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
 	path := GetProjectConfigPath(gitContext.Project)
 	r, err := g.git.PlainOpen(path)
@@ -549,6 +570,91 @@ func (g *Git) GetFileRevision(gitContext common_models.GitContext, revision stri
 	return ioutil.ReadAll(re)
 }
 
+// GetFileHistory returns the commits that changed the given file, starting with the most recent one
+func (g *Git) GetFileHistory(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error) {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "read history of", gitContext.Project, err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "read history of", gitContext.Project, err)
+	}
+	commits, err := r.Log(&git.LogOptions{From: head.Hash(), FileName: &file})
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "read history of", gitContext.Project, err)
+	}
+	defer commits.Close()
+
+	history := []common_models.GitCommit{}
+	err = commits.ForEach(func(c *object.Commit) error {
+		history = append(history, common_models.GitCommit{
+			ID:        c.Hash.String(),
+			Author:    c.Author.Name,
+			Message:   strings.TrimSpace(c.Message),
+			Timestamp: c.Author.When,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "read history of", gitContext.Project, err)
+	}
+	if len(history) == 0 {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "read history of", gitContext.Project, kerrors.ErrResourceNotFound)
+	}
+	return history, nil
+}
+
+// GetFileDiff returns the unified diff of the given file between two revisions.
+// If toRevision is empty, the diff is computed against the current HEAD
+func (g *Git) GetFileDiff(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+	}
+	if toRevision == "" {
+		toRevision = string(plumbing.HEAD)
+	}
+	fromTree, err := resolveTree(r, fromRevision)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+	}
+	toTree, err := resolveTree(r, toRevision)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+	}
+
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+	}
+	fileChanges := object.Changes{}
+	for _, change := range changes {
+		if change.From.Name == file || change.To.Name == file {
+			fileChanges = append(fileChanges, change)
+		}
+	}
+	if len(fileChanges) == 0 {
+		_, fromErr := fromTree.File(file)
+		_, toErr := toTree.File(file)
+		if fromErr != nil && toErr != nil {
+			return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, kerrors.ErrResourceNotFound)
+		}
+		// the file exists but has not been changed between the two revisions
+		return "", nil
+	}
+
+	patch, err := fileChanges.Patch()
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+	}
+	buf := &bytes.Buffer{}
+	if err := diff.NewUnifiedEncoder(buf, diff.DefaultContextLines).Encode(patch); err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+	}
+	return buf.String(), nil
+}
+
 func (g *Git) GetDefaultBranch(gitContext common_models.GitContext) (string, error) {
 	r, _, err := g.getWorkTree(gitContext)
 	if err != nil {
@@ -767,3 +873,16 @@ func resolve(obj object.Object, path string) (*object.Blob, error) {
 		return nil, object.ErrUnsupportedObject
 	}
 }
+
+func resolveTree(r *git.Repository, revision string) (*object.Tree, error) {
+	h, err := r.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		logger.Debugf("Could not resolve revision %s: %s", revision, err)
+		return nil, kerrors.ErrResolveRevision
+	}
+	commit, err := r.CommitObject(*h)
+	if err != nil {
+		return nil, err
+	}
+	return commit.Tree()
+}
@@ -858,6 +858,53 @@ func (s *BaseSuite) TestGit_GetFileRevision(c *C) {
 	}
 }
 
+func (s *BaseSuite) TestGit_GetFileHistory(c *C) {
+	g := NewGit(s.NewTestGit())
+
+	first := s.commitAndPush("foo/history.yaml", "version: 1", c)
+	s.commitAndPush("foo/other.yaml", "unrelated", c)
+	second := s.commitAndPush("foo/history.yaml", "version: 2", c)
+
+	history, err := g.GetFileHistory(s.NewGitContext(), "foo/history.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(history, HasLen, 2)
+	c.Assert(history[0].ID, Equals, second.String())
+	c.Assert(history[0].Author, Equals, "Test Create Branch")
+	c.Assert(history[0].Message, Equals, "added a file")
+	c.Assert(history[1].ID, Equals, first.String())
+
+	_, err = g.GetFileHistory(s.NewGitContext(), "foo/not-existing.yaml")
+	c.Assert(errors.Is(err, kerrors.ErrResourceNotFound), Equals, true)
+}
+
+func (s *BaseSuite) TestGit_GetFileDiff(c *C) {
+	g := NewGit(s.NewTestGit())
+
+	first := s.commitAndPush("foo/diff.yaml", "line: 1\n", c)
+	second := s.commitAndPush("foo/diff.yaml", "line: 2\n", c)
+
+	diff, err := g.GetFileDiff(s.NewGitContext(), first.String(), second.String(), "foo/diff.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(diff, "--- a/foo/diff.yaml"), Equals, true)
+	c.Assert(strings.Contains(diff, "-line: 1"), Equals, true)
+	c.Assert(strings.Contains(diff, "+line: 2"), Equals, true)
+
+	// an empty target revision compares against HEAD
+	diff, err = g.GetFileDiff(s.NewGitContext(), first.String(), "", "foo/diff.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(diff, "+line: 2"), Equals, true)
+
+	diff, err = g.GetFileDiff(s.NewGitContext(), second.String(), second.String(), "foo/diff.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(diff, Equals, "")
+
+	_, err = g.GetFileDiff(s.NewGitContext(), first.String(), second.String(), "foo/not-existing.yaml")
+	c.Assert(errors.Is(err, kerrors.ErrResourceNotFound), Equals, true)
+
+	_, err = g.GetFileDiff(s.NewGitContext(), "unknown-revision", second.String(), "foo/diff.yaml")
+	c.Assert(errors.Is(err, kerrors.ErrResolveRevision), Equals, true)
+}
+
 func (s *BaseSuite) TestGit_MigrateProject(c *C) {
 	g := NewGit(GogitReal{})
 
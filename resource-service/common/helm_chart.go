@@ -0,0 +1,94 @@
+package common
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// UnpackHelmChart extracts the files of a packaged Helm chart, i.e. a .tgz archive containing a single chart
+// directory. The paths of the returned files are relative to the chart directory
+func UnpackHelmChart(content []byte) (map[string][]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("could not unarchive Helm chart: %w", err)
+	}
+	defer gzipReader.Close()
+
+	files := map[string][]byte{}
+	chartDir := ""
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("could not unarchive Helm chart: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := strings.TrimPrefix(path.Clean(header.Name), "/")
+		dir, file, found := strings.Cut(name, "/")
+		if !found || strings.HasPrefix(name, "../") {
+			return nil, errors.New("unexpected amount of unpacked files")
+		}
+		if chartDir == "" {
+			chartDir = dir
+		} else if dir != chartDir {
+			return nil, errors.New("unexpected amount of unpacked files")
+		}
+
+		fileContent, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("could not unarchive Helm chart: %w", err)
+		}
+		files[file] = fileContent
+	}
+	if len(files) == 0 {
+		return nil, errors.New("unexpected amount of unpacked files")
+	}
+	return files, nil
+}
+
+// PackHelmChart packages the files of a Helm chart into a .tgz archive containing the chart directory with the
+// given name. The paths of the files are relative to the chart directory
+func PackHelmChart(chartName string, files map[string][]byte) ([]byte, error) {
+	paths := []string{}
+	for filePath := range files {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+
+	buffer := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, filePath := range paths {
+		header := &tar.Header{
+			Name:     chartName + "/" + filePath,
+			Mode:     0644,
+			Size:     int64(len(files[filePath])),
+			Typeflag: tar.TypeReg,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return nil, fmt.Errorf("could not archive Helm chart: %w", err)
+		}
+		if _, err := tarWriter.Write(files[filePath]); err != nil {
+			return nil, fmt.Errorf("could not archive Helm chart: %w", err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, fmt.Errorf("could not archive Helm chart: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("could not archive Helm chart: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
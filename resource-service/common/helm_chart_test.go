@@ -0,0 +1,29 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackAndUnpackHelmChart(t *testing.T) {
+	files := map[string][]byte{
+		"Chart.yaml":                []byte("name: carts"),
+		"templates/deployment.yaml": []byte("kind: Deployment"),
+	}
+	chart, err := PackHelmChart("carts", files)
+	require.Nil(t, err)
+
+	unpacked, err := UnpackHelmChart(chart)
+	require.Nil(t, err)
+	require.Equal(t, files, unpacked)
+
+	_, err = UnpackHelmChart([]byte("not a chart"))
+	require.NotNil(t, err)
+
+	// the files of the chart must be contained in a single directory
+	chart, err = PackHelmChart("", files)
+	require.Nil(t, err)
+	_, err = UnpackHelmChart(chart)
+	require.EqualError(t, err, "unexpected amount of unpacked files")
+}
@@ -0,0 +1,304 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+)
+
+const objectStorageHeadKey = "HEAD"
+
+// ObjectRevision is the state of all files of a project at a given revision
+type ObjectRevision struct {
+	Revision  string    `json:"revision"`
+	Message   string    `json:"message"`
+	Author    string    `json:"author"`
+	Timestamp time.Time `json:"timestamp"`
+	// Files maps the path of each file to the hash of its content
+	Files map[string]string `json:"files"`
+}
+
+// DirExists checks whether the revision contains any file within the given directory
+func (r ObjectRevision) DirExists(dir string) bool {
+	if dir == "" {
+		return true
+	}
+	for path := range r.Files {
+		if strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// ListFiles returns the sorted paths of all files within the given directory, relative to the directory
+func (r ObjectRevision) ListFiles(dir string) []string {
+	prefix := ""
+	if dir != "" {
+		prefix = dir + "/"
+	}
+	files := []string{}
+	for path := range r.Files {
+		if strings.HasPrefix(path, prefix) {
+			files = append(files, strings.TrimPrefix(path, prefix))
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// ObjectChange describes the modification of a file. If Delete is set, the file or all files within the directory
+// at the given path are removed
+type ObjectChange struct {
+	Path    string
+	Content []byte
+	Delete  bool
+}
+
+// ObjectStorage stores versioned resources of projects that are not backed by a git repository in an object store.
+// Each commit creates a new revision record containing the content hashes of all files of the project. Revisions are
+// identified by monotonically increasing numbers, which take the place of commit IDs. Since commits are a
+// read-modify-write of the current revision, callers need to hold the project lock while committing.
+//
+// The objects of a project are stored with the following keys:
+//
+//	<project>/HEAD                     current revision number
+//	<project>/revisions/<revision>     revision record, revision number is zero padded to keep the keys sorted
+//	<project>/blobs/<hash>             file content, addressed by its git blob hash
+type ObjectStorage struct {
+	store IObjectStore
+	now   func() time.Time
+}
+
+func NewObjectStorage(store IObjectStore) *ObjectStorage {
+	return &ObjectStorage{store: store, now: time.Now}
+}
+
+// ProjectExists checks whether the given project is stored in the object storage. Errors of the object store, e.g. if
+// it is not reachable, are returned, since they do not tell whether the project exists
+func (s ObjectStorage) ProjectExists(project string) (bool, error) {
+	_, err := s.store.GetObject(objectStorageKey(project, objectStorageHeadKey))
+	if errors.Is(err, kerrors.ErrObjectNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("could not check whether project %s exists: %w", project, err)
+	}
+	return true, nil
+}
+
+// CreateProject creates the first revision of a project containing the given files
+func (s ObjectStorage) CreateProject(project, message string, files map[string][]byte) (string, error) {
+	exists, err := s.ProjectExists(project)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return "", kerrors.ErrProjectAlreadyExists
+	}
+	changes := []ObjectChange{}
+	for path, content := range files {
+		changes = append(changes, ObjectChange{Path: path, Content: content})
+	}
+	return s.commit(project, &ObjectRevision{Revision: "0", Files: map[string]string{}}, message, changes)
+}
+
+// DeleteProject removes all revisions and files of a project
+func (s ObjectStorage) DeleteProject(project string) error {
+	if err := s.store.DeleteObjects(project + "/"); err != nil {
+		return fmt.Errorf("could not delete project %s: %w", project, err)
+	}
+	return nil
+}
+
+// GetRevision returns the given revision of a project. If the revision is empty, the current revision is returned
+func (s ObjectStorage) GetRevision(project, revision string) (*ObjectRevision, error) {
+	if revision == "" {
+		head, err := s.store.GetObject(objectStorageKey(project, objectStorageHeadKey))
+		if errors.Is(err, kerrors.ErrObjectNotFound) {
+			return nil, kerrors.ErrProjectNotFound
+		} else if err != nil {
+			return nil, err
+		}
+		revision = string(head)
+	}
+
+	revisionNumber, err := strconv.ParseUint(revision, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not read revision %s of project %s: %w", revision, project, kerrors.ErrResolveRevision)
+	}
+	content, err := s.store.GetObject(objectRevisionKey(project, revisionNumber))
+	if errors.Is(err, kerrors.ErrObjectNotFound) {
+		return nil, fmt.Errorf("could not read revision %s of project %s: %w", revision, project, kerrors.ErrResolveRevision)
+	} else if err != nil {
+		return nil, err
+	}
+
+	result := &ObjectRevision{}
+	if err := json.Unmarshal(content, result); err != nil {
+		return nil, fmt.Errorf("could not decode revision %s of project %s: %w", revision, project, err)
+	}
+	return result, nil
+}
+
+// ReadFile returns the content of a file at the given revision
+func (s ObjectStorage) ReadFile(project string, revision *ObjectRevision, path string) ([]byte, error) {
+	hash, ok := revision.Files[path]
+	if !ok {
+		return nil, kerrors.ErrResourceNotFound
+	}
+	content, err := s.store.GetObject(blobKey(project, hash))
+	if err != nil {
+		return nil, fmt.Errorf("could not read file %s of project %s: %w", path, project, err)
+	}
+	return content, nil
+}
+
+// Commit applies the given changes to the current revision of a project and stores the result as new revision
+func (s ObjectStorage) Commit(project, message string, changes []ObjectChange) (string, error) {
+	current, err := s.GetRevision(project, "")
+	if err != nil {
+		return "", err
+	}
+	return s.commit(project, current, message, changes)
+}
+
+// GetFileHistory returns the revisions that changed the given file, starting with the most recent one.
+// This requires reading all revision records of the project
+func (s ObjectStorage) GetFileHistory(project, path string) ([]common_models.GitCommit, error) {
+	revision, err := s.GetRevision(project, "")
+	if err != nil {
+		return nil, err
+	}
+
+	history := []common_models.GitCommit{}
+	for {
+		// the first revision is compared against an empty project
+		previous := &ObjectRevision{}
+		revisionNumber, _ := strconv.ParseUint(revision.Revision, 10, 64)
+		if revisionNumber > 1 {
+			previous, err = s.GetRevision(project, strconv.FormatUint(revisionNumber-1, 10))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if revision.Files[path] != previous.Files[path] {
+			history = append(history, common_models.GitCommit{
+				ID:        revision.Revision,
+				Author:    revision.Author,
+				Message:   revision.Message,
+				Timestamp: revision.Timestamp,
+			})
+		}
+		if revisionNumber <= 1 {
+			break
+		}
+		revision = previous
+	}
+	if len(history) == 0 {
+		return nil, kerrors.ErrResourceNotFound
+	}
+	return history, nil
+}
+
+// GetFileDiff returns the unified diff of the given file between two revisions.
+// If toRevision is empty, the diff is computed against the current revision
+func (s ObjectStorage) GetFileDiff(project, fromRevision, toRevision, path string) (string, error) {
+	from, err := s.GetRevision(project, fromRevision)
+	if err != nil {
+		return "", err
+	}
+	to, err := s.GetRevision(project, toRevision)
+	if err != nil {
+		return "", err
+	}
+
+	fromHash, fromExists := from.Files[path]
+	toHash, toExists := to.Files[path]
+	if !fromExists && !toExists {
+		return "", kerrors.ErrResourceNotFound
+	}
+	if fromHash == toHash {
+		// the file exists but has not been changed between the two revisions
+		return "", nil
+	}
+
+	var fromContent, toContent []byte
+	if fromExists {
+		if fromContent, err = s.ReadFile(project, from, path); err != nil {
+			return "", err
+		}
+	}
+	if toExists {
+		if toContent, err = s.ReadFile(project, to, path); err != nil {
+			return "", err
+		}
+	}
+	return UnifiedDiff(path, fromContent, toContent)
+}
+
+func (s ObjectStorage) commit(project string, current *ObjectRevision, message string, changes []ObjectChange) (string, error) {
+	currentNumber, err := strconv.ParseUint(current.Revision, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("could not commit changes in project %s: %w", project, kerrors.ErrResolveRevision)
+	}
+
+	next := &ObjectRevision{
+		Revision:  strconv.FormatUint(currentNumber+1, 10),
+		Message:   message,
+		Author:    gitKeptnUserDefault,
+		Timestamp: s.now().UTC(),
+		Files:     map[string]string{},
+	}
+	for path, hash := range current.Files {
+		next.Files[path] = hash
+	}
+
+	for _, change := range changes {
+		if change.Delete {
+			for path := range next.Files {
+				if path == change.Path || strings.HasPrefix(path, change.Path+"/") {
+					delete(next.Files, path)
+				}
+			}
+			continue
+		}
+		hash := plumbing.ComputeHash(plumbing.BlobObject, change.Content).String()
+		if err := s.store.PutObject(blobKey(project, hash), change.Content); err != nil {
+			return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, project, err)
+		}
+		next.Files[change.Path] = hash
+	}
+
+	content, err := json.Marshal(next)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, project, err)
+	}
+	if err := s.store.PutObject(objectRevisionKey(project, currentNumber+1), content); err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, project, err)
+	}
+	// the new revision only becomes visible once the head has been updated
+	if err := s.store.PutObject(objectStorageKey(project, objectStorageHeadKey), []byte(next.Revision)); err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, project, err)
+	}
+	return next.Revision, nil
+}
+
+func objectStorageKey(project string, elements ...string) string {
+	return project + "/" + strings.Join(elements, "/")
+}
+
+func objectRevisionKey(project string, revision uint64) string {
+	return objectStorageKey(project, "revisions", fmt.Sprintf("%020d", revision))
+}
+
+func blobKey(project, hash string) string {
+	return objectStorageKey(project, "blobs", hash)
+}
@@ -0,0 +1,186 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	common_mock "github.com/keptn/keptn/resource-service/common/fake"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestObjectStorage(t *testing.T) *ObjectStorage {
+	storage := NewObjectStorage(common_mock.NewInMemoryObjectStore())
+	revision, err := storage.CreateProject("my-project", "initialized project", map[string][]byte{"metadata.yaml": []byte("projectName: my-project")})
+	require.Nil(t, err)
+	require.Equal(t, "1", revision)
+	return storage
+}
+
+func TestObjectStorage_CreateProject(t *testing.T) {
+	storage := newTestObjectStorage(t)
+
+	require.True(t, projectExists(t, storage, "my-project"))
+	require.False(t, projectExists(t, storage, "other-project"))
+
+	_, err := storage.CreateProject("my-project", "initialized project", nil)
+	require.ErrorIs(t, err, kerrors.ErrProjectAlreadyExists)
+}
+
+func TestObjectStorage_ProjectExistsObjectStoreNotReachable(t *testing.T) {
+	store := common_mock.NewInMemoryObjectStore()
+	storage := NewObjectStorage(store)
+	store.Err = errors.New("connection refused")
+
+	// the project might still exist, which must not be mistaken for its absence
+	_, err := storage.ProjectExists("my-project")
+	require.ErrorContains(t, err, "connection refused")
+
+	_, err = storage.CreateProject("my-project", "initialized project", nil)
+	require.ErrorContains(t, err, "connection refused")
+}
+
+func projectExists(t *testing.T, storage *ObjectStorage, project string) bool {
+	exists, err := storage.ProjectExists(project)
+	require.Nil(t, err)
+	return exists
+}
+
+func TestObjectStorage_CommitIncreasesRevision(t *testing.T) {
+	storage := newTestObjectStorage(t)
+
+	revision, err := storage.Commit("my-project", "Added stage: dev", []ObjectChange{
+		{Path: ".keptn-stages/dev/metadata.yaml", Content: []byte("stageName: dev")},
+		{Path: ".keptn-stages/dev/my-service/file.yaml", Content: []byte("v1")},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "2", revision)
+
+	revision, err = storage.Commit("my-project", "Updated resource", []ObjectChange{
+		{Path: ".keptn-stages/dev/my-service/file.yaml", Content: []byte("v2")},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "3", revision)
+
+	current, err := storage.GetRevision("my-project", "")
+	require.Nil(t, err)
+	require.Equal(t, "3", current.Revision)
+	require.Equal(t, "Updated resource", current.Message)
+	require.True(t, current.DirExists(".keptn-stages/dev/my-service"))
+	require.Equal(t, []string{"metadata.yaml", "my-service/file.yaml"}, current.ListFiles(".keptn-stages/dev"))
+
+	content, err := storage.ReadFile("my-project", current, ".keptn-stages/dev/my-service/file.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "v2", string(content))
+
+	// previous revisions are not modified by later commits
+	previous, err := storage.GetRevision("my-project", "2")
+	require.Nil(t, err)
+	content, err = storage.ReadFile("my-project", previous, ".keptn-stages/dev/my-service/file.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "v1", string(content))
+}
+
+func TestObjectStorage_DeleteDirectory(t *testing.T) {
+	storage := newTestObjectStorage(t)
+
+	_, err := storage.Commit("my-project", "Added service", []ObjectChange{
+		{Path: ".keptn-stages/dev/my-service/metadata.yaml", Content: []byte("serviceName: my-service")},
+		{Path: ".keptn-stages/dev/my-service-2/metadata.yaml", Content: []byte("serviceName: my-service-2")},
+	})
+	require.Nil(t, err)
+
+	_, err = storage.Commit("my-project", "Removed service", []ObjectChange{
+		{Path: ".keptn-stages/dev/my-service", Delete: true},
+	})
+	require.Nil(t, err)
+
+	current, err := storage.GetRevision("my-project", "")
+	require.Nil(t, err)
+	require.False(t, current.DirExists(".keptn-stages/dev/my-service"))
+	require.True(t, current.DirExists(".keptn-stages/dev/my-service-2"))
+
+	_, err = storage.ReadFile("my-project", current, ".keptn-stages/dev/my-service/metadata.yaml")
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+}
+
+func TestObjectStorage_GetRevisionInvalid(t *testing.T) {
+	storage := newTestObjectStorage(t)
+
+	_, err := storage.GetRevision("my-project", "5")
+	require.ErrorIs(t, err, kerrors.ErrResolveRevision)
+
+	_, err = storage.GetRevision("my-project", "a1b2c3")
+	require.ErrorIs(t, err, kerrors.ErrResolveRevision)
+
+	_, err = storage.GetRevision("other-project", "")
+	require.ErrorIs(t, err, kerrors.ErrProjectNotFound)
+}
+
+func TestObjectStorage_GetFileHistory(t *testing.T) {
+	storage := newTestObjectStorage(t)
+
+	_, err := storage.Commit("my-project", "Added file", []ObjectChange{{Path: "file.yaml", Content: []byte("v1")}})
+	require.Nil(t, err)
+	_, err = storage.Commit("my-project", "Added other file", []ObjectChange{{Path: "other.yaml", Content: []byte("v1")}})
+	require.Nil(t, err)
+	_, err = storage.Commit("my-project", "Updated file", []ObjectChange{{Path: "file.yaml", Content: []byte("v2")}})
+	require.Nil(t, err)
+
+	history, err := storage.GetFileHistory("my-project", "file.yaml")
+	require.Nil(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, "4", history[0].ID)
+	require.Equal(t, "Updated file", history[0].Message)
+	require.Equal(t, "keptn", history[0].Author)
+	require.Equal(t, "2", history[1].ID)
+
+	_, err = storage.GetFileHistory("my-project", "unknown.yaml")
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+}
+
+func TestObjectStorage_GetFileDiff(t *testing.T) {
+	storage := newTestObjectStorage(t)
+
+	_, err := storage.Commit("my-project", "Added file", []ObjectChange{{Path: "file.yaml", Content: []byte("a: 1\nb: 2\n")}})
+	require.Nil(t, err)
+	_, err = storage.Commit("my-project", "Updated file", []ObjectChange{{Path: "file.yaml", Content: []byte("a: 1\nb: 3\n")}})
+	require.Nil(t, err)
+
+	diff, err := storage.GetFileDiff("my-project", "2", "", "file.yaml")
+	require.Nil(t, err)
+	require.Contains(t, diff, "--- a/file.yaml")
+	require.Contains(t, diff, "+++ b/file.yaml")
+	require.Contains(t, diff, "-b: 2")
+	require.Contains(t, diff, "+b: 3")
+
+	diff, err = storage.GetFileDiff("my-project", "1", "2", "file.yaml")
+	require.Nil(t, err)
+	require.Contains(t, diff, "new file mode 100644")
+	require.Contains(t, diff, "+a: 1")
+
+	diff, err = storage.GetFileDiff("my-project", "3", "3", "file.yaml")
+	require.Nil(t, err)
+	require.Empty(t, diff)
+
+	_, err = storage.GetFileDiff("my-project", "1", "3", "unknown.yaml")
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+}
+
+func TestObjectStorage_DeleteProject(t *testing.T) {
+	store := common_mock.NewInMemoryObjectStore()
+	storage := NewObjectStorage(store)
+
+	_, err := storage.CreateProject("my-project", "initialized project", map[string][]byte{"metadata.yaml": []byte("projectName: my-project")})
+	require.Nil(t, err)
+	_, err = storage.CreateProject("my-project-2", "initialized project", map[string][]byte{"metadata.yaml": []byte("projectName: my-project-2")})
+	require.Nil(t, err)
+
+	require.Nil(t, storage.DeleteProject("my-project"))
+	require.False(t, projectExists(t, storage, "my-project"))
+	require.True(t, projectExists(t, storage, "my-project-2"))
+
+	keys, err := store.ListObjects("my-project/")
+	require.Nil(t, err)
+	require.Empty(t, keys)
+}
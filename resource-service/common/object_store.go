@@ -0,0 +1,114 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/minio/minio-go/v7"
+	miniocredentials "github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// IObjectStore provides an interface for storing plain objects in an object store
+type IObjectStore interface {
+	PutObject(key string, content []byte) error
+	// GetObject returns the content of an object, or kerrors.ErrObjectNotFound if the object does not exist
+	GetObject(key string) ([]byte, error)
+	ListObjects(prefix string) ([]string, error)
+	DeleteObjects(prefix string) error
+}
+
+// S3ObjectStore stores objects in a bucket of an S3 compatible object store, e.g. AWS S3 or MinIO
+type S3ObjectStore struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3ObjectStore creates a new S3ObjectStore and creates the bucket if it does not exist yet
+func NewS3ObjectStore(endpoint, region, bucket, accessKeyID, secretAccessKey string, useSSL bool) (*S3ObjectStore, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  miniocredentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
+		Secure: useSSL,
+		Region: region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create object store client: %w", err)
+	}
+
+	exists, err := client.BucketExists(context.TODO(), bucket)
+	if err != nil {
+		return nil, fmt.Errorf("could not check if bucket %s exists: %w", bucket, err)
+	}
+	if !exists {
+		if err := client.MakeBucket(context.TODO(), bucket, minio.MakeBucketOptions{Region: region}); err != nil {
+			return nil, fmt.Errorf("could not create bucket %s: %w", bucket, err)
+		}
+	}
+	return &S3ObjectStore{client: client, bucket: bucket}, nil
+}
+
+func (s S3ObjectStore) PutObject(key string, content []byte) error {
+	_, err := s.client.PutObject(context.TODO(), s.bucket, key, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	if err != nil {
+		return fmt.Errorf("could not store object %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s S3ObjectStore) GetObject(key string) ([]byte, error) {
+	object, err := s.client.GetObject(context.TODO(), s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s.mapError(key, err)
+	}
+	defer object.Close()
+
+	content, err := ioutil.ReadAll(object)
+	if err != nil {
+		return nil, s.mapError(key, err)
+	}
+	return content, nil
+}
+
+func (s S3ObjectStore) ListObjects(prefix string) ([]string, error) {
+	keys := []string{}
+	for object := range s.client.ListObjects(context.TODO(), s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("could not list objects with prefix %s: %w", prefix, object.Err)
+		}
+		keys = append(keys, object.Key)
+	}
+	return keys, nil
+}
+
+func (s S3ObjectStore) DeleteObjects(prefix string) error {
+	objects := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(objects)
+		for object := range s.client.ListObjects(context.TODO(), s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+			if object.Err != nil {
+				return
+			}
+			objects <- object
+		}
+	}()
+
+	var err error
+	// the error channel needs to be drained completely, otherwise the removal is stopped
+	for removeErr := range s.client.RemoveObjects(context.TODO(), s.bucket, objects, minio.RemoveObjectsOptions{}) {
+		if err == nil {
+			err = fmt.Errorf("could not delete object %s: %w", removeErr.ObjectName, removeErr.Err)
+		}
+	}
+	return err
+}
+
+func (s S3ObjectStore) mapError(key string, err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return kerrors.ErrObjectNotFound
+	}
+	return fmt.Errorf("could not read object %s: %w", key, err)
+}
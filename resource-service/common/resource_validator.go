@@ -0,0 +1,80 @@
+package common
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+//IResourceValidator validates the content of resources before they are written
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/resource_validator_mock.go . IResourceValidator
+type IResourceValidator interface {
+	// Validate returns a *models.ResourceValidationError containing all issues if at least one of the resources is invalid
+	Validate(resources []models.Resource) error
+}
+
+// ResourceValidatorFunc validates the decoded content of a resource and returns the issues found in it
+type ResourceValidatorFunc func(content []byte) []models.ResourceValidationIssue
+
+// ResourceValidatorRegistry validates resources using the validators registered for their resource URI.
+// Resources without a registered validator are accepted as they are
+type ResourceValidatorRegistry struct {
+	validators map[string]ResourceValidatorFunc
+	mutex      sync.RWMutex
+}
+
+// NewResourceValidatorRegistry creates a new ResourceValidatorRegistry containing the validators for the well-known Keptn resources
+func NewResourceValidatorRegistry() *ResourceValidatorRegistry {
+	registry := &ResourceValidatorRegistry{validators: map[string]ResourceValidatorFunc{}}
+	registry.Register("shipyard.yaml", ValidateShipyard)
+	registry.Register("slo.yaml", ValidateSLO)
+	registry.Register("webhook/webhook.yaml", ValidateWebhookConfig)
+	registry.Register("remediation.yaml", ValidateRemediation)
+	registry.Register("jmeter/jmeter.conf.yaml", ValidateJMeterConf)
+	registry.Register("lighthouse.yaml", ValidateLighthouseConfig)
+	return registry
+}
+
+// Register sets the validator for the given resource URI, replacing any previously registered validator
+func (r *ResourceValidatorRegistry) Register(resourceURI string, validator ResourceValidatorFunc) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.validators[normalizeResourceURI(resourceURI)] = validator
+}
+
+func (r *ResourceValidatorRegistry) Validate(resources []models.Resource) error {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	issues := []models.ResourceValidationIssue{}
+	for _, resource := range resources {
+		resourceURI := normalizeResourceURI(resource.ResourceURI)
+		validator, ok := r.validators[resourceURI]
+		if !ok {
+			continue
+		}
+		// the encoding of the content has already been checked when validating the request
+		content, err := base64.StdEncoding.DecodeString(string(resource.ResourceContent))
+		if err != nil {
+			continue
+		}
+		for _, issue := range validator(content) {
+			issue.ResourceURI = resourceURI
+			issues = append(issues, issue)
+		}
+	}
+	if len(issues) > 0 {
+		return models.NewResourceValidationError(issues)
+	}
+	return nil
+}
+
+func normalizeResourceURI(resourceURI string) string {
+	if unescaped, err := url.QueryUnescape(resourceURI); err == nil {
+		resourceURI = unescaped
+	}
+	return strings.TrimPrefix(resourceURI, "/")
+}
@@ -0,0 +1,340 @@
+package common
+
+import (
+	"encoding/base64"
+	"testing"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+const testValidShipyard = `apiVersion: spec.keptn.sh/0.2.3
+kind: Shipyard
+metadata:
+  name: shipyard-sockshop
+spec:
+  stages:
+    - name: dev
+      sequences:
+        - name: delivery
+          tasks:
+            - name: deployment
+            - name: evaluation
+    - name: production
+      sequences:
+        - name: delivery
+          triggeredOn:
+            - event: dev.delivery.finished
+          tasks:
+            - name: deployment
+`
+
+const testValidSLO = `spec_version: "1.0"
+comparison:
+  aggregate_function: "avg"
+  compare_with: "single_result"
+  include_result_with_score: "pass"
+  number_of_comparison_results: 1
+filter:
+objectives:
+  - sli: "response_time_p95"
+    displayName: "Response time P95"
+    key_sli: false
+    pass:
+      - criteria:
+          - "<=+10%"
+          - "< 600"
+    warning:
+      - criteria:
+          - "<=800"
+    weight: 1
+total_score:
+  pass: "90%"
+  warning: "75%"
+`
+
+const testValidWebhookConfig = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: sh.keptn.event.webhook.triggered
+      subscriptionID: my-subscription
+      sendFinished: true
+      envFrom:
+        - name: secretKey
+          secretRef:
+            name: my-secret
+            key: my-key
+      requests:
+        - url: https://my-webhook.com
+          method: POST
+          headers:
+            - key: x-token
+              value: "{{.env.secretKey}}"
+          payload: "{}"
+`
+
+const testValidRemediation = `apiVersion: spec.keptn.sh/0.1.4
+kind: Remediation
+metadata:
+  name: service-remediation
+spec:
+  remediations:
+    - problemType: Response time degradation
+      actionsOnOpen:
+        - action: scale
+          name: scale
+          value: "+1"
+`
+
+const testValidJMeterConf = `spec_version: '0.1.0'
+workloads:
+  - teststrategy: performance
+    vuser: 10
+    loopcount: 500
+    thinktime: 250
+    script: jmeter/load.jmx
+    acceptederrorrate: 0.1
+    avgrtvalidation: 100
+    properties:
+      PROTOCOL: https
+`
+
+func TestValidateShipyard(t *testing.T) {
+	require.Empty(t, ValidateShipyard([]byte(testValidShipyard)))
+
+	issues := ValidateShipyard([]byte(`apiVersion: spec.keptn.sh/0.1.7
+spec:
+  stages:
+    - name: Dev
+    - name: production
+      sequences:
+        - name: delivery
+        - name: delivery
+          tasks:
+            - deployment
+    - name: production
+`))
+	require.Equal(t, []models.ResourceValidationIssue{
+		{Line: 1, Message: "unsupported shipyard version 'spec.keptn.sh/0.1.7', expected spec.keptn.sh/0.2.0 or later"},
+		{Line: 4, Message: "stage name 'Dev' must start with a lower case letter and contain only lower case letters, numbers and hyphens"},
+		{Line: 8, Message: "duplicate sequence 'delivery'"},
+		{Line: 10, Message: "property 'spec.stages[1].sequences[1].tasks[0]' must be an object"},
+		{Line: 11, Message: "duplicate stage 'production'"},
+	}, issues)
+
+	issues = ValidateShipyard([]byte("apiVersion: spec.keptn.sh/0.2.3\nspec:\n  stages: []\n"))
+	require.Equal(t, []models.ResourceValidationIssue{
+		{Line: 3, Message: "property 'spec.stages' must not be empty"},
+	}, issues)
+}
+
+func TestValidateShipyard_InvalidYAML(t *testing.T) {
+	issues := ValidateShipyard([]byte("apiVersion: spec.keptn.sh/0.2.3\nspec:\n  stages:\n\t- name: dev\n"))
+	require.Equal(t, []models.ResourceValidationIssue{
+		{Line: 4, Message: "invalid YAML: found character that cannot start any token"},
+	}, issues)
+
+	require.Equal(t, []models.ResourceValidationIssue{{Message: "resource must not be empty"}}, ValidateShipyard([]byte("")))
+	require.Equal(t, []models.ResourceValidationIssue{{Line: 1, Message: "resource must be a YAML object"}}, ValidateShipyard([]byte("- dev\n- prod\n")))
+}
+
+func TestValidateSLO(t *testing.T) {
+	require.Empty(t, ValidateSLO([]byte(testValidSLO)))
+
+	for _, aggregateFunction := range []string{"avg", "p50", "p90", "p95"} {
+		require.Empty(t, ValidateSLO([]byte("spec_version: \"1.0\"\ncomparison:\n  aggregate_function: \""+aggregateFunction+"\"\n")), aggregateFunction)
+	}
+	issues := ValidateSLO([]byte("spec_version: \"1.0\"\ncomparison:\n  aggregate_function: \"p99\"\n"))
+	require.Equal(t, []models.ResourceValidationIssue{
+		{Line: 3, Message: "property 'comparison.aggregate_function' has invalid value 'p99', expected one of: avg, p50, p90, p95"},
+	}, issues)
+
+	issues = ValidateSLO([]byte(`spec_version: "1.0"
+comparison:
+  compare_with: "all_results"
+  strategy: "t_test"
+  confidence_level: 95
+objectives:
+  - sli: "response_time_p95"
+    key_sli: "yes"
+    pass:
+      - criteria:
+          - "<=+10%"
+          - "less than 600"
+  - displayName: "Throughput"
+total_score:
+  pass: "ninety"
+`))
+	require.Equal(t, []models.ResourceValidationIssue{
+		{Line: 3, Message: "property 'comparison.compare_with' has invalid value 'all_results', expected one of: single_result, several_results"},
+		{Line: 4, Message: "property 'comparison.strategy' has invalid value 't_test', expected one of: threshold, zscore, mad, mann_whitney"},
+		{Line: 5, Message: "property 'comparison.confidence_level' must be a number greater than 0.5 and less than 1, e.g. 0.95"},
+		{Line: 8, Message: "property 'objectives[0].key_sli' must be true or false"},
+		{Line: 12, Message: "invalid criterion 'less than 600', expected an operator (<, <=, =, >=, >) followed by a number, e.g. <=800 or <+10%"},
+		{Line: 13, Message: "missing required property 'objectives[1].sli'"},
+		{Line: 15, Message: "property 'total_score.pass' must be a percentage, e.g. 90%"},
+	}, issues)
+
+	issues = ValidateSLO([]byte(`spec_version: "1.0"
+objectives:
+  - sli: "error_rate"
+    burn_rate:
+      objective: 100
+      policy: "majority"
+      windows:
+        - window: "5 minutes"
+          threshold: 14.4
+        - window: "1d"
+  - sli: "availability_errors"
+    burn_rate:
+      objective: 99.9
+`))
+	require.Equal(t, []models.ResourceValidationIssue{
+		{Line: 5, Message: "property 'objectives[0].burn_rate.objective' must be a percentage greater than 0 and less than 100, e.g. 99.9"},
+		{Line: 6, Message: "property 'objectives[0].burn_rate.policy' has invalid value 'majority', expected one of: all, any, weighted"},
+		{Line: 8, Message: "property 'objectives[0].burn_rate.windows[0].window' must be a duration like 5m, 1h or 30d"},
+		{Line: 10, Message: "missing required property 'objectives[0].burn_rate.windows[1].threshold'"},
+		{Line: 13, Message: "missing required property 'objectives[1].burn_rate.windows'"},
+	}, issues)
+}
+
+func TestValidateWebhookConfig(t *testing.T) {
+	require.Empty(t, ValidateWebhookConfig([]byte(testValidWebhookConfig)))
+
+	issues := ValidateWebhookConfig([]byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+spec:
+  webhooks:
+    - type: sh.keptn.event.webhook.triggered
+      requests:
+        - url: https://my-webhook.com
+          method: TRACE
+          payload: "{}"
+          payloadFile: webhook/payload.json
+          headers:
+            - key: x-token
+`))
+	require.Equal(t, []models.ResourceValidationIssue{
+		{Line: 5, Message: "missing required property 'spec.webhooks[0].subscriptionID'"},
+		{Line: 8, Message: "property 'spec.webhooks[0].requests[0].method' has invalid value 'TRACE', expected one of: GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS"},
+		{Line: 10, Message: "properties 'spec.webhooks[0].requests[0].payload' and 'spec.webhooks[0].requests[0].payloadFile' must not be set together"},
+		{Line: 12, Message: "missing required property 'spec.webhooks[0].requests[0].headers[0].value'"},
+	}, issues)
+
+	issues = ValidateWebhookConfig([]byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+spec:
+  webhooks:
+    - type: sh.keptn.event.webhook.triggered
+      subscriptionID: my-subscription
+      requests:
+        - "curl http://localhost:8080"
+        - url: https://my-webhook.com
+`))
+	require.Equal(t, []models.ResourceValidationIssue{
+		{Line: 9, Message: "property 'spec.webhooks[0].requests[1]' must be a curl command"},
+	}, issues)
+}
+
+func TestValidateRemediation(t *testing.T) {
+	require.Empty(t, ValidateRemediation([]byte(testValidRemediation)))
+
+	issues := ValidateRemediation([]byte(`apiVersion: spec.keptn.sh/0.1.4
+kind: Shipyard
+spec:
+  remediations:
+    - actionsOnOpen:
+        - name: scale
+`))
+	require.Equal(t, []models.ResourceValidationIssue{
+		{Line: 2, Message: "property 'kind' must be 'Remediation'"},
+		{Line: 5, Message: "missing required property 'spec.remediations[0].problemType'"},
+		{Line: 6, Message: "missing required property 'spec.remediations[0].actionsOnOpen[0].action'"},
+	}, issues)
+}
+
+func TestValidateJMeterConf(t *testing.T) {
+	require.Empty(t, ValidateJMeterConf([]byte(testValidJMeterConf)))
+
+	issues := ValidateJMeterConf([]byte(`spec_version: '0.1.0'
+workloads:
+  - teststrategy: performance
+    vuser: ten
+    script: jmeter/load.jmx
+    acceptederrorrate: -1
+`))
+	require.Equal(t, []models.ResourceValidationIssue{
+		{Line: 4, Message: "property 'workloads[0].vuser' must be a non-negative integer"},
+		{Line: 6, Message: "property 'workloads[0].acceptederrorrate' must be a non-negative number"},
+	}, issues)
+}
+
+func TestValidateLighthouseConfig(t *testing.T) {
+	require.Empty(t, ValidateLighthouseConfig([]byte(`sli_provider: dynatrace
+stages:
+  - name: staging
+    sli_provider: prometheus
+    services:
+      - name: carts
+        sli_provider: datadog
+`)))
+
+	issues := ValidateLighthouseConfig([]byte(`sli_provider:
+  name: dynatrace
+stages:
+  - sli_provider: prometheus
+    services:
+      - name: carts
+`))
+	require.Equal(t, []models.ResourceValidationIssue{
+		{Line: 2, Message: "property 'sli_provider' must be a string"},
+		{Line: 4, Message: "missing required property 'stages[0].name'"},
+		{Line: 6, Message: "missing required property 'stages[0].services[0].sli_provider'"},
+	}, issues)
+}
+
+func TestResourceValidatorRegistry_Validate(t *testing.T) {
+	registry := NewResourceValidatorRegistry()
+
+	encode := func(content string) models.ResourceContent {
+		return models.ResourceContent(base64.StdEncoding.EncodeToString([]byte(content)))
+	}
+
+	err := registry.Validate([]models.Resource{
+		{ResourceURI: "shipyard.yaml", ResourceContent: encode(testValidShipyard)},
+		{ResourceURI: "helm/values.yaml", ResourceContent: encode("not: [valid")},
+		{ResourceURI: "/jmeter%2Fjmeter.conf.yaml", ResourceContent: encode(testValidJMeterConf)},
+	})
+	require.Nil(t, err)
+
+	err = registry.Validate([]models.Resource{
+		{ResourceURI: "shipyard.yaml", ResourceContent: encode("kind: Shipyard\n")},
+		{ResourceURI: "webhook%2Fwebhook.yaml", ResourceContent: encode("apiVersion: webhookconfig.keptn.sh/v1beta1\n")},
+	})
+	require.ErrorIs(t, err, kerrors.ErrResourceInvalidContent)
+
+	validationErr, ok := err.(*models.ResourceValidationError)
+	require.True(t, ok)
+	require.Equal(t, []models.ResourceValidationIssue{
+		{ResourceURI: "shipyard.yaml", Line: 1, Message: "missing required property 'apiVersion'"},
+		{ResourceURI: "shipyard.yaml", Line: 1, Message: "missing required property 'spec'"},
+		{ResourceURI: "webhook/webhook.yaml", Line: 1, Message: "missing required property 'kind'"},
+		{ResourceURI: "webhook/webhook.yaml", Line: 1, Message: "missing required property 'spec'"},
+	}, validationErr.Issues)
+	require.Contains(t, validationErr.Error(), "shipyard.yaml:1: missing required property 'apiVersion'")
+}
+
+func TestResourceValidatorRegistry_Register(t *testing.T) {
+	registry := NewResourceValidatorRegistry()
+	registry.Register("/helm/values.yaml", func(content []byte) []models.ResourceValidationIssue {
+		return []models.ResourceValidationIssue{{Line: 2, Message: "replicas must be set"}}
+	})
+
+	err := registry.Validate([]models.Resource{{ResourceURI: "helm/values.yaml", ResourceContent: "c3RyaW5n"}})
+	require.ErrorIs(t, err, kerrors.ErrResourceInvalidContent)
+	require.Equal(t, "invalid resource content: helm/values.yaml:2: replicas must be set", err.Error())
+}
@@ -0,0 +1,525 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/keptn/go-utils/pkg/lib/keptn"
+	"github.com/keptn/keptn/resource-service/models"
+	"gopkg.in/yaml.v3"
+)
+
+const shipyardAPIVersionPrefix = "spec.keptn.sh/"
+const webhookConfigAPIVersionPrefix = "webhookconfig.keptn.sh/"
+
+var shipyardAPIVersionRegex = regexp.MustCompile(`^spec\.keptn\.sh/(\d+)\.(\d+)\.(\d+)$`)
+var sloCriteriaRegex = regexp.MustCompile(`^(<=|>=|<|>|=)([+-]?\d*\.?\d*)(%?)$`)
+var sloWindowRegex = regexp.MustCompile(`^(\d+(\.\d+)?(ns|us|µs|ms|s|m|h))+$|^\d+d$`)
+var yamlSyntaxErrorRegex = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+var supportedWebhookMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+// ValidateShipyard validates the content of a shipyard.yaml file
+func ValidateShipyard(content []byte) []models.ResourceValidationIssue {
+	v := &yamlValidator{}
+	root := v.parse(content)
+	if root == nil {
+		return v.issues
+	}
+
+	if node, apiVersion := v.str(root, "apiVersion", "apiVersion", true); node != nil && !isSupportedShipyardVersion(apiVersion) {
+		v.addIssue(node, "unsupported shipyard version '%s', expected %s0.2.0 or later", apiVersion, shipyardAPIVersionPrefix)
+	}
+	if node, kind := v.str(root, "kind", "kind", false); node != nil && kind != "Shipyard" {
+		v.addIssue(node, "property 'kind' must be 'Shipyard'")
+	}
+
+	spec := v.mapping(root, "spec", "spec", true)
+	stageNames := map[string]bool{}
+	for i, stage := range v.sequence(spec, "stages", "spec.stages", true) {
+		stagePath := fmt.Sprintf("spec.stages[%d]", i)
+		if !v.isMapping(stage, stagePath) {
+			continue
+		}
+		if node, name := v.str(stage, "name", stagePath+".name", true); node != nil {
+			if !keptn.ValidateKeptnEntityName(name) {
+				v.addIssue(node, "stage name '%s' must start with a lower case letter and contain only lower case letters, numbers and hyphens", name)
+			} else if stageNames[name] {
+				v.addIssue(node, "duplicate stage '%s'", name)
+			}
+			stageNames[name] = true
+		}
+		validateShipyardSequences(v, stage, stagePath)
+	}
+	return v.issues
+}
+
+func validateShipyardSequences(v *yamlValidator, stage *yaml.Node, stagePath string) {
+	sequenceNames := map[string]bool{}
+	for i, sequence := range v.sequence(stage, "sequences", stagePath+".sequences", false) {
+		sequencePath := fmt.Sprintf("%s.sequences[%d]", stagePath, i)
+		if !v.isMapping(sequence, sequencePath) {
+			continue
+		}
+		if node, name := v.str(sequence, "name", sequencePath+".name", true); node != nil {
+			if sequenceNames[name] {
+				v.addIssue(node, "duplicate sequence '%s'", name)
+			}
+			sequenceNames[name] = true
+		}
+		for j, trigger := range v.sequence(sequence, "triggeredOn", sequencePath+".triggeredOn", false) {
+			triggerPath := fmt.Sprintf("%s.triggeredOn[%d]", sequencePath, j)
+			if v.isMapping(trigger, triggerPath) {
+				v.str(trigger, "event", triggerPath+".event", true)
+			}
+		}
+		for j, task := range v.sequence(sequence, "tasks", sequencePath+".tasks", false) {
+			taskPath := fmt.Sprintf("%s.tasks[%d]", sequencePath, j)
+			if v.isMapping(task, taskPath) {
+				v.str(task, "name", taskPath+".name", true)
+			}
+		}
+	}
+}
+
+func isSupportedShipyardVersion(apiVersion string) bool {
+	match := shipyardAPIVersionRegex.FindStringSubmatch(apiVersion)
+	if match == nil {
+		return false
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	return major > 0 || minor >= 2
+}
+
+// ValidateSLO validates the content of an slo.yaml file
+func ValidateSLO(content []byte) []models.ResourceValidationIssue {
+	v := &yamlValidator{}
+	root := v.parse(content)
+	if root == nil {
+		return v.issues
+	}
+
+	v.str(root, "spec_version", "spec_version", true)
+	v.str(root, "sli_provider", "sli_provider", false)
+	v.mapping(root, "filter", "filter", false)
+
+	if comparison := v.mapping(root, "comparison", "comparison", false); comparison != nil {
+		v.oneOf(comparison, "compare_with", "comparison.compare_with", false, "single_result", "several_results")
+		v.oneOf(comparison, "include_result_with_score", "comparison.include_result_with_score", false, "all", "pass", "pass_or_warn")
+		v.oneOf(comparison, "aggregate_function", "comparison.aggregate_function", false, "avg", "p50", "p90", "p95")
+		v.integer(comparison, "number_of_comparison_results", "comparison.number_of_comparison_results")
+		v.oneOf(comparison, "strategy", "comparison.strategy", false, "threshold", "zscore", "mad", "mann_whitney")
+		if confidenceLevel := v.field(comparison, "confidence_level"); confidenceLevel != nil {
+			if f, err := strconv.ParseFloat(confidenceLevel.Value, 64); confidenceLevel.Kind != yaml.ScalarNode || err != nil || f <= 0.5 || f >= 1 {
+				v.addIssue(confidenceLevel, "property 'comparison.confidence_level' must be a number greater than 0.5 and less than 1, e.g. 0.95")
+			}
+		}
+	}
+
+	for i, objective := range v.sequence(root, "objectives", "objectives", false) {
+		objectivePath := fmt.Sprintf("objectives[%d]", i)
+		if !v.isMapping(objective, objectivePath) {
+			continue
+		}
+		v.str(objective, "sli", objectivePath+".sli", true)
+		v.str(objective, "displayName", objectivePath+".displayName", false)
+		v.integer(objective, "weight", objectivePath+".weight")
+		v.boolean(objective, "key_sli", objectivePath+".key_sli")
+		validateSLOCriteria(v, objective, "pass", objectivePath+".pass")
+		validateSLOCriteria(v, objective, "warning", objectivePath+".warning")
+		validateSLOBurnRate(v, objective, objectivePath+".burn_rate")
+	}
+
+	if totalScore := v.mapping(root, "total_score", "total_score", false); totalScore != nil {
+		for _, key := range []string{"pass", "warning"} {
+			path := "total_score." + key
+			if node, score := v.str(totalScore, key, path, false); node != nil {
+				if _, err := strconv.ParseFloat(strings.TrimSuffix(score, "%"), 64); err != nil {
+					v.addIssue(node, "property '%s' must be a percentage, e.g. 90%%", path)
+				}
+			}
+		}
+	}
+	return v.issues
+}
+
+func validateSLOCriteria(v *yamlValidator, objective *yaml.Node, key, path string) {
+	for i, criteria := range v.sequence(objective, key, path, false) {
+		criteriaPath := fmt.Sprintf("%s[%d]", path, i)
+		if !v.isMapping(criteria, criteriaPath) {
+			continue
+		}
+		for j, criterion := range v.sequence(criteria, "criteria", criteriaPath+".criteria", true) {
+			criterionPath := fmt.Sprintf("%s.criteria[%d]", criteriaPath, j)
+			if criterion.Kind != yaml.ScalarNode {
+				v.addIssue(criterion, "property '%s' must be a string", criterionPath)
+				continue
+			}
+			if !isValidSLOCriterion(criterion.Value) {
+				v.addIssue(criterion, "invalid criterion '%s', expected an operator (<, <=, =, >=, >) followed by a number, e.g. <=800 or <+10%%", criterion.Value)
+			}
+		}
+	}
+}
+
+func validateSLOBurnRate(v *yamlValidator, objective *yaml.Node, path string) {
+	burnRate := v.mapping(objective, "burn_rate", path, false)
+	if burnRate == nil {
+		return
+	}
+	if objectiveNode := v.required(burnRate, "objective", path+".objective", true); objectiveNode != nil {
+		if f, err := strconv.ParseFloat(objectiveNode.Value, 64); objectiveNode.Kind != yaml.ScalarNode || err != nil || f <= 0 || f >= 100 {
+			v.addIssue(objectiveNode, "property '%s.objective' must be a percentage greater than 0 and less than 100, e.g. 99.9", path)
+		}
+	}
+	v.oneOf(burnRate, "policy", path+".policy", false, "all", "any", "weighted")
+	for i, window := range v.sequence(burnRate, "windows", path+".windows", true) {
+		windowPath := fmt.Sprintf("%s.windows[%d]", path, i)
+		if !v.isMapping(window, windowPath) {
+			continue
+		}
+		if node, value := v.str(window, "window", windowPath+".window", true); node != nil && !sloWindowRegex.MatchString(value) {
+			v.addIssue(node, "property '%s.window' must be a duration like 5m, 1h or 30d", windowPath)
+		}
+		v.required(window, "threshold", windowPath+".threshold", true)
+		v.number(window, "threshold", windowPath+".threshold")
+		v.integer(window, "weight", windowPath+".weight")
+	}
+}
+
+func isValidSLOCriterion(criterion string) bool {
+	match := sloCriteriaRegex.FindStringSubmatch(strings.Join(strings.Fields(criterion), ""))
+	if match == nil {
+		return false
+	}
+	_, err := strconv.ParseFloat(match[2], 64)
+	return err == nil
+}
+
+// ValidateWebhookConfig validates the content of a webhook/webhook.yaml file
+func ValidateWebhookConfig(content []byte) []models.ResourceValidationIssue {
+	v := &yamlValidator{}
+	root := v.parse(content)
+	if root == nil {
+		return v.issues
+	}
+
+	apiVersionNode, apiVersion := v.oneOf(root, "apiVersion", "apiVersion", true, webhookConfigAPIVersionPrefix+"v1alpha1", webhookConfigAPIVersionPrefix+"v1beta1")
+	if node, kind := v.str(root, "kind", "kind", true); node != nil && kind != "WebhookConfig" {
+		v.addIssue(node, "property 'kind' must be 'WebhookConfig'")
+	}
+
+	spec := v.mapping(root, "spec", "spec", true)
+	for i, webhook := range v.sequence(spec, "webhooks", "spec.webhooks", true) {
+		webhookPath := fmt.Sprintf("spec.webhooks[%d]", i)
+		if !v.isMapping(webhook, webhookPath) {
+			continue
+		}
+		v.str(webhook, "type", webhookPath+".type", true)
+		v.str(webhook, "subscriptionID", webhookPath+".subscriptionID", true)
+		v.boolean(webhook, "sendFinished", webhookPath+".sendFinished")
+		v.boolean(webhook, "sendStarted", webhookPath+".sendStarted")
+
+		for j, envFrom := range v.sequence(webhook, "envFrom", webhookPath+".envFrom", false) {
+			envFromPath := fmt.Sprintf("%s.envFrom[%d]", webhookPath, j)
+			if !v.isMapping(envFrom, envFromPath) {
+				continue
+			}
+			v.str(envFrom, "name", envFromPath+".name", true)
+			if secretRef := v.mapping(envFrom, "secretRef", envFromPath+".secretRef", true); secretRef != nil {
+				v.str(secretRef, "name", envFromPath+".secretRef.name", true)
+				v.str(secretRef, "key", envFromPath+".secretRef.key", true)
+			}
+		}
+
+		for j, request := range v.sequence(webhook, "requests", webhookPath+".requests", true) {
+			requestPath := fmt.Sprintf("%s.requests[%d]", webhookPath, j)
+			if apiVersionNode == nil {
+				continue
+			}
+			if apiVersion == webhookConfigAPIVersionPrefix+"v1alpha1" {
+				if request.Kind != yaml.ScalarNode || strings.TrimSpace(request.Value) == "" {
+					v.addIssue(request, "property '%s' must be a curl command", requestPath)
+				}
+				continue
+			}
+			validateWebhookRequest(v, request, requestPath)
+		}
+	}
+	return v.issues
+}
+
+func validateWebhookRequest(v *yamlValidator, request *yaml.Node, requestPath string) {
+	if !v.isMapping(request, requestPath) {
+		return
+	}
+	v.str(request, "url", requestPath+".url", true)
+	v.oneOf(request, "method", requestPath+".method", true, supportedWebhookMethods...)
+	payload, _ := v.str(request, "payload", requestPath+".payload", false)
+	if payloadFile, _ := v.str(request, "payloadFile", requestPath+".payloadFile", false); payload != nil && payloadFile != nil {
+		v.addIssue(payloadFile, "properties '%s.payload' and '%s.payloadFile' must not be set together", requestPath, requestPath)
+	}
+	v.str(request, "options", requestPath+".options", false)
+	for i, header := range v.sequence(request, "headers", requestPath+".headers", false) {
+		headerPath := fmt.Sprintf("%s.headers[%d]", requestPath, i)
+		if v.isMapping(header, headerPath) {
+			v.str(header, "key", headerPath+".key", true)
+			v.str(header, "value", headerPath+".value", true)
+		}
+	}
+}
+
+// ValidateRemediation validates the content of a remediation.yaml file
+func ValidateRemediation(content []byte) []models.ResourceValidationIssue {
+	v := &yamlValidator{}
+	root := v.parse(content)
+	if root == nil {
+		return v.issues
+	}
+
+	if node, apiVersion := v.str(root, "apiVersion", "apiVersion", true); node != nil && !strings.HasPrefix(apiVersion, shipyardAPIVersionPrefix) {
+		v.addIssue(node, "unsupported remediation version '%s', expected %s<version>", apiVersion, shipyardAPIVersionPrefix)
+	}
+	if node, kind := v.str(root, "kind", "kind", true); node != nil && kind != "Remediation" {
+		v.addIssue(node, "property 'kind' must be 'Remediation'")
+	}
+
+	spec := v.mapping(root, "spec", "spec", true)
+	for i, remediation := range v.sequence(spec, "remediations", "spec.remediations", true) {
+		remediationPath := fmt.Sprintf("spec.remediations[%d]", i)
+		if !v.isMapping(remediation, remediationPath) {
+			continue
+		}
+		v.str(remediation, "problemType", remediationPath+".problemType", true)
+		for j, action := range v.sequence(remediation, "actionsOnOpen", remediationPath+".actionsOnOpen", false) {
+			actionPath := fmt.Sprintf("%s.actionsOnOpen[%d]", remediationPath, j)
+			if v.isMapping(action, actionPath) {
+				v.str(action, "action", actionPath+".action", true)
+			}
+		}
+	}
+	return v.issues
+}
+
+// ValidateJMeterConf validates the content of a jmeter/jmeter.conf.yaml file
+func ValidateJMeterConf(content []byte) []models.ResourceValidationIssue {
+	v := &yamlValidator{}
+	root := v.parse(content)
+	if root == nil {
+		return v.issues
+	}
+
+	v.str(root, "spec_version", "spec_version", true)
+	for i, workload := range v.sequence(root, "workloads", "workloads", true) {
+		workloadPath := fmt.Sprintf("workloads[%d]", i)
+		if !v.isMapping(workload, workloadPath) {
+			continue
+		}
+		v.str(workload, "teststrategy", workloadPath+".teststrategy", true)
+		v.str(workload, "script", workloadPath+".script", true)
+		for _, key := range []string{"vuser", "loopcount", "thinktime", "avgrtvalidation"} {
+			v.integer(workload, key, workloadPath+"."+key)
+		}
+		v.number(workload, "acceptederrorrate", workloadPath+".acceptederrorrate")
+		v.mapping(workload, "properties", workloadPath+".properties", false)
+	}
+	return v.issues
+}
+
+// ValidateLighthouseConfig validates the content of a lighthouse.yaml file
+func ValidateLighthouseConfig(content []byte) []models.ResourceValidationIssue {
+	v := &yamlValidator{}
+	root := v.parse(content)
+	if root == nil {
+		return v.issues
+	}
+
+	v.str(root, "sli_provider", "sli_provider", false)
+	for i, stage := range v.sequence(root, "stages", "stages", false) {
+		stagePath := fmt.Sprintf("stages[%d]", i)
+		if !v.isMapping(stage, stagePath) {
+			continue
+		}
+		v.str(stage, "name", stagePath+".name", true)
+		v.str(stage, "sli_provider", stagePath+".sli_provider", false)
+		for j, service := range v.sequence(stage, "services", stagePath+".services", false) {
+			servicePath := fmt.Sprintf("%s.services[%d]", stagePath, j)
+			if !v.isMapping(service, servicePath) {
+				continue
+			}
+			v.str(service, "name", servicePath+".name", true)
+			v.str(service, "sli_provider", servicePath+".sli_provider", true)
+		}
+	}
+	return v.issues
+}
+
+// yamlValidator collects the issues found while walking through the nodes of a YAML document
+type yamlValidator struct {
+	issues []models.ResourceValidationIssue
+}
+
+func (v *yamlValidator) addIssue(node *yaml.Node, format string, args ...interface{}) {
+	issue := models.ResourceValidationIssue{Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		issue.Line = node.Line
+	}
+	v.issues = append(v.issues, issue)
+}
+
+// parse returns the root node of the document, or nil if the content is not a valid YAML object
+func (v *yamlValidator) parse(content []byte) *yaml.Node {
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal(content, document); err != nil {
+		if match := yamlSyntaxErrorRegex.FindStringSubmatch(err.Error()); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			v.issues = append(v.issues, models.ResourceValidationIssue{Line: line, Message: "invalid YAML: " + match[2]})
+		} else {
+			v.addIssue(nil, "invalid YAML: %s", strings.TrimPrefix(err.Error(), "yaml: "))
+		}
+		return nil
+	}
+	if len(document.Content) == 0 {
+		v.addIssue(nil, "resource must not be empty")
+		return nil
+	}
+	root := resolveAlias(document.Content[0])
+	if root.Kind != yaml.MappingNode {
+		v.addIssue(root, "resource must be a YAML object")
+		return nil
+	}
+	return root
+}
+
+// field returns the value of the given key of a mapping node, or nil if it is not set
+func (v *yamlValidator) field(node *yaml.Node, key string) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			value := resolveAlias(node.Content[i+1])
+			if value.Tag == "!!null" {
+				return nil
+			}
+			return value
+		}
+	}
+	return nil
+}
+
+func (v *yamlValidator) required(node *yaml.Node, key, path string, required bool) *yaml.Node {
+	value := v.field(node, key)
+	if value == nil && node != nil && required {
+		v.addIssue(node, "missing required property '%s'", path)
+	}
+	return value
+}
+
+func (v *yamlValidator) isMapping(node *yaml.Node, path string) bool {
+	if node.Kind != yaml.MappingNode {
+		v.addIssue(node, "property '%s' must be an object", path)
+		return false
+	}
+	return true
+}
+
+func (v *yamlValidator) mapping(node *yaml.Node, key, path string, required bool) *yaml.Node {
+	value := v.required(node, key, path, required)
+	if value == nil || !v.isMapping(value, path) {
+		return nil
+	}
+	return value
+}
+
+// sequence returns the items of a list; required lists must not be empty
+func (v *yamlValidator) sequence(node *yaml.Node, key, path string, required bool) []*yaml.Node {
+	value := v.required(node, key, path, required)
+	if value == nil {
+		return nil
+	}
+	if value.Kind != yaml.SequenceNode {
+		v.addIssue(value, "property '%s' must be a list", path)
+		return nil
+	}
+	if required && len(value.Content) == 0 {
+		v.addIssue(value, "property '%s' must not be empty", path)
+	}
+	items := make([]*yaml.Node, 0, len(value.Content))
+	for _, item := range value.Content {
+		items = append(items, resolveAlias(item))
+	}
+	return items
+}
+
+// str returns the node and value of a scalar property; the node is nil if the property is not set or invalid
+func (v *yamlValidator) str(node *yaml.Node, key, path string, required bool) (*yaml.Node, string) {
+	value := v.required(node, key, path, required)
+	if value == nil {
+		return nil, ""
+	}
+	if value.Kind != yaml.ScalarNode {
+		v.addIssue(value, "property '%s' must be a string", path)
+		return nil, ""
+	}
+	if required && strings.TrimSpace(value.Value) == "" {
+		v.addIssue(value, "property '%s' must not be empty", path)
+		return nil, ""
+	}
+	return value, value.Value
+}
+
+// oneOf returns the node and value of a scalar property that must have one of the allowed values
+func (v *yamlValidator) oneOf(node *yaml.Node, key, path string, required bool, allowed ...string) (*yaml.Node, string) {
+	value := v.required(node, key, path, required)
+	if value == nil {
+		return nil, ""
+	}
+	for _, a := range allowed {
+		if value.Kind == yaml.ScalarNode && value.Value == a {
+			return value, value.Value
+		}
+	}
+	v.addIssue(value, "property '%s' has invalid value '%s', expected one of: %s", path, value.Value, strings.Join(allowed, ", "))
+	return nil, ""
+}
+
+func (v *yamlValidator) integer(node *yaml.Node, key, path string) {
+	value := v.field(node, key)
+	if value == nil {
+		return
+	}
+	if i, err := strconv.Atoi(value.Value); value.Kind != yaml.ScalarNode || err != nil || i < 0 {
+		v.addIssue(value, "property '%s' must be a non-negative integer", path)
+	}
+}
+
+func (v *yamlValidator) number(node *yaml.Node, key, path string) {
+	value := v.field(node, key)
+	if value == nil {
+		return
+	}
+	if f, err := strconv.ParseFloat(value.Value, 64); value.Kind != yaml.ScalarNode || err != nil || f < 0 {
+		v.addIssue(value, "property '%s' must be a non-negative number", path)
+	}
+}
+
+func (v *yamlValidator) boolean(node *yaml.Node, key, path string) {
+	value := v.field(node, key)
+	if value == nil {
+		return
+	}
+	if value.Kind != yaml.ScalarNode || value.Tag != "!!bool" {
+		v.addIssue(value, "property '%s' must be true or false", path)
+	}
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
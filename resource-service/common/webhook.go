@@ -0,0 +1,106 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+)
+
+const (
+	WebhookProviderGitHub = "github"
+	WebhookProviderGitLab = "gitlab"
+	WebhookProviderGitea  = "gitea"
+)
+
+const (
+	headerGitHubEvent     = "X-GitHub-Event"
+	headerGitHubSignature = "X-Hub-Signature-256"
+	headerGitLabEvent     = "X-Gitlab-Event"
+	headerGitLabToken     = "X-Gitlab-Token"
+	headerGiteaEvent      = "X-Gitea-Event"
+	headerGiteaSignature  = "X-Gitea-Signature"
+)
+
+const branchRefPrefix = "refs/heads/"
+
+// pushWebhookPayload contains the properties of a push webhook payload that are shared by GitHub, GitLab and Gitea
+type pushWebhookPayload struct {
+	Ref    string `json:"ref"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// ParseUpstreamWebhook validates the signature of a webhook sent by the upstream of a project and returns the contained push event.
+// If the webhook does not announce a new commit on a branch (e.g. ping events, tag pushes or deleted branches), nil is returned
+func ParseUpstreamWebhook(header http.Header, payload []byte, secret string) (*common_models.UpstreamPushEvent, error) {
+	var provider, event string
+	var err error
+	// Gitea additionally sends the GitHub headers, therefore it needs to be checked first
+	switch {
+	case header.Get(headerGiteaEvent) != "":
+		provider, event = WebhookProviderGitea, header.Get(headerGiteaEvent)
+		err = validateHMACSignature(payload, secret, header.Get(headerGiteaSignature))
+	case header.Get(headerGitHubEvent) != "":
+		provider, event = WebhookProviderGitHub, header.Get(headerGitHubEvent)
+		err = validateHMACSignature(payload, secret, strings.TrimPrefix(header.Get(headerGitHubSignature), "sha256="))
+	case header.Get(headerGitLabEvent) != "":
+		provider, event = WebhookProviderGitLab, header.Get(headerGitLabEvent)
+		err = validateToken(secret, header.Get(headerGitLabToken))
+	default:
+		return nil, kerrors.ErrWebhookUnsupportedProvider
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if event != "push" && event != "Push Hook" {
+		return nil, nil
+	}
+
+	push := &pushWebhookPayload{}
+	if err := json.Unmarshal(payload, push); err != nil {
+		return nil, kerrors.ErrWebhookInvalidPayload
+	}
+	if !strings.HasPrefix(push.Ref, branchRefPrefix) || push.After == "" || isZeroCommitID(push.After) {
+		return nil, nil
+	}
+
+	return &common_models.UpstreamPushEvent{
+		Provider:         provider,
+		Branch:           strings.TrimPrefix(push.Ref, branchRefPrefix),
+		CommitID:         push.After,
+		PreviousCommitID: push.Before,
+	}, nil
+}
+
+func validateHMACSignature(payload []byte, secret, signature string) error {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || len(expected) == 0 {
+		return kerrors.ErrWebhookInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return kerrors.ErrWebhookInvalidSignature
+	}
+	return nil
+}
+
+func validateToken(secret, token string) error {
+	if token == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(token)) != 1 {
+		return kerrors.ErrWebhookInvalidSignature
+	}
+	return nil
+}
+
+// isZeroCommitID checks whether the given commit ID consists of zeros only, which is used to indicate deleted branches
+func isZeroCommitID(commitID string) bool {
+	return strings.Trim(commitID, "0") == ""
+}
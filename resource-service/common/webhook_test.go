@@ -0,0 +1,146 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const testPushPayload = `{"ref": "refs/heads/main", "before": "old-commit", "after": "new-commit"}`
+
+func TestParseUpstreamWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  map[string]string
+		payload string
+		want    *common_models.UpstreamPushEvent
+		wantErr error
+	}{
+		{
+			name: "github push",
+			header: map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": "sha256=" + sign(testPushPayload, "my-secret"),
+			},
+			payload: testPushPayload,
+			want:    &common_models.UpstreamPushEvent{Provider: WebhookProviderGitHub, Branch: "main", CommitID: "new-commit", PreviousCommitID: "old-commit"},
+		},
+		{
+			name: "github push with invalid signature",
+			header: map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": "sha256=" + sign(testPushPayload, "other-secret"),
+			},
+			payload: testPushPayload,
+			wantErr: kerrors.ErrWebhookInvalidSignature,
+		},
+		{
+			name: "github push without signature",
+			header: map[string]string{
+				"X-GitHub-Event": "push",
+			},
+			payload: testPushPayload,
+			wantErr: kerrors.ErrWebhookInvalidSignature,
+		},
+		{
+			name: "github ping is ignored",
+			header: map[string]string{
+				"X-GitHub-Event":      "ping",
+				"X-Hub-Signature-256": "sha256=" + sign(`{"zen": "hello"}`, "my-secret"),
+			},
+			payload: `{"zen": "hello"}`,
+			want:    nil,
+		},
+		{
+			name: "gitea push",
+			header: map[string]string{
+				"X-Gitea-Event":     "push",
+				"X-Gitea-Signature": sign(testPushPayload, "my-secret"),
+				"X-GitHub-Event":    "push",
+			},
+			payload: testPushPayload,
+			want:    &common_models.UpstreamPushEvent{Provider: WebhookProviderGitea, Branch: "main", CommitID: "new-commit", PreviousCommitID: "old-commit"},
+		},
+		{
+			name: "gitlab push",
+			header: map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": "my-secret",
+			},
+			payload: testPushPayload,
+			want:    &common_models.UpstreamPushEvent{Provider: WebhookProviderGitLab, Branch: "main", CommitID: "new-commit", PreviousCommitID: "old-commit"},
+		},
+		{
+			name: "gitlab push with invalid token",
+			header: map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": "other-secret",
+			},
+			payload: testPushPayload,
+			wantErr: kerrors.ErrWebhookInvalidSignature,
+		},
+		{
+			name: "deleted branch is ignored",
+			header: map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": "my-secret",
+			},
+			payload: `{"ref": "refs/heads/main", "before": "old-commit", "after": "0000000000000000000000000000000000000000"}`,
+			want:    nil,
+		},
+		{
+			name: "tag push is ignored",
+			header: map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": "my-secret",
+			},
+			payload: `{"ref": "refs/tags/1.0.0", "before": "old-commit", "after": "new-commit"}`,
+			want:    nil,
+		},
+		{
+			name: "invalid payload",
+			header: map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": "my-secret",
+			},
+			payload: `invalid`,
+			wantErr: kerrors.ErrWebhookInvalidPayload,
+		},
+		{
+			name:    "unsupported provider",
+			header:  map[string]string{},
+			payload: testPushPayload,
+			wantErr: kerrors.ErrWebhookUnsupportedProvider,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range tt.header {
+				header.Set(key, value)
+			}
+
+			got, err := ParseUpstreamWebhook(header, []byte(tt.payload), "my-secret")
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, got)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func sign(payload, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -8,4 +8,7 @@ type ConfigurationContextParams struct {
 	Service                 *models.Service
 	GitContext              GitContext
 	CheckConfigDirAvailable bool
+	// SkipCheckout only resolves the configuration path without checking out (and fetching) the branch.
+	// Since the working tree might belong to another branch, the availability of the config dir is not checked in this case
+	SkipCheckout bool
 }
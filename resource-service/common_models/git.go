@@ -3,6 +3,7 @@ package common_models
 import (
 	"net/url"
 	"strings"
+	"time"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 
@@ -57,3 +58,19 @@ func (g GitCredentials) validateRemoteURLAndToken() error {
 	}
 	return nil
 }
+
+// GitCommit contains the metadata of a commit in the git repository of a project
+type GitCommit struct {
+	ID        string
+	Author    string
+	Message   string
+	Timestamp time.Time
+}
+
+// UpstreamPushEvent contains the information of a push webhook sent by the upstream of a project
+type UpstreamPushEvent struct {
+	Provider         string
+	Branch           string
+	CommitID         string
+	PreviousCommitID string
+}
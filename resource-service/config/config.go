@@ -1,8 +1,22 @@
 package config
 
+import "time"
+
 var Global EnvConfig
 
 type EnvConfig struct {
 	LogLevel                string `envconfig:"LOG_LEVEL" default:"info"`
 	DirectoryStageStructure bool   `envconfig:"DIRECTORY_STAGE_STRUCTURE" default:"false"`
+	// ResourceCacheStalenessWindow is the duration for which the latest revision of a project branch is served without pulling from the upstream
+	ResourceCacheStalenessWindow time.Duration `envconfig:"RESOURCE_CACHE_STALENESS_WINDOW" default:"5s"`
+	// ResourceCacheMaxSize is the maximum size of the cached resource contents in bytes
+	ResourceCacheMaxSize int64 `envconfig:"RESOURCE_CACHE_MAX_SIZE" default:"10485760"`
+	// ObjectStoreEndpoint is the endpoint of the S3 compatible object store used for projects without git upstream.
+	// If it is empty, git is the only available storage backend
+	ObjectStoreEndpoint        string `envconfig:"OBJECT_STORE_ENDPOINT" default:""`
+	ObjectStoreRegion          string `envconfig:"OBJECT_STORE_REGION" default:""`
+	ObjectStoreBucket          string `envconfig:"OBJECT_STORE_BUCKET" default:"keptn-resources"`
+	ObjectStoreAccessKeyID     string `envconfig:"OBJECT_STORE_ACCESS_KEY_ID" default:""`
+	ObjectStoreSecretAccessKey string `envconfig:"OBJECT_STORE_SECRET_ACCESS_KEY" default:""`
+	ObjectStoreUseSSL          bool   `envconfig:"OBJECT_STORE_USE_SSL" default:"true"`
 }
@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/handler"
+)
+
+type MetricsController struct {
+	MetricsHandler handler.IMetricsHandler
+}
+
+func NewMetricsController(metricsHandler handler.IMetricsHandler) Controller {
+	return &MetricsController{MetricsHandler: metricsHandler}
+}
+
+func (controller MetricsController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.GET("/metrics", controller.MetricsHandler.GetMetrics)
+}
@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/handler"
+)
+
+type UpstreamController struct {
+	UpstreamHandler handler.IUpstreamHandler
+}
+
+func NewUpstreamController(upstreamHandler handler.IUpstreamHandler) Controller {
+	return &UpstreamController{UpstreamHandler: upstreamHandler}
+}
+
+func (controller UpstreamController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.POST("/project/:projectName/webhook", controller.UpstreamHandler.ReceiveWebhook)
+}
@@ -40,6 +40,9 @@ var ErrResourceNotFound = New("resource not found")
 var ErrResourceAlreadyExists = New("resource already exists")
 var ErrResourceNotBase64Encoded = New("resource content is not base64 encoded")
 var ErrResourceInvalidResourceURI = New("invalid resource uri")
+var ErrResourceInvalidGitCommitID = New("invalid git commit id")
+var ErrResourceInvalidContent = New("invalid resource content")
+var ErrResourceInvalidValidationMode = New("invalid validation mode, must be 'only'")
 
 // Git specific errors
 
@@ -96,6 +99,18 @@ var ErrInvalidRemoteURL = New("remoteURL scheme must be http, https or ssh")
 var ErrProxyInvalidURL = New("proxy URL must contain IP address and port (<ip-address>:<port>)")
 var ErrInvalidCredentials = New("credentials need to have ssh or http auth method")
 
+// Upstream webhook specific errors
+
+var ErrWebhookSecretNotFound = New("could not find upstream webhook secret")
+var ErrWebhookInvalidSignature = New("invalid webhook signature")
+var ErrWebhookUnsupportedProvider = New("unsupported webhook provider")
+var ErrWebhookInvalidPayload = New("invalid webhook payload")
+
+// Storage backend specific errors
+
+var ErrStorageBackendNotAvailable = New("storage backend not available")
+var ErrObjectNotFound = New("object not found")
+
 // Error messages
 
 const ErrMsgCouldNotRetrieveCredentials = "could not read credentials for project %s: %w"
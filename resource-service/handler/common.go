@@ -18,7 +18,10 @@ const pathParamResourceURI = "resourceURI"
 func OnAPIError(c *gin.Context, err error) {
 	logger.Infof("Could not complete request %s %s: %v", c.Request.Method, c.Request.RequestURI, err)
 
-	if check, resourceType := alreadyExists(err); check {
+	var validationErr *models.ResourceValidationError
+	if errors.As(err, &validationErr) {
+		SetResourceValidationErrorResponse(c, validationErr)
+	} else if check, resourceType := alreadyExists(err); check {
 		SetConflictErrorResponse(c, resourceType+" already exists")
 	} else if errors.Is(err, errors2.ErrInvalidGitToken) {
 		SetFailedDependencyErrorResponse(c, "Invalid git token")
@@ -30,6 +33,16 @@ func OnAPIError(c *gin.Context, err error) {
 		SetBadRequestErrorResponse(c, "Upstream repository not found")
 	} else if errors.Is(err, errors2.ErrRepositoryNotFound) {
 		SetNotFoundErrorResponse(c, "Upstream repository not found")
+	} else if errors.Is(err, errors2.ErrResolveRevision) {
+		SetBadRequestErrorResponse(c, "Invalid revision")
+	} else if errors.Is(err, errors2.ErrStorageBackendNotAvailable) {
+		SetBadRequestErrorResponse(c, "Storage backend not available")
+	} else if errors.Is(err, errors2.ErrWebhookSecretNotFound) {
+		SetNotFoundErrorResponse(c, "Could not find webhook secret for upstream repository")
+	} else if errors.Is(err, errors2.ErrWebhookInvalidSignature) {
+		SetUnauthorizedErrorResponse(c, "Invalid webhook signature")
+	} else if errors.Is(err, errors2.ErrWebhookUnsupportedProvider) || errors.Is(err, errors2.ErrWebhookInvalidPayload) {
+		SetBadRequestErrorResponse(c, err.Error())
 	} else if check, resourceType := resourceNotFound(err); check {
 		SetNotFoundErrorResponse(c, resourceType+" not found")
 	} else {
@@ -90,6 +103,21 @@ func SetBadRequestErrorResponse(c *gin.Context, msg string) {
 	})
 }
 
+func SetResourceValidationErrorResponse(c *gin.Context, err *models.ResourceValidationError) {
+	c.JSON(http.StatusBadRequest, models.ResourceValidationError{
+		Code:    http.StatusBadRequest,
+		Message: err.Message,
+		Issues:  err.Issues,
+	})
+}
+
+func SetUnauthorizedErrorResponse(c *gin.Context, msg string) {
+	c.JSON(http.StatusUnauthorized, models.Error{
+		Code:    http.StatusUnauthorized,
+		Message: msg,
+	})
+}
+
 func SetConflictErrorResponse(c *gin.Context, msg string) {
 	c.JSON(http.StatusConflict, models.Error{
 		Code:    http.StatusConflict,
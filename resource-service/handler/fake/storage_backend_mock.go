@@ -0,0 +1,775 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package handler_mock
+
+import (
+	"github.com/keptn/keptn/resource-service/models"
+	"sync"
+)
+
+// IStorageBackendMock is a mock implementation of handler.IStorageBackend.
+//
+//	func TestSomethingThatUsesIStorageBackend(t *testing.T) {
+//
+//		// make and configure a mocked handler.IStorageBackend
+//		mockedIStorageBackend := &IStorageBackendMock{
+//			CreateProjectFunc: func(project models.CreateProjectParams) error {
+//				panic("mock out the CreateProject method")
+//			},
+//			CreateResourcesFunc: func(params models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+//				panic("mock out the CreateResources method")
+//			},
+//			CreateServiceFunc: func(params models.CreateServiceParams) error {
+//				panic("mock out the CreateService method")
+//			},
+//			CreateStageFunc: func(params models.CreateStageParams) error {
+//				panic("mock out the CreateStage method")
+//			},
+//			DeleteProjectFunc: func(projectName string) error {
+//				panic("mock out the DeleteProject method")
+//			},
+//			DeleteResourceFunc: func(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
+//				panic("mock out the DeleteResource method")
+//			},
+//			DeleteServiceFunc: func(params models.DeleteServiceParams) error {
+//				panic("mock out the DeleteService method")
+//			},
+//			DeleteStageFunc: func(params models.DeleteStageParams) error {
+//				panic("mock out the DeleteStage method")
+//			},
+//			GetResourceFunc: func(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+//				panic("mock out the GetResource method")
+//			},
+//			GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+//				panic("mock out the GetResourceDiff method")
+//			},
+//			GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+//				panic("mock out the GetResourceHistory method")
+//			},
+//			GetResourcesFunc: func(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
+//				panic("mock out the GetResources method")
+//			},
+//			ProjectExistsFunc: func(projectName string) (bool, error) {
+//				panic("mock out the ProjectExists method")
+//			},
+//			RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+//				panic("mock out the RevertResource method")
+//			},
+//			UpdateProjectFunc: func(project models.UpdateProjectParams) error {
+//				panic("mock out the UpdateProject method")
+//			},
+//			UpdateResourceFunc: func(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
+//				panic("mock out the UpdateResource method")
+//			},
+//			UpdateResourcesFunc: func(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
+//				panic("mock out the UpdateResources method")
+//			},
+//		}
+//
+//		// use mockedIStorageBackend in code that requires handler.IStorageBackend
+//		// and then make assertions.
+//
+//	}
+type IStorageBackendMock struct {
+	// CreateProjectFunc mocks the CreateProject method.
+	CreateProjectFunc func(project models.CreateProjectParams) error
+
+	// CreateResourcesFunc mocks the CreateResources method.
+	CreateResourcesFunc func(params models.CreateResourcesParams) (*models.WriteResourceResponse, error)
+
+	// CreateServiceFunc mocks the CreateService method.
+	CreateServiceFunc func(params models.CreateServiceParams) error
+
+	// CreateStageFunc mocks the CreateStage method.
+	CreateStageFunc func(params models.CreateStageParams) error
+
+	// DeleteProjectFunc mocks the DeleteProject method.
+	DeleteProjectFunc func(projectName string) error
+
+	// DeleteResourceFunc mocks the DeleteResource method.
+	DeleteResourceFunc func(params models.DeleteResourceParams) (*models.WriteResourceResponse, error)
+
+	// DeleteServiceFunc mocks the DeleteService method.
+	DeleteServiceFunc func(params models.DeleteServiceParams) error
+
+	// DeleteStageFunc mocks the DeleteStage method.
+	DeleteStageFunc func(params models.DeleteStageParams) error
+
+	// GetResourceFunc mocks the GetResource method.
+	GetResourceFunc func(params models.GetResourceParams) (*models.GetResourceResponse, error)
+
+	// GetResourceDiffFunc mocks the GetResourceDiff method.
+	GetResourceDiffFunc func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error)
+
+	// GetResourceHistoryFunc mocks the GetResourceHistory method.
+	GetResourceHistoryFunc func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error)
+
+	// GetResourcesFunc mocks the GetResources method.
+	GetResourcesFunc func(params models.GetResourcesParams) (*models.GetResourcesResponse, error)
+
+	// ProjectExistsFunc mocks the ProjectExists method.
+	ProjectExistsFunc func(projectName string) (bool, error)
+
+	// RevertResourceFunc mocks the RevertResource method.
+	RevertResourceFunc func(params models.RevertResourceParams) (*models.WriteResourceResponse, error)
+
+	// UpdateProjectFunc mocks the UpdateProject method.
+	UpdateProjectFunc func(project models.UpdateProjectParams) error
+
+	// UpdateResourceFunc mocks the UpdateResource method.
+	UpdateResourceFunc func(params models.UpdateResourceParams) (*models.WriteResourceResponse, error)
+
+	// UpdateResourcesFunc mocks the UpdateResources method.
+	UpdateResourcesFunc func(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateProject holds details about calls to the CreateProject method.
+		CreateProject []struct {
+			// Project is the project argument value.
+			Project models.CreateProjectParams
+		}
+		// CreateResources holds details about calls to the CreateResources method.
+		CreateResources []struct {
+			// Params is the params argument value.
+			Params models.CreateResourcesParams
+		}
+		// CreateService holds details about calls to the CreateService method.
+		CreateService []struct {
+			// Params is the params argument value.
+			Params models.CreateServiceParams
+		}
+		// CreateStage holds details about calls to the CreateStage method.
+		CreateStage []struct {
+			// Params is the params argument value.
+			Params models.CreateStageParams
+		}
+		// DeleteProject holds details about calls to the DeleteProject method.
+		DeleteProject []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+		}
+		// DeleteResource holds details about calls to the DeleteResource method.
+		DeleteResource []struct {
+			// Params is the params argument value.
+			Params models.DeleteResourceParams
+		}
+		// DeleteService holds details about calls to the DeleteService method.
+		DeleteService []struct {
+			// Params is the params argument value.
+			Params models.DeleteServiceParams
+		}
+		// DeleteStage holds details about calls to the DeleteStage method.
+		DeleteStage []struct {
+			// Params is the params argument value.
+			Params models.DeleteStageParams
+		}
+		// GetResource holds details about calls to the GetResource method.
+		GetResource []struct {
+			// Params is the params argument value.
+			Params models.GetResourceParams
+		}
+		// GetResourceDiff holds details about calls to the GetResourceDiff method.
+		GetResourceDiff []struct {
+			// Params is the params argument value.
+			Params models.GetResourceDiffParams
+		}
+		// GetResourceHistory holds details about calls to the GetResourceHistory method.
+		GetResourceHistory []struct {
+			// Params is the params argument value.
+			Params models.GetResourceHistoryParams
+		}
+		// GetResources holds details about calls to the GetResources method.
+		GetResources []struct {
+			// Params is the params argument value.
+			Params models.GetResourcesParams
+		}
+		// ProjectExists holds details about calls to the ProjectExists method.
+		ProjectExists []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+		}
+		// RevertResource holds details about calls to the RevertResource method.
+		RevertResource []struct {
+			// Params is the params argument value.
+			Params models.RevertResourceParams
+		}
+		// UpdateProject holds details about calls to the UpdateProject method.
+		UpdateProject []struct {
+			// Project is the project argument value.
+			Project models.UpdateProjectParams
+		}
+		// UpdateResource holds details about calls to the UpdateResource method.
+		UpdateResource []struct {
+			// Params is the params argument value.
+			Params models.UpdateResourceParams
+		}
+		// UpdateResources holds details about calls to the UpdateResources method.
+		UpdateResources []struct {
+			// Params is the params argument value.
+			Params models.UpdateResourcesParams
+		}
+	}
+	lockCreateProject      sync.RWMutex
+	lockCreateResources    sync.RWMutex
+	lockCreateService      sync.RWMutex
+	lockCreateStage        sync.RWMutex
+	lockDeleteProject      sync.RWMutex
+	lockDeleteResource     sync.RWMutex
+	lockDeleteService      sync.RWMutex
+	lockDeleteStage        sync.RWMutex
+	lockGetResource        sync.RWMutex
+	lockGetResourceDiff    sync.RWMutex
+	lockGetResourceHistory sync.RWMutex
+	lockGetResources       sync.RWMutex
+	lockProjectExists      sync.RWMutex
+	lockRevertResource     sync.RWMutex
+	lockUpdateProject      sync.RWMutex
+	lockUpdateResource     sync.RWMutex
+	lockUpdateResources    sync.RWMutex
+}
+
+// CreateProject calls CreateProjectFunc.
+func (mock *IStorageBackendMock) CreateProject(project models.CreateProjectParams) error {
+	if mock.CreateProjectFunc == nil {
+		panic("IStorageBackendMock.CreateProjectFunc: method is nil but IStorageBackend.CreateProject was just called")
+	}
+	callInfo := struct {
+		Project models.CreateProjectParams
+	}{
+		Project: project,
+	}
+	mock.lockCreateProject.Lock()
+	mock.calls.CreateProject = append(mock.calls.CreateProject, callInfo)
+	mock.lockCreateProject.Unlock()
+	return mock.CreateProjectFunc(project)
+}
+
+// CreateProjectCalls gets all the calls that were made to CreateProject.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.CreateProjectCalls())
+func (mock *IStorageBackendMock) CreateProjectCalls() []struct {
+	Project models.CreateProjectParams
+} {
+	var calls []struct {
+		Project models.CreateProjectParams
+	}
+	mock.lockCreateProject.RLock()
+	calls = mock.calls.CreateProject
+	mock.lockCreateProject.RUnlock()
+	return calls
+}
+
+// CreateResources calls CreateResourcesFunc.
+func (mock *IStorageBackendMock) CreateResources(params models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+	if mock.CreateResourcesFunc == nil {
+		panic("IStorageBackendMock.CreateResourcesFunc: method is nil but IStorageBackend.CreateResources was just called")
+	}
+	callInfo := struct {
+		Params models.CreateResourcesParams
+	}{
+		Params: params,
+	}
+	mock.lockCreateResources.Lock()
+	mock.calls.CreateResources = append(mock.calls.CreateResources, callInfo)
+	mock.lockCreateResources.Unlock()
+	return mock.CreateResourcesFunc(params)
+}
+
+// CreateResourcesCalls gets all the calls that were made to CreateResources.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.CreateResourcesCalls())
+func (mock *IStorageBackendMock) CreateResourcesCalls() []struct {
+	Params models.CreateResourcesParams
+} {
+	var calls []struct {
+		Params models.CreateResourcesParams
+	}
+	mock.lockCreateResources.RLock()
+	calls = mock.calls.CreateResources
+	mock.lockCreateResources.RUnlock()
+	return calls
+}
+
+// CreateService calls CreateServiceFunc.
+func (mock *IStorageBackendMock) CreateService(params models.CreateServiceParams) error {
+	if mock.CreateServiceFunc == nil {
+		panic("IStorageBackendMock.CreateServiceFunc: method is nil but IStorageBackend.CreateService was just called")
+	}
+	callInfo := struct {
+		Params models.CreateServiceParams
+	}{
+		Params: params,
+	}
+	mock.lockCreateService.Lock()
+	mock.calls.CreateService = append(mock.calls.CreateService, callInfo)
+	mock.lockCreateService.Unlock()
+	return mock.CreateServiceFunc(params)
+}
+
+// CreateServiceCalls gets all the calls that were made to CreateService.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.CreateServiceCalls())
+func (mock *IStorageBackendMock) CreateServiceCalls() []struct {
+	Params models.CreateServiceParams
+} {
+	var calls []struct {
+		Params models.CreateServiceParams
+	}
+	mock.lockCreateService.RLock()
+	calls = mock.calls.CreateService
+	mock.lockCreateService.RUnlock()
+	return calls
+}
+
+// CreateStage calls CreateStageFunc.
+func (mock *IStorageBackendMock) CreateStage(params models.CreateStageParams) error {
+	if mock.CreateStageFunc == nil {
+		panic("IStorageBackendMock.CreateStageFunc: method is nil but IStorageBackend.CreateStage was just called")
+	}
+	callInfo := struct {
+		Params models.CreateStageParams
+	}{
+		Params: params,
+	}
+	mock.lockCreateStage.Lock()
+	mock.calls.CreateStage = append(mock.calls.CreateStage, callInfo)
+	mock.lockCreateStage.Unlock()
+	return mock.CreateStageFunc(params)
+}
+
+// CreateStageCalls gets all the calls that were made to CreateStage.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.CreateStageCalls())
+func (mock *IStorageBackendMock) CreateStageCalls() []struct {
+	Params models.CreateStageParams
+} {
+	var calls []struct {
+		Params models.CreateStageParams
+	}
+	mock.lockCreateStage.RLock()
+	calls = mock.calls.CreateStage
+	mock.lockCreateStage.RUnlock()
+	return calls
+}
+
+// DeleteProject calls DeleteProjectFunc.
+func (mock *IStorageBackendMock) DeleteProject(projectName string) error {
+	if mock.DeleteProjectFunc == nil {
+		panic("IStorageBackendMock.DeleteProjectFunc: method is nil but IStorageBackend.DeleteProject was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+	}{
+		ProjectName: projectName,
+	}
+	mock.lockDeleteProject.Lock()
+	mock.calls.DeleteProject = append(mock.calls.DeleteProject, callInfo)
+	mock.lockDeleteProject.Unlock()
+	return mock.DeleteProjectFunc(projectName)
+}
+
+// DeleteProjectCalls gets all the calls that were made to DeleteProject.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.DeleteProjectCalls())
+func (mock *IStorageBackendMock) DeleteProjectCalls() []struct {
+	ProjectName string
+} {
+	var calls []struct {
+		ProjectName string
+	}
+	mock.lockDeleteProject.RLock()
+	calls = mock.calls.DeleteProject
+	mock.lockDeleteProject.RUnlock()
+	return calls
+}
+
+// DeleteResource calls DeleteResourceFunc.
+func (mock *IStorageBackendMock) DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
+	if mock.DeleteResourceFunc == nil {
+		panic("IStorageBackendMock.DeleteResourceFunc: method is nil but IStorageBackend.DeleteResource was just called")
+	}
+	callInfo := struct {
+		Params models.DeleteResourceParams
+	}{
+		Params: params,
+	}
+	mock.lockDeleteResource.Lock()
+	mock.calls.DeleteResource = append(mock.calls.DeleteResource, callInfo)
+	mock.lockDeleteResource.Unlock()
+	return mock.DeleteResourceFunc(params)
+}
+
+// DeleteResourceCalls gets all the calls that were made to DeleteResource.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.DeleteResourceCalls())
+func (mock *IStorageBackendMock) DeleteResourceCalls() []struct {
+	Params models.DeleteResourceParams
+} {
+	var calls []struct {
+		Params models.DeleteResourceParams
+	}
+	mock.lockDeleteResource.RLock()
+	calls = mock.calls.DeleteResource
+	mock.lockDeleteResource.RUnlock()
+	return calls
+}
+
+// DeleteService calls DeleteServiceFunc.
+func (mock *IStorageBackendMock) DeleteService(params models.DeleteServiceParams) error {
+	if mock.DeleteServiceFunc == nil {
+		panic("IStorageBackendMock.DeleteServiceFunc: method is nil but IStorageBackend.DeleteService was just called")
+	}
+	callInfo := struct {
+		Params models.DeleteServiceParams
+	}{
+		Params: params,
+	}
+	mock.lockDeleteService.Lock()
+	mock.calls.DeleteService = append(mock.calls.DeleteService, callInfo)
+	mock.lockDeleteService.Unlock()
+	return mock.DeleteServiceFunc(params)
+}
+
+// DeleteServiceCalls gets all the calls that were made to DeleteService.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.DeleteServiceCalls())
+func (mock *IStorageBackendMock) DeleteServiceCalls() []struct {
+	Params models.DeleteServiceParams
+} {
+	var calls []struct {
+		Params models.DeleteServiceParams
+	}
+	mock.lockDeleteService.RLock()
+	calls = mock.calls.DeleteService
+	mock.lockDeleteService.RUnlock()
+	return calls
+}
+
+// DeleteStage calls DeleteStageFunc.
+func (mock *IStorageBackendMock) DeleteStage(params models.DeleteStageParams) error {
+	if mock.DeleteStageFunc == nil {
+		panic("IStorageBackendMock.DeleteStageFunc: method is nil but IStorageBackend.DeleteStage was just called")
+	}
+	callInfo := struct {
+		Params models.DeleteStageParams
+	}{
+		Params: params,
+	}
+	mock.lockDeleteStage.Lock()
+	mock.calls.DeleteStage = append(mock.calls.DeleteStage, callInfo)
+	mock.lockDeleteStage.Unlock()
+	return mock.DeleteStageFunc(params)
+}
+
+// DeleteStageCalls gets all the calls that were made to DeleteStage.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.DeleteStageCalls())
+func (mock *IStorageBackendMock) DeleteStageCalls() []struct {
+	Params models.DeleteStageParams
+} {
+	var calls []struct {
+		Params models.DeleteStageParams
+	}
+	mock.lockDeleteStage.RLock()
+	calls = mock.calls.DeleteStage
+	mock.lockDeleteStage.RUnlock()
+	return calls
+}
+
+// GetResource calls GetResourceFunc.
+func (mock *IStorageBackendMock) GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+	if mock.GetResourceFunc == nil {
+		panic("IStorageBackendMock.GetResourceFunc: method is nil but IStorageBackend.GetResource was just called")
+	}
+	callInfo := struct {
+		Params models.GetResourceParams
+	}{
+		Params: params,
+	}
+	mock.lockGetResource.Lock()
+	mock.calls.GetResource = append(mock.calls.GetResource, callInfo)
+	mock.lockGetResource.Unlock()
+	return mock.GetResourceFunc(params)
+}
+
+// GetResourceCalls gets all the calls that were made to GetResource.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.GetResourceCalls())
+func (mock *IStorageBackendMock) GetResourceCalls() []struct {
+	Params models.GetResourceParams
+} {
+	var calls []struct {
+		Params models.GetResourceParams
+	}
+	mock.lockGetResource.RLock()
+	calls = mock.calls.GetResource
+	mock.lockGetResource.RUnlock()
+	return calls
+}
+
+// GetResourceDiff calls GetResourceDiffFunc.
+func (mock *IStorageBackendMock) GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+	if mock.GetResourceDiffFunc == nil {
+		panic("IStorageBackendMock.GetResourceDiffFunc: method is nil but IStorageBackend.GetResourceDiff was just called")
+	}
+	callInfo := struct {
+		Params models.GetResourceDiffParams
+	}{
+		Params: params,
+	}
+	mock.lockGetResourceDiff.Lock()
+	mock.calls.GetResourceDiff = append(mock.calls.GetResourceDiff, callInfo)
+	mock.lockGetResourceDiff.Unlock()
+	return mock.GetResourceDiffFunc(params)
+}
+
+// GetResourceDiffCalls gets all the calls that were made to GetResourceDiff.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.GetResourceDiffCalls())
+func (mock *IStorageBackendMock) GetResourceDiffCalls() []struct {
+	Params models.GetResourceDiffParams
+} {
+	var calls []struct {
+		Params models.GetResourceDiffParams
+	}
+	mock.lockGetResourceDiff.RLock()
+	calls = mock.calls.GetResourceDiff
+	mock.lockGetResourceDiff.RUnlock()
+	return calls
+}
+
+// GetResourceHistory calls GetResourceHistoryFunc.
+func (mock *IStorageBackendMock) GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+	if mock.GetResourceHistoryFunc == nil {
+		panic("IStorageBackendMock.GetResourceHistoryFunc: method is nil but IStorageBackend.GetResourceHistory was just called")
+	}
+	callInfo := struct {
+		Params models.GetResourceHistoryParams
+	}{
+		Params: params,
+	}
+	mock.lockGetResourceHistory.Lock()
+	mock.calls.GetResourceHistory = append(mock.calls.GetResourceHistory, callInfo)
+	mock.lockGetResourceHistory.Unlock()
+	return mock.GetResourceHistoryFunc(params)
+}
+
+// GetResourceHistoryCalls gets all the calls that were made to GetResourceHistory.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.GetResourceHistoryCalls())
+func (mock *IStorageBackendMock) GetResourceHistoryCalls() []struct {
+	Params models.GetResourceHistoryParams
+} {
+	var calls []struct {
+		Params models.GetResourceHistoryParams
+	}
+	mock.lockGetResourceHistory.RLock()
+	calls = mock.calls.GetResourceHistory
+	mock.lockGetResourceHistory.RUnlock()
+	return calls
+}
+
+// GetResources calls GetResourcesFunc.
+func (mock *IStorageBackendMock) GetResources(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
+	if mock.GetResourcesFunc == nil {
+		panic("IStorageBackendMock.GetResourcesFunc: method is nil but IStorageBackend.GetResources was just called")
+	}
+	callInfo := struct {
+		Params models.GetResourcesParams
+	}{
+		Params: params,
+	}
+	mock.lockGetResources.Lock()
+	mock.calls.GetResources = append(mock.calls.GetResources, callInfo)
+	mock.lockGetResources.Unlock()
+	return mock.GetResourcesFunc(params)
+}
+
+// GetResourcesCalls gets all the calls that were made to GetResources.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.GetResourcesCalls())
+func (mock *IStorageBackendMock) GetResourcesCalls() []struct {
+	Params models.GetResourcesParams
+} {
+	var calls []struct {
+		Params models.GetResourcesParams
+	}
+	mock.lockGetResources.RLock()
+	calls = mock.calls.GetResources
+	mock.lockGetResources.RUnlock()
+	return calls
+}
+
+// ProjectExists calls ProjectExistsFunc.
+func (mock *IStorageBackendMock) ProjectExists(projectName string) (bool, error) {
+	if mock.ProjectExistsFunc == nil {
+		panic("IStorageBackendMock.ProjectExistsFunc: method is nil but IStorageBackend.ProjectExists was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+	}{
+		ProjectName: projectName,
+	}
+	mock.lockProjectExists.Lock()
+	mock.calls.ProjectExists = append(mock.calls.ProjectExists, callInfo)
+	mock.lockProjectExists.Unlock()
+	return mock.ProjectExistsFunc(projectName)
+}
+
+// ProjectExistsCalls gets all the calls that were made to ProjectExists.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.ProjectExistsCalls())
+func (mock *IStorageBackendMock) ProjectExistsCalls() []struct {
+	ProjectName string
+} {
+	var calls []struct {
+		ProjectName string
+	}
+	mock.lockProjectExists.RLock()
+	calls = mock.calls.ProjectExists
+	mock.lockProjectExists.RUnlock()
+	return calls
+}
+
+// RevertResource calls RevertResourceFunc.
+func (mock *IStorageBackendMock) RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+	if mock.RevertResourceFunc == nil {
+		panic("IStorageBackendMock.RevertResourceFunc: method is nil but IStorageBackend.RevertResource was just called")
+	}
+	callInfo := struct {
+		Params models.RevertResourceParams
+	}{
+		Params: params,
+	}
+	mock.lockRevertResource.Lock()
+	mock.calls.RevertResource = append(mock.calls.RevertResource, callInfo)
+	mock.lockRevertResource.Unlock()
+	return mock.RevertResourceFunc(params)
+}
+
+// RevertResourceCalls gets all the calls that were made to RevertResource.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.RevertResourceCalls())
+func (mock *IStorageBackendMock) RevertResourceCalls() []struct {
+	Params models.RevertResourceParams
+} {
+	var calls []struct {
+		Params models.RevertResourceParams
+	}
+	mock.lockRevertResource.RLock()
+	calls = mock.calls.RevertResource
+	mock.lockRevertResource.RUnlock()
+	return calls
+}
+
+// UpdateProject calls UpdateProjectFunc.
+func (mock *IStorageBackendMock) UpdateProject(project models.UpdateProjectParams) error {
+	if mock.UpdateProjectFunc == nil {
+		panic("IStorageBackendMock.UpdateProjectFunc: method is nil but IStorageBackend.UpdateProject was just called")
+	}
+	callInfo := struct {
+		Project models.UpdateProjectParams
+	}{
+		Project: project,
+	}
+	mock.lockUpdateProject.Lock()
+	mock.calls.UpdateProject = append(mock.calls.UpdateProject, callInfo)
+	mock.lockUpdateProject.Unlock()
+	return mock.UpdateProjectFunc(project)
+}
+
+// UpdateProjectCalls gets all the calls that were made to UpdateProject.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.UpdateProjectCalls())
+func (mock *IStorageBackendMock) UpdateProjectCalls() []struct {
+	Project models.UpdateProjectParams
+} {
+	var calls []struct {
+		Project models.UpdateProjectParams
+	}
+	mock.lockUpdateProject.RLock()
+	calls = mock.calls.UpdateProject
+	mock.lockUpdateProject.RUnlock()
+	return calls
+}
+
+// UpdateResource calls UpdateResourceFunc.
+func (mock *IStorageBackendMock) UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
+	if mock.UpdateResourceFunc == nil {
+		panic("IStorageBackendMock.UpdateResourceFunc: method is nil but IStorageBackend.UpdateResource was just called")
+	}
+	callInfo := struct {
+		Params models.UpdateResourceParams
+	}{
+		Params: params,
+	}
+	mock.lockUpdateResource.Lock()
+	mock.calls.UpdateResource = append(mock.calls.UpdateResource, callInfo)
+	mock.lockUpdateResource.Unlock()
+	return mock.UpdateResourceFunc(params)
+}
+
+// UpdateResourceCalls gets all the calls that were made to UpdateResource.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.UpdateResourceCalls())
+func (mock *IStorageBackendMock) UpdateResourceCalls() []struct {
+	Params models.UpdateResourceParams
+} {
+	var calls []struct {
+		Params models.UpdateResourceParams
+	}
+	mock.lockUpdateResource.RLock()
+	calls = mock.calls.UpdateResource
+	mock.lockUpdateResource.RUnlock()
+	return calls
+}
+
+// UpdateResources calls UpdateResourcesFunc.
+func (mock *IStorageBackendMock) UpdateResources(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
+	if mock.UpdateResourcesFunc == nil {
+		panic("IStorageBackendMock.UpdateResourcesFunc: method is nil but IStorageBackend.UpdateResources was just called")
+	}
+	callInfo := struct {
+		Params models.UpdateResourcesParams
+	}{
+		Params: params,
+	}
+	mock.lockUpdateResources.Lock()
+	mock.calls.UpdateResources = append(mock.calls.UpdateResources, callInfo)
+	mock.lockUpdateResources.Unlock()
+	return mock.UpdateResourcesFunc(params)
+}
+
+// UpdateResourcesCalls gets all the calls that were made to UpdateResources.
+// Check the length with:
+//
+//	len(mockedIStorageBackend.UpdateResourcesCalls())
+func (mock *IStorageBackendMock) UpdateResourcesCalls() []struct {
+	Params models.UpdateResourcesParams
+} {
+	var calls []struct {
+		Params models.UpdateResourcesParams
+	}
+	mock.lockUpdateResources.RLock()
+	calls = mock.calls.UpdateResources
+	mock.lockUpdateResources.RUnlock()
+	return calls
+}
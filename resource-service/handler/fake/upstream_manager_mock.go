@@ -0,0 +1,71 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package handler_mock
+
+import (
+	"github.com/keptn/keptn/resource-service/models"
+	"sync"
+)
+
+// IUpstreamManagerMock is a mock implementation of handler.IUpstreamManager.
+//
+//	func TestSomethingThatUsesIUpstreamManager(t *testing.T) {
+//
+//		// make and configure a mocked handler.IUpstreamManager
+//		mockedIUpstreamManager := &IUpstreamManagerMock{
+//			HandleWebhookFunc: func(params models.UpstreamWebhookParams) (*models.UpstreamWebhookResponse, error) {
+//				panic("mock out the HandleWebhook method")
+//			},
+//		}
+//
+//		// use mockedIUpstreamManager in code that requires handler.IUpstreamManager
+//		// and then make assertions.
+//
+//	}
+type IUpstreamManagerMock struct {
+	// HandleWebhookFunc mocks the HandleWebhook method.
+	HandleWebhookFunc func(params models.UpstreamWebhookParams) (*models.UpstreamWebhookResponse, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// HandleWebhook holds details about calls to the HandleWebhook method.
+		HandleWebhook []struct {
+			// Params is the params argument value.
+			Params models.UpstreamWebhookParams
+		}
+	}
+	lockHandleWebhook sync.RWMutex
+}
+
+// HandleWebhook calls HandleWebhookFunc.
+func (mock *IUpstreamManagerMock) HandleWebhook(params models.UpstreamWebhookParams) (*models.UpstreamWebhookResponse, error) {
+	if mock.HandleWebhookFunc == nil {
+		panic("IUpstreamManagerMock.HandleWebhookFunc: method is nil but IUpstreamManager.HandleWebhook was just called")
+	}
+	callInfo := struct {
+		Params models.UpstreamWebhookParams
+	}{
+		Params: params,
+	}
+	mock.lockHandleWebhook.Lock()
+	mock.calls.HandleWebhook = append(mock.calls.HandleWebhook, callInfo)
+	mock.lockHandleWebhook.Unlock()
+	return mock.HandleWebhookFunc(params)
+}
+
+// HandleWebhookCalls gets all the calls that were made to HandleWebhook.
+// Check the length with:
+//
+//	len(mockedIUpstreamManager.HandleWebhookCalls())
+func (mock *IUpstreamManagerMock) HandleWebhookCalls() []struct {
+	Params models.UpstreamWebhookParams
+} {
+	var calls []struct {
+		Params models.UpstreamWebhookParams
+	}
+	mock.lockHandleWebhook.RLock()
+	calls = mock.calls.HandleWebhook
+	mock.lockHandleWebhook.RUnlock()
+	return calls
+}
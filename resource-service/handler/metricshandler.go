@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/common"
+)
+
+type IMetricsHandler interface {
+	GetMetrics(context *gin.Context)
+}
+
+type MetricsHandler struct {
+	resourceCache *common.ResourceCache
+}
+
+// MetricsResponse contains the metrics exposed by the resource-service
+type MetricsResponse struct {
+	ResourceCache common.CacheMetrics `json:"resourceCache"`
+}
+
+func NewMetricsHandler(resourceCache *common.ResourceCache) *MetricsHandler {
+	return &MetricsHandler{resourceCache: resourceCache}
+}
+
+// GetMetrics returns the hit/miss and pull metrics of the resource cache
+func (h *MetricsHandler) GetMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, MetricsResponse{ResourceCache: h.resourceCache.Metrics()})
+}
@@ -0,0 +1,472 @@
+package handler
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/common"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"gopkg.in/yaml.v3"
+)
+
+// ObjectStorageManager stores projects without a git upstream in an object storage. Stages and services are
+// represented as directories within the project, using the same layout as the directory based stage structure.
+// The revision numbers of the object storage are returned in place of commit IDs
+type ObjectStorageManager struct {
+	storage   *common.ObjectStorage
+	validator common.IResourceValidator
+}
+
+func NewObjectStorageManager(storage *common.ObjectStorage, validator common.IResourceValidator) *ObjectStorageManager {
+	return &ObjectStorageManager{storage: storage, validator: validator}
+}
+
+func (m ObjectStorageManager) ProjectExists(projectName string) (bool, error) {
+	return m.storage.ProjectExists(projectName)
+}
+
+func (m ObjectStorageManager) CreateProject(params models.CreateProjectParams) error {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	metadata, err := yaml.Marshal(&common.ProjectMetadata{
+		ProjectName:               params.ProjectName,
+		CreationTimestamp:         time.Now().UTC().String(),
+		IsUsingDirectoryStructure: true,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create metadata of project %s: %w", params.ProjectName, err)
+	}
+
+	_, err = m.storage.CreateProject(params.ProjectName, "initialized project", map[string][]byte{
+		"metadata.yaml": metadata,
+	})
+	return err
+}
+
+func (m ObjectStorageManager) UpdateProject(params models.UpdateProjectParams) error {
+	// projects in the object storage always use the directory structure, therefore there is nothing to migrate
+	exists, err := m.storage.ProjectExists(params.ProjectName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return kerrors.ErrProjectNotFound
+	}
+	return nil
+}
+
+func (m ObjectStorageManager) DeleteProject(projectName string) error {
+	common.LockProject(projectName)
+	defer common.UnlockProject(projectName)
+
+	return m.storage.DeleteProject(projectName)
+}
+
+func (m ObjectStorageManager) CreateStage(params models.CreateStageParams) error {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	revision, err := m.storage.GetRevision(params.ProjectName, "")
+	if err != nil {
+		return err
+	}
+	stagePath := getObjectStorageConfigPath(&params.Stage, nil)
+	if revision.DirExists(stagePath) {
+		return kerrors.ErrStageAlreadyExists
+	}
+
+	metadata, err := yaml.Marshal(&common.StageMetadata{
+		StageName:         params.StageName,
+		CreationTimestamp: time.Now().UTC().String(),
+	})
+	if err != nil {
+		return fmt.Errorf("could not create metadata file for stage %s: %w", params.StageName, err)
+	}
+
+	_, err = m.storage.Commit(params.ProjectName, "Added stage: "+params.StageName, []common.ObjectChange{
+		{Path: stagePath + "/metadata.yaml", Content: metadata},
+	})
+	return err
+}
+
+func (m ObjectStorageManager) DeleteStage(params models.DeleteStageParams) error {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	revision, err := m.storage.GetRevision(params.ProjectName, "")
+	if err != nil {
+		return err
+	}
+	stagePath := getObjectStorageConfigPath(&params.Stage, nil)
+	if !revision.DirExists(stagePath) {
+		return kerrors.ErrStageNotFound
+	}
+
+	_, err = m.storage.Commit(params.ProjectName, "Removed stage: "+params.StageName, []common.ObjectChange{
+		{Path: stagePath, Delete: true},
+	})
+	return err
+}
+
+func (m ObjectStorageManager) CreateService(params models.CreateServiceParams) error {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	revision, err := m.storage.GetRevision(params.ProjectName, "")
+	if err != nil {
+		return err
+	}
+	if !revision.DirExists(getObjectStorageConfigPath(&params.Stage, nil)) {
+		return kerrors.ErrStageNotFound
+	}
+	servicePath := getObjectStorageConfigPath(&params.Stage, &params.Service)
+	if revision.DirExists(servicePath) {
+		return kerrors.ErrServiceAlreadyExists
+	}
+
+	metadata, err := yaml.Marshal(&common.ServiceMetadata{
+		ServiceName:       params.ServiceName,
+		CreationTimestamp: time.Now().UTC().String(),
+	})
+	if err != nil {
+		return fmt.Errorf("could not create metadata file for service %s: %w", params.ServiceName, err)
+	}
+
+	_, err = m.storage.Commit(params.ProjectName, "Added service: "+params.ServiceName, []common.ObjectChange{
+		{Path: servicePath + "/metadata.yaml", Content: metadata},
+	})
+	return err
+}
+
+func (m ObjectStorageManager) DeleteService(params models.DeleteServiceParams) error {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	revision, err := m.storage.GetRevision(params.ProjectName, "")
+	if err != nil {
+		return err
+	}
+	servicePath := getObjectStorageConfigPath(&params.Stage, &params.Service)
+	if !revision.DirExists(servicePath) {
+		return kerrors.ErrServiceNotFound
+	}
+
+	_, err = m.storage.Commit(params.ProjectName, "Removed service: "+params.ServiceName, []common.ObjectChange{
+		{Path: servicePath, Delete: true},
+	})
+	return err
+}
+
+func (m ObjectStorageManager) CreateResources(params models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	return m.writeResources(params.ResourceContext, params.Resources, "Updated resource", params.ValidateOnly())
+}
+
+func (m ObjectStorageManager) GetResources(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
+	revision, configPath, err := m.establishContext(params.ResourceContext, params.GitCommitID)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, file := range revision.ListFiles(configPath) {
+		// stages are not exposed as resources of the project
+		if !strings.Contains(file, common.StageDirectoryName) {
+			files = append(files, file)
+		}
+	}
+
+	return paginateResourceURIs(files, params.PageSize, params.NextPageKey, models.Version{Version: revision.Revision}), nil
+}
+
+func (m ObjectStorageManager) UpdateResources(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	return m.writeResources(params.ResourceContext, params.Resources, "Updated resource", params.ValidateOnly())
+}
+
+func (m ObjectStorageManager) GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	gitCommitID := params.GitCommitID
+	if gitCommitID == "\"\"" {
+		gitCommitID = ""
+	}
+	revision, configPath, err := m.establishContext(params.ResourceContext, gitCommitID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := m.readResource(params.ProjectName, revision, joinObjectStoragePath(configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+	return newGetResourceResponse(params.ResourceURI, common.CachedResource{Content: content}, revision.Revision), nil
+}
+
+func (m ObjectStorageManager) UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	return m.writeResources(params.ResourceContext, []models.Resource{
+		{ResourceURI: params.ResourceURI, ResourceContent: params.ResourceContent},
+	}, "Updated resource", params.ValidateOnly())
+}
+
+func (m ObjectStorageManager) DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, configPath, err := m.establishContext(params.ResourceContext, "")
+	if err != nil {
+		return nil, err
+	}
+
+	resourcePath := joinObjectStoragePath(configPath, unescapedResourceName)
+	if common.IsHelmChartPath(resourcePath) {
+		resourcePath = getHelmChartDir(resourcePath)
+	}
+	if _, ok := revision.Files[resourcePath]; !ok && !revision.DirExists(resourcePath) {
+		return nil, kerrors.ErrResourceNotFound
+	}
+
+	return m.commit(params.ProjectName, "Deleted resources", []common.ObjectChange{
+		{Path: resourcePath, Delete: true},
+	})
+}
+
+func (m ObjectStorageManager) GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+	revision, configPath, err := m.establishContext(params.ResourceContext, "")
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	commits, err := m.storage.GetFileHistory(params.ProjectName, joinObjectStoragePath(configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.GetResourceHistoryResponse{
+		ResourceURI: params.ResourceURI,
+		Revisions:   []models.ResourceRevision{},
+		Metadata: models.Version{
+			Version: revision.Revision,
+		},
+	}
+	for _, commit := range commits {
+		result.Revisions = append(result.Revisions, models.ResourceRevision{
+			CommitID:  commit.ID,
+			Author:    commit.Author,
+			Message:   commit.Message,
+			Timestamp: commit.Timestamp,
+		})
+	}
+	return result, nil
+}
+
+func (m ObjectStorageManager) GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+	revision, configPath, err := m.establishContext(params.ResourceContext, "")
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	toCommitID := params.ToCommitID
+	if toCommitID == "" {
+		toCommitID = revision.Revision
+	}
+
+	diff, err := m.storage.GetFileDiff(params.ProjectName, params.FromCommitID, toCommitID, joinObjectStoragePath(configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetResourceDiffResponse{
+		ResourceURI:  params.ResourceURI,
+		FromCommitID: params.FromCommitID,
+		ToCommitID:   toCommitID,
+		Diff:         diff,
+	}, nil
+}
+
+func (m ObjectStorageManager) RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	revision, configPath, err := m.establishContext(params.ResourceContext, params.GitCommitID)
+	if err != nil {
+		return nil, err
+	}
+
+	resourcePath := joinObjectStoragePath(configPath, unescapedResourceName)
+	content, err := m.readResource(params.ProjectName, revision, resourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.validator.Validate([]models.Resource{
+		{ResourceURI: unescapedResourceName, ResourceContent: models.ResourceContent(base64.StdEncoding.EncodeToString(content))},
+	}); err != nil {
+		return nil, err
+	}
+
+	changes, err := getResourceChanges(resourcePath, content)
+	if err != nil {
+		return nil, err
+	}
+	message := fmt.Sprintf("Reverted resource %s to revision %s", unescapedResourceName, params.GitCommitID)
+	return m.commit(params.ProjectName, message, changes)
+}
+
+// establishContext reads the given revision of a project and checks that the stage and service of the resource
+// context exist in this revision. It returns the revision together with the config path of the resource context
+func (m ObjectStorageManager) establishContext(resourceContext models.ResourceContext, revisionID string) (*common.ObjectRevision, string, error) {
+	revision, err := m.storage.GetRevision(resourceContext.ProjectName, revisionID)
+	if err != nil {
+		return nil, "", err
+	}
+	if resourceContext.Stage != nil && !revision.DirExists(getObjectStorageConfigPath(resourceContext.Stage, nil)) {
+		return nil, "", kerrors.ErrStageNotFound
+	}
+	configPath := getObjectStorageConfigPath(resourceContext.Stage, resourceContext.Service)
+	if resourceContext.Stage != nil && resourceContext.Service != nil && !revision.DirExists(configPath) {
+		return nil, "", kerrors.ErrServiceNotFound
+	}
+	return revision, configPath, nil
+}
+
+func (m ObjectStorageManager) writeResources(resourceContext models.ResourceContext, resources []models.Resource, message string, validateOnly bool) (*models.WriteResourceResponse, error) {
+	_, configPath, err := m.establishContext(resourceContext, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.validator.Validate(resources); err != nil {
+		return nil, err
+	}
+	if validateOnly {
+		return &models.WriteResourceResponse{}, nil
+	}
+
+	changes := []common.ObjectChange{}
+	for _, resource := range resources {
+		content, err := base64.StdEncoding.DecodeString(string(resource.ResourceContent))
+		if err != nil {
+			return nil, kerrors.ErrResourceNotBase64Encoded
+		}
+		resourceChanges, err := getResourceChanges(joinObjectStoragePath(configPath, resource.ResourceURI), content)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, resourceChanges...)
+	}
+	return m.commit(resourceContext.ProjectName, message, changes)
+}
+
+// readResource returns the content of a resource at the given revision. Helm charts are packaged from the files of
+// the chart directory, as they are for projects stored in git
+func (m ObjectStorageManager) readResource(projectName string, revision *common.ObjectRevision, resourcePath string) ([]byte, error) {
+	if !common.IsHelmChartPath(resourcePath) {
+		return m.storage.ReadFile(projectName, revision, resourcePath)
+	}
+	chartDir := getHelmChartDir(resourcePath)
+	files := map[string][]byte{}
+	for _, file := range revision.ListFiles(chartDir) {
+		content, err := m.storage.ReadFile(projectName, revision, chartDir+"/"+file)
+		if err != nil {
+			return nil, err
+		}
+		files[file] = content
+	}
+	if len(files) == 0 {
+		return nil, kerrors.ErrResourceNotFound
+	}
+	return common.PackHelmChart(path.Base(chartDir), files)
+}
+
+// getResourceChanges returns the changes writing a resource. Helm charts are unpacked into the chart directory,
+// replacing its previous content, so that the files of the chart can be updated individually as for projects stored
+// in git
+func getResourceChanges(resourcePath string, content []byte) ([]common.ObjectChange, error) {
+	if !common.IsHelmChartPath(resourcePath) {
+		return []common.ObjectChange{{Path: resourcePath, Content: content}}, nil
+	}
+	files, err := common.UnpackHelmChart(content)
+	if err != nil {
+		return nil, err
+	}
+	chartDir := getHelmChartDir(resourcePath)
+	changes := []common.ObjectChange{{Path: chartDir, Delete: true}}
+	for file, fileContent := range files {
+		changes = append(changes, common.ObjectChange{Path: chartDir + "/" + file, Content: fileContent})
+	}
+	return changes, nil
+}
+
+func getHelmChartDir(resourcePath string) string {
+	return strings.TrimSuffix(resourcePath, ".tgz")
+}
+
+func (m ObjectStorageManager) commit(projectName, message string, changes []common.ObjectChange) (*models.WriteResourceResponse, error) {
+	revision, err := m.storage.Commit(projectName, message, changes)
+	if err != nil {
+		return nil, err
+	}
+	return &models.WriteResourceResponse{
+		CommitID: revision,
+		Metadata: models.Version{
+			Version: revision,
+		},
+	}, nil
+}
+
+// getObjectStorageConfigPath returns the directory of a stage or service within the object storage of a project
+func getObjectStorageConfigPath(stage *models.Stage, service *models.Service) string {
+	if stage == nil {
+		return ""
+	}
+	stagePath := common.StageDirectoryName + "/" + stage.StageName
+	if service == nil {
+		return stagePath
+	}
+	return stagePath + "/" + service.ServiceName
+}
+
+func joinObjectStoragePath(dir, name string) string {
+	name = strings.TrimPrefix(name, "/")
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
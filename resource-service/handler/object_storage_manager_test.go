@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/keptn/keptn/resource-service/common"
+	common_mock "github.com/keptn/keptn/resource-service/common/fake"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestObjectStorageManager(t *testing.T) *ObjectStorageManager {
+	return newTestObjectStorageManagerWithValidator(t, &common_mock.IResourceValidatorMock{
+		ValidateFunc: func(resources []models.Resource) error { return nil },
+	})
+}
+
+func newTestObjectStorageManagerWithValidator(t *testing.T, validator common.IResourceValidator) *ObjectStorageManager {
+	manager := NewObjectStorageManager(common.NewObjectStorage(common_mock.NewInMemoryObjectStore()), validator)
+
+	require.Nil(t, manager.CreateProject(models.CreateProjectParams{
+		Project:        models.Project{ProjectName: "my-project"},
+		StorageBackend: models.StorageBackendObjectStore,
+	}))
+	require.Nil(t, manager.CreateStage(models.CreateStageParams{
+		Project:            models.Project{ProjectName: "my-project"},
+		CreateStagePayload: models.CreateStagePayload{Stage: models.Stage{StageName: "dev"}},
+	}))
+	require.Nil(t, manager.CreateService(models.CreateServiceParams{
+		Project:              models.Project{ProjectName: "my-project"},
+		Stage:                models.Stage{StageName: "dev"},
+		CreateServicePayload: models.CreateServicePayload{Service: models.Service{ServiceName: "my-service"}},
+	}))
+	return manager
+}
+
+func newServiceResourceContext() models.ResourceContext {
+	return models.ResourceContext{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   &models.Stage{StageName: "dev"},
+		Service: &models.Service{ServiceName: "my-service"},
+	}
+}
+
+func encodeResourceContent(content string) models.ResourceContent {
+	return models.ResourceContent(base64.StdEncoding.EncodeToString([]byte(content)))
+}
+
+func TestObjectStorageManager_ProjectLifecycle(t *testing.T) {
+	manager := newTestObjectStorageManager(t)
+
+	exists, err := manager.ProjectExists("my-project")
+	require.Nil(t, err)
+	require.True(t, exists)
+
+	err = manager.CreateProject(models.CreateProjectParams{Project: models.Project{ProjectName: "my-project"}})
+	require.ErrorIs(t, err, kerrors.ErrProjectAlreadyExists)
+
+	require.Nil(t, manager.UpdateProject(models.UpdateProjectParams{Project: models.Project{ProjectName: "my-project"}, Migrate: true}))
+	require.ErrorIs(t, manager.UpdateProject(models.UpdateProjectParams{Project: models.Project{ProjectName: "unknown"}}), kerrors.ErrProjectNotFound)
+
+	require.Nil(t, manager.DeleteProject("my-project"))
+	exists, err = manager.ProjectExists("my-project")
+	require.Nil(t, err)
+	require.False(t, exists)
+}
+
+func TestObjectStorageManager_StagesAndServices(t *testing.T) {
+	manager := newTestObjectStorageManager(t)
+
+	err := manager.CreateStage(models.CreateStageParams{
+		Project:            models.Project{ProjectName: "my-project"},
+		CreateStagePayload: models.CreateStagePayload{Stage: models.Stage{StageName: "dev"}},
+	})
+	require.ErrorIs(t, err, kerrors.ErrStageAlreadyExists)
+
+	err = manager.CreateService(models.CreateServiceParams{
+		Project:              models.Project{ProjectName: "my-project"},
+		Stage:                models.Stage{StageName: "dev"},
+		CreateServicePayload: models.CreateServicePayload{Service: models.Service{ServiceName: "my-service"}},
+	})
+	require.ErrorIs(t, err, kerrors.ErrServiceAlreadyExists)
+
+	err = manager.CreateService(models.CreateServiceParams{
+		Project:              models.Project{ProjectName: "my-project"},
+		Stage:                models.Stage{StageName: "prod"},
+		CreateServicePayload: models.CreateServicePayload{Service: models.Service{ServiceName: "my-service"}},
+	})
+	require.ErrorIs(t, err, kerrors.ErrStageNotFound)
+
+	require.Nil(t, manager.DeleteService(models.DeleteServiceParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "dev"},
+		Service: models.Service{ServiceName: "my-service"},
+	}))
+
+	_, err = manager.GetResources(models.GetResourcesParams{ResourceContext: newServiceResourceContext()})
+	require.ErrorIs(t, err, kerrors.ErrServiceNotFound)
+
+	require.Nil(t, manager.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "dev"},
+	}))
+	err = manager.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "dev"},
+	})
+	require.ErrorIs(t, err, kerrors.ErrStageNotFound)
+}
+
+func TestObjectStorageManager_WriteAndReadResources(t *testing.T) {
+	manager := newTestObjectStorageManager(t)
+
+	created, err := manager.CreateResources(models.CreateResourcesParams{
+		ResourceContext: newServiceResourceContext(),
+		CreateResourcesPayload: models.CreateResourcesPayload{Resources: []models.Resource{
+			{ResourceURI: "slo.yaml", ResourceContent: encodeResourceContent("v1")},
+			{ResourceURI: "helm/values.yaml", ResourceContent: encodeResourceContent("replicas: 1")},
+		}},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "4", created.CommitID)
+	require.Equal(t, "4", created.Metadata.Version)
+
+	updated, err := manager.UpdateResource(models.UpdateResourceParams{
+		ResourceContext:       newServiceResourceContext(),
+		ResourceURI:           "slo.yaml",
+		UpdateResourcePayload: models.UpdateResourcePayload{ResourceContent: encodeResourceContent("v2")},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "5", updated.CommitID)
+
+	latest, err := manager.GetResource(models.GetResourceParams{ResourceContext: newServiceResourceContext(), ResourceURI: "slo.yaml"})
+	require.Nil(t, err)
+	require.Equal(t, encodeResourceContent("v2"), latest.ResourceContent)
+	require.Equal(t, "5", latest.Metadata.Version)
+
+	previous, err := manager.GetResource(models.GetResourceParams{
+		ResourceContext:  newServiceResourceContext(),
+		ResourceURI:      "slo.yaml",
+		GetResourceQuery: models.GetResourceQuery{GitCommitID: "4"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, encodeResourceContent("v1"), previous.ResourceContent)
+	require.Equal(t, "4", previous.Metadata.Version)
+
+	resources, err := manager.GetResources(models.GetResourcesParams{
+		ResourceContext:   newServiceResourceContext(),
+		GetResourcesQuery: models.GetResourcesQuery{PageSize: 20},
+	})
+	require.Nil(t, err)
+	require.Equal(t, float64(3), resources.TotalCount)
+	require.Equal(t, "helm/values.yaml", resources.Resources[0].ResourceURI)
+	require.Equal(t, "metadata.yaml", resources.Resources[1].ResourceURI)
+	require.Equal(t, "slo.yaml", resources.Resources[2].ResourceURI)
+
+	// the stages are not exposed as project resources
+	projectResources, err := manager.GetResources(models.GetResourcesParams{
+		ResourceContext:   models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		GetResourcesQuery: models.GetResourcesQuery{PageSize: 20},
+	})
+	require.Nil(t, err)
+	require.Equal(t, float64(1), projectResources.TotalCount)
+	require.Equal(t, "metadata.yaml", projectResources.Resources[0].ResourceURI)
+
+	_, err = manager.DeleteResource(models.DeleteResourceParams{ResourceContext: newServiceResourceContext(), ResourceURI: "slo.yaml"})
+	require.Nil(t, err)
+	_, err = manager.GetResource(models.GetResourceParams{ResourceContext: newServiceResourceContext(), ResourceURI: "slo.yaml"})
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+	_, err = manager.DeleteResource(models.DeleteResourceParams{ResourceContext: newServiceResourceContext(), ResourceURI: "slo.yaml"})
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+}
+
+func TestObjectStorageManager_HistoryDiffAndRevert(t *testing.T) {
+	manager := newTestObjectStorageManager(t)
+
+	for _, content := range []string{"a: 1\n", "a: 2\n"} {
+		_, err := manager.UpdateResource(models.UpdateResourceParams{
+			ResourceContext:       newServiceResourceContext(),
+			ResourceURI:           "slo.yaml",
+			UpdateResourcePayload: models.UpdateResourcePayload{ResourceContent: encodeResourceContent(content)},
+		})
+		require.Nil(t, err)
+	}
+
+	history, err := manager.GetResourceHistory(models.GetResourceHistoryParams{ResourceContext: newServiceResourceContext(), ResourceURI: "slo.yaml"})
+	require.Nil(t, err)
+	require.Len(t, history.Revisions, 2)
+	require.Equal(t, "5", history.Revisions[0].CommitID)
+	require.Equal(t, "4", history.Revisions[1].CommitID)
+	require.Equal(t, "5", history.Metadata.Version)
+
+	diff, err := manager.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext:      newServiceResourceContext(),
+		ResourceURI:          "slo.yaml",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{FromCommitID: "4"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "5", diff.ToCommitID)
+	require.Contains(t, diff.Diff, "-a: 1")
+	require.Contains(t, diff.Diff, "+a: 2")
+
+	reverted, err := manager.RevertResource(models.RevertResourceParams{
+		ResourceContext:       newServiceResourceContext(),
+		ResourceURI:           "slo.yaml",
+		RevertResourcePayload: models.RevertResourcePayload{GitCommitID: "4"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "6", reverted.CommitID)
+
+	latest, err := manager.GetResource(models.GetResourceParams{ResourceContext: newServiceResourceContext(), ResourceURI: "slo.yaml"})
+	require.Nil(t, err)
+	require.Equal(t, encodeResourceContent("a: 1\n"), latest.ResourceContent)
+
+	_, err = manager.RevertResource(models.RevertResourceParams{
+		ResourceContext:       newServiceResourceContext(),
+		ResourceURI:           "slo.yaml",
+		RevertResourcePayload: models.RevertResourcePayload{GitCommitID: "42"},
+	})
+	require.ErrorIs(t, err, kerrors.ErrResolveRevision)
+}
+
+func TestObjectStorageManager_ValidatesResources(t *testing.T) {
+	manager := newTestObjectStorageManagerWithValidator(t, common.NewResourceValidatorRegistry())
+
+	_, err := manager.CreateResources(models.CreateResourcesParams{
+		ResourceContext: newServiceResourceContext(),
+		CreateResourcesPayload: models.CreateResourcesPayload{Resources: []models.Resource{
+			{ResourceURI: "slo.yaml", ResourceContent: encodeResourceContent("spec_version: '1.0'\nobjectives:\n  - sli: response_time_p95\n")},
+		}},
+		WriteResourcesQuery: models.WriteResourcesQuery{ValidationMode: models.ValidationModeOnly},
+	})
+	require.Nil(t, err)
+
+	_, err = manager.UpdateResource(models.UpdateResourceParams{
+		ResourceContext:       newServiceResourceContext(),
+		ResourceURI:           "slo.yaml",
+		UpdateResourcePayload: models.UpdateResourcePayload{ResourceContent: encodeResourceContent("spec_version: '1.0'\nobjectives:\n  - sli: \"\"\n")},
+	})
+	require.ErrorIs(t, err, kerrors.ErrResourceInvalidContent)
+
+	// neither the dry-run nor the invalid resource have been written
+	_, err = manager.GetResource(models.GetResourceParams{ResourceContext: newServiceResourceContext(), ResourceURI: "slo.yaml"})
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+}
+
+func TestObjectStorageManager_HelmCharts(t *testing.T) {
+	manager := newTestObjectStorageManager(t)
+	chart, err := common.PackHelmChart("chart", map[string][]byte{
+		"Chart.yaml":                []byte("name: carts"),
+		"templates/deployment.yaml": []byte("kind: Deployment"),
+	})
+	require.Nil(t, err)
+
+	_, err = manager.CreateResources(models.CreateResourcesParams{
+		ResourceContext: newServiceResourceContext(),
+		CreateResourcesPayload: models.CreateResourcesPayload{Resources: []models.Resource{
+			{ResourceURI: "helm/carts.tgz", ResourceContent: models.ResourceContent(base64.StdEncoding.EncodeToString(chart))},
+		}},
+	})
+	require.Nil(t, err)
+
+	// the chart is unpacked into the chart directory, whose files can be updated individually
+	resources, err := manager.GetResources(models.GetResourcesParams{
+		ResourceContext:   newServiceResourceContext(),
+		GetResourcesQuery: models.GetResourcesQuery{PageSize: 20},
+	})
+	require.Nil(t, err)
+	require.Equal(t, float64(3), resources.TotalCount)
+	require.Equal(t, "helm/carts/Chart.yaml", resources.Resources[0].ResourceURI)
+	require.Equal(t, "helm/carts/templates/deployment.yaml", resources.Resources[1].ResourceURI)
+
+	_, err = manager.UpdateResource(models.UpdateResourceParams{
+		ResourceContext:       newServiceResourceContext(),
+		ResourceURI:           "helm/carts/Chart.yaml",
+		UpdateResourcePayload: models.UpdateResourcePayload{ResourceContent: encodeResourceContent("name: carts-v2")},
+	})
+	require.Nil(t, err)
+
+	resource, err := manager.GetResource(models.GetResourceParams{ResourceContext: newServiceResourceContext(), ResourceURI: "helm/carts.tgz"})
+	require.Nil(t, err)
+	content, err := base64.StdEncoding.DecodeString(string(resource.ResourceContent))
+	require.Nil(t, err)
+	files, err := common.UnpackHelmChart(content)
+	require.Nil(t, err)
+	require.Equal(t, map[string][]byte{
+		"Chart.yaml":                []byte("name: carts-v2"),
+		"templates/deployment.yaml": []byte("kind: Deployment"),
+	}, files)
+
+	_, err = manager.DeleteResource(models.DeleteResourceParams{ResourceContext: newServiceResourceContext(), ResourceURI: "helm/carts.tgz"})
+	require.Nil(t, err)
+	_, err = manager.GetResource(models.GetResourceParams{ResourceContext: newServiceResourceContext(), ResourceURI: "helm/carts.tgz"})
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+}
@@ -51,12 +51,6 @@ func Paginate(totalCount int, pageSize int64, nextPageKeyString string) *Paginat
 
 // GetPaginatedResources returns a paginated resources set
 func GetPaginatedResources(dir string, pageSize int64, nextPageKey string, writer common.IFileSystem, metadata models.Version) (*models.GetResourcesResponse, error) {
-	var result = &models.GetResourcesResponse{
-		PageSize:    0,
-		NextPageKey: "0",
-		TotalCount:  0,
-		Resources:   []models.GetResourceResponse{},
-	}
 	var files = []string{}
 	err := writer.WalkPath(dir,
 		func(path string, info os.FileInfo, err error) error {
@@ -81,6 +75,17 @@ func GetPaginatedResources(dir string, pageSize int64, nextPageKey string, write
 		return nil, err
 	}
 
+	return paginateResourceURIs(files, pageSize, nextPageKey, metadata), nil
+}
+
+// paginateResourceURIs returns the requested page of the given resource URIs
+func paginateResourceURIs(files []string, pageSize int64, nextPageKey string, metadata models.Version) *models.GetResourcesResponse {
+	var result = &models.GetResourcesResponse{
+		PageSize:    0,
+		NextPageKey: "0",
+		TotalCount:  0,
+		Resources:   []models.GetResourceResponse{},
+	}
 	paginationInfo := Paginate(len(files), pageSize, nextPageKey)
 
 	totalCount := len(files)
@@ -98,5 +103,5 @@ func GetPaginatedResources(dir string, pageSize int64, nextPageKey string, write
 
 	result.TotalCount = float64(totalCount)
 	result.NextPageKey = paginationInfo.NewNextPageKey
-	return result, nil
+	return result
 }
@@ -25,13 +25,15 @@ type ProjectManager struct {
 	git              common.IGit
 	credentialReader common.CredentialReader
 	fileSystem       common.IFileSystem
+	cache            *common.ResourceCache
 }
 
-func NewProjectManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem) *ProjectManager {
+func NewProjectManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, cache *common.ResourceCache) *ProjectManager {
 	projectManager := &ProjectManager{
 		git:              git,
 		credentialReader: credentialReader,
 		fileSystem:       fileWriter,
+		cache:            cache,
 	}
 	return projectManager
 }
@@ -99,6 +101,7 @@ func (p ProjectManager) CreateProject(project models.CreateProjectParams) error
 		rollbackFunc()
 		return fmt.Errorf("could not complete initial commit for project %s: %w", project.ProjectName, err)
 	}
+	p.cache.InvalidateProject(project.ProjectName)
 	return nil
 }
 
@@ -149,6 +152,7 @@ func (p ProjectManager) UpdateProject(project models.UpdateProjectParams) error
 		if err := p.migrateProject(project, gitContext); err != nil {
 			return err
 		}
+		p.cache.InvalidateProject(project.ProjectName)
 	}
 
 	return nil
@@ -194,6 +198,7 @@ func (p ProjectManager) DeleteProject(projectName string) error {
 	if err := p.fileSystem.DeleteFile(common.GetProjectConfigPath(projectName)); err != nil {
 		return fmt.Errorf("could not delete project %s: %w", projectName, err)
 	}
+	p.cache.InvalidateProject(projectName)
 
 	return nil
 }
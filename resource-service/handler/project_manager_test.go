@@ -38,7 +38,7 @@ func TestProjectManager_CreateProject(t *testing.T) {
 	}
 
 	fields := getTestProjectManagerFields()
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.CreateProject(project)
 
 	require.Nil(t, err)
@@ -92,7 +92,7 @@ func TestProjectManager_CreateProject_ProjectAlreadyExists(t *testing.T) {
 		}
 		return false
 	}
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.CreateProject(project)
 
 	require.Equal(t, errors2.ErrProjectAlreadyExists, err)
@@ -125,7 +125,7 @@ func TestProjectManager_CreateProject_CannotReadCredentials(t *testing.T) {
 		return nil, errors2.ErrMalformedCredentials
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.CreateProject(project)
 
 	require.ErrorIs(t, err, errors2.ErrMalformedCredentials)
@@ -165,7 +165,7 @@ func TestProjectManager_CreateProject_ProjectRepoDoesNotExist(t *testing.T) {
 		return false
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.CreateProject(project)
 
 	require.Equal(t, errors2.ErrRepositoryNotFound, err)
@@ -206,7 +206,7 @@ func TestProjectManager_CreateProject_WritingFileFails(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.CreateProject(project)
 
 	require.NotNil(t, err)
@@ -250,7 +250,7 @@ func TestProjectManager_CreateProject_CommitFails(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.CreateProject(project)
 
 	require.NotNil(t, err)
@@ -297,7 +297,7 @@ func TestProjectManager_UpdateProject(t *testing.T) {
 		return true
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.UpdateProject(project)
 
 	require.Nil(t, err)
@@ -352,7 +352,7 @@ func TestProjectManager_UpdateProject_WithMigration(t *testing.T) {
 		return []byte("content"), nil
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.UpdateProject(project)
 
 	require.Nil(t, err)
@@ -413,7 +413,7 @@ func TestProjectManager_UpdateProject_WithMigration_CannotPull(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.UpdateProject(project)
 
 	require.NotNil(t, err)
@@ -479,7 +479,7 @@ func TestProjectManager_UpdateProject_WithMigration_MigrationFailsOnFirstTry(t *
 		return nil
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.UpdateProject(project)
 
 	require.Nil(t, err)
@@ -537,7 +537,7 @@ isUsingDirectoryStructure: true`), nil
 		return []byte("content"), nil
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.UpdateProject(project)
 
 	require.Nil(t, err)
@@ -596,7 +596,7 @@ func TestProjectManager_UpdateProject_WithMigration_InvalidMetadata(t *testing.T
 		return []byte("content"), nil
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.UpdateProject(project)
 
 	require.NotNil(t, err)
@@ -659,7 +659,7 @@ func TestProjectManager_UpdateProject_WithMigration_NoMetadata(t *testing.T) {
 		return []byte("content"), nil
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.UpdateProject(project)
 
 	require.NotNil(t, err)
@@ -694,7 +694,7 @@ func TestProjectManager_UpdateProject_CannotReadCredentials(t *testing.T) {
 		return nil, errors2.ErrMalformedCredentials
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.UpdateProject(project)
 
 	require.ErrorIs(t, err, errors2.ErrMalformedCredentials)
@@ -730,7 +730,7 @@ func TestProjectManager_UpdateProject_ProjectDoesNotExist(t *testing.T) {
 		return false
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.UpdateProject(project)
 
 	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
@@ -771,7 +771,7 @@ func TestProjectManager_UpdateProject_ProjectNotInitialized(t *testing.T) {
 		return nil, errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.UpdateProject(project)
 
 	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
@@ -812,7 +812,7 @@ func TestProjectManager_UpdateProject_ProjectNotInitializedEmptyMetadataFile(t *
 		return []byte(""), nil
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.UpdateProject(project)
 
 	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
@@ -856,7 +856,7 @@ func TestProjectManager_UpdateProject_CannotGetDefaultBranch(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.UpdateProject(project)
 
 	require.NotNil(t, err)
@@ -900,7 +900,7 @@ func TestProjectManager_UpdateProject_CheckoutBranchFails(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.UpdateProject(project)
 
 	require.NotNil(t, err)
@@ -931,7 +931,7 @@ func TestProjectManager_DeleteProject(t *testing.T) {
 		return true
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.DeleteProject(project)
 
 	require.Nil(t, err)
@@ -958,7 +958,7 @@ func TestProjectManager_DeleteProject_CannotDeleteDirectory(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, common.NewResourceCache(0, 0))
 	err := p.DeleteProject(project)
 
 	require.NotNil(t, err)
@@ -969,7 +969,7 @@ func TestProjectManager_DeleteProject_CannotDeleteDirectory(t *testing.T) {
 func getTestProjectManagerFields() projectManagerTestFields {
 	return projectManagerTestFields{
 		git: &common_mock.IGitMock{
-			ResetHardFunc:         func(gitContext common_models.GitContext) error { return nil },
+			ResetHardFunc:         func(gitContext common_models.GitContext, revision string) error { return nil },
 			ProjectExistsFunc:     func(gitContext common_models.GitContext) bool { return true },
 			ProjectRepoExistsFunc: func(projectName string) bool { return true },
 			CloneRepoFunc:         func(gitContext common_models.GitContext) (bool, error) { return true, nil },
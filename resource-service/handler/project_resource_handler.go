@@ -15,6 +15,9 @@ type IProjectResourceHandler interface {
 	GetProjectResource(context *gin.Context)
 	UpdateProjectResource(context *gin.Context)
 	DeleteProjectResource(context *gin.Context)
+	GetProjectResourceHistory(context *gin.Context)
+	GetProjectResourceDiff(context *gin.Context)
+	RevertProjectResource(context *gin.Context)
 }
 
 type ProjectResourceHandler struct {
@@ -37,7 +40,9 @@ func NewProjectResourceHandler(projectResourceManager IResourceManager) *Project
 // @Produce      json
 // @Param        projectName                                                 path  string  true  "The name of the project"
 // @Param        resources    body      models.CreateResourcesPayload  true  "List of resources"
+// @Param        validate     query     string  false  "Set to \'only\' to validate the resources without writing them"
 // @Success      201          {string}  models.WriteResourceResponse
+// @Success      200          {string}  models.WriteResourceResponse  "The resources are valid, if validate=only is set"
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
 // @Router       /project/{projectName}/resource [post]
@@ -56,6 +61,11 @@ func (ph *ProjectResourceHandler) CreateProjectResources(c *gin.Context) {
 
 	params.CreateResourcesPayload = *createResources
 
+	if err := c.ShouldBindQuery(&params.WriteResourcesQuery); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
@@ -67,6 +77,10 @@ func (ph *ProjectResourceHandler) CreateProjectResources(c *gin.Context) {
 		return
 	}
 
+	if params.ValidateOnly() {
+		c.JSON(http.StatusOK, result)
+		return
+	}
 	c.JSON(http.StatusCreated, result)
 }
 
@@ -124,6 +138,7 @@ func (ph *ProjectResourceHandler) GetProjectResources(c *gin.Context) {
 // @Produce      json
 // @Param        projectName                                                 path  string  true  "The name of the project"
 // @Param        resources    body      models.UpdateResourcesPayload  true  "List of resources"
+// @Param        validate     query     string  false  "Set to \'only\' to validate the resources without writing them"
 // @Success      200          {string}  models.WriteResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
@@ -143,6 +158,11 @@ func (ph *ProjectResourceHandler) UpdateProjectResources(c *gin.Context) {
 
 	params.UpdateResourcesPayload = *updateResources
 
+	if err := c.ShouldBindQuery(&params.WriteResourcesQuery); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
@@ -212,6 +232,7 @@ func (ph *ProjectResourceHandler) GetProjectResource(c *gin.Context) {
 // @Param        projectName  path    string  true  "The name of the project"
 // @Param        resourceURI  path  string  true    "The path of the resource file"
 // @Param        resources    body      models.UpdateResourcePayload  true  "resource"
+// @Param        validate     query     string  false  "Set to \'only\' to validate the resources without writing them"
 // @Success      200          {string}  models.WriteResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
@@ -231,6 +252,11 @@ func (ph *ProjectResourceHandler) UpdateProjectResource(c *gin.Context) {
 
 	params.UpdateResourcePayload = *updateResource
 
+	if err := c.ShouldBindQuery(&params.WriteResourcesQuery); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
@@ -280,3 +306,131 @@ func (ph *ProjectResourceHandler) DeleteProjectResource(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetProjectResourceHistory godoc
+// @Summary      Get the history of a project resource
+// @Description  Get the list of commits that changed the project resource, starting with the most recent one
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/history [get]
+func (ph *ProjectResourceHandler) GetProjectResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	history, err := ph.ProjectResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetProjectResourceDiff godoc
+// @Summary      Get the diff of a project resource
+// @Description  Get the unified diff of the project resource between two revisions
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        from         query     string  true   "The commit ID the diff starts from"
+// @Param        to           query     string  false  "The commit ID the diff ends at. Defaults to the latest revision"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/diff [get]
+func (ph *ProjectResourceHandler) GetProjectResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	diffQuery := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(diffQuery); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *diffQuery
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.ProjectResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RevertProjectResource godoc
+// @Summary      Reverts a project resource
+// @Description  Restores the content of the project resource at the given revision in a new commit
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        resourceURI  path      string                        true   "The path of the resource file"
+// @Param        revision     body      models.RevertResourcePayload  true   "The revision to restore"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/revert [post]
+func (ph *ProjectResourceHandler) RevertProjectResource(c *gin.Context) {
+	params := &models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	revertResource := &models.RevertResourcePayload{}
+	if err := c.ShouldBindJSON(revertResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.RevertResourcePayload = *revertResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ProjectResourceManager.RevertResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -182,6 +182,66 @@ func TestProjectResourceHandler_CreateProjectResources(t *testing.T) {
 			},
 			wantStatus: http.StatusInternalServerError,
 		},
+		{
+			name: "validate resources only",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{CreateResourcesFunc: func(project models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+					return &models.WriteResourceResponse{}, nil
+				}},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/resource?validate=only", bytes.NewBuffer([]byte(createResourcesTestPayload))),
+			wantParams: &models.CreateResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				CreateResourcesPayload: models.CreateResourcesPayload{
+					Resources: []models.Resource{
+						{
+							ResourceURI:     "resource.yaml",
+							ResourceContent: "c3RyaW5n",
+						},
+					},
+				},
+				WriteResourcesQuery: models.WriteResourcesQuery{ValidationMode: models.ValidationModeOnly},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "invalid validation mode",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{CreateResourcesFunc: func(project models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+					return nil, errors.New("should not have been called")
+				}},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/resource?validate=always", bytes.NewBuffer([]byte(createResourcesTestPayload))),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid resource content",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{CreateResourcesFunc: func(project models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+					return nil, models.NewResourceValidationError([]models.ResourceValidationIssue{
+						{ResourceURI: "resource.yaml", Line: 1, Message: "invalid YAML"},
+					})
+				}},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/resource", bytes.NewBuffer([]byte(createResourcesTestPayload))),
+			wantParams: &models.CreateResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				CreateResourcesPayload: models.CreateResourcesPayload{
+					Resources: []models.Resource{
+						{
+							ResourceURI:     "resource.yaml",
+							ResourceContent: "c3RyaW5n",
+						},
+					},
+				},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
 		{
 			name: "invalid payload",
 			fields: fields{
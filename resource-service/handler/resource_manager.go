@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
@@ -24,21 +25,30 @@ type IResourceManager interface {
 	GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error)
 	UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error)
 	DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error)
+	GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error)
+	GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error)
+	RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error)
 }
 
+var commitHashRegex = regexp.MustCompile("^[0-9a-f]{40}$")
+
 type ResourceManager struct {
 	git                  common.IGit
 	credentialReader     common.CredentialReader
 	fileSystem           common.IFileSystem
 	configurationContext IConfigurationContext
+	cache                *common.ResourceCache
+	validator            common.IResourceValidator
 }
 
-func NewResourceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext) *ResourceManager {
+func NewResourceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext, cache *common.ResourceCache, validator common.IResourceValidator) *ResourceManager {
 	projectResourceManager := &ResourceManager{
 		git:                  git,
 		credentialReader:     credentialReader,
 		fileSystem:           fileWriter,
 		configurationContext: stageContext,
+		cache:                cache,
+		validator:            validator,
 	}
 	return projectResourceManager
 }
@@ -52,7 +62,7 @@ func (p ResourceManager) CreateResources(params models.CreateResourcesParams) (*
 		return nil, err
 	}
 
-	return p.writeAndCommitResources(gitContext, params.Resources, configPath)
+	return p.writeAndCommitResources(gitContext, params.Resources, configPath, params.ValidateOnly())
 }
 
 func (p ResourceManager) GetResources(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
@@ -89,24 +99,115 @@ func (p ResourceManager) UpdateResources(params models.UpdateResourcesParams) (*
 		return nil, err
 	}
 
-	return p.writeAndCommitResources(gitContext, params.Resources, configPath)
+	return p.writeAndCommitResources(gitContext, params.Resources, configPath, params.ValidateOnly())
 }
 
 func (p ResourceManager) GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	if params.GitCommitID != "" && params.GitCommitID != "\"\"" {
+		return p.getResourceRevision(params, unescapedResourceName)
+	}
+	return p.getLatestResource(params, unescapedResourceName)
+}
+
+// getResourceRevision returns the content of a resource at an explicit revision. The revision is resolved to the hash
+// of its commit, whose content never changes and is therefore served from the cache or the local repository, including
+// the absence of the resource. The upstream is only contacted if the revision cannot be resolved locally
+func (p ResourceManager) getResourceRevision(params models.GetResourceParams, resourceName string) (*models.GetResourceResponse, error) {
+	resourceKey := getResourceCacheKey(params.ResourceContext, resourceName)
+	// only a full commit hash can be looked up before resolving it, whereas e.g. a branch could point to another commit by now
+	if isCommitHash(params.GitCommitID) {
+		if cached, ok := p.cache.Get(params.GitCommitID, resourceKey); ok {
+			return newCachedGetResourceResponse(params.ResourceURI, cached, params.GitCommitID)
+		}
+	}
+
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, err := p.getLocalGitContext(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := p.git.ResolveRevision(gitContext, params.GitCommitID)
+	if errors.Is(err, kerrors.ErrResolveRevision) {
+		// the revision might not have been fetched yet
+		establishedContext, _, establishErr := p.establishContext(params.Project, params.Stage, params.Service)
+		if establishErr != nil {
+			return nil, establishErr
+		}
+		gitContext = *establishedContext
+		revision, err = p.git.ResolveRevision(gitContext, params.GitCommitID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cached, ok := p.cache.Get(revision, resourceKey); ok {
+		return newCachedGetResourceResponse(params.ResourceURI, cached, revision)
+	}
+	cached, err := p.readLocalResourceRevision(params.ResourceContext, gitContext, revision, resourceName)
+	if errors.Is(err, kerrors.ErrResourceNotFound) {
+		// optional resources are looked up repeatedly, e.g. by the lighthouse service, so their absence is cached as well
+		p.cache.Set(revision, resourceKey, common.CachedResource{NotFound: true})
+		return nil, err
+	} else if err != nil {
+		return nil, err
+	}
+
+	p.cache.Set(revision, resourceKey, cached)
+	return newGetResourceResponse(params.ResourceURI, cached, revision), nil
+}
+
+// getLatestResource returns the content of a resource at the latest revision of the stage branch. The branch is pulled
+// at most once per staleness window; concurrent requests are coalesced by the project lock
+func (p ResourceManager) getLatestResource(params models.GetResourceParams, resourceName string) (*models.GetResourceResponse, error) {
+	resourceKey := getResourceCacheKey(params.ResourceContext, resourceName)
+	branch := getResourceCacheBranch(params.ResourceContext)
+	if cached, revision, ok := p.cache.GetLatest(params.ProjectName, branch, resourceKey); ok {
+		return newCachedGetResourceResponse(params.ResourceURI, cached, revision)
+	}
+
 	common.LockProject(params.ProjectName)
 	defer common.UnlockProject(params.ProjectName)
 
+	// the branch might have been pulled by another request while waiting for the lock
+	if revision, ok := p.cache.GetLatestRevision(params.ProjectName, branch); ok {
+		if gitContext, err := p.getLocalGitContext(params.ProjectName); err == nil {
+			if cached, err := p.readLocalResourceRevision(params.ResourceContext, gitContext, revision, resourceName); err == nil {
+				p.cache.RecordThrottledPull()
+				p.cache.Set(revision, resourceKey, cached)
+				return newGetResourceResponse(params.ResourceURI, cached, revision), nil
+			}
+		}
+	}
+
 	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
 	if err != nil {
 		return nil, err
 	}
 
-	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+	revision, err := p.git.GetCurrentRevision(*gitContext)
 	if err != nil {
-		return nil, kerrors.ErrResourceInvalidResourceURI
+		return nil, err
+	}
+	p.cache.SetLatestRevision(params.ProjectName, branch, revision)
+
+	fileContent, err := p.fileSystem.ReadFile(configPath + "/" + resourceName)
+	if err != nil {
+		return nil, err
 	}
 
-	return p.readResource(gitContext, params, configPath, unescapedResourceName)
+	cached := common.CachedResource{Content: fileContent, UpstreamURL: gitContext.Credentials.RemoteURL}
+	p.cache.Set(revision, resourceKey, cached)
+	return newGetResourceResponse(params.ResourceURI, cached, revision), nil
 }
 
 func (p ResourceManager) UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
@@ -118,9 +219,9 @@ func (p ResourceManager) UpdateResource(params models.UpdateResourceParams) (*mo
 		return nil, err
 	}
 
-	resourcePath := configPath + "/" + params.ResourceURI
+	resource := models.Resource{ResourceURI: params.ResourceURI, ResourceContent: params.ResourceContent}
 
-	return p.writeAndCommitResource(gitContext, resourcePath, string(params.ResourceContent))
+	return p.writeAndCommitResource(gitContext, configPath, resource, "Updated resource", params.ValidateOnly())
 }
 
 func (p ResourceManager) DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
@@ -162,6 +263,120 @@ func (p ResourceManager) DeleteResource(params models.DeleteResourceParams) (*mo
 	return resultCommit, resultErr
 }
 
+func (p ResourceManager) GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+
+	commits, err := p.git.GetFileHistory(*gitContext, getRepositoryResourcePath(params.ProjectName, configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := p.git.GetCurrentRevision(*gitContext)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.GetResourceHistoryResponse{
+		ResourceURI: params.ResourceURI,
+		Revisions:   []models.ResourceRevision{},
+		Metadata: models.Version{
+			UpstreamURL: gitContext.Credentials.RemoteURL,
+			Version:     revision,
+		},
+	}
+	for _, commit := range commits {
+		result.Revisions = append(result.Revisions, models.ResourceRevision{
+			CommitID:  commit.ID,
+			Author:    commit.Author,
+			Message:   commit.Message,
+			Timestamp: commit.Timestamp,
+		})
+	}
+	return result, nil
+}
+
+func (p ResourceManager) GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+
+	toCommitID := params.ToCommitID
+	if toCommitID == "" {
+		toCommitID, err = p.git.GetCurrentRevision(*gitContext)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	diff, err := p.git.GetFileDiff(*gitContext, params.FromCommitID, toCommitID, getRepositoryResourcePath(params.ProjectName, configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetResourceDiffResponse{
+		ResourceURI:  params.ResourceURI,
+		FromCommitID: params.FromCommitID,
+		ToCommitID:   toCommitID,
+		Diff:         diff,
+	}, nil
+}
+
+func (p ResourceManager) RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	fileContent, err := p.git.GetFileRevision(*gitContext, params.GitCommitID, getRepositoryResourcePath(params.ProjectName, configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	resource := models.Resource{
+		ResourceURI:     unescapedResourceName,
+		ResourceContent: models.ResourceContent(base64.StdEncoding.EncodeToString(fileContent)),
+	}
+	message := fmt.Sprintf("Reverted resource %s to revision %s", unescapedResourceName, params.GitCommitID)
+
+	return p.writeAndCommitResource(gitContext, configPath, resource, message, false)
+}
+
 func (p ResourceManager) establishContext(project models.Project, stage *models.Stage, service *models.Service) (*common_models.GitContext, string, error) {
 	credentials, err := p.credentialReader.GetCredentials(project.ProjectName)
 	if err != nil {
@@ -190,49 +405,52 @@ func (p ResourceManager) establishContext(project models.Project, stage *models.
 	return &gitContext, configPath, nil
 }
 
-func (p ResourceManager) readResource(gitContext *common_models.GitContext, params models.GetResourceParams, configPath string, resourceName string) (*models.GetResourceResponse, error) {
-	var fileContent []byte
-	var revision string
-	var err error
-
-	if params.GitCommitID != "" && params.GitCommitID != "\"\"" {
-		// if commit ID is set, path needs to be relative to the project directory
-		configPath = strings.TrimPrefix(configPath, common.GetProjectConfigPath(params.ProjectName))
-		// resource path must not start with "/", otherwise git is not able to resolve the revision
-		resourcePath := strings.TrimPrefix(configPath+"/"+resourceName, "/")
-		fileContent, err = p.git.GetFileRevision(*gitContext, params.GitCommitID, resourcePath)
-		revision = params.GitCommitID
-	} else {
-		resourcePath := configPath + "/" + resourceName
-		if err := p.git.Pull(*gitContext); err != nil {
-			return nil, err
-		}
-		fileContent, err = p.fileSystem.ReadFile(resourcePath)
-		if err != nil {
-			return nil, err
-		}
-		revision, err = p.git.GetCurrentRevision(*gitContext)
+// getLocalGitContext returns the git context of a project whose repository has already been cloned, without checking
+// out any branch
+func (p ResourceManager) getLocalGitContext(projectName string) (common_models.GitContext, error) {
+	if !p.git.ProjectRepoExists(projectName) {
+		return common_models.GitContext{}, kerrors.ErrProjectNotFound
 	}
+	credentials, err := p.credentialReader.GetCredentials(projectName)
 	if err != nil {
-		return nil, err
+		return common_models.GitContext{}, fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, projectName, err)
 	}
+	return common_models.GitContext{
+		Project:     projectName,
+		Credentials: credentials,
+	}, nil
+}
 
-	resourceContent := base64.StdEncoding.EncodeToString(fileContent)
+// readLocalResourceRevision reads a resource at the given revision from the local repository, without checking out
+// the stage branch or contacting the upstream
+func (p ResourceManager) readLocalResourceRevision(resourceContext models.ResourceContext, gitContext common_models.GitContext, revision, resourceName string) (common.CachedResource, error) {
+	configPath, err := p.configurationContext.Establish(common_models.ConfigurationContextParams{
+		Project:      resourceContext.Project,
+		Stage:        resourceContext.Stage,
+		Service:      resourceContext.Service,
+		GitContext:   gitContext,
+		SkipCheckout: true,
+	})
+	if err != nil {
+		return common.CachedResource{}, err
+	}
 
-	return &models.GetResourceResponse{
-		Resource: models.Resource{
-			ResourceURI:     params.ResourceURI,
-			ResourceContent: models.ResourceContent(resourceContent),
-		},
-		Metadata: models.Version{
-			UpstreamURL: gitContext.Credentials.RemoteURL,
-			Version:     revision,
-		},
-	}, nil
+	fileContent, err := p.git.GetFileRevision(gitContext, revision, getRepositoryResourcePath(resourceContext.ProjectName, configPath, resourceName))
+	if err != nil {
+		return common.CachedResource{}, err
+	}
+	return common.CachedResource{Content: fileContent, UpstreamURL: gitContext.Credentials.RemoteURL}, nil
 }
 
-func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitContext, resourcePath, resourceContent string) (*models.WriteResourceResponse, error) {
+func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitContext, directory string, resource models.Resource, message string, validateOnly bool) (*models.WriteResourceResponse, error) {
+	if err := p.validator.Validate([]models.Resource{resource}); err != nil {
+		return nil, err
+	}
+	if validateOnly {
+		return &models.WriteResourceResponse{}, nil
+	}
 
+	resourcePath := directory + "/" + resource.ResourceURI
 	var resultErr error
 	var resultCommit *models.WriteResourceResponse
 	_ = retry.Retry(func() error {
@@ -241,12 +459,12 @@ func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitCon
 			resultErr = err
 			return nil
 		}
-		if err := p.storeResource(resourcePath, resourceContent); err != nil {
+		if err := p.storeResource(resourcePath, string(resource.ResourceContent)); err != nil {
 			resultErr = err
 			return nil
 		}
 
-		commit, err := p.stageAndCommit(gitContext, "Updated resource")
+		commit, err := p.stageAndCommit(gitContext, message)
 		if err != nil {
 			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
 				return err
@@ -260,7 +478,13 @@ func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitCon
 	return resultCommit, resultErr
 }
 
-func (p ResourceManager) writeAndCommitResources(gitContext *common_models.GitContext, resources []models.Resource, directory string) (*models.WriteResourceResponse, error) {
+func (p ResourceManager) writeAndCommitResources(gitContext *common_models.GitContext, resources []models.Resource, directory string, validateOnly bool) (*models.WriteResourceResponse, error) {
+	if err := p.validator.Validate(resources); err != nil {
+		return nil, err
+	}
+	if validateOnly {
+		return &models.WriteResourceResponse{}, nil
+	}
 
 	var resultErr error
 	var resultCommit *models.WriteResourceResponse
@@ -309,6 +533,8 @@ func (p ResourceManager) stageAndCommit(gitContext *common_models.GitContext, me
 	if err != nil {
 		return nil, err
 	}
+	p.cache.InvalidateProject(gitContext.Project)
+
 	result := &models.WriteResourceResponse{
 		CommitID: commitID,
 		Metadata: models.Version{
@@ -330,3 +556,59 @@ func (p ResourceManager) deleteResource(gitContext *common_models.GitContext, re
 
 	return p.stageAndCommit(gitContext, "Deleted resources")
 }
+
+// getRepositoryResourcePath returns the path of a resource relative to the root of the project repository.
+// This is needed for operations that access the git objects directly instead of the checked out files
+func getRepositoryResourcePath(projectName, configPath, resourceName string) string {
+	configPath = strings.TrimPrefix(configPath, common.GetProjectConfigPath(projectName))
+	// resource path must not start with "/", otherwise git is not able to resolve the revision
+	return strings.TrimPrefix(configPath+"/"+resourceName, "/")
+}
+
+// newCachedGetResourceResponse returns the cached content of a resource, or ErrResourceNotFound if the resource does
+// not exist at the revision
+func newCachedGetResourceResponse(resourceURI string, resource common.CachedResource, revision string) (*models.GetResourceResponse, error) {
+	if resource.NotFound {
+		return nil, kerrors.ErrResourceNotFound
+	}
+	return newGetResourceResponse(resourceURI, resource, revision), nil
+}
+
+func newGetResourceResponse(resourceURI string, resource common.CachedResource, revision string) *models.GetResourceResponse {
+	return &models.GetResourceResponse{
+		Resource: models.Resource{
+			ResourceURI:     resourceURI,
+			ResourceContent: models.ResourceContent(base64.StdEncoding.EncodeToString(resource.Content)),
+		},
+		Metadata: models.Version{
+			UpstreamURL: resource.UpstreamURL,
+			Version:     revision,
+		},
+	}
+}
+
+// isCommitHash returns whether the revision is the full hash of a commit, which always refers to the same content
+func isCommitHash(revision string) bool {
+	return commitHashRegex.MatchString(revision)
+}
+
+// getResourceCacheKey returns the key of a resource within the resource cache
+func getResourceCacheKey(resourceContext models.ResourceContext, resourceName string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", resourceContext.ProjectName, getResourceCacheBranch(resourceContext), getServiceName(resourceContext.Service), resourceName)
+}
+
+// getResourceCacheBranch returns the key under which the latest revision of a resource context is tracked.
+// Each stage corresponds to a branch of the project repository, project resources are stored on the default branch
+func getResourceCacheBranch(resourceContext models.ResourceContext) string {
+	if resourceContext.Stage == nil {
+		return ""
+	}
+	return resourceContext.Stage.StageName
+}
+
+func getServiceName(service *models.Service) string {
+	if service == nil {
+		return ""
+	}
+	return service.ServiceName
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -22,17 +24,23 @@ import (
 const testConfigDir = "/data/config/my-project"
 const testServiceConfigDir = "/data/config/my-project/my-service"
 
+const testCommitHash = "0123456789abcdef0123456789abcdef01234567"
+
+var testCommitTimestamp = time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+
 type testResourceManagerFields struct {
 	git              *common_mock.IGitMock
 	credentialReader *common_mock.CredentialReaderMock
 	fileSystem       *common_mock.IFileSystemMock
 	stageContext     *handler_mock.IConfigurationContextMock
+	cache            *common.ResourceCache
+	validator        *common_mock.IResourceValidatorMock
 }
 
 func TestResourceManager_CreateResources_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -71,7 +79,7 @@ func TestResourceManager_CreateResources_ProjectResource(t *testing.T) {
 func TestResourceManager_CreateResources_StageResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -111,7 +119,7 @@ func TestResourceManager_CreateResources_StageResource(t *testing.T) {
 func TestResourceManager_CreateResources_ServiceResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -152,7 +160,7 @@ func TestResourceManager_CreateResources_ServiceResource(t *testing.T) {
 func TestResourceManager_CreateResources_ServiceResource_HelmChart(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -190,7 +198,7 @@ func TestResourceManager_CreateResources_ServiceResource_HelmChartWriteFails(t *
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -227,7 +235,7 @@ func TestResourceManager_CreateResources_ProjectResource_ProjectNotFound(t *test
 	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
 		return false
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -264,7 +272,7 @@ func TestResourceManager_CreateResources_ProjectResource_CannotReadCredentials(t
 	fields.credentialReader.GetCredentialsFunc = func(project string) (*common_models.GitCredentials, error) {
 		return nil, errors2.ErrMalformedCredentials
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -301,7 +309,7 @@ func TestResourceManager_CreateResources_ProjectResource_CannotEstablishContext(
 	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
 		return "", errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -333,7 +341,7 @@ func TestResourceManager_CreateResources_ProjectResource_CannotEstablishContext(
 func TestResourceManager_UpdateResources_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -371,7 +379,7 @@ func TestResourceManager_UpdateResources_ProjectResource_ProjectNotFound(t *test
 		return false
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -409,7 +417,7 @@ func TestResourceManager_UpdateResources_ProjectResource_WritingFileFails(t *tes
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -446,7 +454,7 @@ func TestResourceManager_UpdateResources_ProjectResource_CommitFails(t *testing.
 		return "", errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -484,7 +492,7 @@ func TestResourceManager_UpdateResources_ProjectResource_PullFails(t *testing.T)
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -526,7 +534,7 @@ func TestResourceManager_UpdateResources_ProjectResource_CommitFailsOnFirstTry(t
 		return "my-revision", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -560,7 +568,7 @@ func TestResourceManager_UpdateResources_ProjectResource_CommitFailsOnFirstTry(t
 func TestResourceManager_UpdateResource_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -589,7 +597,7 @@ func TestResourceManager_UpdateResource_ProjectResource_ProjectNotFound(t *testi
 		return false
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -617,7 +625,7 @@ func TestResourceManager_UpdateResource_ProjectResource_WritingFileFails(t *test
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -646,7 +654,7 @@ func TestResourceManager_UpdateResource_ProjectResource_CommitFails(t *testing.T
 		return "", errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -675,7 +683,7 @@ func TestResourceManager_UpdateResource_ProjectResource_PullFails(t *testing.T)
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -709,7 +717,7 @@ func TestResourceManager_UpdateResource_ProjectResource_CommitFailsOnFirstTry(t
 		return "my-revision", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -731,10 +739,73 @@ func TestResourceManager_UpdateResource_ProjectResource_CommitFailsOnFirstTry(t
 	require.Equal(t, testConfigDir+"/file1", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Path)
 }
 
+func TestResourceManager_UpdateResource_ProjectResource_InvalidContent(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	validationErr := models.NewResourceValidationError([]models.ResourceValidationIssue{
+		{ResourceURI: "shipyard.yaml", Line: 1, Message: "missing required property 'apiVersion'"},
+	})
+	fields.validator.ValidateFunc = func(resources []models.Resource) error {
+		return validationErr
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	revision, err := rm.UpdateResource(models.UpdateResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "shipyard.yaml",
+		UpdateResourcePayload: models.UpdateResourcePayload{
+			ResourceContent: "c3RyaW5n",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceInvalidContent)
+	require.Equal(t, validationErr, err)
+	require.Nil(t, revision)
+
+	require.Len(t, fields.validator.ValidateCalls(), 1)
+	require.Equal(t, []models.Resource{{ResourceURI: "shipyard.yaml", ResourceContent: "c3RyaW5n"}}, fields.validator.ValidateCalls()[0].Resources)
+
+	require.Empty(t, fields.git.PullCalls())
+	require.Empty(t, fields.fileSystem.WriteBase64EncodedFileCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func TestResourceManager_CreateResources_ProjectResource_ValidateOnly(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	revision, err := rm.CreateResources(models.CreateResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		CreateResourcesPayload: models.CreateResourcesPayload{
+			Resources: []models.Resource{
+				{
+					ResourceContent: "c3RyaW5n",
+					ResourceURI:     "file1",
+				},
+			},
+		},
+		WriteResourcesQuery: models.WriteResourcesQuery{ValidationMode: models.ValidationModeOnly},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.WriteResourceResponse{}, revision)
+
+	require.Len(t, fields.validator.ValidateCalls(), 1)
+	require.Empty(t, fields.git.PullCalls())
+	require.Empty(t, fields.fileSystem.WriteBase64EncodedFileCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
 func TestResourceManager_DeleteResource_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -759,7 +830,7 @@ func TestResourceManager_DeleteResource_ProjectResource_ProjectNotFound(t *testi
 	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
 		return false
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -786,7 +857,7 @@ func TestResourceManager_DeleteResource_ProjectResource_ResourceNotFound(t *test
 		}
 		return true
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -810,7 +881,7 @@ func TestResourceManager_DeleteResource_ProjectResource_DeleteFails(t *testing.T
 	fields.fileSystem.DeleteFileFunc = func(path string) error {
 		return errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -845,7 +916,7 @@ func TestResourceManager_DeleteResource_Git_ThrowsNonFastForward_Or_ThrowsForceN
 		return "my-revision", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -872,7 +943,7 @@ func TestResourceManager_DeleteResource_ProjectResource_CommitFails(t *testing.T
 	fields.fileSystem.DeleteFileFunc = func(path string) error {
 		return errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -896,7 +967,7 @@ func TestResourceManager_DeleteResource_ProjectResource_CommitFails(t *testing.T
 func TestResourceManager_GetResource_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -929,7 +1000,7 @@ func TestResourceManager_GetResource_ProjectResource(t *testing.T) {
 func TestResourceManager_GetResource_ProjectResource_ProvideGitCommitID(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -956,6 +1027,10 @@ func TestResourceManager_GetResource_ProjectResource_ProvideGitCommitID(t *testi
 
 	require.Len(t, fields.stageContext.EstablishCalls(), 1)
 
+	require.True(t, fields.stageContext.EstablishCalls()[0].Params.SkipCheckout)
+	require.Empty(t, fields.git.PullCalls())
+	require.Empty(t, fields.git.CheckoutBranchCalls())
+
 	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
 	require.Equal(t, "my-commit-id", fields.git.GetFileRevisionCalls()[0].Revision)
 	require.Equal(t, "file1", fields.git.GetFileRevisionCalls()[0].File)
@@ -968,7 +1043,7 @@ func TestResourceManager_GetResource_ServiceResource_ProvideGitCommitID(t *testi
 		return testConfigDir + "/my-service", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1008,7 +1083,7 @@ func TestResourceManager_GetResource_ProjectResource_PullFails(t *testing.T) {
 	fields.git.PullFunc = func(gitContext common_models.GitContext) error {
 		return errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1035,7 +1110,10 @@ func TestResourceManager_GetResource_ProjectResource_ProjectNotFound(t *testing.
 	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
 		return false
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	fields.git.ProjectRepoExistsFunc = func(projectName string) bool {
+		return false
+	}
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1060,7 +1138,7 @@ func TestResourceManager_GetResource_ServiceResource_ServiceNotFound(t *testing.
 	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
 		return "", errors2.ErrServiceNotFound
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1078,7 +1156,10 @@ func TestResourceManager_GetResource_ServiceResource_ServiceNotFound(t *testing.
 
 	require.Nil(t, result)
 
+	// the revision is available locally, so the branch is not checked out
 	require.Len(t, fields.stageContext.EstablishCalls(), 1)
+	require.True(t, fields.stageContext.EstablishCalls()[0].Params.SkipCheckout)
+	require.Empty(t, fields.git.CheckoutBranchCalls())
 	require.Equal(t, models.Project{ProjectName: "my-project"}, fields.stageContext.EstablishCalls()[0].Params.Project, 1)
 	require.Equal(t, &models.Stage{StageName: "my-stage"}, fields.stageContext.EstablishCalls()[0].Params.Stage, 1)
 	require.Equal(t, &models.Service{ServiceName: "my-service"}, fields.stageContext.EstablishCalls()[0].Params.Service, 1)
@@ -1091,7 +1172,7 @@ func TestResourceManager_GetResource_ProjectResource_ResourceNotFound(t *testing
 	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
 		return nil, errors2.ErrResourceNotFound
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1117,7 +1198,7 @@ func TestResourceManager_GetResource_ProjectResource_CannotReadFIle(t *testing.T
 	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
 		return nil, errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1140,7 +1221,7 @@ func TestResourceManager_GetResource_ProjectResource_CannotReadFIle(t *testing.T
 func TestResourceManager_GetResource_ProjectResource_InvalidResourceName(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1156,15 +1237,225 @@ func TestResourceManager_GetResource_ProjectResource_InvalidResourceName(t *test
 
 	require.Nil(t, result)
 
-	require.Len(t, fields.stageContext.EstablishCalls(), 1)
+	require.Empty(t, fields.stageContext.EstablishCalls())
 
 	require.Empty(t, fields.git.GetFileRevisionCalls())
 }
 
+func TestResourceManager_GetResource_ProvideGitCommitID_ServedFromCache(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	fields.cache = common.NewResourceCache(0, 1024)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	params := models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceQuery: models.GetResourceQuery{
+			GitCommitID: "my-commit-id",
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := rm.GetResource(params)
+		require.Nil(t, err)
+		require.Equal(t, models.ResourceContent("ZmlsZS1jb250ZW50"), result.ResourceContent)
+		require.Equal(t, "my-commit-id", result.Metadata.Version)
+		require.Equal(t, "remote-url", result.Metadata.UpstreamURL)
+	}
+
+	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+	require.Empty(t, fields.git.PullCalls())
+	require.Empty(t, fields.git.CheckoutBranchCalls())
+
+	metrics := fields.cache.Metrics()
+	require.Equal(t, int64(1), metrics.Hits)
+	require.Equal(t, int64(1), metrics.Misses)
+}
+
+func TestResourceManager_GetResource_ProvideGitCommitID_RevisionNotAvailableLocally(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.ResolveRevisionFunc = func(gitContext common_models.GitContext, revision string) (string, error) {
+		if len(fields.git.ResolveRevisionCalls()) == 1 {
+			return "", fmt.Errorf(errors2.ErrMsgCouldNotGitAction, "resolve revision in", gitContext.Project, errors2.ErrResolveRevision)
+		}
+		return testCommitHash, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	result, err := rm.GetResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceQuery: models.GetResourceQuery{
+			GitCommitID: "my-commit-id",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, testCommitHash, result.Metadata.Version)
+
+	require.Len(t, fields.stageContext.EstablishCalls(), 2)
+	require.False(t, fields.stageContext.EstablishCalls()[0].Params.SkipCheckout)
+	require.True(t, fields.stageContext.EstablishCalls()[1].Params.SkipCheckout)
+	require.Len(t, fields.git.ResolveRevisionCalls(), 2)
+	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+	require.Equal(t, testCommitHash, fields.git.GetFileRevisionCalls()[0].Revision)
+}
+
+func TestResourceManager_GetResource_ProvideGitCommitID_ResourceNotFoundIsCached(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	fields.cache = common.NewResourceCache(0, 1024)
+
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		return nil, fmt.Errorf(errors2.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, errors2.ErrResourceNotFound)
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	fields.git.ResolveRevisionFunc = func(gitContext common_models.GitContext, revision string) (string, error) {
+		return testCommitHash, nil
+	}
+
+	// the abbreviated hash is resolved, whereas the full hash is served from the cache right away
+	for _, revision := range []string{"0123456", testCommitHash} {
+		result, err := rm.GetResource(models.GetResourceParams{
+			ResourceContext: models.ResourceContext{
+				Project: models.Project{ProjectName: "my-project"},
+			},
+			ResourceURI: "lighthouse.yaml",
+			GetResourceQuery: models.GetResourceQuery{
+				GitCommitID: revision,
+			},
+		})
+		require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+		require.Nil(t, result)
+	}
+
+	// the absence of the resource is read once from the local repository, without contacting the upstream
+	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+	require.Len(t, fields.git.ResolveRevisionCalls(), 1)
+	require.Empty(t, fields.git.CheckoutBranchCalls())
+	require.Empty(t, fields.git.PullCalls())
+}
+
+func TestResourceManager_GetResource_ProvideGitCommitID_BranchIsResolved(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	fields.cache = common.NewResourceCache(0, 1024)
+
+	revisions := []string{testCommitHash, strings.Repeat("b", 40)}
+	fields.git.ResolveRevisionFunc = func(gitContext common_models.GitContext, revision string) (string, error) {
+		return revisions[len(fields.git.ResolveRevisionCalls())-1], nil
+	}
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		return []byte("content-" + revision[:1]), nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	// the branch points to another commit between the requests
+	for _, revision := range revisions {
+		result, err := rm.GetResource(models.GetResourceParams{
+			ResourceContext: models.ResourceContext{
+				Project: models.Project{ProjectName: "my-project"},
+			},
+			ResourceURI: "file1",
+			GetResourceQuery: models.GetResourceQuery{
+				GitCommitID: "main",
+			},
+		})
+		require.Nil(t, err)
+		require.Equal(t, revision, result.Metadata.Version)
+		require.Equal(t, models.ResourceContent(base64.StdEncoding.EncodeToString([]byte("content-"+revision[:1]))), result.ResourceContent)
+	}
+
+	require.Len(t, fields.git.GetFileRevisionCalls(), 2)
+	_, ok := fields.cache.Get("main", getResourceCacheKey(models.ResourceContext{Project: models.Project{ProjectName: "my-project"}}, "file1"))
+	require.False(t, ok)
+}
+
+func TestResourceManager_GetResource_PullsAreThrottled(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	fields.cache = common.NewResourceCache(time.Minute, 1024)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	getResource := func(resourceURI string) *models.GetResourceResponse {
+		result, err := rm.GetResource(models.GetResourceParams{
+			ResourceContext: models.ResourceContext{
+				Project: models.Project{ProjectName: "my-project"},
+				Stage:   &models.Stage{StageName: "my-stage"},
+			},
+			ResourceURI: resourceURI,
+		})
+		require.Nil(t, err)
+		require.Equal(t, models.ResourceContent("ZmlsZS1jb250ZW50"), result.ResourceContent)
+		require.Equal(t, "my-revision", result.Metadata.Version)
+		return result
+	}
+
+	getResource("file1")
+	getResource("file1")
+
+	require.Len(t, fields.git.PullCalls(), 1)
+	require.Len(t, fields.fileSystem.ReadFileCalls(), 1)
+	require.Empty(t, fields.git.GetFileRevisionCalls())
+
+	// the revision is still fresh, so another resource is read from the local repository without pulling
+	getResource("file2")
+
+	require.Len(t, fields.git.PullCalls(), 1)
+	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+	require.Equal(t, "my-revision", fields.git.GetFileRevisionCalls()[0].Revision)
+
+	metrics := fields.cache.Metrics()
+	require.Equal(t, int64(1), metrics.Hits)
+	require.Equal(t, int64(2), metrics.Misses)
+	require.Equal(t, int64(1), metrics.Pulls)
+	require.Equal(t, int64(2), metrics.ThrottledPulls)
+}
+
+func TestResourceManager_GetResource_WriteInvalidatesLatestRevision(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	fields.cache = common.NewResourceCache(time.Minute, 1024)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	params := models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+	}
+
+	_, err := rm.GetResource(params)
+	require.Nil(t, err)
+
+	_, err = rm.UpdateResource(models.UpdateResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI:           "file1",
+		UpdateResourcePayload: models.UpdateResourcePayload{ResourceContent: "c3RyaW5n"},
+	})
+	require.Nil(t, err)
+
+	_, err = rm.GetResource(params)
+	require.Nil(t, err)
+
+	// one pull for each read and one for the update
+	require.Len(t, fields.git.PullCalls(), 3)
+}
+
 func TestResourceManager_GetResources(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
 
 	result, err := rm.GetResources(models.GetResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -1230,6 +1521,171 @@ type fakeFileInfo struct {
 	isDir bool
 }
 
+func TestResourceManager_GetResourceHistory_ServiceResource(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testServiceConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	result, err := rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "slo.yaml",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.GetResourceHistoryResponse{
+		ResourceURI: "slo.yaml",
+		Revisions: []models.ResourceRevision{
+			{CommitID: "my-revision", Author: "keptn", Message: "Updated resource", Timestamp: testCommitTimestamp},
+			{CommitID: "my-old-revision", Author: "keptn", Message: "Added resource", Timestamp: testCommitTimestamp.Add(-time.Hour)},
+		},
+		Metadata: models.Version{
+			UpstreamURL: "remote-url",
+			Version:     "my-revision",
+		},
+	}, result)
+
+	require.Len(t, fields.git.PullCalls(), 1)
+	require.Len(t, fields.git.GetFileHistoryCalls(), 1)
+	require.Equal(t, "my-service/slo.yaml", fields.git.GetFileHistoryCalls()[0].File)
+}
+
+func TestResourceManager_GetResourceHistory_ResourceNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetFileHistoryFunc = func(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error) {
+		return nil, errors2.ErrResourceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	result, err := rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+}
+
+func TestResourceManager_GetResourceDiff(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	result, err := rm.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{
+			FromCommitID: "my-old-revision",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.GetResourceDiffResponse{
+		ResourceURI:  "file1",
+		FromCommitID: "my-old-revision",
+		ToCommitID:   "my-revision",
+		Diff:         "my-diff",
+	}, result)
+
+	require.Len(t, fields.git.GetFileDiffCalls(), 1)
+	require.Equal(t, "my-old-revision", fields.git.GetFileDiffCalls()[0].FromRevision)
+	require.Equal(t, "my-revision", fields.git.GetFileDiffCalls()[0].ToRevision)
+	require.Equal(t, "file1", fields.git.GetFileDiffCalls()[0].File)
+}
+
+func TestResourceManager_GetResourceDiff_InvalidRevision(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetFileDiffFunc = func(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+		return "", errors2.ErrResolveRevision
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	result, err := rm.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{
+			FromCommitID: "unknown",
+			ToCommitID:   "my-revision",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResolveRevision)
+	require.Nil(t, result)
+	require.Empty(t, fields.git.GetCurrentRevisionCalls())
+}
+
+func TestResourceManager_RevertResource(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	result, err := rm.RevertResource(models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		RevertResourcePayload: models.RevertResourcePayload{
+			GitCommitID: "my-old-revision",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{UpstreamURL: "remote-url", Version: "my-revision"}}, result)
+
+	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+	require.Equal(t, "my-old-revision", fields.git.GetFileRevisionCalls()[0].Revision)
+	require.Equal(t, "file1", fields.git.GetFileRevisionCalls()[0].File)
+
+	require.Len(t, fields.fileSystem.WriteBase64EncodedFileCalls(), 1)
+	require.Equal(t, testConfigDir+"/file1", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Path)
+	require.Equal(t, "ZmlsZS1jb250ZW50", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Content)
+
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "Reverted resource file1 to revision my-old-revision", fields.git.StageAndCommitAllCalls()[0].Message)
+}
+
+func TestResourceManager_RevertResource_ResourceNotFoundInRevision(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		return nil, errors2.ErrResourceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.cache, fields.validator)
+
+	result, err := rm.RevertResource(models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		RevertResourcePayload: models.RevertResourcePayload{
+			GitCommitID: "my-old-revision",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+	require.Empty(t, fields.fileSystem.WriteBase64EncodedFileCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
 func newFakeFileInfo(name string, isDir bool) *fakeFileInfo {
 	return &fakeFileInfo{name: name, isDir: isDir}
 }
@@ -1261,7 +1717,7 @@ func (fakeFileInfo) Sys() interface{} {
 func getTestResourceManagerFields() testResourceManagerFields {
 	return testResourceManagerFields{
 		git: &common_mock.IGitMock{
-			ResetHardFunc:          func(gitContext common_models.GitContext) error { return nil },
+			ResetHardFunc:          func(gitContext common_models.GitContext, revision string) error { return nil },
 			CheckoutBranchFunc:     func(gitContext common_models.GitContext, branch string) error { return nil },
 			CloneRepoFunc:          func(gitContext common_models.GitContext) (bool, error) { return true, nil },
 			CreateBranchFunc:       func(gitContext common_models.GitContext, branch string, sourceBranch string) error { return nil },
@@ -1270,10 +1726,20 @@ func getTestResourceManagerFields() testResourceManagerFields {
 			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
 				return []byte("file-content"), nil
 			},
+			GetFileHistoryFunc: func(gitContext common_models.GitContext, file string) ([]common_models.GitCommit, error) {
+				return []common_models.GitCommit{
+					{ID: "my-revision", Author: "keptn", Message: "Updated resource", Timestamp: testCommitTimestamp},
+					{ID: "my-old-revision", Author: "keptn", Message: "Added resource", Timestamp: testCommitTimestamp.Add(-time.Hour)},
+				}, nil
+			},
+			GetFileDiffFunc: func(gitContext common_models.GitContext, fromRevision string, toRevision string, file string) (string, error) {
+				return "my-diff", nil
+			},
 			ProjectExistsFunc:     func(gitContext common_models.GitContext) bool { return true },
 			ProjectRepoExistsFunc: func(projectName string) bool { return true },
 			PullFunc:              func(gitContext common_models.GitContext) error { return nil },
 			PushFunc:              func(gitContext common_models.GitContext) error { return nil },
+			ResolveRevisionFunc:   func(gitContext common_models.GitContext, revision string) (string, error) { return revision, nil },
 			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) { return "my-revision", nil },
 		},
 		credentialReader: &common_mock.CredentialReaderMock{
@@ -1323,5 +1789,9 @@ func getTestResourceManagerFields() testResourceManagerFields {
 				return testConfigDir, nil
 			},
 		},
+		cache: common.NewResourceCache(0, 0),
+		validator: &common_mock.IResourceValidatorMock{
+			ValidateFunc: func(resources []models.Resource) error { return nil },
+		},
 	}
 }
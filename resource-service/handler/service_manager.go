@@ -25,14 +25,16 @@ type ServiceManager struct {
 	credentialReader common.CredentialReader
 	fileSystem       common.IFileSystem
 	stageContext     IConfigurationContext
+	cache            *common.ResourceCache
 }
 
-func NewServiceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext) *ServiceManager {
+func NewServiceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext, cache *common.ResourceCache) *ServiceManager {
 	serviceManager := &ServiceManager{
 		git:              git,
 		credentialReader: credentialReader,
 		fileSystem:       fileWriter,
 		stageContext:     stageContext,
+		cache:            cache,
 	}
 	return serviceManager
 }
@@ -108,7 +110,16 @@ func (s ServiceManager) deleteService(gitContext *common_models.GitContext, serv
 		return "", err
 	}
 
-	return s.git.StageAndCommitAll(*gitContext, "Removed service: "+serviceName)
+	return s.stageAndCommit(gitContext, "Removed service: "+serviceName)
+}
+
+func (s ServiceManager) stageAndCommit(gitContext *common_models.GitContext, message string) (string, error) {
+	commitID, err := s.git.StageAndCommitAll(*gitContext, message)
+	if err != nil {
+		return "", err
+	}
+	s.cache.InvalidateProject(gitContext.Project)
+	return commitID, nil
 }
 
 func (s ServiceManager) establishServiceContext(project models.Project, stage models.Stage, service models.Service) (*common_models.GitContext, string, error) {
@@ -157,5 +168,5 @@ func (s ServiceManager) createService(gitContext *common_models.GitContext, serv
 	if err = s.fileSystem.WriteFile(servicePath+"/metadata.yaml", metadataString); err != nil {
 		return "", fmt.Errorf("could not create metadata file for service %s: %w", serviceName, err)
 	}
-	return s.git.StageAndCommitAll(*gitContext, "Added service: "+serviceName)
+	return s.stageAndCommit(gitContext, "Added service: "+serviceName)
 }
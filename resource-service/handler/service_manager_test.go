@@ -48,7 +48,7 @@ func TestServiceManager_CreateService(t *testing.T) {
 
 	fields := getTestServiceManagerFields()
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, common.NewResourceCache(0, 0))
 	err := p.CreateService(params)
 
 	require.Nil(t, err)
@@ -82,7 +82,7 @@ func TestServiceManager_CreateService_CannotReadCredentials(t *testing.T) {
 	fields.credentialReader.GetCredentialsFunc = func(project string) (*common_models.GitCredentials, error) {
 		return nil, errors2.ErrCredentialsNotFound
 	}
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, common.NewResourceCache(0, 0))
 	err := p.CreateService(params)
 
 	require.ErrorIs(t, err, errors2.ErrCredentialsNotFound)
@@ -119,7 +119,7 @@ func TestServiceManager_CreateService_ProjectNotFound(t *testing.T) {
 		return false
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, common.NewResourceCache(0, 0))
 	err := p.CreateService(params)
 
 	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
@@ -159,7 +159,7 @@ func TestServiceManager_CreateService_StageNotFound(t *testing.T) {
 		return "", errors2.ErrStageNotFound
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, common.NewResourceCache(0, 0))
 	err := p.CreateService(params)
 
 	require.ErrorIs(t, err, errors2.ErrStageNotFound)
@@ -200,7 +200,7 @@ func TestServiceManager_CreateService_ServiceAlreadyExists(t *testing.T) {
 		return true
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, common.NewResourceCache(0, 0))
 	err := p.CreateService(params)
 
 	require.ErrorIs(t, err, errors2.ErrServiceAlreadyExists)
@@ -241,7 +241,7 @@ func TestServiceManager_CreateService_CannotCreateDirectory(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, common.NewResourceCache(0, 0))
 	err := p.CreateService(params)
 
 	require.NotNil(t, err)
@@ -282,7 +282,7 @@ func TestServiceManager_CreateService_CannotCreateMetadata(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, common.NewResourceCache(0, 0))
 	err := p.CreateService(params)
 
 	require.NotNil(t, err)
@@ -324,7 +324,7 @@ func TestServiceManager_CreateService_CannotCommit(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, common.NewResourceCache(0, 0))
 	err := p.CreateService(params)
 
 	require.NotNil(t, err)
@@ -364,11 +364,17 @@ func TestServiceManager_DeleteService(t *testing.T) {
 		return false
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	cache := common.NewResourceCache(0, 0)
+	cache.SetLatestRevision("my-project", "", "my-old-revision")
+
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, cache)
 	err := p.DeleteService(params)
 
 	require.Nil(t, err)
 
+	_, ok := cache.GetLatestRevision("my-project", "")
+	require.False(t, ok)
+
 	require.Len(t, fields.git.ProjectExistsCalls(), 1)
 	require.Equal(t, fields.git.ProjectExistsCalls()[0].GitContext, expectedGitContext)
 
@@ -409,7 +415,7 @@ func TestServiceManager_DeleteService_ProjectDoesNotExist(t *testing.T) {
 		return false
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, common.NewResourceCache(0, 0))
 	err := p.DeleteService(params)
 
 	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
@@ -445,7 +451,7 @@ func TestServiceManager_DeleteService_ServiceDoesNotExist(t *testing.T) {
 		return false
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, common.NewResourceCache(0, 0))
 	err := p.DeleteService(params)
 
 	require.ErrorIs(t, err, errors2.ErrServiceNotFound)
@@ -488,7 +494,7 @@ func TestServiceManager_DeleteService_DeleteDirectoryFails(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, common.NewResourceCache(0, 0))
 	err := p.DeleteService(params)
 
 	require.NotNil(t, err)
@@ -531,7 +537,7 @@ func TestServiceManager_DeleteService_CannotCommit(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, common.NewResourceCache(0, 0))
 	err := p.DeleteService(params)
 
 	require.NotNil(t, err)
@@ -547,7 +553,7 @@ func TestServiceManager_DeleteService_CannotCommit(t *testing.T) {
 func getTestServiceManagerFields() serviceManagerTestFields {
 	return serviceManagerTestFields{
 		git: &common_mock.IGitMock{
-			ResetHardFunc:         func(gitContext common_models.GitContext) error { return nil },
+			ResetHardFunc:         func(gitContext common_models.GitContext, revision string) error { return nil },
 			PullFunc:              func(gitContext common_models.GitContext) error { return nil },
 			ProjectExistsFunc:     func(gitContext common_models.GitContext) bool { return true },
 			ProjectRepoExistsFunc: func(projectName string) bool { return true },
@@ -16,6 +16,9 @@ type IServiceResourceHandler interface {
 	GetServiceResource(context *gin.Context)
 	UpdateServiceResource(context *gin.Context)
 	DeleteServiceResource(context *gin.Context)
+	GetServiceResourceHistory(context *gin.Context)
+	GetServiceResourceDiff(context *gin.Context)
+	RevertServiceResource(context *gin.Context)
 }
 
 type ServiceResourceHandler struct {
@@ -40,7 +43,9 @@ func NewServiceResourceHandler(serviceResourceManager IResourceManager) *Service
 // @Param        stageName		path  string  true  "The name of the stage"
 // @Param        serviceName	path  string  true  "The name of the service"
 // @Param        resources		body      models.CreateResourcesPayload  true  "List of resources"
+// @Param        validate		query     string  false  "Set to 'only' to validate the resources without writing them"
 // @Success      201			{string}  models.WriteResourceResponse
+// @Success      200			{string}  models.WriteResourceResponse  "The resources are valid, if validate=only is set"
 // @Failure      400			{object}  models.Error  "Invalid payload"
 // @Failure      500			{object}  models.Error  "Internal error"
 // @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource [post]
@@ -61,6 +66,11 @@ func (ph *ServiceResourceHandler) CreateServiceResources(c *gin.Context) {
 
 	params.CreateResourcesPayload = *createResources
 
+	if err := c.ShouldBindQuery(&params.WriteResourcesQuery); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
@@ -72,6 +82,10 @@ func (ph *ServiceResourceHandler) CreateServiceResources(c *gin.Context) {
 		return
 	}
 
+	if params.ValidateOnly() {
+		c.JSON(http.StatusOK, result)
+		return
+	}
 	c.JSON(http.StatusCreated, result)
 }
 
@@ -135,6 +149,7 @@ func (ph *ServiceResourceHandler) GetServiceResources(c *gin.Context) {
 // @Param        stageName                                                     path  string  true  "The name of the stage"
 // @Param        serviceName                                                   path  string  true  "The name of the service"
 // @Param        resources    body      models.UpdateResourcesPayload  true  "List of resources"
+// @Param        validate     query     string  false  "Set to \'only\' to validate the resources without writing them"
 // @Success      200          {string}  models.WriteResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
@@ -156,6 +171,11 @@ func (ph *ServiceResourceHandler) UpdateServiceResources(c *gin.Context) {
 
 	params.UpdateResourcesPayload = *updateResources
 
+	if err := c.ShouldBindQuery(&params.WriteResourcesQuery); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
@@ -230,6 +250,7 @@ func (ph *ServiceResourceHandler) GetServiceResource(c *gin.Context) {
 // @Param        serviceName                                                      path    string  true  "The name of the service"
 // @Param        resourceURI                                                path  string  true    "The path of the resource file"
 // @Param        resources    body      models.UpdateResourcePayload  true  "resource"
+// @Param        validate     query     string  false  "Set to \'only\' to validate the resources without writing them"
 // @Success      200          {string}  models.WriteResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
@@ -251,6 +272,11 @@ func (ph *ServiceResourceHandler) UpdateServiceResource(c *gin.Context) {
 
 	params.UpdateResourcePayload = *updateResource
 
+	if err := c.ShouldBindQuery(&params.WriteResourcesQuery); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
@@ -304,3 +330,143 @@ func (ph *ServiceResourceHandler) DeleteServiceResource(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetServiceResourceHistory godoc
+// @Summary      Get the history of a service resource
+// @Description  Get the list of commits that changed the service resource, starting with the most recent one
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        serviceName  path      string  true   "The name of the service"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/history [get]
+func (ph *ServiceResourceHandler) GetServiceResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	history, err := ph.ServiceResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetServiceResourceDiff godoc
+// @Summary      Get the diff of a service resource
+// @Description  Get the unified diff of the service resource between two revisions
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        serviceName  path      string  true   "The name of the service"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        from         query     string  true   "The commit ID the diff starts from"
+// @Param        to           query     string  false  "The commit ID the diff ends at. Defaults to the latest revision"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/diff [get]
+func (ph *ServiceResourceHandler) GetServiceResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	diffQuery := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(diffQuery); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *diffQuery
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.ServiceResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RevertServiceResource godoc
+// @Summary      Reverts a service resource
+// @Description  Restores the content of the service resource at the given revision in a new commit
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        serviceName  path      string  true   "The name of the service"
+// @Param        resourceURI  path      string                        true   "The path of the resource file"
+// @Param        revision     body      models.RevertResourcePayload  true   "The revision to restore"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/revert [post]
+func (ph *ServiceResourceHandler) RevertServiceResource(c *gin.Context) {
+	params := &models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	revertResource := &models.RevertResourcePayload{}
+	if err := c.ShouldBindJSON(revertResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.RevertResourcePayload = *revertResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ServiceResourceManager.RevertResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
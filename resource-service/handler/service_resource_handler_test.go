@@ -897,3 +897,262 @@ func TestServiceResourceHandler_DeleteServiceResource(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceResourceHandler_GetServiceResourceHistory(t *testing.T) {
+	type fields struct {
+		ResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceHistoryParams
+		wantStatus int
+	}{
+		{
+			name: "get resource history",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return &models.GetResourceHistoryResponse{ResourceURI: params.ResourceURI}, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/history", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "resource in parent directory - should return error",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/..my-resource.yaml/history", nil),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "resource not found",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return nil, errors2.ErrResourceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/history", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/history", ph.GetServiceResourceHistory)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ResourceManager.GetResourceHistoryCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ResourceManager.GetResourceHistoryCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ResourceManager.GetResourceHistoryCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
+
+func TestServiceResourceHandler_GetServiceResourceDiff(t *testing.T) {
+	type fields struct {
+		ResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceDiffParams
+		wantStatus int
+	}{
+		{
+			name: "get resource diff",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return &models.GetResourceDiffResponse{ResourceURI: params.ResourceURI}, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/diff?from=commit-1&to=commit-2", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					FromCommitID: "commit-1",
+					ToCommitID:   "commit-2",
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "from revision missing - should return error",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/diff", nil),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid revision",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return nil, errors2.ErrResolveRevision
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/diff?from=unknown", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					FromCommitID: "unknown",
+				},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/diff", ph.GetServiceResourceDiff)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ResourceManager.GetResourceDiffCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ResourceManager.GetResourceDiffCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ResourceManager.GetResourceDiffCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
+
+func TestServiceResourceHandler_RevertServiceResource(t *testing.T) {
+	type fields struct {
+		ResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.RevertResourceParams
+		wantStatus int
+	}{
+		{
+			name: "revert resource",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{
+					RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+						return &models.WriteResourceResponse{CommitID: "my-commit-id"}, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/revert", bytes.NewBuffer([]byte(`{"gitCommitID": "commit-1"}`))),
+			wantParams: &models.RevertResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				RevertResourcePayload: models.RevertResourcePayload{
+					GitCommitID: "commit-1",
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "commit ID missing - should return error",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/revert", bytes.NewBuffer([]byte(`{}`))),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "resource not found in revision",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{
+					RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+						return nil, errors2.ErrResourceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/revert", bytes.NewBuffer([]byte(`{"gitCommitID": "commit-1"}`))),
+			wantParams: &models.RevertResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				RevertResourcePayload: models.RevertResourcePayload{
+					GitCommitID: "commit-1",
+				},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ResourceManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/revert", ph.RevertServiceResource)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ResourceManager.RevertResourceCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ResourceManager.RevertResourceCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ResourceManager.RevertResourceCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
@@ -22,36 +22,46 @@ func NewBranchConfigurationContext(git common.IGit, fileSystem common.IFileSyste
 }
 
 func (bs BranchConfigurationContext) Establish(params common_models.ConfigurationContextParams) (string, error) {
-	var branch string
-	var err error
-	if params.Stage == nil {
-		branch, err = bs.git.GetDefaultBranch(params.GitContext)
-		if err != nil {
-			return "", fmt.Errorf("could not determine default branch of project %s: %w", params.Project.ProjectName, err)
+	if !params.SkipCheckout {
+		if err := bs.checkoutStageBranch(params); err != nil {
+			return "", err
 		}
-	} else {
-		branch = params.Stage.StageName
-	}
-
-	if err := bs.git.CheckoutBranch(params.GitContext, branch); err != nil {
-		return "", fmt.Errorf("could not check out branch %s of project %s: %w", branch, params.Project.ProjectName, err)
 	}
 
+	checkConfigDir := params.CheckConfigDirAvailable && !params.SkipCheckout
 	var configPath string
 	if params.Service == nil {
 		configPath = common.GetProjectConfigPath(params.Project.ProjectName)
-		if params.CheckConfigDirAvailable && !bs.fileSystem.FileExists(configPath) {
+		if checkConfigDir && !bs.fileSystem.FileExists(configPath) {
 			return "", kerrors.ErrProjectNotFound
 		}
 	} else {
 		configPath = common.GetServiceConfigPath(params.Project.ProjectName, params.Service.ServiceName)
-		if params.CheckConfigDirAvailable && !bs.fileSystem.FileExists(configPath) {
+		if checkConfigDir && !bs.fileSystem.FileExists(configPath) {
 			return "", kerrors.ErrServiceNotFound
 		}
 	}
 	return configPath, nil
 }
 
+func (bs BranchConfigurationContext) checkoutStageBranch(params common_models.ConfigurationContextParams) error {
+	var branch string
+	var err error
+	if params.Stage == nil {
+		branch, err = bs.git.GetDefaultBranch(params.GitContext)
+		if err != nil {
+			return fmt.Errorf("could not determine default branch of project %s: %w", params.Project.ProjectName, err)
+		}
+	} else {
+		branch = params.Stage.StageName
+	}
+
+	if err := bs.git.CheckoutBranch(params.GitContext, branch); err != nil {
+		return fmt.Errorf("could not check out branch %s of project %s: %w", branch, params.Project.ProjectName, err)
+	}
+	return nil
+}
+
 type DirectoryConfigurationContext struct {
 	git        common.IGit
 	fileSystem common.IFileSystem
@@ -62,28 +72,31 @@ func NewDirectoryConfigurationContext(git common.IGit, fileSystem common.IFileSy
 }
 
 func (ds DirectoryConfigurationContext) Establish(params common_models.ConfigurationContextParams) (string, error) {
-	branch, err := ds.git.GetDefaultBranch(params.GitContext)
-	if err != nil {
-		return "", fmt.Errorf("could not determine default branch of project %s: %w", params.Project.ProjectName, err)
-	}
-	if err := ds.git.CheckoutBranch(params.GitContext, branch); err != nil {
-		return "", fmt.Errorf("could not check out branch %s of project %s: %w", branch, params.Project.ProjectName, err)
+	if !params.SkipCheckout {
+		branch, err := ds.git.GetDefaultBranch(params.GitContext)
+		if err != nil {
+			return "", fmt.Errorf("could not determine default branch of project %s: %w", params.Project.ProjectName, err)
+		}
+		if err := ds.git.CheckoutBranch(params.GitContext, branch); err != nil {
+			return "", fmt.Errorf("could not check out branch %s of project %s: %w", branch, params.Project.ProjectName, err)
+		}
 	}
 
+	checkConfigDir := params.CheckConfigDirAvailable && !params.SkipCheckout
 	var configPath string
 	if params.Stage != nil && params.Service != nil {
 		configPath = ds.GetServiceConfigPath(params.Project.ProjectName, params.Stage.StageName, params.Service.ServiceName)
-		if params.CheckConfigDirAvailable && !ds.fileSystem.FileExists(configPath) {
+		if checkConfigDir && !ds.fileSystem.FileExists(configPath) {
 			return "", kerrors.ErrServiceNotFound
 		}
 	} else if params.Stage != nil {
 		configPath = ds.GetStageConfigPath(params.Project.ProjectName, params.Stage.StageName)
-		if params.CheckConfigDirAvailable && !ds.fileSystem.FileExists(configPath) {
+		if checkConfigDir && !ds.fileSystem.FileExists(configPath) {
 			return "", kerrors.ErrStageNotFound
 		}
 	} else {
 		configPath = ds.GetProjectConfigPath(params.Project.ProjectName)
-		if params.CheckConfigDirAvailable && !ds.fileSystem.FileExists(configPath) {
+		if checkConfigDir && !ds.fileSystem.FileExists(configPath) {
 			return "", kerrors.ErrProjectNotFound
 		}
 	}
@@ -196,6 +196,30 @@ func TestBranchStageContext_Establish_CannotCheckoutBranch(t *testing.T) {
 	require.Len(t, fields.git.CheckoutBranchCalls(), 1)
 }
 
+func TestBranchStageContext_Establish_SkipCheckout(t *testing.T) {
+	fields := getTestBranchStageContextFields()
+
+	bs := NewBranchConfigurationContext(fields.git, fields.fileSystem)
+
+	params := common_models.ConfigurationContextParams{
+		Project:                 models.Project{ProjectName: "my-project"},
+		Stage:                   &models.Stage{StageName: "my-stage"},
+		Service:                 &models.Service{ServiceName: "my-service"},
+		GitContext:              common_models.GitContext{},
+		CheckConfigDirAvailable: true,
+		SkipCheckout:            true,
+	}
+	configPath, err := bs.Establish(params)
+
+	require.Nil(t, err)
+
+	require.Equal(t, common.GetServiceConfigPath("my-project", "my-service"), configPath)
+
+	require.Empty(t, fields.git.GetDefaultBranchCalls())
+	require.Empty(t, fields.git.CheckoutBranchCalls())
+	require.Empty(t, fields.fileSystem.FileExistsCalls())
+}
+
 func getTestBranchStageContextFields() testBranchStageContextFields {
 	return testBranchStageContextFields{
 		git: &common_mock.IGitMock{
@@ -406,3 +430,27 @@ func TestDirectoryConfigurationContext_Establish_CannotCheckoutDefaultBranch(t *
 
 	require.Equal(t, "", configDir)
 }
+
+func TestDirectoryConfigurationContext_Establish_SkipCheckout(t *testing.T) {
+	fields := getTestBranchStageContextFields()
+
+	ds := NewDirectoryConfigurationContext(fields.git, fields.fileSystem)
+
+	params := common_models.ConfigurationContextParams{
+		Project:                 models.Project{ProjectName: "my-project"},
+		Stage:                   &models.Stage{StageName: "my-stage"},
+		Service:                 &models.Service{ServiceName: "my-service"},
+		GitContext:              common_models.GitContext{},
+		CheckConfigDirAvailable: true,
+		SkipCheckout:            true,
+	}
+	configDir, err := ds.Establish(params)
+
+	require.Nil(t, err)
+
+	require.Equal(t, ds.GetServiceConfigPath("my-project", "my-stage", "my-service"), configDir)
+
+	require.Empty(t, fields.git.GetDefaultBranchCalls())
+	require.Empty(t, fields.git.CheckoutBranchCalls())
+	require.Empty(t, fields.fileSystem.FileExistsCalls())
+}
@@ -20,12 +20,14 @@ type IStageManager interface {
 type BranchingStageManager struct {
 	git              common.IGit
 	credentialReader common.CredentialReader
+	cache            *common.ResourceCache
 }
 
-func NewStageManager(git common.IGit, credentialReader common.CredentialReader) *BranchingStageManager {
+func NewStageManager(git common.IGit, credentialReader common.CredentialReader, cache *common.ResourceCache) *BranchingStageManager {
 	stageManager := &BranchingStageManager{
 		git:              git,
 		credentialReader: credentialReader,
+		cache:            cache,
 	}
 	return stageManager
 }
@@ -62,6 +64,7 @@ func (s BranchingStageManager) CreateStage(params models.CreateStageParams) erro
 	if err != nil {
 		return fmt.Errorf("could not push new branch %s of project %s: %w", params.StageName, params.ProjectName, err)
 	}
+	s.cache.InvalidateProject(params.ProjectName)
 
 	return nil
 }
@@ -75,10 +78,11 @@ type DirectoryStageManager struct {
 	fileSystem           common.IFileSystem
 	credentialReader     common.CredentialReader
 	git                  common.IGit
+	cache                *common.ResourceCache
 }
 
-func NewDirectoryStageManager(configurationContext IConfigurationContext, fileSystem common.IFileSystem, credentialReader common.CredentialReader, git common.IGit) *DirectoryStageManager {
-	return &DirectoryStageManager{configurationContext: configurationContext, fileSystem: fileSystem, credentialReader: credentialReader, git: git}
+func NewDirectoryStageManager(configurationContext IConfigurationContext, fileSystem common.IFileSystem, credentialReader common.CredentialReader, git common.IGit, cache *common.ResourceCache) *DirectoryStageManager {
+	return &DirectoryStageManager{configurationContext: configurationContext, fileSystem: fileSystem, credentialReader: credentialReader, git: git, cache: cache}
 }
 
 func (dm DirectoryStageManager) CreateStage(params models.CreateStageParams) error {
@@ -110,6 +114,7 @@ func (dm DirectoryStageManager) CreateStage(params models.CreateStageParams) err
 	if _, err := dm.git.StageAndCommitAll(*gitContext, "Added stage: "+params.StageName); err != nil {
 		return fmt.Errorf("could not initialize stage %s: %w", params.StageName, err)
 	}
+	dm.cache.InvalidateProject(params.ProjectName)
 
 	return nil
 }
@@ -133,6 +138,7 @@ func (dm DirectoryStageManager) DeleteStage(params models.DeleteStageParams) err
 	if _, err := dm.git.StageAndCommitAll(*gitContext, "Added stage: "+params.StageName); err != nil {
 		return fmt.Errorf("could not delete stage %s: %w", params.StageName, err)
 	}
+	dm.cache.InvalidateProject(params.ProjectName)
 
 	return nil
 }
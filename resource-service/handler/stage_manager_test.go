@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/resource-service/common"
 	common_mock "github.com/keptn/keptn/resource-service/common/fake"
 	"github.com/keptn/keptn/resource-service/common_models"
 	errors2 "github.com/keptn/keptn/resource-service/errors"
@@ -44,7 +45,7 @@ func TestStageManager_CreateStage(t *testing.T) {
 	}
 
 	fields := getTestStageManagerFields()
-	s := NewStageManager(fields.git, fields.credentialReader)
+	s := NewStageManager(fields.git, fields.credentialReader, common.NewResourceCache(0, 0))
 	err := s.CreateStage(params)
 
 	require.Nil(t, err)
@@ -75,7 +76,7 @@ func TestStageManager_CreateStage_NoCredentialsFound(t *testing.T) {
 	fields.credentialReader.GetCredentialsFunc = func(project string) (*common_models.GitCredentials, error) {
 		return nil, errors2.ErrCredentialsNotFound
 	}
-	s := NewStageManager(fields.git, fields.credentialReader)
+	s := NewStageManager(fields.git, fields.credentialReader, common.NewResourceCache(0, 0))
 	err := s.CreateStage(params)
 
 	require.ErrorIs(t, err, errors2.ErrCredentialsNotFound)
@@ -110,7 +111,7 @@ func TestStageManager_CreateStage_ProjectDoesNotExist(t *testing.T) {
 		return false
 	}
 
-	s := NewStageManager(fields.git, fields.credentialReader)
+	s := NewStageManager(fields.git, fields.credentialReader, common.NewResourceCache(0, 0))
 	err := s.CreateStage(params)
 
 	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
@@ -148,7 +149,7 @@ func TestStageManager_CreateStage_CannotGetDefaultBranch(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	s := NewStageManager(fields.git, fields.credentialReader)
+	s := NewStageManager(fields.git, fields.credentialReader, common.NewResourceCache(0, 0))
 	err := s.CreateStage(params)
 
 	require.NotNil(t, err)
@@ -186,7 +187,7 @@ func TestStageManager_CreateStage_CannotCreateBranch(t *testing.T) {
 		return errors2.ErrStageAlreadyExists
 	}
 
-	s := NewStageManager(fields.git, fields.credentialReader)
+	s := NewStageManager(fields.git, fields.credentialReader, common.NewResourceCache(0, 0))
 	err := s.CreateStage(params)
 
 	require.ErrorIs(t, err, errors2.ErrStageAlreadyExists)
@@ -227,7 +228,7 @@ func TestStageManager_CreateStage_CannotPushBranch(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	s := NewStageManager(fields.git, fields.credentialReader)
+	s := NewStageManager(fields.git, fields.credentialReader, common.NewResourceCache(0, 0))
 	err := s.CreateStage(params)
 
 	require.NotNil(t, err)
@@ -304,7 +305,10 @@ func TestDirectoryStageManager_CreateStage(t *testing.T) {
 		return false
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	cache := common.NewResourceCache(0, 0)
+	cache.SetLatestRevision("my-project", "", "my-old-revision")
+
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, cache)
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -314,6 +318,10 @@ func TestDirectoryStageManager_CreateStage(t *testing.T) {
 	})
 
 	require.Nil(t, err)
+
+	// the new stage is not visible in the cached revision of the project
+	_, ok := cache.GetLatestRevision("my-project", "")
+	require.False(t, ok)
 }
 
 func TestDirectoryStageManager_CreateStage_CannotEstablishContext(t *testing.T) {
@@ -323,7 +331,7 @@ func TestDirectoryStageManager_CreateStage_CannotEstablishContext(t *testing.T)
 		return "", errors.New("oops")
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, common.NewResourceCache(0, 0))
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -344,7 +352,7 @@ func TestDirectoryStageManager_CreateStage_CannotGetCredentials(t *testing.T) {
 		return nil, errors.New("oops")
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, common.NewResourceCache(0, 0))
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -365,7 +373,7 @@ func TestDirectoryStageManager_CreateStage_ProjectNotFound(t *testing.T) {
 		return false
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, common.NewResourceCache(0, 0))
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -386,7 +394,7 @@ func TestDirectoryStageManager_CreateStage_StageAlreadyExists(t *testing.T) {
 		return true
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, common.NewResourceCache(0, 0))
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -409,7 +417,7 @@ func TestDirectoryStageManager_CreateStage_CannotCreateDirectory(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, common.NewResourceCache(0, 0))
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -432,7 +440,7 @@ func TestDirectoryStageManager_CreateStage_CannotWriteMetadata(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, common.NewResourceCache(0, 0))
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -455,7 +463,7 @@ func TestDirectoryStageManager_CreateStage_CannotCommit(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, common.NewResourceCache(0, 0))
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -470,7 +478,7 @@ func TestDirectoryStageManager_CreateStage_CannotCommit(t *testing.T) {
 func TestDirectoryStageManager_DeleteStage(t *testing.T) {
 	fields := getTestStageManagerFields()
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, common.NewResourceCache(0, 0))
 
 	err := dm.DeleteStage(models.DeleteStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -488,7 +496,7 @@ func TestDirectoryStageManager_DeleteStage_CannotEstablishContext(t *testing.T)
 	fields.configurationContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
 		return "", errors.New("oops")
 	}
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, common.NewResourceCache(0, 0))
 
 	err := dm.DeleteStage(models.DeleteStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -506,7 +514,7 @@ func TestDirectoryStageManager_DeleteStage_StageDirectoryNotAvailable(t *testing
 	fields.fileSystem.FileExistsFunc = func(path string) bool {
 		return false
 	}
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, common.NewResourceCache(0, 0))
 
 	err := dm.DeleteStage(models.DeleteStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -524,7 +532,7 @@ func TestDirectoryStageManager_DeleteStage_CannotDeleteDirectory(t *testing.T) {
 	fields.fileSystem.DeleteFileFunc = func(path string) error {
 		return errors.New("oops")
 	}
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, common.NewResourceCache(0, 0))
 
 	err := dm.DeleteStage(models.DeleteStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -542,7 +550,7 @@ func TestDirectoryStageManager_DeleteStage_CannotCommitChanges(t *testing.T) {
 	fields.git.StageAndCommitAllFunc = func(gitContext common_models.GitContext, message string) (string, error) {
 		return "", errors.New("oops")
 	}
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, common.NewResourceCache(0, 0))
 
 	err := dm.DeleteStage(models.DeleteStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -16,6 +16,9 @@ type IStageResourceHandler interface {
 	GetStageResource(context *gin.Context)
 	UpdateStageResource(context *gin.Context)
 	DeleteStageResource(context *gin.Context)
+	GetStageResourceHistory(context *gin.Context)
+	GetStageResourceDiff(context *gin.Context)
+	RevertStageResource(context *gin.Context)
 }
 
 type StageResourceHandler struct {
@@ -39,7 +42,9 @@ func NewStageResourceHandler(stageResourceManager IResourceManager) *StageResour
 // @Param        projectName  path  string  true  "The name of the project"
 // @Param        stageName    path  string  true  "The name of the stage"
 // @Param        resources    body      models.CreateResourcesPayload  true  "List of resources"
+// @Param        validate     query     string  false  "Set to \'only\' to validate the resources without writing them"
 // @Success      201          {string}  models.WriteResourceResponse
+// @Success      200          {string}  models.WriteResourceResponse  "The resources are valid, if validate=only is set"
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
 // @Router       /project/{projectName}/stage/{stageName}/resource [post]
@@ -59,6 +64,11 @@ func (ph *StageResourceHandler) CreateStageResources(c *gin.Context) {
 
 	params.CreateResourcesPayload = *createResources
 
+	if err := c.ShouldBindQuery(&params.WriteResourcesQuery); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
@@ -70,6 +80,10 @@ func (ph *StageResourceHandler) CreateStageResources(c *gin.Context) {
 		return
 	}
 
+	if params.ValidateOnly() {
+		c.JSON(http.StatusOK, result)
+		return
+	}
 	c.JSON(http.StatusCreated, result)
 }
 
@@ -129,6 +143,7 @@ func (ph *StageResourceHandler) GetStageResources(c *gin.Context) {
 // @Param        projectName                                                   path  string  true  "The name of the project"
 // @Param        stageName                                                     path  string  true  "The name of the stage"
 // @Param        resources    body      models.UpdateResourcesPayload  true  "List of resources"
+// @Param        validate     query     string  false  "Set to \'only\' to validate the resources without writing them"
 // @Success      200          {string}  models.WriteResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
@@ -149,6 +164,11 @@ func (ph *StageResourceHandler) UpdateStageResources(c *gin.Context) {
 
 	params.UpdateResourcesPayload = *updateResources
 
+	if err := c.ShouldBindQuery(&params.WriteResourcesQuery); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
@@ -221,6 +241,7 @@ func (ph *StageResourceHandler) GetStageResource(c *gin.Context) {
 // @Param        stageName    path    string  true  "The name of the stage"
 // @Param        resourceURI  path  string  true    "The path of the resource file"
 // @Param        resources    body      models.UpdateResourcePayload  true  "resource"
+// @Param        validate     query     string  false  "Set to \'only\' to validate the resources without writing them"
 // @Success      200          {string}  models.WriteResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
 // @Failure      500          {object}  models.Error  "Internal error"
@@ -241,6 +262,11 @@ func (ph *StageResourceHandler) UpdateStageResource(c *gin.Context) {
 
 	params.UpdateResourcePayload = *updateResource
 
+	if err := c.ShouldBindQuery(&params.WriteResourcesQuery); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
@@ -292,3 +318,137 @@ func (ph *StageResourceHandler) DeleteStageResource(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetStageResourceHistory godoc
+// @Summary      Get the history of a stage resource
+// @Description  Get the list of commits that changed the stage resource, starting with the most recent one
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/history [get]
+func (ph *StageResourceHandler) GetStageResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	history, err := ph.StageResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetStageResourceDiff godoc
+// @Summary      Get the diff of a stage resource
+// @Description  Get the unified diff of the stage resource between two revisions
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        from         query     string  true   "The commit ID the diff starts from"
+// @Param        to           query     string  false  "The commit ID the diff ends at. Defaults to the latest revision"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/diff [get]
+func (ph *StageResourceHandler) GetStageResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	diffQuery := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(diffQuery); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *diffQuery
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.StageResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RevertStageResource godoc
+// @Summary      Reverts a stage resource
+// @Description  Restores the content of the stage resource at the given revision in a new commit
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        resourceURI  path      string                        true   "The path of the resource file"
+// @Param        revision     body      models.RevertResourcePayload  true   "The revision to restore"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/revert [post]
+func (ph *StageResourceHandler) RevertStageResource(c *gin.Context) {
+	params := &models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	revertResource := &models.RevertResourcePayload{}
+	if err := c.ShouldBindJSON(revertResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.RevertResourcePayload = *revertResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.StageResourceManager.RevertResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"sync"
+
+	"github.com/keptn/keptn/resource-service/common"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+//IStorageBackend provides an interface for storing projects and their resources
+//go:generate moq -pkg handler_mock -skip-ensure -out ./fake/storage_backend_mock.go . IStorageBackend
+type IStorageBackend interface {
+	IProjectManager
+	IStageManager
+	IServiceManager
+	IResourceManager
+	// ProjectExists checks whether the project is stored in the backend. An error is returned if this cannot be
+	// determined, e.g. because the backend is not reachable
+	ProjectExists(projectName string) (bool, error)
+}
+
+// GitStorageBackend stores projects in git repositories that are synchronized with their upstream
+type GitStorageBackend struct {
+	IProjectManager
+	IStageManager
+	IServiceManager
+	IResourceManager
+	fileSystem common.IFileSystem
+}
+
+func NewGitStorageBackend(projectManager IProjectManager, stageManager IStageManager, serviceManager IServiceManager, resourceManager IResourceManager, fileSystem common.IFileSystem) *GitStorageBackend {
+	return &GitStorageBackend{
+		IProjectManager:  projectManager,
+		IStageManager:    stageManager,
+		IServiceManager:  serviceManager,
+		IResourceManager: resourceManager,
+		fileSystem:       fileSystem,
+	}
+}
+
+// ProjectExists checks whether the local repository of the project has been created
+func (b GitStorageBackend) ProjectExists(projectName string) (bool, error) {
+	return b.fileSystem.FileExists(common.GetProjectConfigPath(projectName)), nil
+}
+
+// StorageBackendRouter forwards all requests to the storage backend of the respective project.
+// The backend is selected when creating a project; projects that are not known by any other backend are stored in git.
+// The backend of each project is cached, so that it is only looked up with the first request for the project
+type StorageBackendRouter struct {
+	backends        map[string]IStorageBackend
+	mutex           sync.RWMutex
+	projectBackends map[string]string
+}
+
+// NewStorageBackendRouter creates a new StorageBackendRouter. The backends are identified by the storage backend names
+// defined in the models package; the git backend is mandatory
+func NewStorageBackendRouter(backends map[string]IStorageBackend) *StorageBackendRouter {
+	return &StorageBackendRouter{backends: backends, projectBackends: map[string]string{}}
+}
+
+func (r *StorageBackendRouter) CreateProject(params models.CreateProjectParams) error {
+	backendName := params.StorageBackend
+	if backendName == "" {
+		backendName = models.StorageBackendGit
+	}
+	backend, ok := r.backends[backendName]
+	if !ok {
+		return kerrors.ErrStorageBackendNotAvailable
+	}
+	for name, other := range r.backends {
+		if name == backendName {
+			continue
+		}
+		exists, err := other.ProjectExists(params.ProjectName)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return kerrors.ErrProjectAlreadyExists
+		}
+	}
+	if err := backend.CreateProject(params); err != nil {
+		return err
+	}
+	r.setProjectBackend(params.ProjectName, backendName)
+	return nil
+}
+
+func (r *StorageBackendRouter) UpdateProject(params models.UpdateProjectParams) error {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return err
+	}
+	return backend.UpdateProject(params)
+}
+
+func (r *StorageBackendRouter) DeleteProject(projectName string) error {
+	backend, err := r.getBackend(projectName)
+	if err != nil {
+		return err
+	}
+	err = backend.DeleteProject(projectName)
+	r.mutex.Lock()
+	delete(r.projectBackends, projectName)
+	r.mutex.Unlock()
+	return err
+}
+
+func (r *StorageBackendRouter) CreateStage(params models.CreateStageParams) error {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return err
+	}
+	return backend.CreateStage(params)
+}
+
+func (r *StorageBackendRouter) DeleteStage(params models.DeleteStageParams) error {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return err
+	}
+	return backend.DeleteStage(params)
+}
+
+func (r *StorageBackendRouter) CreateService(params models.CreateServiceParams) error {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return err
+	}
+	return backend.CreateService(params)
+}
+
+func (r *StorageBackendRouter) DeleteService(params models.DeleteServiceParams) error {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return err
+	}
+	return backend.DeleteService(params)
+}
+
+func (r *StorageBackendRouter) CreateResources(params models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	return backend.CreateResources(params)
+}
+
+func (r *StorageBackendRouter) GetResources(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	return backend.GetResources(params)
+}
+
+func (r *StorageBackendRouter) UpdateResources(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	return backend.UpdateResources(params)
+}
+
+func (r *StorageBackendRouter) GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	return backend.GetResource(params)
+}
+
+func (r *StorageBackendRouter) UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	return backend.UpdateResource(params)
+}
+
+func (r *StorageBackendRouter) DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	return backend.DeleteResource(params)
+}
+
+func (r *StorageBackendRouter) GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	return backend.GetResourceHistory(params)
+}
+
+func (r *StorageBackendRouter) GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	return backend.GetResourceDiff(params)
+}
+
+func (r *StorageBackendRouter) RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+	backend, err := r.getBackend(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	return backend.RevertResource(params)
+}
+
+// getBackend returns the backend of the project. If a backend cannot tell whether it stores the project, an error is
+// returned instead of falling back to git, since this could serve or create a project that shadows the one in the backend
+func (r *StorageBackendRouter) getBackend(projectName string) (IStorageBackend, error) {
+	r.mutex.RLock()
+	backendName, ok := r.projectBackends[projectName]
+	r.mutex.RUnlock()
+	if ok {
+		return r.backends[backendName], nil
+	}
+
+	for name, backend := range r.backends {
+		if name == models.StorageBackendGit {
+			continue
+		}
+		exists, err := backend.ProjectExists(projectName)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			r.setProjectBackend(projectName, name)
+			return backend, nil
+		}
+	}
+	// unknown projects are not cached, since they might still be created in another backend
+	gitBackend := r.backends[models.StorageBackendGit]
+	exists, err := gitBackend.ProjectExists(projectName)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		r.setProjectBackend(projectName, models.StorageBackendGit)
+	}
+	return gitBackend, nil
+}
+
+func (r *StorageBackendRouter) setProjectBackend(projectName, backendName string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.projectBackends[projectName] = backendName
+}
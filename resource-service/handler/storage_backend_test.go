@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStorageBackends(objectStoreProjects ...string) (*handler_mock.IStorageBackendMock, *handler_mock.IStorageBackendMock) {
+	gitBackend := &handler_mock.IStorageBackendMock{
+		ProjectExistsFunc: func(projectName string) (bool, error) {
+			return projectName == "git-project", nil
+		},
+		CreateProjectFunc: func(project models.CreateProjectParams) error {
+			return nil
+		},
+		GetResourceFunc: func(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+			return &models.GetResourceResponse{Metadata: models.Version{Version: "git"}}, nil
+		},
+	}
+	objectStoreBackend := &handler_mock.IStorageBackendMock{
+		ProjectExistsFunc: func(projectName string) (bool, error) {
+			for _, project := range objectStoreProjects {
+				if project == projectName {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		CreateProjectFunc: func(project models.CreateProjectParams) error {
+			return nil
+		},
+		GetResourceFunc: func(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+			return &models.GetResourceResponse{Metadata: models.Version{Version: "1"}}, nil
+		},
+	}
+	return gitBackend, objectStoreBackend
+}
+
+func TestStorageBackendRouter_CreateProject(t *testing.T) {
+	gitBackend, objectStoreBackend := newTestStorageBackends("object-project")
+	router := NewStorageBackendRouter(map[string]IStorageBackend{
+		models.StorageBackendGit:         gitBackend,
+		models.StorageBackendObjectStore: objectStoreBackend,
+	})
+
+	err := router.CreateProject(models.CreateProjectParams{Project: models.Project{ProjectName: "new-project"}})
+	require.Nil(t, err)
+	require.Len(t, gitBackend.CreateProjectCalls(), 1)
+
+	err = router.CreateProject(models.CreateProjectParams{
+		Project:        models.Project{ProjectName: "new-project-2"},
+		StorageBackend: models.StorageBackendObjectStore,
+	})
+	require.Nil(t, err)
+	require.Len(t, objectStoreBackend.CreateProjectCalls(), 1)
+
+	// project names must be unique across all backends
+	err = router.CreateProject(models.CreateProjectParams{
+		Project:        models.Project{ProjectName: "git-project"},
+		StorageBackend: models.StorageBackendObjectStore,
+	})
+	require.ErrorIs(t, err, kerrors.ErrProjectAlreadyExists)
+
+	err = router.CreateProject(models.CreateProjectParams{Project: models.Project{ProjectName: "object-project"}})
+	require.ErrorIs(t, err, kerrors.ErrProjectAlreadyExists)
+
+	require.Len(t, gitBackend.CreateProjectCalls(), 1)
+	require.Len(t, objectStoreBackend.CreateProjectCalls(), 1)
+}
+
+func TestStorageBackendRouter_CreateProjectBackendNotAvailable(t *testing.T) {
+	gitBackend, _ := newTestStorageBackends()
+	router := NewStorageBackendRouter(map[string]IStorageBackend{
+		models.StorageBackendGit: gitBackend,
+	})
+
+	err := router.CreateProject(models.CreateProjectParams{
+		Project:        models.Project{ProjectName: "new-project"},
+		StorageBackend: models.StorageBackendObjectStore,
+	})
+	require.ErrorIs(t, err, kerrors.ErrStorageBackendNotAvailable)
+	require.Empty(t, gitBackend.CreateProjectCalls())
+}
+
+func TestStorageBackendRouter_ForwardsToProjectBackend(t *testing.T) {
+	gitBackend, objectStoreBackend := newTestStorageBackends("object-project")
+	router := NewStorageBackendRouter(map[string]IStorageBackend{
+		models.StorageBackendGit:         gitBackend,
+		models.StorageBackendObjectStore: objectStoreBackend,
+	})
+
+	resource, err := router.GetResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "object-project"}},
+		ResourceURI:     "shipyard.yaml",
+	})
+	require.Nil(t, err)
+	require.Equal(t, "1", resource.Metadata.Version)
+
+	// projects that are unknown to the other backends are handled by git, which reports missing projects
+	for _, project := range []string{"git-project", "unknown-project"} {
+		resource, err = router.GetResource(models.GetResourceParams{
+			ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: project}},
+			ResourceURI:     "shipyard.yaml",
+		})
+		require.Nil(t, err)
+		require.Equal(t, "git", resource.Metadata.Version)
+	}
+
+	require.Len(t, objectStoreBackend.GetResourceCalls(), 1)
+	require.Len(t, gitBackend.GetResourceCalls(), 2)
+}
+
+func TestStorageBackendRouter_CachesProjectBackend(t *testing.T) {
+	gitBackend, objectStoreBackend := newTestStorageBackends("object-project")
+	objectStoreBackend.DeleteProjectFunc = func(projectName string) error {
+		return nil
+	}
+	router := NewStorageBackendRouter(map[string]IStorageBackend{
+		models.StorageBackendGit:         gitBackend,
+		models.StorageBackendObjectStore: objectStoreBackend,
+	})
+	getResource := func(project string) {
+		_, err := router.GetResource(models.GetResourceParams{
+			ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: project}},
+			ResourceURI:     "shipyard.yaml",
+		})
+		require.Nil(t, err)
+	}
+
+	getResource("object-project")
+	getResource("object-project")
+	require.Len(t, objectStoreBackend.ProjectExistsCalls(), 1)
+
+	// the backend of a created project is known without looking it up
+	require.Nil(t, router.CreateProject(models.CreateProjectParams{
+		Project:        models.Project{ProjectName: "new-project"},
+		StorageBackend: models.StorageBackendObjectStore,
+	}))
+	projectExistsCalls := len(objectStoreBackend.ProjectExistsCalls())
+	getResource("new-project")
+	require.Len(t, objectStoreBackend.ProjectExistsCalls(), projectExistsCalls)
+	require.Len(t, objectStoreBackend.GetResourceCalls(), 3)
+
+	// the backend is looked up again after the project has been deleted
+	require.Nil(t, router.DeleteProject("object-project"))
+	projectExistsCalls = len(objectStoreBackend.ProjectExistsCalls())
+	getResource("object-project")
+	require.Len(t, objectStoreBackend.ProjectExistsCalls(), projectExistsCalls+1)
+}
+
+func TestStorageBackendRouter_BackendNotReachable(t *testing.T) {
+	gitBackend, objectStoreBackend := newTestStorageBackends()
+	objectStoreBackend.ProjectExistsFunc = func(projectName string) (bool, error) {
+		return false, errors.New("connection refused")
+	}
+	router := NewStorageBackendRouter(map[string]IStorageBackend{
+		models.StorageBackendGit:         gitBackend,
+		models.StorageBackendObjectStore: objectStoreBackend,
+	})
+
+	// the project might be stored in the unreachable backend, so it is neither served nor created by git
+	_, err := router.GetResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "object-project"}},
+		ResourceURI:     "shipyard.yaml",
+	})
+	require.ErrorContains(t, err, "connection refused")
+
+	err = router.CreateProject(models.CreateProjectParams{Project: models.Project{ProjectName: "object-project"}})
+	require.ErrorContains(t, err, "connection refused")
+
+	require.Empty(t, gitBackend.GetResourceCalls())
+	require.Empty(t, gitBackend.CreateProjectCalls())
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+// maxWebhookPayloadSize is the maximum size of a webhook payload that is accepted
+const maxWebhookPayloadSize = 5 * 1024 * 1024
+
+type IUpstreamHandler interface {
+	ReceiveWebhook(context *gin.Context)
+}
+
+type UpstreamHandler struct {
+	UpstreamManager IUpstreamManager
+}
+
+func NewUpstreamHandler(upstreamManager IUpstreamManager) *UpstreamHandler {
+	return &UpstreamHandler{
+		UpstreamManager: upstreamManager,
+	}
+}
+
+// ReceiveWebhook godoc
+// @Summary      Receive a push webhook from the upstream repository of a project
+// @Description  Receive a push webhook sent by GitHub, GitLab or Gitea. The signature of the webhook is validated using the secret stored in the git-webhook-{projectName} secret.
+// @Description  Afterwards, the affected branch is fast-forwarded and an sh.keptn.event.upstream.updated event containing the new commit ID is sent.
+// @Tags         Project
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string                          true  "The name of the project"
+// @Success      200          {object}  models.UpstreamWebhookResponse  "ok"
+// @Failure      400          {object}  models.Error                    "Invalid payload"
+// @Failure      401          {object}  models.Error                    "Invalid signature"
+// @Failure      404          {object}  models.Error                    "Not found"
+// @Failure      500          {object}  models.Error                    "Internal error"
+// @Router       /project/{projectName}/webhook [post]
+func (uh *UpstreamHandler) ReceiveWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookPayloadSize))
+	if err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params := models.UpstreamWebhookParams{
+		Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		Header:  c.Request.Header,
+		Payload: payload,
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := uh.UpstreamManager.HandleWebhook(params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
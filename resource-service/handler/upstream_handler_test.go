@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	errors2 "github.com/keptn/keptn/resource-service/errors"
+	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+const upstreamWebhookTestPayload = `{"ref": "refs/heads/main", "before": "old-commit", "after": "new-commit"}`
+
+func TestUpstreamHandler_ReceiveWebhook(t *testing.T) {
+	type fields struct {
+		UpstreamManager *handler_mock.IUpstreamManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams bool
+		wantStatus int
+	}{
+		{
+			name: "webhook processed successfully",
+			fields: fields{
+				UpstreamManager: &handler_mock.IUpstreamManagerMock{HandleWebhookFunc: func(params models.UpstreamWebhookParams) (*models.UpstreamWebhookResponse, error) {
+					return &models.UpstreamWebhookResponse{Synced: true, Branch: "main", CommitID: "new-commit"}, nil
+				}},
+			},
+			request:    newUpstreamWebhookRequest("/project/my-project/webhook"),
+			wantParams: true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "invalid project name",
+			fields: fields{
+				UpstreamManager: &handler_mock.IUpstreamManagerMock{},
+			},
+			request:    newUpstreamWebhookRequest("/project/%20/webhook"),
+			wantParams: false,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid signature",
+			fields: fields{
+				UpstreamManager: &handler_mock.IUpstreamManagerMock{HandleWebhookFunc: func(params models.UpstreamWebhookParams) (*models.UpstreamWebhookResponse, error) {
+					return nil, errors2.ErrWebhookInvalidSignature
+				}},
+			},
+			request:    newUpstreamWebhookRequest("/project/my-project/webhook"),
+			wantParams: true,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "webhook secret not found",
+			fields: fields{
+				UpstreamManager: &handler_mock.IUpstreamManagerMock{HandleWebhookFunc: func(params models.UpstreamWebhookParams) (*models.UpstreamWebhookResponse, error) {
+					return nil, errors2.ErrWebhookSecretNotFound
+				}},
+			},
+			request:    newUpstreamWebhookRequest("/project/my-project/webhook"),
+			wantParams: true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "unsupported provider",
+			fields: fields{
+				UpstreamManager: &handler_mock.IUpstreamManagerMock{HandleWebhookFunc: func(params models.UpstreamWebhookParams) (*models.UpstreamWebhookResponse, error) {
+					return nil, errors2.ErrWebhookUnsupportedProvider
+				}},
+			},
+			request:    newUpstreamWebhookRequest("/project/my-project/webhook"),
+			wantParams: true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "project not found",
+			fields: fields{
+				UpstreamManager: &handler_mock.IUpstreamManagerMock{HandleWebhookFunc: func(params models.UpstreamWebhookParams) (*models.UpstreamWebhookResponse, error) {
+					return nil, errors2.ErrProjectNotFound
+				}},
+			},
+			request:    newUpstreamWebhookRequest("/project/my-project/webhook"),
+			wantParams: true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			fields: fields{
+				UpstreamManager: &handler_mock.IUpstreamManagerMock{HandleWebhookFunc: func(params models.UpstreamWebhookParams) (*models.UpstreamWebhookResponse, error) {
+					return nil, errors.New("oops")
+				}},
+			},
+			request:    newUpstreamWebhookRequest("/project/my-project/webhook"),
+			wantParams: true,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uh := NewUpstreamHandler(tt.fields.UpstreamManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/webhook", uh.ReceiveWebhook)
+
+			resp := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantParams {
+				require.Len(t, tt.fields.UpstreamManager.HandleWebhookCalls(), 1)
+				params := tt.fields.UpstreamManager.HandleWebhookCalls()[0].Params
+				require.Equal(t, "my-project", params.ProjectName)
+				require.Equal(t, "push", params.Header.Get("X-GitHub-Event"))
+				require.Equal(t, upstreamWebhookTestPayload, string(params.Payload))
+			} else {
+				require.Empty(t, tt.fields.UpstreamManager.HandleWebhookCalls())
+			}
+		})
+	}
+}
+
+func newUpstreamWebhookRequest(path string) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer([]byte(upstreamWebhookTestPayload)))
+	request.Header.Set("X-GitHub-Event", "push")
+	return request
+}
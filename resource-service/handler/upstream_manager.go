@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/go-utils/pkg/common/strutils"
+	"github.com/keptn/keptn/resource-service/common"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	logger "github.com/sirupsen/logrus"
+)
+
+const eventSource = "resource-service"
+
+//IUpstreamManager provides an interface for synchronizing projects with their upstream repository
+//go:generate moq -pkg handler_mock -skip-ensure -out ./fake/upstream_manager_mock.go . IUpstreamManager
+type IUpstreamManager interface {
+	HandleWebhook(params models.UpstreamWebhookParams) (*models.UpstreamWebhookResponse, error)
+}
+
+type UpstreamManager struct {
+	git              common.IGit
+	credentialReader common.CredentialReader
+	eventPublisher   common.EventPublisher
+	cache            *common.ResourceCache
+}
+
+func NewUpstreamManager(git common.IGit, credentialReader common.CredentialReader, eventPublisher common.EventPublisher, cache *common.ResourceCache) *UpstreamManager {
+	return &UpstreamManager{
+		git:              git,
+		credentialReader: credentialReader,
+		eventPublisher:   eventPublisher,
+		cache:            cache,
+	}
+}
+
+// HandleWebhook validates a push webhook sent by the upstream of a project, fast-forwards the affected branch
+// and announces the new commit ID via an sh.keptn.event.upstream.updated event
+func (m UpstreamManager) HandleWebhook(params models.UpstreamWebhookParams) (*models.UpstreamWebhookResponse, error) {
+	webhookSecret, err := m.credentialReader.GetWebhookSecret(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	pushEvent, err := common.ParseUpstreamWebhook(params.Header, params.Payload, webhookSecret)
+	if err != nil {
+		return nil, err
+	}
+	if pushEvent == nil {
+		logger.Debugf("Ignoring webhook for project %s since it does not contain a new commit", params.ProjectName)
+		return &models.UpstreamWebhookResponse{Synced: false}, nil
+	}
+
+	commitID, err := m.syncBranch(params.ProjectName, pushEvent.Branch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.eventPublisher.Publish(newUpstreamUpdatedEvent(models.UpstreamUpdatedEventData{
+		Project:          params.ProjectName,
+		Branch:           pushEvent.Branch,
+		CommitID:         commitID,
+		PreviousCommitID: pushEvent.PreviousCommitID,
+		Provider:         pushEvent.Provider,
+	})); err != nil {
+		return nil, fmt.Errorf("could not send %s event for project %s: %w", models.UpstreamUpdatedEventType, params.ProjectName, err)
+	}
+
+	return &models.UpstreamWebhookResponse{
+		Synced:   true,
+		Branch:   pushEvent.Branch,
+		CommitID: commitID,
+	}, nil
+}
+
+func (m UpstreamManager) syncBranch(projectName, branch string) (string, error) {
+	common.LockProject(projectName)
+	defer common.UnlockProject(projectName)
+
+	credentials, err := m.credentialReader.GetCredentials(projectName)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, projectName, err)
+	}
+
+	gitContext := common_models.GitContext{
+		Project:     projectName,
+		Credentials: credentials,
+	}
+
+	if !m.git.ProjectExists(gitContext) {
+		return "", kerrors.ErrProjectNotFound
+	}
+
+	// checking out the branch fetches all references from the upstream
+	if err := m.git.CheckoutBranch(gitContext, branch); err != nil {
+		return "", fmt.Errorf("could not check out branch %s of project %s: %w", branch, projectName, err)
+	}
+	if err := m.git.Pull(gitContext); err != nil {
+		return "", err
+	}
+	m.cache.InvalidateProject(projectName)
+
+	return m.git.GetCurrentRevision(gitContext)
+}
+
+func newUpstreamUpdatedEvent(data models.UpstreamUpdatedEventData) apimodels.KeptnContextExtendedCE {
+	return apimodels.KeptnContextExtendedCE{
+		Contenttype:    "application/json",
+		Data:           data,
+		Shkeptncontext: uuid.New().String(),
+		Source:         strutils.Stringp(eventSource),
+		Type:           strutils.Stringp(models.UpstreamUpdatedEventType),
+	}
+}
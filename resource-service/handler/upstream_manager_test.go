@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/resource-service/common"
+	common_mock "github.com/keptn/keptn/resource-service/common/fake"
+	"github.com/keptn/keptn/resource-service/common_models"
+	errors2 "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+type testUpstreamManagerFields struct {
+	git              *common_mock.IGitMock
+	credentialReader *common_mock.CredentialReaderMock
+	eventPublisher   *common_mock.EventPublisherMock
+	cache            *common.ResourceCache
+}
+
+func TestUpstreamManager_HandleWebhook(t *testing.T) {
+	fields := getTestUpstreamManagerFields()
+	fields.cache.SetLatestRevision("my-project", "", "my-old-revision")
+
+	um := NewUpstreamManager(fields.git, fields.credentialReader, fields.eventPublisher, fields.cache)
+
+	result, err := um.HandleWebhook(newTestUpstreamWebhookParams(upstreamWebhookTestPayload, "my-secret"))
+
+	require.Nil(t, err)
+	require.Equal(t, &models.UpstreamWebhookResponse{Synced: true, Branch: "main", CommitID: "new-commit"}, result)
+
+	require.Len(t, fields.git.CheckoutBranchCalls(), 1)
+	require.Equal(t, "main", fields.git.CheckoutBranchCalls()[0].Branch)
+	require.Len(t, fields.git.PullCalls(), 1)
+
+	// the latest revisions of the project are not fresh anymore
+	_, ok := fields.cache.GetLatestRevision("my-project", "")
+	require.False(t, ok)
+
+	require.Len(t, fields.eventPublisher.PublishCalls(), 1)
+	event := fields.eventPublisher.PublishCalls()[0].Event
+	require.Equal(t, models.UpstreamUpdatedEventType, *event.Type)
+	require.Equal(t, "resource-service", *event.Source)
+	require.NotEmpty(t, event.Shkeptncontext)
+	require.Equal(t, models.UpstreamUpdatedEventData{
+		Project:          "my-project",
+		Branch:           "main",
+		CommitID:         "new-commit",
+		PreviousCommitID: "old-commit",
+		Provider:         common.WebhookProviderGitHub,
+	}, event.Data)
+}
+
+func TestUpstreamManager_HandleWebhook_InvalidSignature(t *testing.T) {
+	fields := getTestUpstreamManagerFields()
+
+	um := NewUpstreamManager(fields.git, fields.credentialReader, fields.eventPublisher, fields.cache)
+
+	result, err := um.HandleWebhook(newTestUpstreamWebhookParams(upstreamWebhookTestPayload, "other-secret"))
+
+	require.ErrorIs(t, err, errors2.ErrWebhookInvalidSignature)
+	require.Nil(t, result)
+
+	require.Empty(t, fields.git.CheckoutBranchCalls())
+	require.Empty(t, fields.eventPublisher.PublishCalls())
+}
+
+func TestUpstreamManager_HandleWebhook_WebhookSecretNotFound(t *testing.T) {
+	fields := getTestUpstreamManagerFields()
+	fields.credentialReader.GetWebhookSecretFunc = func(project string) (string, error) {
+		return "", errors2.ErrWebhookSecretNotFound
+	}
+
+	um := NewUpstreamManager(fields.git, fields.credentialReader, fields.eventPublisher, fields.cache)
+
+	result, err := um.HandleWebhook(newTestUpstreamWebhookParams(upstreamWebhookTestPayload, "my-secret"))
+
+	require.ErrorIs(t, err, errors2.ErrWebhookSecretNotFound)
+	require.Nil(t, result)
+	require.Empty(t, fields.git.CheckoutBranchCalls())
+}
+
+func TestUpstreamManager_HandleWebhook_NoNewCommit(t *testing.T) {
+	fields := getTestUpstreamManagerFields()
+
+	um := NewUpstreamManager(fields.git, fields.credentialReader, fields.eventPublisher, fields.cache)
+
+	result, err := um.HandleWebhook(newTestUpstreamWebhookParams(`{"ref": "refs/tags/1.0.0", "before": "old-commit", "after": "new-commit"}`, "my-secret"))
+
+	require.Nil(t, err)
+	require.Equal(t, &models.UpstreamWebhookResponse{Synced: false}, result)
+	require.Empty(t, fields.git.CheckoutBranchCalls())
+	require.Empty(t, fields.eventPublisher.PublishCalls())
+}
+
+func TestUpstreamManager_HandleWebhook_ProjectNotFound(t *testing.T) {
+	fields := getTestUpstreamManagerFields()
+	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
+		return false
+	}
+
+	um := NewUpstreamManager(fields.git, fields.credentialReader, fields.eventPublisher, fields.cache)
+
+	result, err := um.HandleWebhook(newTestUpstreamWebhookParams(upstreamWebhookTestPayload, "my-secret"))
+
+	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
+	require.Nil(t, result)
+	require.Empty(t, fields.git.CheckoutBranchCalls())
+	require.Empty(t, fields.eventPublisher.PublishCalls())
+}
+
+func TestUpstreamManager_HandleWebhook_PullFails(t *testing.T) {
+	fields := getTestUpstreamManagerFields()
+	fields.git.PullFunc = func(gitContext common_models.GitContext) error {
+		return errors.New("oops")
+	}
+
+	um := NewUpstreamManager(fields.git, fields.credentialReader, fields.eventPublisher, fields.cache)
+
+	result, err := um.HandleWebhook(newTestUpstreamWebhookParams(upstreamWebhookTestPayload, "my-secret"))
+
+	require.NotNil(t, err)
+	require.Nil(t, result)
+	require.Empty(t, fields.eventPublisher.PublishCalls())
+}
+
+func TestUpstreamManager_HandleWebhook_PublishFails(t *testing.T) {
+	fields := getTestUpstreamManagerFields()
+	fields.eventPublisher.PublishFunc = func(event apimodels.KeptnContextExtendedCE) error {
+		return errors.New("oops")
+	}
+
+	um := NewUpstreamManager(fields.git, fields.credentialReader, fields.eventPublisher, fields.cache)
+
+	result, err := um.HandleWebhook(newTestUpstreamWebhookParams(upstreamWebhookTestPayload, "my-secret"))
+
+	require.NotNil(t, err)
+	require.Nil(t, result)
+	require.Len(t, fields.git.PullCalls(), 1)
+}
+
+func newTestUpstreamWebhookParams(payload, secret string) models.UpstreamWebhookParams {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+
+	header := http.Header{}
+	header.Set("X-GitHub-Event", "push")
+	header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	return models.UpstreamWebhookParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Header:  header,
+		Payload: []byte(payload),
+	}
+}
+
+func getTestUpstreamManagerFields() testUpstreamManagerFields {
+	return testUpstreamManagerFields{
+		git: &common_mock.IGitMock{
+			CheckoutBranchFunc:     func(gitContext common_models.GitContext, branch string) error { return nil },
+			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) { return "new-commit", nil },
+			ProjectExistsFunc:      func(gitContext common_models.GitContext) bool { return true },
+			PullFunc:               func(gitContext common_models.GitContext) error { return nil },
+		},
+		credentialReader: &common_mock.CredentialReaderMock{
+			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
+				return &common_models.GitCredentials{
+					User: "user",
+					HttpsAuth: &apimodels.HttpsGitAuth{
+						Token: "token",
+					},
+					RemoteURL: "remote-url",
+				}, nil
+			},
+			GetWebhookSecretFunc: func(project string) (string, error) {
+				return "my-secret", nil
+			},
+		},
+		eventPublisher: &common_mock.EventPublisherMock{
+			PublishFunc: func(event apimodels.KeptnContextExtendedCE) error { return nil },
+		},
+		cache: common.NewResourceCache(time.Minute, 1024),
+	}
+}
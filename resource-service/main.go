@@ -16,9 +16,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/keptn/go-utils/pkg/common/osutils"
+	"github.com/keptn/go-utils/pkg/sdk/connector/nats"
 	"github.com/keptn/keptn/resource-service/config"
 	"github.com/keptn/keptn/resource-service/controller"
 	"github.com/keptn/keptn/resource-service/handler"
+	"github.com/keptn/keptn/resource-service/models"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -87,41 +89,52 @@ func main() {
 
 	git := common.NewGit(&common.GogitReal{})
 	configurationContext := createConfigurationContext(git, fileSystem)
+	resourceCache := common.NewResourceCache(config.Global.ResourceCacheStalenessWindow, config.Global.ResourceCacheMaxSize)
+	resourceValidator := common.NewResourceValidatorRegistry()
 
-	projectManager := handler.NewProjectManager(git, credentialReader, fileSystem)
-	projectHandler := handler.NewProjectHandler(projectManager)
+	projectManager := handler.NewProjectManager(git, credentialReader, fileSystem, resourceCache)
+	stageManager := createStageManager(configurationContext, git, fileSystem, credentialReader, resourceCache)
+	serviceManager := handler.NewServiceManager(git, credentialReader, fileSystem, configurationContext, resourceCache)
+	resourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext, resourceCache, resourceValidator)
+	storageBackend := createStorageBackend(handler.NewGitStorageBackend(projectManager, stageManager, serviceManager, resourceManager, fileSystem), resourceValidator)
+
+	projectHandler := handler.NewProjectHandler(storageBackend)
 	projectController := controller.NewProjectController(projectHandler)
 	projectController.Inject(apiV1)
 
-	stageManager := createStageManager(configurationContext, git, fileSystem, credentialReader)
-	stageHandler := handler.NewStageHandler(stageManager)
+	stageHandler := handler.NewStageHandler(storageBackend)
 	stageController := controller.NewStageController(stageHandler)
 	stageController.Inject(apiV1)
 
-	serviceManager := handler.NewServiceManager(git, credentialReader, fileSystem, configurationContext)
-	serviceHandler := handler.NewServiceHandler(serviceManager)
+	serviceHandler := handler.NewServiceHandler(storageBackend)
 	serviceController := controller.NewServiceController(serviceHandler)
 	serviceController.Inject(apiV1)
 
-	projectResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
-	projectResourceHandler := handler.NewProjectResourceHandler(projectResourceManager)
+	projectResourceHandler := handler.NewProjectResourceHandler(storageBackend)
 	projectResourceController := controller.NewProjectResourceController(projectResourceHandler)
 	projectResourceController.Inject(apiV1)
 
-	stageResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
-	stageResourceHandler := handler.NewStageResourceHandler(stageResourceManager)
+	stageResourceHandler := handler.NewStageResourceHandler(storageBackend)
 	stageResourceController := controller.NewStageResourceController(stageResourceHandler)
 	stageResourceController.Inject(apiV1)
 
-	serviceResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
-	serviceResourceHandler := handler.NewServiceResourceHandler(serviceResourceManager)
+	serviceResourceHandler := handler.NewServiceResourceHandler(storageBackend)
 	serviceResourceController := controller.NewServiceResourceController(serviceResourceHandler)
 	serviceResourceController.Inject(apiV1)
 
+	upstreamManager := handler.NewUpstreamManager(git, credentialReader, nats.NewFromEnv(), resourceCache)
+	upstreamHandler := handler.NewUpstreamHandler(upstreamManager)
+	upstreamController := controller.NewUpstreamController(upstreamHandler)
+	upstreamController.Inject(apiV1)
+
 	healthHandler := handler.NewHealthHandler()
 	healthController := controller.NewHealthController(healthHandler)
 	healthController.Inject(apiHealth)
 
+	metricsHandler := handler.NewMetricsHandler(resourceCache)
+	metricsController := controller.NewMetricsController(metricsHandler)
+	metricsController.Inject(apiHealth)
+
 	engine.Static("/swagger-ui", "./swagger-ui")
 	srv := &http.Server{
 		Addr:    ":8080",
@@ -150,16 +163,39 @@ func createConfigurationContext(git *common.Git, fileSystem *common.FileSystem)
 	return configContext
 }
 
-func createStageManager(configurationContext handler.IConfigurationContext, git common.IGit, fileSystem common.IFileSystem, credentialReader common.CredentialReader) handler.IStageManager {
+func createStageManager(configurationContext handler.IConfigurationContext, git common.IGit, fileSystem common.IFileSystem, credentialReader common.CredentialReader, resourceCache *common.ResourceCache) handler.IStageManager {
 	var stageManager handler.IStageManager
 	if config.Global.DirectoryStageStructure {
-		stageManager = handler.NewDirectoryStageManager(configurationContext, fileSystem, credentialReader, git)
+		stageManager = handler.NewDirectoryStageManager(configurationContext, fileSystem, credentialReader, git, resourceCache)
 	} else {
-		stageManager = handler.NewStageManager(git, credentialReader)
+		stageManager = handler.NewStageManager(git, credentialReader, resourceCache)
 	}
 	return stageManager
 }
 
+// createStorageBackend sets up the available storage backends. Projects are stored in git unless the object store
+// backend has been configured and selected when creating the project
+func createStorageBackend(gitBackend *handler.GitStorageBackend, resourceValidator common.IResourceValidator) *handler.StorageBackendRouter {
+	backends := map[string]handler.IStorageBackend{
+		models.StorageBackendGit: gitBackend,
+	}
+	if config.Global.ObjectStoreEndpoint != "" {
+		objectStore, err := common.NewS3ObjectStore(
+			config.Global.ObjectStoreEndpoint,
+			config.Global.ObjectStoreRegion,
+			config.Global.ObjectStoreBucket,
+			config.Global.ObjectStoreAccessKeyID,
+			config.Global.ObjectStoreSecretAccessKey,
+			config.Global.ObjectStoreUseSSL,
+		)
+		if err != nil {
+			log.Fatalf("could not connect to object store: %s", err.Error())
+		}
+		backends[models.StorageBackendObjectStore] = handler.NewObjectStorageManager(common.NewObjectStorage(objectStore), resourceValidator)
+	}
+	return handler.NewStorageBackendRouter(backends)
+}
+
 func gracefulShutdown(ctx context.Context, wg *sync.WaitGroup, srv *http.Server) {
 	quit := make(chan os.Signal, 1)
 
@@ -1,5 +1,14 @@
 package models
 
+import "errors"
+
+const (
+	// StorageBackendGit stores the resources of a project in a git repository that is synchronized with its upstream
+	StorageBackendGit = "git"
+	// StorageBackendObjectStore stores the resources of a project in an S3 compatible object store, without an upstream
+	StorageBackendObjectStore = "objectstore"
+)
+
 type Project struct {
 	// ProjectName the name of the project
 	ProjectName string `form:"projectName" json:"projectName,omitempty"`
@@ -14,9 +23,14 @@ func (p Project) Validate() error {
 // swagger:model CreateProjectParams
 type CreateProjectParams struct {
 	Project
+	// StorageBackend the backend that stores the resources of the project, either "git" (default) or "objectstore"
+	StorageBackend string `json:"storageBackend,omitempty"`
 }
 
 func (p CreateProjectParams) Validate() error {
+	if p.StorageBackend != "" && p.StorageBackend != StorageBackendGit && p.StorageBackend != StorageBackendObjectStore {
+		return errors.New("storage backend must be either 'git' or 'objectstore'")
+	}
 	return p.Project.Validate()
 }
 
@@ -84,7 +84,8 @@ func TestUpdateProjectParams_Validate(t *testing.T) {
 
 func TestCreateProjectParams_Validate(t *testing.T) {
 	type fields struct {
-		Project Project
+		Project        Project
+		StorageBackend string
 	}
 	tests := []struct {
 		name    string
@@ -109,11 +110,32 @@ func TestCreateProjectParams_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "object store backend",
+			fields: fields{
+				Project: Project{
+					ProjectName: "my-project",
+				},
+				StorageBackend: StorageBackendObjectStore,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid storage backend",
+			fields: fields{
+				Project: Project{
+					ProjectName: "my-project",
+				},
+				StorageBackend: "mongodb",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := CreateProjectParams{
-				Project: tt.fields.Project,
+				Project:        tt.fields.Project,
+				StorageBackend: tt.fields.StorageBackend,
 			}
 			if err := p.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
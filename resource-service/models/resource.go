@@ -2,8 +2,10 @@ package models
 
 import (
 	"encoding/base64"
-	"github.com/keptn/keptn/resource-service/errors"
 	"strings"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/errors"
 )
 
 type ResourceContent string
@@ -128,12 +130,16 @@ type UpdateResourceParams struct {
 	ResourceContext
 	ResourceURI string
 	UpdateResourcePayload
+	WriteResourcesQuery
 }
 
 func (p UpdateResourceParams) Validate() error {
 	if err := p.ResourceContext.Validate(); err != nil {
 		return err
 	}
+	if err := p.WriteResourcesQuery.validate(); err != nil {
+		return err
+	}
 	if err := validateResourceURI(p.ResourceURI); err != nil {
 		return err
 	}
@@ -150,12 +156,16 @@ type CreateResourcesPayload struct {
 type CreateResourcesParams struct {
 	ResourceContext
 	CreateResourcesPayload
+	WriteResourcesQuery
 }
 
 func (p CreateResourcesParams) Validate() error {
 	if err := p.ResourceContext.Validate(); err != nil {
 		return err
 	}
+	if err := p.WriteResourcesQuery.validate(); err != nil {
+		return err
+	}
 	for _, res := range p.Resources {
 		if err := res.Validate(); err != nil {
 			return err
@@ -171,12 +181,16 @@ type UpdateResourcesPayload struct {
 type UpdateResourcesParams struct {
 	ResourceContext
 	UpdateResourcesPayload
+	WriteResourcesQuery
 }
 
 func (p UpdateResourcesParams) Validate() error {
 	if err := p.ResourceContext.Validate(); err != nil {
 		return err
 	}
+	if err := p.WriteResourcesQuery.validate(); err != nil {
+		return err
+	}
 
 	for _, res := range p.Resources {
 		if err := res.Validate(); err != nil {
@@ -217,6 +231,115 @@ type WriteResourceResponse struct {
 	Metadata Version `json:"metadata"`
 }
 
+type GetResourceHistoryParams struct {
+	ResourceContext
+	ResourceURI string
+}
+
+func (p GetResourceHistoryParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	return nil
+}
+
+type GetResourceDiffQuery struct {
+	FromCommitID string `json:"from" form:"from"`
+	ToCommitID   string `json:"to,omitempty" form:"to"`
+}
+
+type GetResourceDiffParams struct {
+	ResourceContext
+	ResourceURI string
+	GetResourceDiffQuery
+}
+
+func (p GetResourceDiffParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	if p.FromCommitID == "" {
+		return errors.ErrResourceInvalidGitCommitID
+	}
+	return nil
+}
+
+type RevertResourcePayload struct {
+	GitCommitID string `json:"gitCommitID"`
+}
+
+type RevertResourceParams struct {
+	ResourceContext
+	ResourceURI string
+	RevertResourcePayload
+}
+
+func (p RevertResourceParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	if p.GitCommitID == "" {
+		return errors.ErrResourceInvalidGitCommitID
+	}
+	return nil
+}
+
+// ResourceRevision describes a commit that changed a resource
+//
+// swagger:model ResourceRevision
+type ResourceRevision struct {
+	// ID of the commit
+	CommitID string `json:"commitID"`
+
+	// Author of the commit
+	Author string `json:"author"`
+
+	// Commit message
+	Message string `json:"message"`
+
+	// Time of the commit
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// GetResourceHistoryResponse resource history
+//
+// swagger:model GetResourceHistoryResponse
+type GetResourceHistoryResponse struct {
+	// Resource URI in URL-encoded format
+	ResourceURI string `json:"resourceURI"`
+
+	// Commits that changed the resource, starting with the most recent one
+	Revisions []ResourceRevision `json:"revisions"`
+
+	Metadata Version `json:"metadata"`
+}
+
+// GetResourceDiffResponse resource diff
+//
+// swagger:model GetResourceDiffResponse
+type GetResourceDiffResponse struct {
+	// Resource URI in URL-encoded format
+	ResourceURI string `json:"resourceURI"`
+
+	// Commit ID the diff starts from
+	FromCommitID string `json:"from"`
+
+	// Commit ID the diff ends at
+	ToCommitID string `json:"to"`
+
+	// Unified diff of the resource content
+	Diff string `json:"diff"`
+}
+
 func validateResourceURI(uri string) error {
 	if strings.Contains(uri, "~") || strings.Contains(uri, "..") {
 		return errors.ErrResourceInvalidResourceURI
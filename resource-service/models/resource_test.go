@@ -279,6 +279,7 @@ func TestUpdateResourceParams_Validate(t *testing.T) {
 		Service               *Service
 		ResourceURI           string
 		UpdateResourcePayload UpdateResourcePayload
+		WriteResourcesQuery   WriteResourcesQuery
 	}
 	tests := []struct {
 		name    string
@@ -363,6 +364,30 @@ func TestUpdateResourceParams_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "validate only",
+			fields: fields{
+				Project:     Project{ProjectName: "my-project"},
+				ResourceURI: "shipyard.yaml",
+				UpdateResourcePayload: UpdateResourcePayload{
+					ResourceContent: "aGVsbG8K",
+				},
+				WriteResourcesQuery: WriteResourcesQuery{ValidationMode: ValidationModeOnly},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid validation mode",
+			fields: fields{
+				Project:     Project{ProjectName: "my-project"},
+				ResourceURI: "shipyard.yaml",
+				UpdateResourcePayload: UpdateResourcePayload{
+					ResourceContent: "aGVsbG8K",
+				},
+				WriteResourcesQuery: WriteResourcesQuery{ValidationMode: "never"},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -374,6 +399,7 @@ func TestUpdateResourceParams_Validate(t *testing.T) {
 				},
 				ResourceURI:           tt.fields.ResourceURI,
 				UpdateResourcePayload: tt.fields.UpdateResourcePayload,
+				WriteResourcesQuery:   tt.fields.WriteResourcesQuery,
 			}
 			if err := p.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keptn/keptn/resource-service/errors"
+)
+
+// ValidationModeOnly validates the resources without writing them
+const ValidationModeOnly = "only"
+
+type WriteResourcesQuery struct {
+	ValidationMode string `json:"validate,omitempty" form:"validate"`
+}
+
+func (q WriteResourcesQuery) validate() error {
+	if q.ValidationMode != "" && q.ValidationMode != ValidationModeOnly {
+		return errors.ErrResourceInvalidValidationMode
+	}
+	return nil
+}
+
+// ValidateOnly returns true if the resources should be validated without being written
+func (q WriteResourcesQuery) ValidateOnly() bool {
+	return q.ValidationMode == ValidationModeOnly
+}
+
+// ResourceValidationIssue describes a problem found in the content of a resource
+//
+// swagger:model ResourceValidationIssue
+type ResourceValidationIssue struct {
+
+	// Resource URI of the invalid resource
+	ResourceURI string `json:"resourceURI"`
+
+	// Line of the resource the issue refers to, 0 if it refers to the whole resource
+	Line int `json:"line,omitempty"`
+
+	// Description of the issue
+	Message string `json:"message"`
+}
+
+func (i ResourceValidationIssue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", i.ResourceURI, i.Line, i.Message)
+	}
+	return fmt.Sprintf("%s: %s", i.ResourceURI, i.Message)
+}
+
+// ResourceValidationError is returned if the content of at least one resource is invalid
+//
+// swagger:model ResourceValidationError
+type ResourceValidationError struct {
+
+	// Error code
+	Code int64 `json:"code,omitempty"`
+
+	// Error message
+	// Required: true
+	Message string `json:"message"`
+
+	// Issues found in the resources
+	Issues []ResourceValidationIssue `json:"issues"`
+}
+
+func NewResourceValidationError(issues []ResourceValidationIssue) *ResourceValidationError {
+	messages := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		messages = append(messages, issue.String())
+	}
+	return &ResourceValidationError{
+		Message: fmt.Sprintf("%s: %s", errors.ErrResourceInvalidContent.Error(), strings.Join(messages, "; ")),
+		Issues:  issues,
+	}
+}
+
+func (e *ResourceValidationError) Error() string {
+	return e.Message
+}
+
+func (e *ResourceValidationError) Unwrap() error {
+	return errors.ErrResourceInvalidContent
+}
@@ -0,0 +1,45 @@
+package models
+
+import "net/http"
+
+// UpstreamUpdatedEventType is the type of the event that is sent after a project has been synchronized with its upstream
+const UpstreamUpdatedEventType = "sh.keptn.event.upstream.updated"
+
+// UpstreamWebhookParams contains a push webhook sent by the upstream repository of a project
+type UpstreamWebhookParams struct {
+	Project
+	// Header contains the HTTP headers of the webhook, which are used to determine the provider and validate the signature
+	Header http.Header
+	// Payload is the raw payload of the webhook
+	Payload []byte
+}
+
+func (p UpstreamWebhookParams) Validate() error {
+	return p.Project.Validate()
+}
+
+// UpstreamWebhookResponse contains the result of processing an upstream webhook
+//
+// swagger:model UpstreamWebhookResponse
+type UpstreamWebhookResponse struct {
+	// Synced indicates whether the project has been synchronized with its upstream. Webhooks that do not announce a new commit on a branch are ignored
+	Synced bool `json:"synced"`
+	// Branch is the branch that has been synchronized
+	Branch string `json:"branch,omitempty"`
+	// CommitID is the commit ID of the branch after the synchronization
+	CommitID string `json:"commitID,omitempty"`
+}
+
+// UpstreamUpdatedEventData is the data of the event that is sent after a project has been synchronized with its upstream
+type UpstreamUpdatedEventData struct {
+	// Project is the name of the project
+	Project string `json:"project"`
+	// Branch is the branch that has been updated
+	Branch string `json:"branch"`
+	// CommitID is the commit ID of the branch after the synchronization
+	CommitID string `json:"commitID"`
+	// PreviousCommitID is the commit ID of the branch before the push, as reported by the upstream
+	PreviousCommitID string `json:"previousCommitID,omitempty"`
+	// Provider is the git provider that has sent the webhook
+	Provider string `json:"provider"`
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/keptn/go-utils/pkg/common/osutils"
 	_ "github.com/keptn/keptn/secret-service/docs"
 	"github.com/keptn/keptn/secret-service/pkg/backend"
+	"github.com/keptn/keptn/secret-service/pkg/common"
 	"github.com/keptn/keptn/secret-service/pkg/controller"
 	"github.com/keptn/keptn/secret-service/pkg/handler"
 	"github.com/keptn/keptn/secret-service/pkg/repository"
@@ -34,6 +35,7 @@ import (
 // @BasePath  /v1
 
 const envVarLogLevel = "LOG_LEVEL"
+const envVarSecretBackend = "SECRET_BACKEND"
 
 func main() {
 	log.SetLevel(log.InfoLevel)
@@ -62,14 +64,27 @@ func main() {
 	engine := gin.Default()
 	apiV1 := engine.Group("/v1")
 
-	// only kubernetes supported, so we hard code it for now
-	secretsBackend := backend.CreateBackend("kubernetes")
+	backendType := common.EnvBasedStringSupplier(envVarSecretBackend, backend.SecretBackendTypeK8s)()
+	if !isRegisteredBackend(backendType) {
+		log.Fatalf("Unknown secret backend: %s", backendType)
+	}
+	log.Infof("Using secret backend: %s", backendType)
+	secretsBackend := backend.CreateBackend(backendType)
 	secretController := controller.NewSecretController(handler.NewSecretHandler(secretsBackend))
 	secretController.Inject(apiV1)
 
 	scopeController := controller.NewScopeController(handler.NewScopeHandler(secretsBackend))
 	scopeController.Inject(apiV1)
 
+	// the values of secrets are only served to services within the cluster, the API gateway blocks this endpoint
+	// backends without kubernetes roles review the access to secrets themselves
+	accessReviewer, ok := secretsBackend.(backend.AccessReviewer)
+	if !ok {
+		accessReviewer = backend.NewK8sAccessReviewer()
+	}
+	secretValueController := controller.NewSecretValueController(handler.NewSecretValueHandler(secretsBackend, accessReviewer))
+	secretValueController.Inject(apiV1)
+
 	engine.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
 
 	engine.Static("/swagger-ui", "./swagger-ui")
@@ -102,3 +117,12 @@ func main() {
 
 	log.Println("Server exiting")
 }
+
+func isRegisteredBackend(backendType string) bool {
+	for _, registered := range backend.GetRegisteredBackends() {
+		if registered == backendType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keptn/keptn/secret-service/pkg/common"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// K8sAccessReviewer lets kubernetes decide whether a service account may read a secret. Since the secret-service
+// creates roles allowing the service accounts of a scope to get the secrets of the scope, a service account
+// may read exactly the secrets of its scope
+type K8sAccessReviewer struct {
+	KeptnNamespaceProvider common.StringSupplier
+	// ClientProvider creates a kubernetes client that is authenticated with the token of the caller
+	ClientProvider func(token string) (kubernetes.Interface, error)
+}
+
+func NewK8sAccessReviewer() *K8sAccessReviewer {
+	return &K8sAccessReviewer{
+		KeptnNamespaceProvider: common.EnvBasedStringSupplier("POD_NAMESPACE", DefaultNamespace),
+		ClientProvider:         createKubeAPIForToken,
+	}
+}
+
+// CanReadSecret reviews the access of the caller with a SelfSubjectAccessReview, which can be created by any
+// authenticated user, so the secret-service does not require additional permissions
+func (r K8sAccessReviewer) CanReadSecret(token, name string) (bool, error) {
+	kubeAPI, err := r.ClientProvider(token)
+	if err != nil {
+		return false, fmt.Errorf("could not create kubernetes client: %w", err)
+	}
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: r.KeptnNamespaceProvider(),
+				Verb:      "get",
+				Resource:  "secrets",
+				Name:      name,
+			},
+		},
+	}
+	result, err := kubeAPI.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), review, metav1.CreateOptions{})
+	if err != nil {
+		if k8serr.IsUnauthorized(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not review access to secret %s: %w", name, err)
+	}
+	return result.Status.Allowed, nil
+}
+
+func createKubeAPIForToken(token string) (kubernetes.Interface, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	// only keep the connection settings, so that the client is authenticated with the given token only
+	config = rest.AnonymousClientConfig(config)
+	config.BearerToken = token
+	return kubernetes.NewForConfig(config)
+}
@@ -0,0 +1,67 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCanReadSecret(t *testing.T) {
+	var reviewedToken string
+	reviewer := K8sAccessReviewer{
+		KeptnNamespaceProvider: FakeNamespaceProvider(),
+		ClientProvider: func(token string) (kubernetes.Interface, error) {
+			reviewedToken = token
+			kubernetes := k8sfake.NewSimpleClientset()
+			kubernetes.Fake.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+				review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				require.Equal(t, "keptn_namespace", review.Spec.ResourceAttributes.Namespace)
+				require.Equal(t, "get", review.Spec.ResourceAttributes.Verb)
+				require.Equal(t, "secrets", review.Spec.ResourceAttributes.Resource)
+				review.Status.Allowed = review.Spec.ResourceAttributes.Name == "my-secret"
+				return true, review, nil
+			})
+			return kubernetes, nil
+		},
+	}
+
+	allowed, err := reviewer.CanReadSecret("my-token", "my-secret")
+	require.Nil(t, err)
+	require.True(t, allowed)
+	require.Equal(t, "my-token", reviewedToken)
+
+	allowed, err = reviewer.CanReadSecret("my-token", "other-secret")
+	require.Nil(t, err)
+	require.False(t, allowed)
+}
+
+func TestCanReadSecret_Fails(t *testing.T) {
+	reviewer := K8sAccessReviewer{
+		KeptnNamespaceProvider: FakeNamespaceProvider(),
+		ClientProvider: func(token string) (kubernetes.Interface, error) {
+			kubernetes := k8sfake.NewSimpleClientset()
+			kubernetes.Fake.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+				if token == "invalid-token" {
+					return true, nil, k8serr.NewUnauthorized("invalid token")
+				}
+				return true, nil, errors.New("oops")
+			})
+			return kubernetes, nil
+		},
+	}
+
+	allowed, err := reviewer.CanReadSecret("invalid-token", "my-secret")
+	require.Nil(t, err)
+	require.False(t, allowed)
+
+	allowed, err = reviewer.CanReadSecret("my-token", "my-secret")
+	require.EqualError(t, err, "could not review access to secret my-secret: oops")
+	require.False(t, allowed)
+}
@@ -0,0 +1,185 @@
+package backend
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+const envVarMasterKeys = "SECRET_SERVICE_MASTER_KEYS"
+const envVarMasterKeysFile = "SECRET_SERVICE_MASTER_KEYS_FILE"
+
+const masterKeySize = 32
+
+var ErrNoMasterKey = errors.New("no master key configured")
+var ErrUnknownMasterKey = errors.New("unknown master key")
+
+type masterKey struct {
+	id  string
+	key []byte
+}
+
+// Keyring contains the master keys the data keys of the secrets are encrypted with. New data keys are encrypted with the
+// first master key, the other ones are only used to decrypt data keys that have not been rotated yet
+type Keyring struct {
+	keys []masterKey
+}
+
+// ParseKeyring parses master keys in the format <id>:<base64 encoded 256 bit key>, separated by newlines or commas.
+// The first key is the current master key
+func ParseKeyring(content string) (*Keyring, error) {
+	keyring := &Keyring{}
+	ids := map[string]bool{}
+	for _, entry := range strings.FieldsFunc(content, func(r rune) bool { return r == '\n' || r == ',' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.New("invalid master key: must be in the format <id>:<base64 encoded key>")
+		}
+		key, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid master key %s: %w", parts[0], err)
+		}
+		if len(key) != masterKeySize {
+			return nil, fmt.Errorf("invalid master key %s: must be %d bytes long", parts[0], masterKeySize)
+		}
+		if ids[parts[0]] {
+			return nil, fmt.Errorf("invalid master key %s: duplicate id", parts[0])
+		}
+		ids[parts[0]] = true
+		keyring.keys = append(keyring.keys, masterKey{id: parts[0], key: key})
+	}
+	if len(keyring.keys) == 0 {
+		return nil, ErrNoMasterKey
+	}
+	return keyring, nil
+}
+
+// NewKeyringFromEnv reads the master keys from the SECRET_SERVICE_MASTER_KEYS env var, or from the file referenced by
+// the SECRET_SERVICE_MASTER_KEYS_FILE env var
+func NewKeyringFromEnv() (*Keyring, error) {
+	if keys := os.Getenv(envVarMasterKeys); keys != "" {
+		return ParseKeyring(keys)
+	}
+	if file := os.Getenv(envVarMasterKeysFile); file != "" {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read master keys: %w", err)
+		}
+		return ParseKeyring(string(content))
+	}
+	return nil, ErrNoMasterKey
+}
+
+// CurrentKeyID returns the ID of the master key new data keys are encrypted with
+func (k *Keyring) CurrentKeyID() string {
+	return k.keys[0].id
+}
+
+func (k *Keyring) getKey(id string) ([]byte, error) {
+	for _, key := range k.keys {
+		if key.id == id {
+			return key.key, nil
+		}
+	}
+	return nil, fmt.Errorf("could not decrypt data key encrypted with master key %s: %w", id, ErrUnknownMasterKey)
+}
+
+// Seal encrypts the plaintext with a new data key, which is encrypted with the current master key. The additional data
+// is authenticated, but not encrypted
+func (k *Keyring) Seal(plaintext, additionalData []byte) (masterKeyID string, encryptedDataKey []byte, ciphertext []byte, err error) {
+	dataKey := make([]byte, masterKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", nil, nil, fmt.Errorf("could not generate data key: %w", err)
+	}
+	ciphertext, err = encrypt(dataKey, plaintext, additionalData)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	masterKeyID = k.CurrentKeyID()
+	encryptedDataKey, err = encrypt(k.keys[0].key, dataKey, []byte(masterKeyID))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return masterKeyID, encryptedDataKey, ciphertext, nil
+}
+
+// Open decrypts the data key with the given master key, and the ciphertext with the data key
+func (k *Keyring) Open(masterKeyID string, encryptedDataKey, ciphertext, additionalData []byte) ([]byte, error) {
+	dataKey, err := k.openDataKey(masterKeyID, encryptedDataKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := decrypt(dataKey, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt data: %w", err)
+	}
+	return plaintext, nil
+}
+
+// Rewrap encrypts the data key with the current master key. The encrypted data remains unchanged
+func (k *Keyring) Rewrap(masterKeyID string, encryptedDataKey []byte) (string, []byte, error) {
+	dataKey, err := k.openDataKey(masterKeyID, encryptedDataKey)
+	if err != nil {
+		return "", nil, err
+	}
+	encryptedDataKey, err = encrypt(k.keys[0].key, dataKey, []byte(k.CurrentKeyID()))
+	if err != nil {
+		return "", nil, err
+	}
+	return k.CurrentKeyID(), encryptedDataKey, nil
+}
+
+func (k *Keyring) openDataKey(masterKeyID string, encryptedDataKey []byte) ([]byte, error) {
+	key, err := k.getKey(masterKeyID)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := decrypt(key, encryptedDataKey, []byte(masterKeyID))
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt data key: %w", err)
+	}
+	return dataKey, nil
+}
+
+// encrypt encrypts the plaintext with AES-256-GCM and prepends the nonce to the ciphertext
+func encrypt(key, plaintext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("could not generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func decrypt(key, ciphertext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	return gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
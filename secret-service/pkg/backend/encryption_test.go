@@ -0,0 +1,107 @@
+package backend
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMasterKey(id, fill string) string {
+	return id + ":" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat(fill, masterKeySize)))
+}
+
+func TestParseKeyring(t *testing.T) {
+	keyring, err := ParseKeyring(testMasterKey("key-2", "b") + "\n" + testMasterKey("key-1", "a") + "\n")
+	require.Nil(t, err)
+	assert.Equal(t, "key-2", keyring.CurrentKeyID())
+	assert.Len(t, keyring.keys, 2)
+
+	keyring, err = ParseKeyring(testMasterKey("key-2", "b") + ", " + testMasterKey("key-1", "a"))
+	require.Nil(t, err)
+	assert.Len(t, keyring.keys, 2)
+
+	_, err = ParseKeyring("")
+	assert.ErrorIs(t, err, ErrNoMasterKey)
+
+	_, err = ParseKeyring("key-1")
+	assert.EqualError(t, err, "invalid master key: must be in the format <id>:<base64 encoded key>")
+
+	_, err = ParseKeyring("key-1:" + base64.StdEncoding.EncodeToString([]byte("too-short")))
+	assert.EqualError(t, err, "invalid master key key-1: must be 32 bytes long")
+
+	_, err = ParseKeyring(testMasterKey("key-1", "a") + "\n" + testMasterKey("key-1", "b"))
+	assert.EqualError(t, err, "invalid master key key-1: duplicate id")
+}
+
+func TestNewKeyringFromEnv(t *testing.T) {
+	t.Setenv(envVarMasterKeys, "")
+	t.Setenv(envVarMasterKeysFile, "")
+	_, err := NewKeyringFromEnv()
+	assert.ErrorIs(t, err, ErrNoMasterKey)
+
+	file := filepath.Join(t.TempDir(), "master-keys")
+	require.Nil(t, os.WriteFile(file, []byte(testMasterKey("file-key", "a")+"\n"), 0600))
+	t.Setenv(envVarMasterKeysFile, file)
+	keyring, err := NewKeyringFromEnv()
+	require.Nil(t, err)
+	assert.Equal(t, "file-key", keyring.CurrentKeyID())
+
+	// the env var takes precedence over the file
+	t.Setenv(envVarMasterKeys, testMasterKey("env-key", "b"))
+	keyring, err = NewKeyringFromEnv()
+	require.Nil(t, err)
+	assert.Equal(t, "env-key", keyring.CurrentKeyID())
+}
+
+func TestKeyring_SealAndOpen(t *testing.T) {
+	keyring, err := ParseKeyring(testMasterKey("key-1", "a"))
+	require.Nil(t, err)
+
+	masterKeyID, encryptedDataKey, ciphertext, err := keyring.Seal([]byte("my-value"), []byte("my-secret"))
+	require.Nil(t, err)
+	assert.Equal(t, "key-1", masterKeyID)
+	assert.NotContains(t, string(ciphertext), "my-value")
+
+	plaintext, err := keyring.Open(masterKeyID, encryptedDataKey, ciphertext, []byte("my-secret"))
+	require.Nil(t, err)
+	assert.Equal(t, "my-value", string(plaintext))
+
+	// the additional data is authenticated
+	_, err = keyring.Open(masterKeyID, encryptedDataKey, ciphertext, []byte("other-secret"))
+	assert.NotNil(t, err)
+
+	_, err = keyring.Open("unknown", encryptedDataKey, ciphertext, []byte("my-secret"))
+	assert.True(t, errors.Is(err, ErrUnknownMasterKey))
+
+	// each secret is encrypted with its own data key
+	_, otherEncryptedDataKey, _, err := keyring.Seal([]byte("my-value"), []byte("my-secret"))
+	require.Nil(t, err)
+	_, err = keyring.Open(masterKeyID, otherEncryptedDataKey, ciphertext, []byte("my-secret"))
+	assert.NotNil(t, err)
+}
+
+func TestKeyring_Rewrap(t *testing.T) {
+	oldKeyring, err := ParseKeyring(testMasterKey("key-1", "a"))
+	require.Nil(t, err)
+	masterKeyID, encryptedDataKey, ciphertext, err := oldKeyring.Seal([]byte("my-value"), nil)
+	require.Nil(t, err)
+
+	keyring, err := ParseKeyring(testMasterKey("key-2", "b") + "\n" + testMasterKey("key-1", "a"))
+	require.Nil(t, err)
+	newMasterKeyID, newEncryptedDataKey, err := keyring.Rewrap(masterKeyID, encryptedDataKey)
+	require.Nil(t, err)
+	assert.Equal(t, "key-2", newMasterKeyID)
+
+	// after the rotation, the previous master key is no longer required
+	newKeyring, err := ParseKeyring(testMasterKey("key-2", "b"))
+	require.Nil(t, err)
+	plaintext, err := newKeyring.Open(newMasterKeyID, newEncryptedDataKey, ciphertext, nil)
+	require.Nil(t, err)
+	assert.Equal(t, "my-value", string(plaintext))
+}
@@ -0,0 +1,81 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package fake
+
+import (
+	"github.com/keptn/keptn/secret-service/pkg/backend"
+	"sync"
+)
+
+// Ensure, that AccessReviewerMock does implement backend.AccessReviewer.
+// If this is not the case, regenerate this file with moq.
+var _ backend.AccessReviewer = &AccessReviewerMock{}
+
+// AccessReviewerMock is a mock implementation of backend.AccessReviewer.
+//
+//	func TestSomethingThatUsesAccessReviewer(t *testing.T) {
+//
+//		// make and configure a mocked backend.AccessReviewer
+//		mockedAccessReviewer := &AccessReviewerMock{
+//			CanReadSecretFunc: func(token string, name string) (bool, error) {
+//				panic("mock out the CanReadSecret method")
+//			},
+//		}
+//
+//		// use mockedAccessReviewer in code that requires backend.AccessReviewer
+//		// and then make assertions.
+//
+//	}
+type AccessReviewerMock struct {
+	// CanReadSecretFunc mocks the CanReadSecret method.
+	CanReadSecretFunc func(token string, name string) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CanReadSecret holds details about calls to the CanReadSecret method.
+		CanReadSecret []struct {
+			// Token is the token argument value.
+			Token string
+			// Name is the name argument value.
+			Name string
+		}
+	}
+	lockCanReadSecret sync.RWMutex
+}
+
+// CanReadSecret calls CanReadSecretFunc.
+func (mock *AccessReviewerMock) CanReadSecret(token string, name string) (bool, error) {
+	if mock.CanReadSecretFunc == nil {
+		panic("AccessReviewerMock.CanReadSecretFunc: method is nil but AccessReviewer.CanReadSecret was just called")
+	}
+	callInfo := struct {
+		Token string
+		Name  string
+	}{
+		Token: token,
+		Name:  name,
+	}
+	mock.lockCanReadSecret.Lock()
+	mock.calls.CanReadSecret = append(mock.calls.CanReadSecret, callInfo)
+	mock.lockCanReadSecret.Unlock()
+	return mock.CanReadSecretFunc(token, name)
+}
+
+// CanReadSecretCalls gets all the calls that were made to CanReadSecret.
+// Check the length with:
+//
+//	len(mockedAccessReviewer.CanReadSecretCalls())
+func (mock *AccessReviewerMock) CanReadSecretCalls() []struct {
+	Token string
+	Name  string
+} {
+	var calls []struct {
+		Token string
+		Name  string
+	}
+	mock.lockCanReadSecret.RLock()
+	calls = mock.calls.CanReadSecret
+	mock.lockCanReadSecret.RUnlock()
+	return calls
+}
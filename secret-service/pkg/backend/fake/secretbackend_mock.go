@@ -15,31 +15,34 @@ var _ backend.SecretBackend = &SecretBackendMock{}
 
 // SecretBackendMock is a mock implementation of backend.SecretBackend.
 //
-// 	func TestSomethingThatUsesSecretBackend(t *testing.T) {
+//	func TestSomethingThatUsesSecretBackend(t *testing.T) {
 //
-// 		// make and configure a mocked backend.SecretBackend
-// 		mockedSecretBackend := &SecretBackendMock{
-// 			CreateSecretFunc: func(secret model.Secret) error {
-// 				panic("mock out the CreateSecret method")
-// 			},
-// 			DeleteSecretFunc: func(secret model.Secret) error {
-// 				panic("mock out the DeleteSecret method")
-// 			},
-// 			GetScopesFunc: func() ([]string, error) {
-// 				panic("mock out the GetScopes method")
-// 			},
-// 			GetSecretsFunc: func() ([]model.GetSecretResponseItem, error) {
-// 				panic("mock out the GetSecrets method")
-// 			},
-// 			UpdateSecretFunc: func(secret model.Secret) error {
-// 				panic("mock out the UpdateSecret method")
-// 			},
-// 		}
+//		// make and configure a mocked backend.SecretBackend
+//		mockedSecretBackend := &SecretBackendMock{
+//			CreateSecretFunc: func(secret model.Secret) error {
+//				panic("mock out the CreateSecret method")
+//			},
+//			DeleteSecretFunc: func(secret model.Secret) error {
+//				panic("mock out the DeleteSecret method")
+//			},
+//			GetScopesFunc: func() ([]string, error) {
+//				panic("mock out the GetScopes method")
+//			},
+//			GetSecretValueFunc: func(name string, key string) (string, error) {
+//				panic("mock out the GetSecretValue method")
+//			},
+//			GetSecretsFunc: func() ([]model.GetSecretResponseItem, error) {
+//				panic("mock out the GetSecrets method")
+//			},
+//			UpdateSecretFunc: func(secret model.Secret) error {
+//				panic("mock out the UpdateSecret method")
+//			},
+//		}
 //
-// 		// use mockedSecretBackend in code that requires backend.SecretBackend
-// 		// and then make assertions.
+//		// use mockedSecretBackend in code that requires backend.SecretBackend
+//		// and then make assertions.
 //
-// 	}
+//	}
 type SecretBackendMock struct {
 	// CreateSecretFunc mocks the CreateSecret method.
 	CreateSecretFunc func(secret model.Secret) error
@@ -50,6 +53,9 @@ type SecretBackendMock struct {
 	// GetScopesFunc mocks the GetScopes method.
 	GetScopesFunc func() ([]string, error)
 
+	// GetSecretValueFunc mocks the GetSecretValue method.
+	GetSecretValueFunc func(name string, key string) (string, error)
+
 	// GetSecretsFunc mocks the GetSecrets method.
 	GetSecretsFunc func() ([]model.GetSecretResponseItem, error)
 
@@ -71,6 +77,13 @@ type SecretBackendMock struct {
 		// GetScopes holds details about calls to the GetScopes method.
 		GetScopes []struct {
 		}
+		// GetSecretValue holds details about calls to the GetSecretValue method.
+		GetSecretValue []struct {
+			// Name is the name argument value.
+			Name string
+			// Key is the key argument value.
+			Key string
+		}
 		// GetSecrets holds details about calls to the GetSecrets method.
 		GetSecrets []struct {
 		}
@@ -80,11 +93,12 @@ type SecretBackendMock struct {
 			Secret model.Secret
 		}
 	}
-	lockCreateSecret sync.RWMutex
-	lockDeleteSecret sync.RWMutex
-	lockGetScopes    sync.RWMutex
-	lockGetSecrets   sync.RWMutex
-	lockUpdateSecret sync.RWMutex
+	lockCreateSecret   sync.RWMutex
+	lockDeleteSecret   sync.RWMutex
+	lockGetScopes      sync.RWMutex
+	lockGetSecretValue sync.RWMutex
+	lockGetSecrets     sync.RWMutex
+	lockUpdateSecret   sync.RWMutex
 }
 
 // CreateSecret calls CreateSecretFunc.
@@ -105,7 +119,8 @@ func (mock *SecretBackendMock) CreateSecret(secret model.Secret) error {
 
 // CreateSecretCalls gets all the calls that were made to CreateSecret.
 // Check the length with:
-//     len(mockedSecretBackend.CreateSecretCalls())
+//
+//	len(mockedSecretBackend.CreateSecretCalls())
 func (mock *SecretBackendMock) CreateSecretCalls() []struct {
 	Secret model.Secret
 } {
@@ -136,7 +151,8 @@ func (mock *SecretBackendMock) DeleteSecret(secret model.Secret) error {
 
 // DeleteSecretCalls gets all the calls that were made to DeleteSecret.
 // Check the length with:
-//     len(mockedSecretBackend.DeleteSecretCalls())
+//
+//	len(mockedSecretBackend.DeleteSecretCalls())
 func (mock *SecretBackendMock) DeleteSecretCalls() []struct {
 	Secret model.Secret
 } {
@@ -164,7 +180,8 @@ func (mock *SecretBackendMock) GetScopes() ([]string, error) {
 
 // GetScopesCalls gets all the calls that were made to GetScopes.
 // Check the length with:
-//     len(mockedSecretBackend.GetScopesCalls())
+//
+//	len(mockedSecretBackend.GetScopesCalls())
 func (mock *SecretBackendMock) GetScopesCalls() []struct {
 } {
 	var calls []struct {
@@ -175,6 +192,42 @@ func (mock *SecretBackendMock) GetScopesCalls() []struct {
 	return calls
 }
 
+// GetSecretValue calls GetSecretValueFunc.
+func (mock *SecretBackendMock) GetSecretValue(name string, key string) (string, error) {
+	if mock.GetSecretValueFunc == nil {
+		panic("SecretBackendMock.GetSecretValueFunc: method is nil but SecretBackend.GetSecretValue was just called")
+	}
+	callInfo := struct {
+		Name string
+		Key  string
+	}{
+		Name: name,
+		Key:  key,
+	}
+	mock.lockGetSecretValue.Lock()
+	mock.calls.GetSecretValue = append(mock.calls.GetSecretValue, callInfo)
+	mock.lockGetSecretValue.Unlock()
+	return mock.GetSecretValueFunc(name, key)
+}
+
+// GetSecretValueCalls gets all the calls that were made to GetSecretValue.
+// Check the length with:
+//
+//	len(mockedSecretBackend.GetSecretValueCalls())
+func (mock *SecretBackendMock) GetSecretValueCalls() []struct {
+	Name string
+	Key  string
+} {
+	var calls []struct {
+		Name string
+		Key  string
+	}
+	mock.lockGetSecretValue.RLock()
+	calls = mock.calls.GetSecretValue
+	mock.lockGetSecretValue.RUnlock()
+	return calls
+}
+
 // GetSecrets calls GetSecretsFunc.
 func (mock *SecretBackendMock) GetSecrets() ([]model.GetSecretResponseItem, error) {
 	if mock.GetSecretsFunc == nil {
@@ -190,7 +243,8 @@ func (mock *SecretBackendMock) GetSecrets() ([]model.GetSecretResponseItem, erro
 
 // GetSecretsCalls gets all the calls that were made to GetSecrets.
 // Check the length with:
-//     len(mockedSecretBackend.GetSecretsCalls())
+//
+//	len(mockedSecretBackend.GetSecretsCalls())
 func (mock *SecretBackendMock) GetSecretsCalls() []struct {
 } {
 	var calls []struct {
@@ -219,7 +273,8 @@ func (mock *SecretBackendMock) UpdateSecret(secret model.Secret) error {
 
 // UpdateSecretCalls gets all the calls that were made to UpdateSecret.
 // Check the length with:
-//     len(mockedSecretBackend.UpdateSecretCalls())
+//
+//	len(mockedSecretBackend.UpdateSecretCalls())
 func (mock *SecretBackendMock) UpdateSecretCalls() []struct {
 	Secret model.Secret
 } {
@@ -19,5 +19,6 @@ func Test_Register(t *testing.T) {
 
 	backends := backend.GetRegisteredBackends()
 	assert.Contains(t, backends, backend.SecretBackendTypeK8s)
+	assert.Contains(t, backends, backend.SecretBackendTypeMongoDB)
 
 }
@@ -17,8 +17,20 @@ type ScopeManager interface {
 	GetScopes() ([]string, error)
 }
 
+// SecretReader reads the value of a key of a secret managed by the secret-service
+type SecretReader interface {
+	GetSecretValue(name, key string) (string, error)
+}
+
 //go:generate moq -pkg fake -out ./fake/secretbackend_mock.go . SecretBackend
 type SecretBackend interface {
 	SecretManager
 	ScopeManager
+	SecretReader
+}
+
+// AccessReviewer checks whether the caller authenticated by the given token may read a secret
+//go:generate moq -pkg fake -out ./fake/accessreviewer_mock.go . AccessReviewer
+type AccessReviewer interface {
+	CanReadSecret(token, name string) (bool, error)
 }
@@ -26,6 +26,7 @@ var ErrSecretAlreadyExists = errors.New("secret already exists")
 var ErrSecretNotFound = errors.New("secret not found")
 var ErrTooBigKeySize = errors.New("name and key values must be no more than 253 characters")
 var ErrScopeNotFound = errors.New("scope not found")
+var ErrSecretKeyNotFound = errors.New("key not found in secret")
 
 type K8sSecretBackend struct {
 	KubeAPI                kubernetes.Interface
@@ -209,6 +210,27 @@ func (k K8sSecretBackend) GetSecrets() ([]model.GetSecretResponseItem, error) {
 	return result, nil
 }
 
+func (k K8sSecretBackend) GetSecretValue(name, key string) (string, error) {
+	secret, err := k.KubeAPI.CoreV1().Secrets(k.KeptnNamespaceProvider()).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if statusError, isStatus := err.(*k8serr.StatusError); isStatus && statusError.Status().Reason == metav1.StatusReasonNotFound {
+			return "", ErrSecretNotFound
+		}
+		return "", fmt.Errorf("could not retrieve secret %s: %s", name, err.Error())
+	}
+	// secrets that are not managed by the secret-service are treated as if they did not exist
+	if secret.Labels["app.kubernetes.io/managed-by"] != SecretServiceName {
+		return "", ErrSecretNotFound
+	}
+	if value, ok := secret.Data[key]; ok {
+		return string(value), nil
+	}
+	if value, ok := secret.StringData[key]; ok {
+		return value, nil
+	}
+	return "", ErrSecretKeyNotFound
+}
+
 func (k K8sSecretBackend) UpdateSecret(secret model.Secret) error {
 	log.Infof("Updating secret: %s with scope %s", secret.Name, secret.Scope)
 
@@ -316,7 +338,12 @@ func (k K8sSecretBackend) createK8sSecretObj(secret model.Secret, namespace stri
 }
 
 func (k K8sSecretBackend) GetScopes() ([]string, error) {
-	scopes, err := k.ScopesRepository.Read()
+	return getScopeNames(k.ScopesRepository)
+}
+
+// getScopeNames returns the sorted names of the scopes of the scopes configuration
+func getScopeNames(scopesRepository repository.ScopesRepository) ([]string, error) {
+	scopes, err := scopesRepository.Read()
 	if err != nil {
 		return nil, err
 	}
@@ -240,6 +240,38 @@ func TestGetSecret_Fails(t *testing.T) {
 	require.Nil(t, secrets)
 }
 
+func TestGetSecretValue(t *testing.T) {
+	kubernetes := k8sfake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "unmanaged-secret",
+			Namespace: FakeNamespaceProvider()(),
+		},
+		Data: map[string][]byte{"password": []byte("keptn")},
+	})
+	scopesRepository := &fake.ScopesRepositoryMock{}
+	scopesRepository.ReadFunc = func() (model.Scopes, error) { return createTestScopes(), nil }
+
+	backend := K8sSecretBackend{
+		KubeAPI:                kubernetes,
+		KeptnNamespaceProvider: FakeNamespaceProvider(),
+		ScopesRepository:       scopesRepository,
+	}
+	require.Nil(t, backend.CreateSecret(createTestSecret("my-secret", "my-scope")))
+
+	value, err := backend.GetSecretValue("my-secret", "password")
+	require.Nil(t, err)
+	require.Equal(t, "keptn", value)
+
+	_, err = backend.GetSecretValue("my-secret", "username")
+	require.ErrorIs(t, err, ErrSecretKeyNotFound)
+
+	_, err = backend.GetSecretValue("my-missing-secret", "password")
+	require.ErrorIs(t, err, ErrSecretNotFound)
+
+	_, err = backend.GetSecretValue("unmanaged-secret", "password")
+	require.ErrorIs(t, err, ErrSecretNotFound)
+}
+
 /**
 DELETE SECRET TESTS
 */
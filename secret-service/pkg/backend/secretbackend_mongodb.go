@@ -0,0 +1,278 @@
+package backend
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	"github.com/keptn/keptn/secret-service/pkg/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+const SecretBackendTypeMongoDB = "mongodb"
+
+// maxNameSize is the maximum length of the names and keys of secrets, as for kubernetes secrets
+const maxNameSize = 253
+
+// MongoDBSecretBackend stores secrets envelope-encrypted in MongoDB, i.e. the data of each secret is encrypted with its
+// own data key, which is encrypted with a master key. Since there are no kubernetes roles, the access to secrets is
+// reviewed by the backend itself, based on the token hashes of the scopes
+type MongoDBSecretBackend struct {
+	SecretsRepository repository.SecretsRepository
+	ScopesRepository  repository.ScopesRepository
+	Keyring           *Keyring
+}
+
+func NewMongoDBSecretBackend(secretsRepository repository.SecretsRepository, scopesRepository repository.ScopesRepository, keyring *Keyring) *MongoDBSecretBackend {
+	return &MongoDBSecretBackend{
+		SecretsRepository: secretsRepository,
+		ScopesRepository:  scopesRepository,
+		Keyring:           keyring,
+	}
+}
+
+func (m MongoDBSecretBackend) CreateSecret(secret model.Secret) error {
+	log.Infof("Creating secret: %s with scope %s", secret.Name, secret.Scope)
+	encryptedSecret, err := m.encryptSecret(secret)
+	if err != nil {
+		return err
+	}
+	if err := m.SecretsRepository.Create(*encryptedSecret); err != nil {
+		log.Errorf("Unable to create secret %s with scope %s: %s", secret.Name, secret.Scope, err)
+		if errors.Is(err, repository.ErrSecretAlreadyExists) {
+			return ErrSecretAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (m MongoDBSecretBackend) UpdateSecret(secret model.Secret) error {
+	log.Infof("Updating secret: %s with scope %s", secret.Name, secret.Scope)
+	encryptedSecret, err := m.encryptSecret(secret)
+	if err != nil {
+		return err
+	}
+	if err := m.SecretsRepository.Update(*encryptedSecret); err != nil {
+		log.Errorf("Unable to update secret %s: %s", secret.Name, err)
+		if errors.Is(err, repository.ErrSecretNotFound) {
+			return ErrSecretNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (m MongoDBSecretBackend) DeleteSecret(secret model.Secret) error {
+	log.Infof("Deleting secret: %s with scope %s", secret.Name, secret.Scope)
+	if _, err := m.checkScopeDefined(secret); err != nil {
+		return err
+	}
+	if err := m.SecretsRepository.Delete(secret.Name); err != nil {
+		log.Errorf("Unable to delete secret %s with scope %s: %s", secret.Name, secret.Scope, err)
+		if errors.Is(err, repository.ErrSecretNotFound) {
+			return fmt.Errorf("could not delete secret %s in scope %s: %w", secret.Name, secret.Scope, ErrSecretNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+func (m MongoDBSecretBackend) GetSecrets() ([]model.GetSecretResponseItem, error) {
+	secrets, err := m.SecretsRepository.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve secrets: %s", err.Error())
+	}
+	result := []model.GetSecretResponseItem{}
+	for _, secret := range secrets {
+		result = append(result, model.GetSecretResponseItem{
+			SecretMetadata: model.SecretMetadata{
+				Name:  secret.Name,
+				Scope: secret.Scope,
+			},
+			Keys: secret.Keys,
+		})
+	}
+	return result, nil
+}
+
+func (m MongoDBSecretBackend) GetSecretValue(name, key string) (string, error) {
+	secret, err := m.SecretsRepository.Get(name)
+	if err != nil {
+		if errors.Is(err, repository.ErrSecretNotFound) {
+			return "", ErrSecretNotFound
+		}
+		return "", fmt.Errorf("could not retrieve secret %s: %s", name, err.Error())
+	}
+	data, err := m.decryptSecret(secret)
+	if err != nil {
+		return "", fmt.Errorf("could not retrieve secret %s: %w", name, err)
+	}
+	if value, ok := data[key]; ok {
+		return value, nil
+	}
+	return "", ErrSecretKeyNotFound
+}
+
+func (m MongoDBSecretBackend) GetScopes() ([]string, error) {
+	return getScopeNames(m.ScopesRepository)
+}
+
+// CanReadSecret allows callers to read the secrets of the scope whose token hashes contain the hash of their token, if
+// a capability of the scope grants the permission get
+func (m MongoDBSecretBackend) CanReadSecret(token, name string) (bool, error) {
+	secret, err := m.SecretsRepository.Get(name)
+	if err != nil {
+		if errors.Is(err, repository.ErrSecretNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not review access to secret %s: %w", name, err)
+	}
+	scopes, err := m.ScopesRepository.Read()
+	if err != nil {
+		return false, fmt.Errorf("could not review access to secret %s: %w", name, err)
+	}
+	scope, ok := scopes.Scopes[secret.Scope]
+	if !ok || !hasTokenHash(scope, token) {
+		return false, nil
+	}
+	for _, capability := range scope.Capabilities {
+		for _, permission := range capability.Permissions {
+			if permission == "get" {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// RotateMasterKey encrypts the data keys of all secrets that are encrypted with a previous master key with the current
+// master key, and returns the number of rotated secrets. The data of the secrets does not need to be re-encrypted
+func (m MongoDBSecretBackend) RotateMasterKey() (int, error) {
+	secrets, err := m.SecretsRepository.GetAll()
+	if err != nil {
+		return 0, fmt.Errorf("could not rotate master key: %w", err)
+	}
+	rotated := 0
+	for i := range secrets {
+		if secrets[i].MasterKeyID == m.Keyring.CurrentKeyID() {
+			continue
+		}
+		masterKeyID, encryptedDataKey, err := m.Keyring.Rewrap(secrets[i].MasterKeyID, secrets[i].EncryptedDataKey)
+		if err != nil {
+			return rotated, fmt.Errorf("could not rotate master key of secret %s: %w", secrets[i].Name, err)
+		}
+		updated, err := m.SecretsRepository.UpdateDataKey(secrets[i].Name, secrets[i].DataKey, model.DataKey{MasterKeyID: masterKeyID, EncryptedDataKey: encryptedDataKey})
+		if err != nil {
+			return rotated, fmt.Errorf("could not rotate master key of secret %s: %w", secrets[i].Name, err)
+		}
+		if !updated {
+			// the secret has been updated or deleted in the meantime, i.e. it is encrypted with the current master key
+			continue
+		}
+		rotated++
+	}
+	return rotated, nil
+}
+
+func (m MongoDBSecretBackend) checkScopeDefined(secret model.Secret) (model.Scopes, error) {
+	scopes, err := m.ScopesRepository.Read()
+	if err != nil {
+		return model.Scopes{}, err
+	}
+	if _, ok := scopes.Scopes[secret.Scope]; !ok {
+		log.Errorf("Unable to find scope %s for secret %s", secret.Scope, secret.Name)
+		return model.Scopes{}, fmt.Errorf("unable to check defined scope %s for secret %s: %w", secret.Scope, secret.Name, ErrScopeNotFound)
+	}
+	return scopes, nil
+}
+
+func (m MongoDBSecretBackend) encryptSecret(secret model.Secret) (*model.EncryptedSecret, error) {
+	if _, err := m.checkScopeDefined(secret); err != nil {
+		return nil, err
+	}
+	if len(secret.Name) > maxNameSize {
+		return nil, ErrTooBigKeySize
+	}
+	keys := []string{}
+	for key := range secret.Data {
+		if len(key) > maxNameSize {
+			return nil, ErrTooBigKeySize
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	data, err := json.Marshal(secret.Data)
+	if err != nil {
+		return nil, err
+	}
+	masterKeyID, encryptedDataKey, encryptedData, err := m.Keyring.Seal(data, secretAdditionalData(secret.Name, secret.Scope))
+	if err != nil {
+		return nil, fmt.Errorf("could not encrypt secret %s: %w", secret.Name, err)
+	}
+	return &model.EncryptedSecret{
+		Name:  secret.Name,
+		Scope: secret.Scope,
+		Keys:  keys,
+		DataKey: model.DataKey{
+			MasterKeyID:      masterKeyID,
+			EncryptedDataKey: encryptedDataKey,
+		},
+		EncryptedData: encryptedData,
+	}, nil
+}
+
+func (m MongoDBSecretBackend) decryptSecret(secret *model.EncryptedSecret) (model.Data, error) {
+	plaintext, err := m.Keyring.Open(secret.MasterKeyID, secret.EncryptedDataKey, secret.EncryptedData, secretAdditionalData(secret.Name, secret.Scope))
+	if err != nil {
+		return nil, err
+	}
+	data := model.Data{}
+	if err := json.Unmarshal(plaintext, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// secretAdditionalData binds the encrypted data to the name and the scope of the secret, so that the data cannot be
+// moved to a secret of another scope within the database
+func secretAdditionalData(name, scope string) []byte {
+	return []byte(name + "\x00" + scope)
+}
+
+func hasTokenHash(scope model.Scope, token string) bool {
+	hash := sha256.Sum256([]byte(token))
+	encodedHash := []byte(hex.EncodeToString(hash[:]))
+	for _, tokenHash := range scope.TokenHashes {
+		if subtle.ConstantTimeCompare([]byte(strings.ToLower(tokenHash)), encodedHash) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	log.Info("Registering Secret Backend type: mongodb")
+	Register(SecretBackendTypeMongoDB, func() SecretBackend {
+		keyring, err := NewKeyringFromEnv()
+		if err != nil {
+			log.Fatalf("Unable to load master keys: %s", err)
+		}
+		mongoDBBackend := NewMongoDBSecretBackend(repository.NewMongoDBSecretsRepository(), repository.NewFileBasedScopesRepository(), keyring)
+		// data keys that are still encrypted with a previous master key are rotated with each start of the service
+		rotated, err := mongoDBBackend.RotateMasterKey()
+		if err != nil {
+			log.Errorf("Unable to rotate master key: %s", err)
+		} else if rotated > 0 {
+			log.Infof("Rotated master key of %d secrets", rotated)
+		}
+		return mongoDBBackend
+	})
+}
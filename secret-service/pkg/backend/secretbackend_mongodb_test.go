@@ -0,0 +1,265 @@
+package backend
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	"github.com/keptn/keptn/secret-service/pkg/repository"
+	"github.com/keptn/keptn/secret-service/pkg/repository/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeSecretsRepository returns a secrets repository keeping the secrets in memory
+func newFakeSecretsRepository() (*fake.SecretsRepositoryMock, map[string]model.EncryptedSecret) {
+	secrets := map[string]model.EncryptedSecret{}
+	return &fake.SecretsRepositoryMock{
+		CreateFunc: func(secret model.EncryptedSecret) error {
+			if _, ok := secrets[secret.Name]; ok {
+				return repository.ErrSecretAlreadyExists
+			}
+			secrets[secret.Name] = secret
+			return nil
+		},
+		UpdateFunc: func(secret model.EncryptedSecret) error {
+			if _, ok := secrets[secret.Name]; !ok {
+				return repository.ErrSecretNotFound
+			}
+			secrets[secret.Name] = secret
+			return nil
+		},
+		DeleteFunc: func(name string) error {
+			if _, ok := secrets[name]; !ok {
+				return repository.ErrSecretNotFound
+			}
+			delete(secrets, name)
+			return nil
+		},
+		GetFunc: func(name string) (*model.EncryptedSecret, error) {
+			secret, ok := secrets[name]
+			if !ok {
+				return nil, repository.ErrSecretNotFound
+			}
+			return &secret, nil
+		},
+		UpdateDataKeyFunc: func(name string, previous model.DataKey, updated model.DataKey) (bool, error) {
+			secret, ok := secrets[name]
+			if !ok || secret.MasterKeyID != previous.MasterKeyID || !bytes.Equal(secret.EncryptedDataKey, previous.EncryptedDataKey) {
+				return false, nil
+			}
+			secret.DataKey = updated
+			secrets[name] = secret
+			return true, nil
+		},
+		GetAllFunc: func() ([]model.EncryptedSecret, error) {
+			result := []model.EncryptedSecret{}
+			for _, secret := range secrets {
+				result = append(result, secret)
+			}
+			return result, nil
+		},
+	}, secrets
+}
+
+func tokenHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func newTestMongoDBSecretBackend(t *testing.T) (*MongoDBSecretBackend, map[string]model.EncryptedSecret) {
+	keyring, err := ParseKeyring(testMasterKey("key-1", "a"))
+	require.Nil(t, err)
+	scopes := createTestScopes()
+	scopes.Scopes["my-scope"] = model.Scope{
+		Capabilities: map[string]model.Capability{
+			"my-scope-read-secrets": {Permissions: []string{"get"}},
+		},
+		TokenHashes: []string{tokenHash("my-scope-token")},
+	}
+	scopes.Scopes["keptn-default"] = model.Scope{
+		Capabilities: scopes.Scopes["keptn-default"].Capabilities,
+		TokenHashes:  []string{strings.ToUpper(tokenHash("keptn-default-token"))},
+	}
+	scopesRepository := &fake.ScopesRepositoryMock{}
+	scopesRepository.ReadFunc = func() (model.Scopes, error) { return scopes, nil }
+	secretsRepository, secrets := newFakeSecretsRepository()
+	return NewMongoDBSecretBackend(secretsRepository, scopesRepository, keyring), secrets
+}
+
+func TestMongoDBSecretBackend_CreateSecret(t *testing.T) {
+	backend, secrets := newTestMongoDBSecretBackend(t)
+
+	secret := createTestSecret("my-secret", "my-scope")
+	secret.Data["user"] = "keptn-user"
+	err := backend.CreateSecret(secret)
+	require.Nil(t, err)
+
+	require.Contains(t, secrets, "my-secret")
+	assert.Equal(t, "my-scope", secrets["my-secret"].Scope)
+	assert.Equal(t, []string{"password", "user"}, secrets["my-secret"].Keys)
+	assert.Equal(t, "key-1", secrets["my-secret"].MasterKeyID)
+	// the data is stored encrypted only
+	assert.NotContains(t, string(secrets["my-secret"].EncryptedData), "keptn-user")
+
+	value, err := backend.GetSecretValue("my-secret", "user")
+	require.Nil(t, err)
+	assert.Equal(t, "keptn-user", value)
+
+	err = backend.CreateSecret(secret)
+	assert.ErrorIs(t, err, ErrSecretAlreadyExists)
+
+	err = backend.CreateSecret(createTestSecret("other-secret", "unknown-scope"))
+	assert.ErrorIs(t, err, ErrScopeNotFound)
+
+	err = backend.CreateSecret(createTestSecret(strings.Repeat("a", 254), "my-scope"))
+	assert.ErrorIs(t, err, ErrTooBigKeySize)
+}
+
+func TestMongoDBSecretBackend_UpdateSecret(t *testing.T) {
+	backend, _ := newTestMongoDBSecretBackend(t)
+
+	err := backend.UpdateSecret(createTestSecret("my-secret", "my-scope"))
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+
+	require.Nil(t, backend.CreateSecret(createTestSecret("my-secret", "my-scope")))
+	secret := createTestSecret("my-secret", "my-scope")
+	secret.Data["password"] = "new-password"
+	require.Nil(t, backend.UpdateSecret(secret))
+
+	value, err := backend.GetSecretValue("my-secret", "password")
+	require.Nil(t, err)
+	assert.Equal(t, "new-password", value)
+}
+
+func TestMongoDBSecretBackend_DeleteSecret(t *testing.T) {
+	backend, secrets := newTestMongoDBSecretBackend(t)
+	require.Nil(t, backend.CreateSecret(createTestSecret("my-secret", "my-scope")))
+
+	require.Nil(t, backend.DeleteSecret(createTestSecret("my-secret", "my-scope")))
+	assert.NotContains(t, secrets, "my-secret")
+
+	err := backend.DeleteSecret(createTestSecret("my-secret", "my-scope"))
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+}
+
+func TestMongoDBSecretBackend_GetSecrets(t *testing.T) {
+	backend, _ := newTestMongoDBSecretBackend(t)
+	require.Nil(t, backend.CreateSecret(createTestSecret("my-secret", "my-scope")))
+
+	secrets, err := backend.GetSecrets()
+	require.Nil(t, err)
+	assert.Equal(t, []model.GetSecretResponseItem{
+		{SecretMetadata: model.SecretMetadata{Name: "my-secret", Scope: "my-scope"}, Keys: []string{"password"}},
+	}, secrets)
+}
+
+func TestMongoDBSecretBackend_GetSecretValue(t *testing.T) {
+	backend, secrets := newTestMongoDBSecretBackend(t)
+	require.Nil(t, backend.CreateSecret(createTestSecret("my-secret", "my-scope")))
+
+	_, err := backend.GetSecretValue("my-secret", "unknown")
+	assert.ErrorIs(t, err, ErrSecretKeyNotFound)
+
+	_, err = backend.GetSecretValue("unknown", "password")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+
+	// the encrypted data is bound to the scope of the secret
+	secret := secrets["my-secret"]
+	secret.Scope = "keptn-default"
+	secrets["my-secret"] = secret
+	_, err = backend.GetSecretValue("my-secret", "password")
+	assert.NotNil(t, err)
+}
+
+func TestMongoDBSecretBackend_CanReadSecret(t *testing.T) {
+	backend, _ := newTestMongoDBSecretBackend(t)
+	require.Nil(t, backend.CreateSecret(createTestSecret("my-secret", "my-scope")))
+	require.Nil(t, backend.CreateSecret(createTestSecret("default-secret", "keptn-default")))
+
+	allowed, err := backend.CanReadSecret("my-scope-token", "my-secret")
+	require.Nil(t, err)
+	assert.True(t, allowed)
+
+	// the token of another scope is rejected
+	allowed, err = backend.CanReadSecret("keptn-default-token", "my-secret")
+	require.Nil(t, err)
+	assert.False(t, allowed)
+
+	allowed, err = backend.CanReadSecret("my-scope-token", "default-secret")
+	require.Nil(t, err)
+	assert.False(t, allowed)
+
+	// the capabilities of keptn-default do not grant the permission get
+	allowed, err = backend.CanReadSecret("keptn-default-token", "default-secret")
+	require.Nil(t, err)
+	assert.False(t, allowed)
+
+	allowed, err = backend.CanReadSecret("my-scope-token", "unknown")
+	require.Nil(t, err)
+	assert.False(t, allowed)
+}
+
+func TestMongoDBSecretBackend_RotateMasterKey(t *testing.T) {
+	backend, secrets := newTestMongoDBSecretBackend(t)
+	require.Nil(t, backend.CreateSecret(createTestSecret("my-secret", "my-scope")))
+	encryptedData := secrets["my-secret"].EncryptedData
+
+	rotated, err := backend.RotateMasterKey()
+	require.Nil(t, err)
+	assert.Equal(t, 0, rotated)
+
+	backend.Keyring, err = ParseKeyring(testMasterKey("key-2", "b") + "\n" + testMasterKey("key-1", "a"))
+	require.Nil(t, err)
+	rotated, err = backend.RotateMasterKey()
+	require.Nil(t, err)
+	assert.Equal(t, 1, rotated)
+	assert.Equal(t, "key-2", secrets["my-secret"].MasterKeyID)
+	assert.Equal(t, encryptedData, secrets["my-secret"].EncryptedData)
+
+	// the previous master key can be removed after the rotation
+	backend.Keyring, err = ParseKeyring(testMasterKey("key-2", "b"))
+	require.Nil(t, err)
+	value, err := backend.GetSecretValue("my-secret", "password")
+	require.Nil(t, err)
+	assert.Equal(t, "keptn", value)
+
+	// secrets encrypted with an unknown master key cannot be rotated
+	secret := secrets["my-secret"]
+	secret.MasterKeyID = "unknown"
+	secrets["my-secret"] = secret
+	_, err = backend.RotateMasterKey()
+	assert.True(t, errors.Is(err, ErrUnknownMasterKey))
+}
+
+func TestMongoDBSecretBackend_RotateMasterKey_ConcurrentUpdate(t *testing.T) {
+	backend, secrets := newTestMongoDBSecretBackend(t)
+	require.Nil(t, backend.CreateSecret(createTestSecret("my-secret", "my-scope")))
+
+	var err error
+	backend.Keyring, err = ParseKeyring(testMasterKey("key-2", "b") + "\n" + testMasterKey("key-1", "a"))
+	require.Nil(t, err)
+
+	// the secret is updated by another replica after it has been read for the rotation
+	secretsRepository := backend.SecretsRepository.(*fake.SecretsRepositoryMock)
+	getAll := secretsRepository.GetAllFunc
+	secretsRepository.GetAllFunc = func() ([]model.EncryptedSecret, error) {
+		result, err := getAll()
+		updatedSecret := createTestSecret("my-secret", "my-scope")
+		updatedSecret.Data["password"] = "new-password"
+		require.Nil(t, backend.UpdateSecret(updatedSecret))
+		return result, err
+	}
+
+	rotated, err := backend.RotateMasterKey()
+	require.Nil(t, err)
+	assert.Equal(t, 0, rotated)
+	assert.Equal(t, "key-2", secrets["my-secret"].MasterKeyID)
+	value, err := backend.GetSecretValue("my-secret", "password")
+	require.Nil(t, err)
+	assert.Equal(t, "new-password", value)
+}
@@ -0,0 +1,20 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/secret-service/pkg/handler"
+)
+
+const SecretValueAPIBasePath = "/secret/value"
+
+type SecretValueController struct {
+	SecretValueHandler handler.ISecretValueHandler
+}
+
+func NewSecretValueController(secretValueHandler handler.ISecretValueHandler) *SecretValueController {
+	return &SecretValueController{SecretValueHandler: secretValueHandler}
+}
+
+func (controller SecretValueController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.GET(SecretValueAPIBasePath, controller.SecretValueHandler.GetSecretValue)
+}
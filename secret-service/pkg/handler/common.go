@@ -13,6 +13,7 @@ var ErrUpdateSecretMsg = "Unable to update secret: %s"
 var ErrGetSecretMsg = "Unable to get secret: %s"
 var ErrDeleteSecretMsg = "Unable to delete secret: %s"
 var ErrGetScopesMsg = "Unable to get scopes: %s"
+var ErrReadSecretMsg = "Unable to read secret: %s"
 
 func SetBadRequestErrorResponse(c *gin.Context, msg string) {
 	c.JSON(http.StatusBadRequest, model.Error{
@@ -21,6 +22,20 @@ func SetBadRequestErrorResponse(c *gin.Context, msg string) {
 	})
 }
 
+func SetUnauthorizedErrorResponse(c *gin.Context, msg string) {
+	c.JSON(http.StatusUnauthorized, model.Error{
+		Code:    http.StatusUnauthorized,
+		Message: &msg,
+	})
+}
+
+func SetForbiddenErrorResponse(c *gin.Context, msg string) {
+	c.JSON(http.StatusForbidden, model.Error{
+		Code:    http.StatusForbidden,
+		Message: &msg,
+	})
+}
+
 func SetInternalServerErrorResponse(c *gin.Context, msg string) {
 	c.JSON(http.StatusInternalServerError, model.Error{
 		Code:    http.StatusInternalServerError,
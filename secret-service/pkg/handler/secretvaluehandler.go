@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/secret-service/pkg/backend"
+	"github.com/keptn/keptn/secret-service/pkg/model"
+)
+
+type ISecretValueHandler interface {
+	GetSecretValue(c *gin.Context)
+}
+
+func NewSecretValueHandler(secretReader backend.SecretReader, accessReviewer backend.AccessReviewer) *SecretValueHandler {
+	return &SecretValueHandler{
+		SecretReader:   secretReader,
+		AccessReviewer: accessReviewer,
+	}
+}
+
+// SecretValueHandler serves the values of secrets to the services within the cluster. Callers authenticate with the
+// token of their service account, and may only read the secrets of the scope of their service account.
+// The endpoint is not exposed by the API gateway
+type SecretValueHandler struct {
+	SecretReader   backend.SecretReader
+	AccessReviewer backend.AccessReviewer
+}
+
+func (s SecretValueHandler) GetSecretValue(c *gin.Context) {
+	params := &GetSecretValueQueryParams{}
+	if err := c.ShouldBindQuery(params); err != nil {
+		SetBadRequestErrorResponse(c, fmt.Sprintf(ErrInvalidRequestFormatMsg, err.Error()))
+		return
+	}
+
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if token == "" || token == c.GetHeader("Authorization") {
+		SetUnauthorizedErrorResponse(c, fmt.Sprintf(ErrReadSecretMsg, "missing service account token"))
+		return
+	}
+	allowed, err := s.AccessReviewer.CanReadSecret(token, params.Name)
+	if err != nil {
+		SetInternalServerErrorResponse(c, fmt.Sprintf(ErrReadSecretMsg, err.Error()))
+		return
+	}
+	if !allowed {
+		SetForbiddenErrorResponse(c, fmt.Sprintf(ErrReadSecretMsg, "access to secret "+params.Name+" denied"))
+		return
+	}
+
+	value, err := s.SecretReader.GetSecretValue(params.Name, params.Key)
+	if err != nil {
+		if errors.Is(err, backend.ErrSecretNotFound) || errors.Is(err, backend.ErrSecretKeyNotFound) {
+			SetNotFoundErrorResponse(c, fmt.Sprintf(ErrReadSecretMsg, err.Error()))
+			return
+		}
+		SetInternalServerErrorResponse(c, fmt.Sprintf(ErrReadSecretMsg, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, model.GetSecretValueResponse{Value: value})
+}
+
+type GetSecretValueQueryParams struct {
+	Name string `form:"name" binding:"required"`
+	Key  string `form:"key" binding:"required"`
+}
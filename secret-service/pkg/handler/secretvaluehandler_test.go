@@ -0,0 +1,117 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/secret-service/pkg/backend"
+	"github.com/keptn/keptn/secret-service/pkg/backend/fake"
+	"github.com/keptn/keptn/secret-service/pkg/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetSecretValue(t *testing.T) {
+	secretReader := &fake.SecretBackendMock{
+		GetSecretValueFunc: func(name string, key string) (string, error) {
+			if name == "my-secret" && key == "token" {
+				return "my-token", nil
+			}
+			if name == "my-secret" {
+				return "", backend.ErrSecretKeyNotFound
+			}
+			if name == "broken-secret" {
+				return "", errors.New("oops")
+			}
+			return "", backend.ErrSecretNotFound
+		},
+	}
+	accessReviewer := &fake.AccessReviewerMock{
+		CanReadSecretFunc: func(token string, name string) (bool, error) {
+			if token == "invalid" {
+				return false, errors.New("oops")
+			}
+			return token == "my-service-account-token", nil
+		},
+	}
+
+	tests := []struct {
+		name               string
+		url                string
+		authorization      string
+		expectedHTTPStatus int
+		expectedBody       string
+	}{
+		{
+			name:               "GET Secret Value - SUCCESS",
+			url:                "/secret/value?name=my-secret&key=token",
+			authorization:      "Bearer my-service-account-token",
+			expectedHTTPStatus: http.StatusOK,
+			expectedBody:       `{"value":"my-token"}`,
+		},
+		{
+			name:               "GET Secret Value - missing key",
+			url:                "/secret/value?name=my-secret",
+			authorization:      "Bearer my-service-account-token",
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			name:               "GET Secret Value - missing token",
+			url:                "/secret/value?name=my-secret&key=token",
+			expectedHTTPStatus: http.StatusUnauthorized,
+		},
+		{
+			name:               "GET Secret Value - access denied",
+			url:                "/secret/value?name=my-secret&key=token",
+			authorization:      "Bearer other-service-account-token",
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedBody:       `{"code":403,"message":"Unable to read secret: access to secret my-secret denied"}`,
+		},
+		{
+			name:               "GET Secret Value - access review fails",
+			url:                "/secret/value?name=my-secret&key=token",
+			authorization:      "Bearer invalid",
+			expectedHTTPStatus: http.StatusInternalServerError,
+		},
+		{
+			name:               "GET Secret Value - secret not found",
+			url:                "/secret/value?name=my-missing-secret&key=token",
+			authorization:      "Bearer my-service-account-token",
+			expectedHTTPStatus: http.StatusNotFound,
+		},
+		{
+			name:               "GET Secret Value - key not found",
+			url:                "/secret/value?name=my-secret&key=password",
+			authorization:      "Bearer my-service-account-token",
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedBody:       `{"code":404,"message":"Unable to read secret: key not found in secret"}`,
+		},
+		{
+			name:               "GET Secret Value - Backend some error",
+			url:                "/secret/value?name=broken-secret&key=token",
+			authorization:      "Bearer my-service-account-token",
+			expectedHTTPStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secretValueHandler := handler.NewSecretValueHandler(secretReader, accessReviewer)
+			request := httptest.NewRequest("GET", tt.url, nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = request
+			secretValueHandler.GetSecretValue(c)
+
+			assert.Equal(t, tt.expectedHTTPStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
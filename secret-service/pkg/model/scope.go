@@ -6,6 +6,9 @@ type Scopes struct {
 
 type Scope struct {
 	Capabilities map[string]Capability `yaml:"capabilities"`
+	// TokenHashes are the hex encoded SHA-256 hashes of the tokens the callers of the scope authenticate with. They are
+	// only used by secret backends that review the access to secrets themselves
+	TokenHashes []string `yaml:"tokenHashes,omitempty"`
 }
 
 type Capability struct {
@@ -25,3 +25,25 @@ type GetSecretResponseItem struct {
 type GetSecretsResponse struct {
 	Secrets []GetSecretResponseItem `json:"Secrets"`
 }
+
+type GetSecretValueResponse struct {
+	Value string `json:"value"`
+}
+
+// EncryptedSecret is a secret as stored by the mongodb secret backend. The data of the secret is encrypted with a
+// data key, which in turn is encrypted with a master key
+type EncryptedSecret struct {
+	Name  string `bson:"name"`
+	Scope string `bson:"scope"`
+	// Keys are the keys of the data of the secret, which are stored unencrypted to list the secrets
+	Keys          []string `bson:"keys"`
+	DataKey       `bson:",inline"`
+	EncryptedData []byte `bson:"encryptedData"`
+}
+
+// DataKey is the encrypted data key of an encrypted secret
+type DataKey struct {
+	// MasterKeyID identifies the master key the data key has been encrypted with
+	MasterKeyID      string `bson:"masterKeyId"`
+	EncryptedDataKey []byte `bson:"encryptedDataKey"`
+}
@@ -0,0 +1,301 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package fake
+
+import (
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	"github.com/keptn/keptn/secret-service/pkg/repository"
+	"sync"
+)
+
+// Ensure, that SecretsRepositoryMock does implement repository.SecretsRepository.
+// If this is not the case, regenerate this file with moq.
+var _ repository.SecretsRepository = &SecretsRepositoryMock{}
+
+// SecretsRepositoryMock is a mock implementation of repository.SecretsRepository.
+//
+//	func TestSomethingThatUsesSecretsRepository(t *testing.T) {
+//
+//		// make and configure a mocked repository.SecretsRepository
+//		mockedSecretsRepository := &SecretsRepositoryMock{
+//			CreateFunc: func(secret model.EncryptedSecret) error {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(name string) error {
+//				panic("mock out the Delete method")
+//			},
+//			GetFunc: func(name string) (*model.EncryptedSecret, error) {
+//				panic("mock out the Get method")
+//			},
+//			GetAllFunc: func() ([]model.EncryptedSecret, error) {
+//				panic("mock out the GetAll method")
+//			},
+//			UpdateFunc: func(secret model.EncryptedSecret) error {
+//				panic("mock out the Update method")
+//			},
+//			UpdateDataKeyFunc: func(name string, previous model.DataKey, updated model.DataKey) (bool, error) {
+//				panic("mock out the UpdateDataKey method")
+//			},
+//		}
+//
+//		// use mockedSecretsRepository in code that requires repository.SecretsRepository
+//		// and then make assertions.
+//
+//	}
+type SecretsRepositoryMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(secret model.EncryptedSecret) error
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(name string) error
+
+	// GetFunc mocks the Get method.
+	GetFunc func(name string) (*model.EncryptedSecret, error)
+
+	// GetAllFunc mocks the GetAll method.
+	GetAllFunc func() ([]model.EncryptedSecret, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(secret model.EncryptedSecret) error
+
+	// UpdateDataKeyFunc mocks the UpdateDataKey method.
+	UpdateDataKeyFunc func(name string, previous model.DataKey, updated model.DataKey) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Secret is the secret argument value.
+			Secret model.EncryptedSecret
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// GetAll holds details about calls to the GetAll method.
+		GetAll []struct {
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Secret is the secret argument value.
+			Secret model.EncryptedSecret
+		}
+		// UpdateDataKey holds details about calls to the UpdateDataKey method.
+		UpdateDataKey []struct {
+			// Name is the name argument value.
+			Name string
+			// Previous is the previous argument value.
+			Previous model.DataKey
+			// Updated is the updated argument value.
+			Updated model.DataKey
+		}
+	}
+	lockCreate        sync.RWMutex
+	lockDelete        sync.RWMutex
+	lockGet           sync.RWMutex
+	lockGetAll        sync.RWMutex
+	lockUpdate        sync.RWMutex
+	lockUpdateDataKey sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *SecretsRepositoryMock) Create(secret model.EncryptedSecret) error {
+	if mock.CreateFunc == nil {
+		panic("SecretsRepositoryMock.CreateFunc: method is nil but SecretsRepository.Create was just called")
+	}
+	callInfo := struct {
+		Secret model.EncryptedSecret
+	}{
+		Secret: secret,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(secret)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedSecretsRepository.CreateCalls())
+func (mock *SecretsRepositoryMock) CreateCalls() []struct {
+	Secret model.EncryptedSecret
+} {
+	var calls []struct {
+		Secret model.EncryptedSecret
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *SecretsRepositoryMock) Delete(name string) error {
+	if mock.DeleteFunc == nil {
+		panic("SecretsRepositoryMock.DeleteFunc: method is nil but SecretsRepository.Delete was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(name)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedSecretsRepository.DeleteCalls())
+func (mock *SecretsRepositoryMock) DeleteCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *SecretsRepositoryMock) Get(name string) (*model.EncryptedSecret, error) {
+	if mock.GetFunc == nil {
+		panic("SecretsRepositoryMock.GetFunc: method is nil but SecretsRepository.Get was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	return mock.GetFunc(name)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedSecretsRepository.GetCalls())
+func (mock *SecretsRepositoryMock) GetCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// GetAll calls GetAllFunc.
+func (mock *SecretsRepositoryMock) GetAll() ([]model.EncryptedSecret, error) {
+	if mock.GetAllFunc == nil {
+		panic("SecretsRepositoryMock.GetAllFunc: method is nil but SecretsRepository.GetAll was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetAll.Lock()
+	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
+	mock.lockGetAll.Unlock()
+	return mock.GetAllFunc()
+}
+
+// GetAllCalls gets all the calls that were made to GetAll.
+// Check the length with:
+//
+//	len(mockedSecretsRepository.GetAllCalls())
+func (mock *SecretsRepositoryMock) GetAllCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetAll.RLock()
+	calls = mock.calls.GetAll
+	mock.lockGetAll.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *SecretsRepositoryMock) Update(secret model.EncryptedSecret) error {
+	if mock.UpdateFunc == nil {
+		panic("SecretsRepositoryMock.UpdateFunc: method is nil but SecretsRepository.Update was just called")
+	}
+	callInfo := struct {
+		Secret model.EncryptedSecret
+	}{
+		Secret: secret,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	return mock.UpdateFunc(secret)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedSecretsRepository.UpdateCalls())
+func (mock *SecretsRepositoryMock) UpdateCalls() []struct {
+	Secret model.EncryptedSecret
+} {
+	var calls []struct {
+		Secret model.EncryptedSecret
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// UpdateDataKey calls UpdateDataKeyFunc.
+func (mock *SecretsRepositoryMock) UpdateDataKey(name string, previous model.DataKey, updated model.DataKey) (bool, error) {
+	if mock.UpdateDataKeyFunc == nil {
+		panic("SecretsRepositoryMock.UpdateDataKeyFunc: method is nil but SecretsRepository.UpdateDataKey was just called")
+	}
+	callInfo := struct {
+		Name     string
+		Previous model.DataKey
+		Updated  model.DataKey
+	}{
+		Name:     name,
+		Previous: previous,
+		Updated:  updated,
+	}
+	mock.lockUpdateDataKey.Lock()
+	mock.calls.UpdateDataKey = append(mock.calls.UpdateDataKey, callInfo)
+	mock.lockUpdateDataKey.Unlock()
+	return mock.UpdateDataKeyFunc(name, previous, updated)
+}
+
+// UpdateDataKeyCalls gets all the calls that were made to UpdateDataKey.
+// Check the length with:
+//
+//	len(mockedSecretsRepository.UpdateDataKeyCalls())
+func (mock *SecretsRepositoryMock) UpdateDataKeyCalls() []struct {
+	Name     string
+	Previous model.DataKey
+	Updated  model.DataKey
+} {
+	var calls []struct {
+		Name     string
+		Previous model.DataKey
+		Updated  model.DataKey
+	}
+	mock.lockUpdateDataKey.RLock()
+	calls = mock.calls.UpdateDataKey
+	mock.lockUpdateDataKey.RUnlock()
+	return calls
+}
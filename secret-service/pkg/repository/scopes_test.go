@@ -51,6 +51,27 @@ func Test_ReadFromFileBasedRepository_ReadFails(t *testing.T) {
 	assert.Equal(t, model.Scopes{}, scopes)
 }
 
+func Test_ReadFromFileBasedRepository_TokenHashes(t *testing.T) {
+	fakeReader := func(filename string) ([]byte, error) {
+		return []byte(`Scopes:
+  my-scope:
+    Capabilities:
+      my-scope-read-secrets:
+        Permissions:
+          - get
+    TokenHashes:
+      - 0a6b1f1d9b1e1c5e5d3d2d7c8f3e2b4a6c9d0e1f2a3b4c5d6e7f8091a2b3c4d5
+`), nil
+	}
+	repository := FileBasedScopesRepository{
+		FileReader: fakeReader,
+		Decoder:    yaml.Unmarshal,
+	}
+	scopes, err := repository.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0a6b1f1d9b1e1c5e5d3d2d7c8f3e2b4a6c9d0e1f2a3b4c5d6e7f8091a2b3c4d5"}, scopes.Scopes["my-scope"].TokenHashes)
+}
+
 type failingReader struct{}
 
 func (r failingReader) Read([]byte) (int, error) {
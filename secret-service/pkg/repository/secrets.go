@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	keptnmongoutils "github.com/keptn/go-utils/pkg/common/mongoutils"
+	"github.com/keptn/keptn/secret-service/pkg/model"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const secretsCollectionName = "keptn-secrets"
+
+var ErrSecretNotFound = errors.New("secret not found")
+var ErrSecretAlreadyExists = errors.New("secret already exists")
+
+//go:generate moq -pkg fake -out ./fake/secretsrepository_mock.go . SecretsRepository
+type SecretsRepository interface {
+	Create(secret model.EncryptedSecret) error
+	Update(secret model.EncryptedSecret) error
+	Delete(name string) error
+	// Get returns the secret with the given name, or ErrSecretNotFound
+	Get(name string) (*model.EncryptedSecret, error)
+	GetAll() ([]model.EncryptedSecret, error)
+	// UpdateDataKey replaces the encrypted data key of the secret, if it is still encrypted with the given previous data
+	// key. It returns false if the secret has been changed or deleted in the meantime
+	UpdateDataKey(name string, previous model.DataKey, updated model.DataKey) (bool, error)
+}
+
+// MongoDBSecretsRepository stores the encrypted secrets in MongoDB. The connection is established with the first
+// request, using the MONGODB_* env vars of the service
+type MongoDBSecretsRepository struct {
+	mutex      sync.Mutex
+	collection *mongo.Collection
+}
+
+func NewMongoDBSecretsRepository() *MongoDBSecretsRepository {
+	return &MongoDBSecretsRepository{}
+}
+
+func (r *MongoDBSecretsRepository) Create(secret model.EncryptedSecret) error {
+	collection, err := r.getCollection()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.InsertOne(ctx, secret); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrSecretAlreadyExists
+		}
+		return fmt.Errorf("could not store secret %s: %w", secret.Name, err)
+	}
+	return nil
+}
+
+func (r *MongoDBSecretsRepository) Update(secret model.EncryptedSecret) error {
+	collection, err := r.getCollection()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+
+	result, err := collection.ReplaceOne(ctx, bson.M{"name": secret.Name}, secret)
+	if err != nil {
+		return fmt.Errorf("could not update secret %s: %w", secret.Name, err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrSecretNotFound
+	}
+	return nil
+}
+
+func (r *MongoDBSecretsRepository) Delete(name string) error {
+	collection, err := r.getCollection()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+
+	result, err := collection.DeleteOne(ctx, bson.M{"name": name})
+	if err != nil {
+		return fmt.Errorf("could not delete secret %s: %w", name, err)
+	}
+	if result.DeletedCount == 0 {
+		return ErrSecretNotFound
+	}
+	return nil
+}
+
+func (r *MongoDBSecretsRepository) Get(name string) (*model.EncryptedSecret, error) {
+	collection, err := r.getCollection()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+
+	secret := &model.EncryptedSecret{}
+	err = collection.FindOne(ctx, bson.M{"name": name}).Decode(secret)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrSecretNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("could not retrieve secret %s: %w", name, err)
+	}
+	return secret, nil
+}
+
+func (r *MongoDBSecretsRepository) GetAll() ([]model.EncryptedSecret, error) {
+	collection, err := r.getCollection()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"name": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve secrets: %w", err)
+	}
+	secrets := []model.EncryptedSecret{}
+	if err := cursor.All(ctx, &secrets); err != nil {
+		return nil, fmt.Errorf("could not retrieve secrets: %w", err)
+	}
+	return secrets, nil
+}
+
+func (r *MongoDBSecretsRepository) UpdateDataKey(name string, previous model.DataKey, updated model.DataKey) (bool, error) {
+	collection, err := r.getCollection()
+	if err != nil {
+		return false, err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+
+	// the data key is only replaced if the secret has not been updated since it has been read
+	filter := bson.M{"name": name, "masterKeyId": previous.MasterKeyID, "encryptedDataKey": previous.EncryptedDataKey}
+	update := bson.M{"$set": bson.M{"masterKeyId": updated.MasterKeyID, "encryptedDataKey": updated.EncryptedDataKey}}
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("could not update data key of secret %s: %w", name, err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *MongoDBSecretsRepository) getCollection() (*mongo.Collection, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.collection != nil {
+		return r.collection, nil
+	}
+
+	connectionString, databaseName, err := keptnmongoutils.GetMongoConnectionStringFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mongo client: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(connectionString).SetConnectTimeout(30*time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect client to MongoDB: %w", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, fmt.Errorf("failed to connect client to MongoDB: %w", err)
+	}
+	log.Info("Successfully connected to MongoDB")
+
+	collection := client.Database(databaseName).Collection(secretsCollectionName)
+	// the names of the secrets are unique, as they are for kubernetes secrets
+	index := mongo.IndexModel{Keys: bson.M{"name": 1}, Options: options.Index().SetUnique(true)}
+	if _, err := collection.Indexes().CreateOne(ctx, index); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, fmt.Errorf("could not create index of secrets: %w", err)
+	}
+	r.collection = collection
+	return r.collection, nil
+}
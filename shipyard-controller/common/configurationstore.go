@@ -1,7 +1,11 @@
 package common
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strings"
 
@@ -21,6 +25,8 @@ const resourceServiceSvcDoesNotExistErrorMsg = "service not found"
 //go:generate moq -pkg common_mock -out ./fake/configurationstore_mock.go . ConfigurationStore
 type ConfigurationStore interface {
 	CreateProject(project apimodels.Project) error
+	// CreateProjectInStorageBackend creates the project in the given storage backend of the resource-service
+	CreateProjectInStorageBackend(project apimodels.Project, storageBackend string) error
 	UpdateProject(project apimodels.Project) error
 	CreateProjectShipyard(projectName string, resources []*apimodels.Resource) error
 	UpdateProjectResource(projectName string, resource *apimodels.Resource) error
@@ -33,6 +39,8 @@ type ConfigurationStore interface {
 }
 
 type GitConfigurationStore struct {
+	endpoint    string
+	httpClient  *http.Client
 	projectAPI  *keptnapi.ProjectHandler
 	stagesAPI   *keptnapi.StageHandler
 	servicesAPI *keptnapi.ServiceHandler
@@ -41,6 +49,8 @@ type GitConfigurationStore struct {
 
 func NewGitConfigurationStore(configurationServiceEndpoint string) *GitConfigurationStore {
 	return &GitConfigurationStore{
+		endpoint:    strings.TrimSuffix(configurationServiceEndpoint, "/"),
+		httpClient:  &http.Client{},
 		projectAPI:  keptnapi.NewProjectHandler(configurationServiceEndpoint),
 		stagesAPI:   keptnapi.NewStageHandler(configurationServiceEndpoint),
 		servicesAPI: keptnapi.NewServiceHandler(configurationServiceEndpoint),
@@ -63,6 +73,40 @@ func (g GitConfigurationStore) CreateProject(project apimodels.Project) error {
 	return nil
 }
 
+// createProjectPayload is the payload for creating a project in the resource-service, which, in contrast to
+// apimodels.Project, contains the storage backend of the project
+type createProjectPayload struct {
+	ProjectName    string `json:"projectName"`
+	StorageBackend string `json:"storageBackend,omitempty"`
+}
+
+func (g GitConfigurationStore) CreateProjectInStorageBackend(project apimodels.Project, storageBackend string) error {
+	payload, err := json.Marshal(createProjectPayload{ProjectName: project.ProjectName, StorageBackend: storageBackend})
+	if err != nil {
+		return err
+	}
+	resp, err := g.httpClient.Post(g.endpoint+"/v1/project", "application/json", bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("could not create project %s: %w", project.ProjectName, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not create project %s: %w", project.ProjectName, err)
+	}
+	respErr := &apimodels.Error{}
+	if err := json.Unmarshal(body, respErr); err != nil || respErr.Message == nil {
+		message := string(body)
+		respErr.Message = &message
+	}
+	respErr.Code = int64(resp.StatusCode)
+	return g.buildErrResponse(respErr)
+}
+
 func (g GitConfigurationStore) UpdateProject(project apimodels.Project) error {
 	if _, err := g.projectAPI.UpdateConfigurationServiceProject(project); err != nil {
 		return g.buildErrResponse(err)
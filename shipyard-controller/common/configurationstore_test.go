@@ -32,6 +32,32 @@ func TestConfigurationStore(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 
+	t.Run("TestCreateProjectInStorageBackend_Success", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, "/v1/project", r.URL.Path)
+			assert.JSONEq(t, `{"projectName": "my-project", "storageBackend": "objectstore"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer ts.Close()
+
+		instance := NewGitConfigurationStore(ts.URL)
+		err := instance.CreateProjectInStorageBackend(apimodels.Project{ProjectName: "my-project"}, "objectstore")
+		assert.Nil(t, err)
+	})
+
+	t.Run("TestCreateProjectInStorageBackend_APIReturnsError", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"code": 500, "message": "storage backend not available"}`))
+		}))
+		defer ts.Close()
+
+		instance := NewGitConfigurationStore(ts.URL)
+		err := instance.CreateProjectInStorageBackend(apimodels.Project{ProjectName: "my-project"}, "objectstore")
+		assert.EqualError(t, err, "storage backend not available")
+	})
+
 	t.Run("TestUpdateProject_Success", func(t *testing.T) {
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
 		defer ts.Close()
@@ -26,6 +26,9 @@ const (
 	RootEvent EventStatus = "root"
 )
 
+// UpstreamUpdatedEventType is the type of the event sent by the resource-service after the upstream repository of a project has been updated
+const UpstreamUpdatedEventType = "sh.keptn.event.upstream.updated"
+
 // EventFilter allows to pass filters
 type EventFilter struct {
 	Type         string
@@ -22,6 +22,9 @@ var _ common.ConfigurationStore = &ConfigurationStoreMock{}
 // 			CreateProjectFunc: func(project apimodels.Project) error {
 // 				panic("mock out the CreateProject method")
 // 			},
+// 			CreateProjectInStorageBackendFunc: func(project apimodels.Project, storageBackend string) error {
+// 				panic("mock out the CreateProjectInStorageBackend method")
+// 			},
 // 			CreateProjectShipyardFunc: func(projectName string, resources []*apimodels.Resource) error {
 // 				panic("mock out the CreateProjectShipyard method")
 // 			},
@@ -59,6 +62,9 @@ type ConfigurationStoreMock struct {
 	// CreateProjectFunc mocks the CreateProject method.
 	CreateProjectFunc func(project apimodels.Project) error
 
+	// CreateProjectInStorageBackendFunc mocks the CreateProjectInStorageBackend method.
+	CreateProjectInStorageBackendFunc func(project apimodels.Project, storageBackend string) error
+
 	// CreateProjectShipyardFunc mocks the CreateProjectShipyard method.
 	CreateProjectShipyardFunc func(projectName string, resources []*apimodels.Resource) error
 
@@ -93,6 +99,13 @@ type ConfigurationStoreMock struct {
 			// Project is the project argument value.
 			Project apimodels.Project
 		}
+		// CreateProjectInStorageBackend holds details about calls to the CreateProjectInStorageBackend method.
+		CreateProjectInStorageBackend []struct {
+			// Project is the project argument value.
+			Project apimodels.Project
+			// StorageBackend is the storageBackend argument value.
+			StorageBackend string
+		}
 		// CreateProjectShipyard holds details about calls to the CreateProjectShipyard method.
 		CreateProjectShipyard []struct {
 			// ProjectName is the projectName argument value.
@@ -159,16 +172,17 @@ type ConfigurationStoreMock struct {
 			Resource *apimodels.Resource
 		}
 	}
-	lockCreateProject         sync.RWMutex
-	lockCreateProjectShipyard sync.RWMutex
-	lockCreateService         sync.RWMutex
-	lockCreateStage           sync.RWMutex
-	lockDeleteProject         sync.RWMutex
-	lockDeleteService         sync.RWMutex
-	lockGetProjectResource    sync.RWMutex
-	lockGetStageResource      sync.RWMutex
-	lockUpdateProject         sync.RWMutex
-	lockUpdateProjectResource sync.RWMutex
+	lockCreateProject                 sync.RWMutex
+	lockCreateProjectInStorageBackend sync.RWMutex
+	lockCreateProjectShipyard         sync.RWMutex
+	lockCreateService                 sync.RWMutex
+	lockCreateStage                   sync.RWMutex
+	lockDeleteProject                 sync.RWMutex
+	lockDeleteService                 sync.RWMutex
+	lockGetProjectResource            sync.RWMutex
+	lockGetStageResource              sync.RWMutex
+	lockUpdateProject                 sync.RWMutex
+	lockUpdateProjectResource         sync.RWMutex
 }
 
 // CreateProject calls CreateProjectFunc.
@@ -189,7 +203,8 @@ func (mock *ConfigurationStoreMock) CreateProject(project apimodels.Project) err
 
 // CreateProjectCalls gets all the calls that were made to CreateProject.
 // Check the length with:
-//     len(mockedConfigurationStore.CreateProjectCalls())
+//
+// 	len(mockedConfigurationStore.CreateProjectCalls())
 func (mock *ConfigurationStoreMock) CreateProjectCalls() []struct {
 	Project apimodels.Project
 } {
@@ -202,6 +217,42 @@ func (mock *ConfigurationStoreMock) CreateProjectCalls() []struct {
 	return calls
 }
 
+// CreateProjectInStorageBackend calls CreateProjectInStorageBackendFunc.
+func (mock *ConfigurationStoreMock) CreateProjectInStorageBackend(project apimodels.Project, storageBackend string) error {
+	if mock.CreateProjectInStorageBackendFunc == nil {
+		panic("ConfigurationStoreMock.CreateProjectInStorageBackendFunc: method is nil but ConfigurationStore.CreateProjectInStorageBackend was just called")
+	}
+	callInfo := struct {
+		Project        apimodels.Project
+		StorageBackend string
+	}{
+		Project:        project,
+		StorageBackend: storageBackend,
+	}
+	mock.lockCreateProjectInStorageBackend.Lock()
+	mock.calls.CreateProjectInStorageBackend = append(mock.calls.CreateProjectInStorageBackend, callInfo)
+	mock.lockCreateProjectInStorageBackend.Unlock()
+	return mock.CreateProjectInStorageBackendFunc(project, storageBackend)
+}
+
+// CreateProjectInStorageBackendCalls gets all the calls that were made to CreateProjectInStorageBackend.
+// Check the length with:
+//
+// 	len(mockedConfigurationStore.CreateProjectInStorageBackendCalls())
+func (mock *ConfigurationStoreMock) CreateProjectInStorageBackendCalls() []struct {
+	Project        apimodels.Project
+	StorageBackend string
+} {
+	var calls []struct {
+		Project        apimodels.Project
+		StorageBackend string
+	}
+	mock.lockCreateProjectInStorageBackend.RLock()
+	calls = mock.calls.CreateProjectInStorageBackend
+	mock.lockCreateProjectInStorageBackend.RUnlock()
+	return calls
+}
+
 // CreateProjectShipyard calls CreateProjectShipyardFunc.
 func (mock *ConfigurationStoreMock) CreateProjectShipyard(projectName string, resources []*apimodels.Resource) error {
 	if mock.CreateProjectShipyardFunc == nil {
@@ -222,7 +273,8 @@ func (mock *ConfigurationStoreMock) CreateProjectShipyard(projectName string, re
 
 // CreateProjectShipyardCalls gets all the calls that were made to CreateProjectShipyard.
 // Check the length with:
-//     len(mockedConfigurationStore.CreateProjectShipyardCalls())
+//
+// 	len(mockedConfigurationStore.CreateProjectShipyardCalls())
 func (mock *ConfigurationStoreMock) CreateProjectShipyardCalls() []struct {
 	ProjectName string
 	Resources   []*apimodels.Resource
@@ -259,7 +311,8 @@ func (mock *ConfigurationStoreMock) CreateService(projectName string, stageName
 
 // CreateServiceCalls gets all the calls that were made to CreateService.
 // Check the length with:
-//     len(mockedConfigurationStore.CreateServiceCalls())
+//
+// 	len(mockedConfigurationStore.CreateServiceCalls())
 func (mock *ConfigurationStoreMock) CreateServiceCalls() []struct {
 	ProjectName string
 	StageName   string
@@ -296,7 +349,8 @@ func (mock *ConfigurationStoreMock) CreateStage(projectName string, stage string
 
 // CreateStageCalls gets all the calls that were made to CreateStage.
 // Check the length with:
-//     len(mockedConfigurationStore.CreateStageCalls())
+//
+// 	len(mockedConfigurationStore.CreateStageCalls())
 func (mock *ConfigurationStoreMock) CreateStageCalls() []struct {
 	ProjectName string
 	Stage       string
@@ -329,7 +383,8 @@ func (mock *ConfigurationStoreMock) DeleteProject(projectName string) error {
 
 // DeleteProjectCalls gets all the calls that were made to DeleteProject.
 // Check the length with:
-//     len(mockedConfigurationStore.DeleteProjectCalls())
+//
+// 	len(mockedConfigurationStore.DeleteProjectCalls())
 func (mock *ConfigurationStoreMock) DeleteProjectCalls() []struct {
 	ProjectName string
 } {
@@ -364,7 +419,8 @@ func (mock *ConfigurationStoreMock) DeleteService(projectName string, stageName
 
 // DeleteServiceCalls gets all the calls that were made to DeleteService.
 // Check the length with:
-//     len(mockedConfigurationStore.DeleteServiceCalls())
+//
+// 	len(mockedConfigurationStore.DeleteServiceCalls())
 func (mock *ConfigurationStoreMock) DeleteServiceCalls() []struct {
 	ProjectName string
 	StageName   string
@@ -401,7 +457,8 @@ func (mock *ConfigurationStoreMock) GetProjectResource(projectName string, resou
 
 // GetProjectResourceCalls gets all the calls that were made to GetProjectResource.
 // Check the length with:
-//     len(mockedConfigurationStore.GetProjectResourceCalls())
+//
+// 	len(mockedConfigurationStore.GetProjectResourceCalls())
 func (mock *ConfigurationStoreMock) GetProjectResourceCalls() []struct {
 	ProjectName string
 	ResourceURI string
@@ -438,7 +495,8 @@ func (mock *ConfigurationStoreMock) GetStageResource(projectName string, stageNa
 
 // GetStageResourceCalls gets all the calls that were made to GetStageResource.
 // Check the length with:
-//     len(mockedConfigurationStore.GetStageResourceCalls())
+//
+// 	len(mockedConfigurationStore.GetStageResourceCalls())
 func (mock *ConfigurationStoreMock) GetStageResourceCalls() []struct {
 	ProjectName string
 	StageName   string
@@ -473,7 +531,8 @@ func (mock *ConfigurationStoreMock) UpdateProject(project apimodels.Project) err
 
 // UpdateProjectCalls gets all the calls that were made to UpdateProject.
 // Check the length with:
-//     len(mockedConfigurationStore.UpdateProjectCalls())
+//
+// 	len(mockedConfigurationStore.UpdateProjectCalls())
 func (mock *ConfigurationStoreMock) UpdateProjectCalls() []struct {
 	Project apimodels.Project
 } {
@@ -506,7 +565,8 @@ func (mock *ConfigurationStoreMock) UpdateProjectResource(projectName string, re
 
 // UpdateProjectResourceCalls gets all the calls that were made to UpdateProjectResource.
 // Check the length with:
-//     len(mockedConfigurationStore.UpdateProjectResourceCalls())
+//
+// 	len(mockedConfigurationStore.UpdateProjectResourceCalls())
 func (mock *ConfigurationStoreMock) UpdateProjectResourceCalls() []struct {
 	ProjectName string
 	Resource    *apimodels.Resource
@@ -68,6 +68,16 @@ func (p ProjectValidator) validateCreateProjectParams(createProjectParams *model
 		return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
 	}
 
+	switch createProjectParams.StorageBackend {
+	case "", models.StorageBackendGit:
+	case models.StorageBackendObjectStore:
+		if createProjectParams.GitCredentials != nil {
+			return errors.New("projects stored in the object store cannot have a Git upstream")
+		}
+	default:
+		return fmt.Errorf("storage backend must be either '%s' or '%s'", models.StorageBackendGit, models.StorageBackendObjectStore)
+	}
+
 	if createProjectParams.GitCredentials == nil {
 		return nil
 	}
@@ -285,7 +295,8 @@ func (ph *ProjectHandler) CreateProject(c *gin.Context) {
 	}
 
 	automaticProvisioningURL := ph.Env.AutomaticProvisioningURL
-	if automaticProvisioningURL != "" && params.GitCredentials == nil {
+	// projects stored in the object store do not have an upstream
+	if automaticProvisioningURL != "" && params.GitCredentials == nil && params.StorageBackend != models.StorageBackendObjectStore {
 		provisioningData, err := ph.RepositoryProvisioner.ProvideRepository(*params.Name, common.GetKeptnNamespace())
 		if err != nil {
 			log.Errorf(err.Error())
@@ -955,6 +955,36 @@ func Test_ProjectValidator(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "object store backend",
+			params: models.CreateProjectParams{
+				Shipyard:       &encodedShipyard,
+				Name:           &projectName,
+				StorageBackend: models.StorageBackendObjectStore,
+			},
+			wantErr: false,
+		},
+		{
+			name: "object store backend with Git upstream",
+			params: models.CreateProjectParams{
+				Shipyard:       &encodedShipyard,
+				Name:           &projectName,
+				StorageBackend: models.StorageBackendObjectStore,
+				GitCredentials: &apimodels.GitAuthCredentials{
+					RemoteURL: "https://some.url",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown storage backend",
+			params: models.CreateProjectParams{
+				Shipyard:       &encodedShipyard,
+				Name:           &projectName,
+				StorageBackend: "database",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
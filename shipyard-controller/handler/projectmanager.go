@@ -94,9 +94,15 @@ func (pm *ProjectManager) Create(params *models.CreateProjectParams) (error, com
 		return err, nilRollback
 	}
 
-	err = pm.ConfigurationStore.CreateProject(apimodels.Project{
-		ProjectName: *params.Name,
-	})
+	if params.StorageBackend == "" {
+		err = pm.ConfigurationStore.CreateProject(apimodels.Project{
+			ProjectName: *params.Name,
+		})
+	} else {
+		err = pm.ConfigurationStore.CreateProjectInStorageBackend(apimodels.Project{
+			ProjectName: *params.Name,
+		}, params.StorageBackend)
+	}
 
 	rollbackFunc := func() error {
 		log.Infof("Rollback: Try to delete GIT repository credentials secret for project %s", *params.Name)
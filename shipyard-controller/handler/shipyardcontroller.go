@@ -180,11 +180,26 @@ func (sc *shipyardController) HandleIncomingEvent(event apimodels.KeptnContextEx
 			cb(err)
 		}()
 	default:
+		if *event.Type == common.UpstreamUpdatedEventType {
+			go func() {
+				sc.onUpstreamUpdated(eventData.Project)
+				cb(nil)
+			}()
+			break
+		}
 		return nil
 	}
 	return completeEventHandler(waitForCompletion, done)
 }
 
+// onUpstreamUpdated refreshes the shipyard stored for a project after its upstream repository has been updated
+// outside of Keptn, so that the shipyard content of the project does not become outdated
+func (sc *shipyardController) onUpstreamUpdated(projectName string) {
+	if _, err := sc.shipyardRetriever.GetShipyard(projectName); err != nil {
+		log.WithError(err).Errorf("Unable to refresh shipyard of project %s after upstream update", projectName)
+	}
+}
+
 func completeEventHandler(waitForCompletion bool, done chan error) error {
 	if waitForCompletion {
 		return <-done
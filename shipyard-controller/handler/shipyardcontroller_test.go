@@ -3,6 +3,7 @@ package handler
 import (
 	"errors"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
 	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
@@ -205,3 +206,25 @@ func TestHandleTaskEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleIncomingEvent_UpstreamUpdated(t *testing.T) {
+	shipyardRetriever := &fake.IShipyardRetrieverMock{
+		GetShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
+			return &keptnv2.Shipyard{}, nil
+		},
+	}
+	sc := &shipyardController{
+		shipyardRetriever: shipyardRetriever,
+	}
+
+	err := sc.HandleIncomingEvent(apimodels.KeptnContextExtendedCE{
+		Data:           map[string]interface{}{"project": "my-project", "branch": "main", "commitID": "my-commit-id"},
+		Shkeptncontext: "my-context",
+		Source:         common.Stringp("resource-service"),
+		Type:           common.Stringp(common.UpstreamUpdatedEventType),
+	}, true)
+
+	require.Nil(t, err)
+	require.Len(t, shipyardRetriever.GetShipyardCalls(), 1)
+	require.Equal(t, "my-project", shipyardRetriever.GetShipyardCalls()[0].ProjectName)
+}
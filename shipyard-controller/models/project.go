@@ -17,6 +17,13 @@ type UpdateProjectParams struct {
 	Shipyard *string `json:"shipyard,omitempty"`
 }
 
+const (
+	// StorageBackendGit stores the resources of a project in a git repository that is synchronized with its upstream
+	StorageBackendGit = "git"
+	// StorageBackendObjectStore stores the resources of a project in an object store, without an upstream
+	StorageBackendObjectStore = "objectstore"
+)
+
 type CreateProjectParams struct {
 	// git credentials
 	GitCredentials *apimodels.GitAuthCredentials `json:"gitCredentials,omitempty"`
@@ -26,6 +33,9 @@ type CreateProjectParams struct {
 
 	// shipyard
 	Shipyard *string `json:"shipyard"`
+
+	// storage backend of the resources of the project, either "git" (default) or "objectstore"
+	StorageBackend string `json:"storageBackend,omitempty"`
 }
 
 type GetProjectParams struct {
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	logger "github.com/sirupsen/logrus"
+)
+
+// CallbackTokenHeader is the header containing the token of a callback. The token is not accepted as query parameter, since URLs
+// end up in the access logs of proxies and gateways
+const CallbackTokenHeader = "X-Keptn-Callback-Token"
+
+const maxCallbackPayloadSize = 1024 * 1024
+
+// CallbackHandler receives the results of async webhooks, which are sent via POST <callback URL>
+type CallbackHandler struct {
+	callbackRegistry *lib.CallbackRegistry
+}
+
+func NewCallbackHandler(callbackRegistry *lib.CallbackRegistry) *CallbackHandler {
+	return &CallbackHandler{callbackRegistry: callbackRegistry}
+}
+
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (ch *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, lib.CallbackPath)
+	if id == "" || strings.Contains(id, "/") {
+		writeErrorResponse(w, http.StatusNotFound, lib.ErrCallbackNotFound.Error())
+		return
+	}
+	token := r.Header.Get(CallbackTokenHeader)
+
+	result := lib.CallbackResult{}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCallbackPayloadSize))
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "could not read callback payload")
+		return
+	}
+	// the called system may also just call the URL without a payload, which results in a successful .finished event
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err := json.Unmarshal(body, &result); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "invalid callback payload: "+err.Error())
+			return
+		}
+	}
+	if err := result.Validate(); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "invalid callback payload: "+err.Error())
+		return
+	}
+
+	if err := ch.callbackRegistry.Receive(id, token, result); err != nil {
+		switch {
+		case errors.Is(err, lib.ErrCallbackNotFound):
+			writeErrorResponse(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, lib.ErrInvalidCallbackToken):
+			writeErrorResponse(w, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, lib.ErrCallbackAlreadyReceived):
+			writeErrorResponse(w, http.StatusConflict, err.Error())
+		default:
+			writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	logger.Infof("received callback %s with result %s", id, result.Result)
+	w.WriteHeader(http.StatusOK)
+}
+
+func writeErrorResponse(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(errorResponse{Code: code, Message: message})
+}
@@ -0,0 +1,58 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/handler"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallbackHandler_ServeHTTP(t *testing.T) {
+	registry := lib.NewCallbackRegistry("http://webhook-service:8081", lib.NewInMemoryCallbackStore())
+	callbackHandler := handler.NewCallbackHandler(registry)
+
+	results := make(chan lib.CallbackResult, 1)
+	require.Nil(t, registry.Start(func(event sdk.KeptnEvent, finishedEventData map[string]interface{}, result lib.CallbackResult) {
+		results <- result
+	}))
+	callback, err := registry.Register(sdk.KeptnEvent{}, map[string]interface{}{}, time.Minute)
+	require.Nil(t, err)
+	require.Nil(t, registry.Await(callback.ID, map[string]interface{}{}))
+
+	send := func(method string, target string, token string, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, target, strings.NewReader(body))
+		if token != "" {
+			request.Header.Set(handler.CallbackTokenHeader, token)
+		}
+		recorder := httptest.NewRecorder()
+		callbackHandler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	require.Equal(t, http.StatusMethodNotAllowed, send(http.MethodGet, "/v1/callback/"+callback.ID, callback.Token, "").Code)
+	require.Equal(t, http.StatusNotFound, send(http.MethodPost, "/v1/callback/unknown", callback.Token, "").Code)
+	require.Equal(t, http.StatusUnauthorized, send(http.MethodPost, "/v1/callback/"+callback.ID, "wrong-token", "").Code)
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPost, "/v1/callback/"+callback.ID, callback.Token, "{invalid").Code)
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPost, "/v1/callback/"+callback.ID, callback.Token, `{"result": "unknown"}`).Code)
+
+	// the token is only accepted via the header
+	require.Equal(t, http.StatusUnauthorized, send(http.MethodPost, "/v1/callback/"+callback.ID+"?token="+callback.Token, "", "").Code)
+
+	response := send(http.MethodPost, "/v1/callback/"+callback.ID, callback.Token, `{"result": "warning", "message": "slow", "data": {"ticket": "T-1"}}`)
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Equal(t, lib.CallbackResult{
+		Result:  keptnv2.ResultWarning,
+		Status:  keptnv2.StatusSucceeded,
+		Message: "slow",
+		Data:    map[string]interface{}{"ticket": "T-1"},
+	}, <-results)
+
+	require.Equal(t, http.StatusNotFound, send(http.MethodPost, "/v1/callback/"+callback.ID, callback.Token, "").Code)
+}
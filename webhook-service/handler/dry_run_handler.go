@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/lib"
+	logger "github.com/sirupsen/logrus"
+)
+
+// DryRunPath is the path of the endpoint rendering, validating and optionally executing the requests of a webhook for a sample event
+const DryRunPath = "/v1/dry-run"
+
+const maxDryRunPayloadSize = 1024 * 1024
+
+// placeholder values of async webhooks, since no callback is registered during a dry run
+const dryRunCallbackURL = "https://webhook-service/v1/callback/dry-run"
+const dryRunCallbackToken = "dry-run"
+
+// dryRunSecretPlaceholder is the value of all secrets while rendering the requests shown in the result of a dry run, so
+// that secrets cannot leak through template functions transforming them
+const dryRunSecretPlaceholder = "***"
+
+// DryRunRequest contains the webhook to test, as well as the event the webhook is tested with
+type DryRunRequest struct {
+	// SubscriptionID is the ID of the subscription whose webhook is tested. If WebhookConfig is not set,
+	// the webhook config is retrieved from the project/stage/service of the event
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+	// WebhookConfig is the content of a webhook.yaml file. If it contains multiple webhooks, the webhook matching the
+	// subscription ID, or the type of the event, is tested
+	WebhookConfig string `json:"webhookConfig,omitempty"`
+	// Event is the sample event the requests are rendered with
+	Event models.KeptnContextExtendedCE `json:"event"`
+	// Execute sends the rendered requests to their targets
+	Execute bool `json:"execute,omitempty"`
+}
+
+// DryRunResult contains the rendered requests of a webhook, in which all secrets are masked
+type DryRunResult struct {
+	Type           string `json:"type"`
+	SubscriptionID string `json:"subscriptionID"`
+	// Skipped contains the conditions of the webhook that are not met by the event
+	Skipped  string                `json:"skipped,omitempty"`
+	Requests []DryRunRequestResult `json:"requests"`
+	// FinishedEvent contains the data of the .finished event that would be sent by the webhook service, if the requests have been executed
+	FinishedEvent map[string]interface{} `json:"finishedEvent,omitempty"`
+}
+
+// DryRunRequestResult contains a rendered request, and its response if the requests have been executed
+type DryRunRequestResult struct {
+	// Curl is the rendered curl command of a v1alpha1 request
+	Curl    string         `json:"curl,omitempty"`
+	Method  string         `json:"method,omitempty"`
+	URL     string         `json:"url,omitempty"`
+	Headers []DryRunHeader `json:"headers,omitempty"`
+	Payload string         `json:"payload,omitempty"`
+	// Skipped contains the conditions of the request that are not met by the event. Skipped requests are neither rendered nor executed
+	Skipped string `json:"skipped,omitempty"`
+	// Error contains the reason why the request could not be rendered, was denied, or failed
+	Error    string `json:"error,omitempty"`
+	Executed bool   `json:"executed"`
+	Response string `json:"response,omitempty"`
+}
+
+type DryRunHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// DryRun renders the requests of a webhook for the given event and validates them against the deny list.
+// If requested, the requests are executed and the resulting .finished event data is returned
+func (th *TaskHandler) DryRun(resourceHandler sdk.ResourceHandler, dryRunRequest DryRunRequest) (*DryRunResult, error) {
+	event := sdk.KeptnEvent(dryRunRequest.Event)
+	if event.Type == nil || *event.Type == "" {
+		return nil, errors.New("event type must be set")
+	}
+	eventAdapter, err := lib.NewEventDataAdapter(event)
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := th.getDryRunWebhook(resourceHandler, eventAdapter, dryRunRequest, event)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadPayloadFiles(resourceHandler, eventAdapter, webhook, event.GitCommitID); err != nil {
+		return nil, err
+	}
+	result := &DryRunResult{Type: webhook.Type, SubscriptionID: webhook.SubscriptionID, Requests: []DryRunRequestResult{}}
+	applicableWebhook, err := webhook.ApplyConditions(eventAdapter.Get())
+	if err != nil {
+		result.Skipped = err.Error()
+		return result, nil
+	}
+
+	secretEnvVars, err := th.gatherSecretEnvVars(*webhook)
+	if err != nil {
+		return nil, err
+	}
+	// the requests are rendered with placeholders for the secrets, which are only substituted when executing the requests
+	secretPlaceholders := map[string]string{}
+	for name := range secretEnvVars {
+		secretPlaceholders[name] = dryRunSecretPlaceholder
+	}
+	eventAdapter.Add("env", secretPlaceholders)
+	if webhook.IsAsync() {
+		eventAdapter.Add("callback", map[string]interface{}{"url": dryRunCallbackURL, "token": dryRunCallbackToken})
+	}
+
+	valid := true
+	// applicableRequests contains the indices of the requests whose conditions are met by the event
+	applicableRequests := []int{}
+	for i, req := range webhook.Requests {
+		if request, ok := req.(lib.Request); ok {
+			if err := lib.CheckConditions(request.Conditions, eventAdapter.Get()); err != nil {
+				result.Requests = append(result.Requests, DryRunRequestResult{Method: request.Method, URL: request.URL, Skipped: err.Error()})
+				continue
+			}
+		}
+		requestResult := th.renderDryRunRequest(req, eventAdapter, secretEnvVars)
+		valid = valid && requestResult.Error == ""
+		result.Requests = append(result.Requests, requestResult)
+		applicableRequests = append(applicableRequests, i)
+	}
+	if !dryRunRequest.Execute || !valid {
+		return result, nil
+	}
+
+	eventAdapter.Add("env", secretEnvVars)
+	webhookResult, err := th.performWebhookRequests(applicableWebhook, eventAdapter, secretEnvVars)
+	if err != nil {
+		executedRequests := 0
+		var whe *lib.WebhookExecutionError
+		if errors.As(err, &whe) {
+			executedRequests = whe.ExecutedRequests
+		}
+		// the request following the successfully executed requests is the one that failed
+		for i := 0; i <= executedRequests && i < len(applicableRequests); i++ {
+			result.Requests[applicableRequests[i]].Executed = true
+		}
+		if executedRequests < len(applicableRequests) {
+			result.Requests[applicableRequests[executedRequests]].Error = removeSecretsFromMessage(err.Error(), secretEnvVars)
+		}
+		if isTaskTriggeredEvent(event) && sendsSingleFinishedEvent(*webhook) {
+			result.FinishedEvent = map[string]interface{}{
+				"project": eventAdapter.Project(),
+				"stage":   eventAdapter.Stage(),
+				"service": eventAdapter.Service(),
+				"labels":  eventAdapter.Labels(),
+				"result":  keptnv2.ResultFailed,
+				"status":  keptnv2.StatusErrored,
+				"message": removeSecretsFromMessage(err.Error(), secretEnvVars),
+			}
+		}
+		return result, nil
+	}
+
+	for i, response := range webhookResult.responses {
+		result.Requests[applicableRequests[i]].Executed = true
+		result.Requests[applicableRequests[i]].Response = removeSecretsFromMessage(response, secretEnvVars)
+	}
+	// the .finished event of an async webhook is only sent once the callback has been received
+	if isTaskTriggeredEvent(event) && sendsSingleFinishedEvent(*webhook) && !webhook.IsAsync() {
+		finishedEventData, err := createFinishedEventData(event, eventAdapter, webhookResult)
+		if err != nil {
+			return nil, err
+		}
+		if webhookResult.result != keptnv2.ResultPass {
+			finishedEventData["result"] = webhookResult.result
+			finishedEventData["message"] = removeSecretsFromMessage(webhookResult.message(), secretEnvVars)
+		}
+		result.FinishedEvent = finishedEventData
+	}
+	return result, nil
+}
+
+func (th *TaskHandler) getDryRunWebhook(resourceHandler sdk.ResourceHandler, eventAdapter *lib.EventDataAdapter, dryRunRequest DryRunRequest, event sdk.KeptnEvent) (*lib.Webhook, error) {
+	if dryRunRequest.WebhookConfig == "" {
+		if dryRunRequest.SubscriptionID == "" {
+			return nil, errors.New("either a subscription ID or a webhook config must be provided")
+		}
+		return th.getWebHookConfig(resourceHandler, eventAdapter, dryRunRequest.SubscriptionID, event.GitCommitID)
+	}
+
+	whConfig, err := lib.DecodeWebHookConfigYAML([]byte(dryRunRequest.WebhookConfig))
+	if err != nil {
+		return nil, err
+	}
+	for _, webhook := range whConfig.Spec.Webhooks {
+		if dryRunRequest.SubscriptionID != "" && webhook.SubscriptionID == dryRunRequest.SubscriptionID {
+			return &webhook, nil
+		}
+		if dryRunRequest.SubscriptionID == "" && webhook.Type == *event.Type {
+			return &webhook, nil
+		}
+	}
+	if dryRunRequest.SubscriptionID != "" {
+		return nil, fmt.Errorf("no webhook found for subscription ID '%s'", dryRunRequest.SubscriptionID)
+	}
+	return nil, fmt.Errorf("no webhook found for event type '%s'", *event.Type)
+}
+
+// renderDryRunRequest renders a request with the placeholders of the secrets contained in the event adapter and validates
+// it against the deny list. The request is also validated with the actual secrets, which are never part of the result
+func (th *TaskHandler) renderDryRunRequest(req interface{}, eventAdapter *lib.EventDataAdapter, secrets map[string]string) DryRunRequestResult {
+	requestResult := DryRunRequestResult{}
+	onError := func(err error) DryRunRequestResult {
+		requestResult.Error = removeSecretsFromMessage(err.Error(), secrets)
+		return requestResult
+	}
+	// errors of the request rendered with the actual secrets might contain transformed secrets, and are therefore not returned
+	errDeniedWithSecrets := errors.New("request is denied after substituting the secrets")
+	secretData := map[string]interface{}{}
+	for key, value := range eventAdapter.Get() {
+		secretData[key] = value
+	}
+	secretData["env"] = secrets
+
+	// v1alpha1 requests are rendered before validating them, so that the curl command is shown even if it is denied
+	if curlCmd, ok := req.(string); ok {
+		rendered, err := th.templateEngine.ParseTemplate(eventAdapter.Get(), curlCmd)
+		if err != nil {
+			return onError(fmt.Errorf("could not parse request '%s' : %w", curlCmd, err))
+		}
+		requestResult.Curl = rendered
+		if err := th.validateAlphaCurlRequest(curlCmd); err != nil {
+			return onError(err)
+		}
+		if err := th.validateAlphaCurlRequest(rendered); err != nil {
+			return onError(err)
+		}
+		if renderedWithSecrets, err := th.templateEngine.ParseTemplate(secretData, curlCmd); err != nil || th.validateAlphaCurlRequest(renderedWithSecrets) != nil {
+			return onError(errDeniedWithSecrets)
+		}
+		return requestResult
+	}
+
+	request, err := th.CreateRequest(req)
+	if err != nil {
+		return onError(err)
+	}
+	r, ok := request.(lib.Request)
+	if !ok {
+		return onError(errors.New("invalid request type"))
+	}
+	rendered, err := th.parseRequestTemplate(eventAdapter.Get(), r)
+	if err != nil {
+		return onError(fmt.Errorf("could not parse request '%s' : %w", r, err))
+	}
+	requestResult.Method = rendered.Method
+	requestResult.URL = rendered.URL
+	requestResult.Payload = rendered.Payload
+	for _, header := range rendered.Headers {
+		requestResult.Headers = append(requestResult.Headers, DryRunHeader{Key: header.Key, Value: header.Value})
+	}
+	if _, err := rendered.ResolveCredentials(secrets); err != nil {
+		return onError(err)
+	}
+	if err := th.requestValidator.Validate(rendered); err != nil {
+		return onError(err)
+	}
+	if renderedWithSecrets, err := th.parseRequestTemplate(secretData, r); err != nil || th.requestValidator.Validate(renderedWithSecrets) != nil {
+		return onError(errDeniedWithSecrets)
+	}
+	return requestResult
+}
+
+// DryRunHandler serves the dry run endpoint of the webhook service
+type DryRunHandler struct {
+	taskHandler     *TaskHandler
+	resourceHandler sdk.ResourceHandler
+}
+
+func NewDryRunHandler(taskHandler *TaskHandler, resourceHandler sdk.ResourceHandler) *DryRunHandler {
+	return &DryRunHandler{taskHandler: taskHandler, resourceHandler: resourceHandler}
+}
+
+func (dh *DryRunHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxDryRunPayloadSize))
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "could not read request payload")
+		return
+	}
+	dryRunRequest := DryRunRequest{}
+	if err := json.Unmarshal(body, &dryRunRequest); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "invalid request payload: "+err.Error())
+		return
+	}
+
+	result, err := dh.taskHandler.DryRun(dh.resourceHandler, dryRunRequest)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	logger.Infof("dry run of webhook with subscriptionID %s completed", result.SubscriptionID)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
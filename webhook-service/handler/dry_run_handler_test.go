@@ -0,0 +1,273 @@
+package handler_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/handler"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/require"
+)
+
+const webHookContentDryRun = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      envFrom:
+        - name: token
+          secretRef:
+            name: my-secret
+            key: token
+      requests:
+        - url: SERVER_URL/{{.data.project}}
+          method: POST
+          headers:
+            - key: Authorization
+              value: "Bearer {{.env.token}}"
+          payload: '{"service": "{{.data.service}}"}'
+          payloadMode: json`
+
+const webHookContentDryRun_ALPHA = `apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      envFrom:
+        - name: token
+          secretRef:
+            name: my-secret
+            key: token
+      requests:
+        - "curl http://{{.data.project}}.kubernetes.default/api --header 'x-token: {{.env.token}}'"`
+
+func newDryRunTaskHandler(httpExecutor lib.IHTTPExecutor) *handler.TaskHandler {
+	secretReaderMock := &fake.ISecretReaderMock{ReadSecretFunc: func(name string, key string) (string, error) {
+		return "my-secret-token", nil
+	}}
+	requestValidator := &fake.RequestValidatorMock{
+		ValidateFunc: func(request lib.Request) error {
+			if strings.Contains(request.URL, "denied") {
+				return errors.New("denied url")
+			}
+			return nil
+		},
+		ValidateAddressFunc: func(address string) error { return nil },
+	}
+	return handler.NewTaskHandler(&lib.TemplateEngine{}, &fake.ICurlExecutorMock{}, httpExecutor, requestValidator, secretReaderMock)
+}
+
+func TestTaskHandler_DryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("received " + r.URL.Path))
+	}))
+	defer server.Close()
+	requestValidator := &fake.RequestValidatorMock{
+		ValidateFunc:        func(request lib.Request) error { return nil },
+		ValidateAddressFunc: func(address string) error { return nil },
+	}
+
+	t.Run("render requests without executing them", func(t *testing.T) {
+		taskHandler := newDryRunTaskHandler(&fake.IHTTPExecutorMock{})
+		result, err := taskHandler.DryRun(nil, handler.DryRunRequest{
+			WebhookConfig: strings.Replace(webHookContentDryRun, "SERVER_URL", "http://my-server", 1),
+			Event:         newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"),
+		})
+		require.Nil(t, err)
+		require.Equal(t, &handler.DryRunResult{
+			Type:           "sh.keptn.event.webhook.triggered",
+			SubscriptionID: "my-subscription-id",
+			Requests: []handler.DryRunRequestResult{
+				{
+					Method:  "POST",
+					URL:     "http://my-server/myproject",
+					Headers: []handler.DryRunHeader{{Key: "Authorization", Value: "Bearer ***"}},
+					Payload: `{"service": "myservice"}`,
+				},
+			},
+		}, result)
+	})
+
+	t.Run("transformed secrets are rendered as placeholders", func(t *testing.T) {
+		var sentAuthorization string
+		httpExecutor := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+			sentAuthorization = request.Headers[0].Value
+			return &lib.HTTPResponse{Body: "ok"}, nil
+		}}
+		taskHandler := newDryRunTaskHandler(httpExecutor)
+		webhookConfig := strings.Replace(webHookContentDryRun, "SERVER_URL", "http://my-server", 1)
+		webhookConfig = strings.Replace(webhookConfig, "Bearer {{.env.token}}", "Basic {{.env.token | upper | b64enc}}", 1)
+		result, err := taskHandler.DryRun(nil, handler.DryRunRequest{
+			WebhookConfig: webhookConfig,
+			Event:         newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"),
+			Execute:       true,
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Requests, 1)
+		require.True(t, result.Requests[0].Executed)
+		require.Equal(t, []handler.DryRunHeader{{Key: "Authorization", Value: "Basic " + base64.StdEncoding.EncodeToString([]byte("***"))}}, result.Requests[0].Headers)
+		// only the executed request contains the actual secret
+		require.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("MY-SECRET-TOKEN")), sentAuthorization)
+	})
+
+	t.Run("request denied after substituting the secrets", func(t *testing.T) {
+		taskHandler := newDryRunTaskHandler(&fake.IHTTPExecutorMock{})
+		webhookConfig := strings.Replace(webHookContentDryRun, "SERVER_URL", "http://{{.env.token | replace \"my-secret-token\" \"DENIED\" | lower}}", 1)
+		result, err := taskHandler.DryRun(nil, handler.DryRunRequest{
+			WebhookConfig: webhookConfig,
+			Event:         newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"),
+			Execute:       true,
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Requests, 1)
+		require.Equal(t, "http://***/myproject", result.Requests[0].URL)
+		require.Equal(t, "request is denied after substituting the secrets", result.Requests[0].Error)
+		require.False(t, result.Requests[0].Executed)
+	})
+
+	t.Run("denied request", func(t *testing.T) {
+		taskHandler := newDryRunTaskHandler(&fake.IHTTPExecutorMock{})
+		result, err := taskHandler.DryRun(nil, handler.DryRunRequest{
+			WebhookConfig: strings.Replace(webHookContentDryRun, "SERVER_URL", "http://denied", 1),
+			Event:         newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"),
+			Execute:       true,
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Requests, 1)
+		require.Equal(t, "denied url", result.Requests[0].Error)
+		require.False(t, result.Requests[0].Executed)
+		require.Nil(t, result.FinishedEvent)
+	})
+
+	t.Run("denied alpha request", func(t *testing.T) {
+		taskHandler := newDryRunTaskHandler(&fake.IHTTPExecutorMock{})
+		result, err := taskHandler.DryRun(nil, handler.DryRunRequest{
+			WebhookConfig: webHookContentDryRun_ALPHA,
+			Event:         newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"),
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Requests, 1)
+		require.Equal(t, "curl http://myproject.kubernetes.default/api --header 'x-token: ***'", result.Requests[0].Curl)
+		require.Equal(t, "curl command contains denied URL 'kubernetes'", result.Requests[0].Error)
+	})
+
+	t.Run("execute requests of webhook config of the resource service", func(t *testing.T) {
+		taskHandler := newDryRunTaskHandler(lib.NewHTTPExecutor(requestValidator))
+		resourceHandler := sdk.StringResourceHandler{ResourceContent: strings.Replace(webHookContentDryRun, "SERVER_URL", server.URL, 1)}
+		result, err := taskHandler.DryRun(resourceHandler, handler.DryRunRequest{
+			SubscriptionID: "my-subscription-id",
+			Event:          newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"),
+			Execute:        true,
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Requests, 1)
+		require.True(t, result.Requests[0].Executed)
+		require.Equal(t, "received /myproject", result.Requests[0].Response)
+		require.Equal(t, map[string]interface{}{
+			"project": "myproject",
+			"stage":   "mystage",
+			"service": "myservice",
+			"labels":  map[string]string(nil),
+			"webhook": map[string]interface{}{"responses": []string{"received /myproject"}},
+		}, result.FinishedEvent)
+	})
+
+	t.Run("failed request", func(t *testing.T) {
+		httpExecutor := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+			return nil, errors.New("unauthorized request with token my-secret-token")
+		}}
+		taskHandler := newDryRunTaskHandler(httpExecutor)
+		result, err := taskHandler.DryRun(nil, handler.DryRunRequest{
+			WebhookConfig: strings.Replace(webHookContentDryRun, "SERVER_URL", "http://my-server", 1),
+			Event:         newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"),
+			Execute:       true,
+		})
+		require.Nil(t, err)
+		require.True(t, result.Requests[0].Executed)
+		require.Contains(t, result.Requests[0].Error, "unauthorized request with token ***")
+		require.Equal(t, keptnv2.ResultFailed, result.FinishedEvent["result"])
+		require.Equal(t, keptnv2.StatusErrored, result.FinishedEvent["status"])
+		require.NotContains(t, result.FinishedEvent["message"], "my-secret-token")
+	})
+
+	t.Run("conditions", func(t *testing.T) {
+		taskHandler := newDryRunTaskHandler(lib.NewHTTPExecutor(requestValidator))
+		result, err := taskHandler.DryRun(nil, handler.DryRunRequest{
+			WebhookConfig: strings.Replace(strings.Replace(webHookContentWithConditions, "WEBHOOK_PROJECT", "myproject", 1), "http://mystage", server.URL, 1),
+			Event:         newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"),
+			Execute:       true,
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Requests, 2)
+		require.Equal(t, "conditions not met: '$.data.stage' is 'mystage' instead of 'production'", result.Requests[0].Skipped)
+		require.False(t, result.Requests[0].Executed)
+		require.Empty(t, result.Requests[1].Skipped)
+		require.True(t, result.Requests[1].Executed)
+		require.Equal(t, "received /", result.Requests[1].Response)
+
+		result, err = taskHandler.DryRun(nil, handler.DryRunRequest{
+			WebhookConfig: strings.Replace(webHookContentWithConditions, "WEBHOOK_PROJECT", "otherproject", 1),
+			Event:         newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"),
+		})
+		require.Nil(t, err)
+		require.Equal(t, "conditions not met: '$.data.project' is 'myproject' instead of 'otherproject'", result.Skipped)
+		require.Empty(t, result.Requests)
+	})
+
+	t.Run("no matching webhook", func(t *testing.T) {
+		taskHandler := newDryRunTaskHandler(&fake.IHTTPExecutorMock{})
+		_, err := taskHandler.DryRun(nil, handler.DryRunRequest{
+			SubscriptionID: "unknown",
+			WebhookConfig:  webHookContentDryRun,
+			Event:          newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"),
+		})
+		require.EqualError(t, err, "no webhook found for subscription ID 'unknown'")
+
+		_, err = taskHandler.DryRun(nil, handler.DryRunRequest{
+			Event: newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"),
+		})
+		require.EqualError(t, err, "either a subscription ID or a webhook config must be provided")
+	})
+}
+
+func TestDryRunHandler_ServeHTTP(t *testing.T) {
+	dryRunHandler := handler.NewDryRunHandler(newDryRunTaskHandler(&fake.IHTTPExecutorMock{}), nil)
+	send := func(method string, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		dryRunHandler.ServeHTTP(recorder, httptest.NewRequest(method, handler.DryRunPath, strings.NewReader(body)))
+		return recorder
+	}
+
+	require.Equal(t, http.StatusMethodNotAllowed, send(http.MethodGet, "").Code)
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPost, "{invalid").Code)
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPost, `{"event": {"data": {}}}`).Code)
+
+	dryRunRequest := handler.DryRunRequest{
+		WebhookConfig: strings.Replace(webHookContentDryRun, "SERVER_URL", "http://my-server", 1),
+		Event:         newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"),
+	}
+	body, err := json.Marshal(dryRunRequest)
+	require.Nil(t, err)
+	response := send(http.MethodPost, string(body))
+	require.Equal(t, http.StatusOK, response.Code)
+
+	result := handler.DryRunResult{}
+	require.Nil(t, json.Unmarshal(response.Body.Bytes(), &result))
+	require.Equal(t, "my-subscription-id", result.SubscriptionID)
+	require.Equal(t, "http://my-server/myproject", result.Requests[0].URL)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	keptn "github.com/keptn/go-utils/pkg/api/utils"
 
@@ -16,6 +17,7 @@ import (
 )
 
 const webhookConfigFileName = "webhook/webhook.yaml"
+const callbackTokenKey = "callbackToken"
 
 type SecretEnv struct {
 	Env map[string]string
@@ -24,17 +26,33 @@ type SecretEnv struct {
 type TaskHandler struct {
 	templateEngine   lib.ITemplateEngine
 	curlExecutor     lib.ICurlExecutor
+	httpExecutor     lib.IHTTPExecutor
 	requestValidator lib.RequestValidator
 	secretReader     lib.ISecretReader
+	callbackRegistry *lib.CallbackRegistry
 }
 
-func NewTaskHandler(templateEngine lib.ITemplateEngine, curlExecutor lib.ICurlExecutor, requestValidator lib.RequestValidator, secretReader lib.ISecretReader) *TaskHandler {
-	return &TaskHandler{
+type TaskHandlerOption func(th *TaskHandler)
+
+// WithCallbackRegistry enables async webhooks, which wait for callbacks registered at the given registry
+func WithCallbackRegistry(callbackRegistry *lib.CallbackRegistry) TaskHandlerOption {
+	return func(th *TaskHandler) {
+		th.callbackRegistry = callbackRegistry
+	}
+}
+
+func NewTaskHandler(templateEngine lib.ITemplateEngine, curlExecutor lib.ICurlExecutor, httpExecutor lib.IHTTPExecutor, requestValidator lib.RequestValidator, secretReader lib.ISecretReader, opts ...TaskHandlerOption) *TaskHandler {
+	th := &TaskHandler{
 		templateEngine:   templateEngine,
 		curlExecutor:     curlExecutor,
+		httpExecutor:     httpExecutor,
 		requestValidator: requestValidator,
 		secretReader:     secretReader,
 	}
+	for _, opt := range opts {
+		opt(th)
+	}
+	return th
 }
 
 func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (interface{}, *sdk.Error) {
@@ -48,14 +66,26 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 		logger.Infof("will not handle event: %s", err.Error())
 		return nil, nil
 	}
-	webhook, err := th.getWebHookConfig(keptnHandler, eventAdapter, subscriptionID, event.GitCommitID)
+	webhook, err := th.getWebHookConfig(keptnHandler.GetResourceHandler(), eventAdapter, subscriptionID, event.GitCommitID)
 	if err != nil {
 		err = fmt.Errorf("could not retrieve Webhook config: %w", err)
 		th.onPreExecutionError(keptnHandler, event, eventAdapter, err)
 		return nil, sdkError(err.Error(), err)
 	}
 
-	responses := []string{}
+	// only the requests whose conditions are met by the event are executed
+	applicableWebhook, err := webhook.ApplyConditions(eventAdapter.Get())
+	if err != nil {
+		logger.Infof("skipping webhook of subscriptionID %s: %s", subscriptionID, err.Error())
+		th.onWebhookSkipped(keptnHandler, event, eventAdapter, err)
+		return nil, nil
+	}
+	webhook = &applicableWebhook
+
+	if err := loadPayloadFiles(keptnHandler.GetResourceHandler(), eventAdapter, webhook, event.GitCommitID); err != nil {
+		th.onPreExecutionError(keptnHandler, event, eventAdapter, err)
+		return nil, sdkError(err.Error(), err)
+	}
 
 	if sdkErr := th.onStartedWebhookExecution(keptnHandler, event, webhook); sdkErr != nil {
 		return nil, sdkErr
@@ -69,27 +99,58 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 		return nil, sdkError(removeSecretsFromMessage(err.Error(), secretEnvVars), err)
 	}
 	eventAdapter.Add("env", secretEnvVars)
-	responses, err = th.performWebhookRequests(*webhook, eventAdapter, responses)
+
+	// the callback token must not be revealed in error messages either
+	sensitiveValues := map[string]string{}
+	for name, value := range secretEnvVars {
+		sensitiveValues[name] = value
+	}
+	callback, err := th.registerCallback(*webhook, event, eventAdapter)
 	if err != nil {
 		onError(err, secretEnvVars)
-		return nil, sdkError(removeSecretsFromMessage(err.Error(), secretEnvVars), err)
+		return nil, sdkError(err.Error(), err)
+	}
+	if callback != nil {
+		sensitiveValues[callbackTokenKey] = callback.Token
+	}
+
+	webhookResult, err := th.performWebhookRequests(*webhook, eventAdapter, secretEnvVars)
+	if err != nil {
+		th.cancelCallback(callback)
+		onError(err, sensitiveValues)
+		return nil, sdkError(removeSecretsFromMessage(err.Error(), sensitiveValues), err)
+	}
+	message := removeSecretsFromMessage(webhookResult.message(), sensitiveValues)
+
+	if callback != nil && webhookResult.result != keptnv2.ResultFailed {
+		// the .finished event is sent once the called system reports its result, or the deadline is exceeded
+		logger.Infof("waiting for callback %s of subscriptionID %s", callback.ID, webhook.SubscriptionID)
+		result, err := createFinishedEventData(event, eventAdapter, webhookResult)
+		if err == nil {
+			result["result"] = webhookResult.result
+			result["message"] = message
+			err = th.callbackRegistry.Await(callback.ID, result)
+		}
+		if err != nil {
+			th.cancelCallback(callback)
+			err = lib.NewWebhookExecutionError(true, fmt.Errorf("could not await callback: %w", err))
+			onError(err, sensitiveValues)
+			return nil, sdkError(err.Error(), err)
+		}
+		return nil, nil
 	}
+	th.cancelCallback(callback)
 
 	// check if the incoming event was a task.triggered event, and if the 'sendFinished'  property of the webhook was set to true
 	// only in this case, the result should be sent back to Keptn in the form of a .finished event
-	if keptnv2.IsTaskEventType(*event.Type) && keptnv2.IsTriggeredEventType(*event.Type) && webhook.ShouldSendFinishedEvent() {
-		taskName, _, err := keptnv2.ParseTaskEventType(*event.Type)
+	if isTaskTriggeredEvent(event) && sendsSingleFinishedEvent(*webhook) {
+		result, err := createFinishedEventData(event, eventAdapter, webhookResult)
 		if err != nil {
-			return nil, sdkError(fmt.Sprintf("could not derive task name from event type %s", *event.Type), err)
+			return nil, sdkError(err.Error(), err)
 		}
-		result := map[string]interface{}{
-			"project": eventAdapter.Project(),
-			"stage":   eventAdapter.Stage(),
-			"service": eventAdapter.Service(),
-			"labels":  eventAdapter.Labels(),
-			taskName: map[string]interface{}{
-				"responses": responses,
-			},
+		if webhookResult.result != keptnv2.ResultPass {
+			result["result"] = webhookResult.result
+			result["message"] = message
 		}
 		err = keptnHandler.SendFinishedEvent(event, result)
 		if err != nil {
@@ -98,9 +159,122 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 		return result, nil
 	}
 
+	if webhookResult.result == keptnv2.ResultFailed {
+		// the failed request, as well as the requests that have not been executed, will not send a .finished event
+		err := lib.NewWebhookExecutionError(true, errors.New(message), lib.WithNrOfExecutedRequests(webhookResult.executedRequests-1))
+		onError(err, sensitiveValues)
+		return nil, sdkError(message, err)
+	}
+
 	return nil, nil
 }
 
+// registerCallback registers the callback of an async webhook and makes its URL and token available to the templates of the requests
+func (th *TaskHandler) registerCallback(webhook lib.Webhook, event sdk.KeptnEvent, eventAdapter *lib.EventDataAdapter) (*lib.Callback, error) {
+	// only <task>.triggered events are answered with a .finished event
+	if !webhook.IsAsync() || !isTaskTriggeredEvent(event) {
+		return nil, nil
+	}
+	if th.callbackRegistry == nil {
+		return nil, lib.NewWebhookExecutionError(true, errors.New("async webhooks are not supported by this webhook service"))
+	}
+	// if the webhook service is restarted while the requests are executed, the expired callback only reports the error
+	finishedEventData := map[string]interface{}{
+		"project": eventAdapter.Project(),
+		"stage":   eventAdapter.Stage(),
+		"service": eventAdapter.Service(),
+		"labels":  eventAdapter.Labels(),
+	}
+	callback, err := th.callbackRegistry.Register(event, finishedEventData, webhook.Async.GetDeadline())
+	if err != nil {
+		return nil, lib.NewWebhookExecutionError(true, err)
+	}
+	eventAdapter.Add("callback", map[string]interface{}{
+		"url":   callback.URL,
+		"token": callback.Token,
+	})
+	return callback, nil
+}
+
+func (th *TaskHandler) cancelCallback(callback *lib.Callback) {
+	if callback != nil {
+		th.callbackRegistry.Cancel(callback.ID)
+	}
+}
+
+// StartCallbacks sends the .finished events of async webhooks via the given handler once their callbacks have been
+// received or have expired, including the callbacks that have been registered before the webhook service was restarted
+func (th *TaskHandler) StartCallbacks(keptnHandler sdk.IKeptn) error {
+	if th.callbackRegistry == nil {
+		return nil
+	}
+	return th.callbackRegistry.Start(func(event sdk.KeptnEvent, finishedEventData map[string]interface{}, callbackResult lib.CallbackResult) {
+		th.onCallbackReceived(keptnHandler, event, finishedEventData, callbackResult)
+	})
+}
+
+func (th *TaskHandler) onCallbackReceived(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, result map[string]interface{}, callbackResult lib.CallbackResult) {
+	if taskData, ok := result[taskDataKey(event)].(map[string]interface{}); ok {
+		for name, value := range callbackResult.Data {
+			if name != "responses" && name != "attempts" {
+				taskData[name] = value
+			}
+		}
+	}
+	webhookMessage, _ := result["message"].(string)
+	messages := []string{}
+	for _, m := range []string{webhookMessage, callbackResult.Message} {
+		if m != "" {
+			messages = append(messages, m)
+		}
+	}
+	webhookResult, _ := result["result"].(string)
+	result["result"] = lib.WorseResult(keptnv2.ResultType(webhookResult), callbackResult.Result)
+	result["status"] = callbackResult.Status
+	result["message"] = strings.Join(messages, "; ")
+	th.sendFinishedEvent(keptnHandler, event, result)
+}
+
+func createFinishedEventData(event sdk.KeptnEvent, eventAdapter *lib.EventDataAdapter, webhookResult *webhookResult) (map[string]interface{}, error) {
+	taskName := taskDataKey(event)
+	if taskName == "" {
+		return nil, fmt.Errorf("could not derive task name from event type %s", *event.Type)
+	}
+	taskData := map[string]interface{}{}
+	for name, value := range webhookResult.properties {
+		taskData[name] = value
+	}
+	taskData["responses"] = webhookResult.responses
+	if webhookResult.reportAttempts {
+		taskData["attempts"] = webhookResult.attempts
+	}
+	return map[string]interface{}{
+		"project": eventAdapter.Project(),
+		"stage":   eventAdapter.Stage(),
+		"service": eventAdapter.Service(),
+		"labels":  eventAdapter.Labels(),
+		taskName:  taskData,
+	}, nil
+}
+
+// taskDataKey returns the name of the task, which is the key of the task specific data within the .finished event
+func taskDataKey(event sdk.KeptnEvent) string {
+	taskName, _, err := keptnv2.ParseTaskEventType(*event.Type)
+	if err != nil {
+		return ""
+	}
+	return taskName
+}
+
+func isTaskTriggeredEvent(event sdk.KeptnEvent) bool {
+	return keptnv2.IsTaskEventType(*event.Type) && keptnv2.IsTriggeredEventType(*event.Type)
+}
+
+// sendsSingleFinishedEvent returns true if the webhook service sends one .finished event containing the results of all requests
+func sendsSingleFinishedEvent(webhook lib.Webhook) bool {
+	return webhook.ShouldSendFinishedEvent() || webhook.IsAsync()
+}
+
 func (th *TaskHandler) onPreExecutionError(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, eventAdapter *lib.EventDataAdapter, err error) {
 	// only send .started events for <task>.triggered events
 	if !keptnv2.IsTaskEventType(*event.Type) || !keptnv2.IsTriggeredEventType(*event.Type) {
@@ -123,6 +297,27 @@ func (th *TaskHandler) onPreExecutionError(keptnHandler sdk.IKeptn, event sdk.Ke
 	th.sendFinishedEvent(keptnHandler, event, result)
 }
 
+// onWebhookSkipped completes the task of a skipped webhook, since no other service will send a .finished event for it
+func (th *TaskHandler) onWebhookSkipped(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, eventAdapter *lib.EventDataAdapter, reason error) {
+	if !isTaskTriggeredEvent(event) {
+		return
+	}
+	if err := keptnHandler.SendStartedEvent(event); err != nil {
+		logger.WithError(err).Error("could not send .started event")
+		return
+	}
+	result := map[string]interface{}{
+		"project": eventAdapter.Project(),
+		"stage":   eventAdapter.Stage(),
+		"service": eventAdapter.Service(),
+		"labels":  eventAdapter.Labels(),
+		"result":  keptnv2.ResultPass,
+		"status":  keptnv2.StatusSucceeded,
+		"message": "webhook skipped: " + reason.Error(),
+	}
+	th.sendFinishedEvent(keptnHandler, event, result)
+}
+
 func (th *TaskHandler) getErrorCallbackForWebhookConfig(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, eventAdapter *lib.EventDataAdapter, webhook *lib.Webhook) func(err error, secrets map[string]string) {
 	return func(err error, secrets map[string]string) {
 		logger.WithError(err).Error("error during webhook execution")
@@ -139,7 +334,7 @@ func (th *TaskHandler) getErrorCallbackForWebhookConfig(keptnHandler sdk.IKeptn,
 		}
 
 		if ok && whe.PreExecutionError {
-			if webhook.ShouldSendFinishedEvent() {
+			if sendsSingleFinishedEvent(*webhook) {
 				// if sendFinished is set, we only need to send one started event
 				// the webhook service will then send a correlating .finished event with the aggregated response payloads
 				th.sendFinishedEvent(keptnHandler, event, result)
@@ -182,8 +377,8 @@ func (th *TaskHandler) onStartedWebhookExecution(keptnHandler sdk.IKeptn, event
 	if !webhook.ShouldSendStartedEvent() {
 		return nil
 	}
-	// check if 'sendFinished' is set to true
-	if webhook.ShouldSendFinishedEvent() {
+	// check if 'sendFinished' is set to true, or if the webhook is async
+	if sendsSingleFinishedEvent(*webhook) {
 		// if sendFinished is set, we only need to send one started event
 		// the webhook service will then send a correlating .finished event with the aggregated response payloads
 		if err := keptnHandler.SendStartedEvent(event); err != nil {
@@ -200,29 +395,166 @@ func (th *TaskHandler) onStartedWebhookExecution(keptnHandler sdk.IKeptn, event
 	return nil
 }
 
-func (th *TaskHandler) performWebhookRequests(webhook lib.Webhook, eventAdapter *lib.EventDataAdapter, responses []string) ([]string, error) {
-	executedRequests := 0
+// webhookResult contains the aggregated outcome of the requests of a webhook
+type webhookResult struct {
+	responses        []string
+	result           keptnv2.ResultType
+	messages         []string
+	properties       map[string]interface{}
+	executedRequests int
+	// attempts contains the number of attempts of each executed request, which are reported if a request has a retry config
+	attempts       []int
+	reportAttempts bool
+}
+
+func (wr *webhookResult) addEvaluation(evaluation lib.ResponseEvaluation) {
+	wr.result = lib.WorseResult(wr.result, evaluation.Result)
+	wr.messages = append(wr.messages, evaluation.Messages...)
+	for name, value := range evaluation.Properties {
+		wr.properties[name] = value
+	}
+}
+
+func (wr *webhookResult) message() string {
+	return strings.Join(wr.messages, "; ")
+}
+
+func (th *TaskHandler) performWebhookRequests(webhook lib.Webhook, eventAdapter *lib.EventDataAdapter, secrets map[string]string) (*webhookResult, error) {
+	result := &webhookResult{
+		responses:  []string{},
+		result:     keptnv2.ResultPass,
+		properties: map[string]interface{}{},
+	}
 	logger.Infof("executing webhooks for subscriptionID %s", webhook.SubscriptionID)
 	for _, req := range webhook.Requests {
 		request, err := th.CreateRequest(req)
 		if err != nil {
-			logger.Infof("creating CURL request failed: %s", err.Error())
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("creating CURL request failed: %s", err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+			logger.Infof("creating request failed: %s", err.Error())
+			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("creating request failed: %s", err.Error()), lib.WithNrOfExecutedRequests(result.executedRequests))
+		}
+		if r, ok := request.(lib.Request); ok && r.Retry != nil {
+			result.reportAttempts = true
+		}
+		response, evaluation, attempts, err := th.performWebhookRequest(request, eventAdapter, secrets)
+		if err != nil {
+			return nil, lib.NewWebhookExecutionError(true, err, lib.WithNrOfExecutedRequests(result.executedRequests))
+		}
+		result.executedRequests = result.executedRequests + 1
+		result.responses = append(result.responses, response)
+		result.attempts = append(result.attempts, attempts)
+		if evaluation != nil {
+			result.addEvaluation(*evaluation)
 		}
+		// the remaining requests are not executed if the response of a request did not meet its expectations
+		if result.result == keptnv2.ResultFailed {
+			logger.Infof("response of request %d did not meet its expectations: %s", result.executedRequests, result.message())
+			break
+		}
+	}
+	return result, nil
+}
+
+// performWebhookRequest executes the request and returns its response and the number of attempts, as well as the evaluation of the response for v1beta1 requests
+func (th *TaskHandler) performWebhookRequest(request interface{}, eventAdapter *lib.EventDataAdapter, secrets map[string]string) (string, *lib.ResponseEvaluation, int, error) {
+	switch req := request.(type) {
+	// v1alpha1 requests are curl commands
+	case string:
 		// parse the data from the event, together with the secret env vars
-		parsedCurlCommand, err := th.templateEngine.ParseTemplate(eventAdapter.Get(), request)
+		parsedCurlCommand, err := th.templateEngine.ParseTemplate(eventAdapter.Get(), req)
 		if err != nil {
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not parse request '%s' : %s", request, err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+			return "", nil, 0, fmt.Errorf("could not parse request '%s' : %s", req, err.Error())
 		}
-		// perform the request
 		response, err := th.curlExecutor.Curl(parsedCurlCommand)
 		if err != nil {
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not execute request '%s': %s", request, err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+			return "", nil, 1, fmt.Errorf("could not execute request '%s': %s", req, err.Error())
+		}
+		return response, nil, 1, nil
+	// v1beta1 requests are executed by the native HTTP client
+	case lib.Request:
+		parsedRequest, err := th.parseRequestTemplate(eventAdapter.Get(), req)
+		if err != nil {
+			return "", nil, 0, fmt.Errorf("could not parse request '%s' : %s", req, err.Error())
+		}
+		// the signing key and certificates are taken from the secrets of the webhook, and are never rendered into templates
+		parsedRequest, err = parsedRequest.ResolveCredentials(secrets)
+		if err != nil {
+			return "", nil, 0, fmt.Errorf("could not prepare request '%s': %s", req, err.Error())
+		}
+		response, attempts, err := th.executeWithRetry(parsedRequest)
+		if err != nil {
+			if attempts > 1 {
+				return "", nil, attempts, fmt.Errorf("could not execute request '%s' after %d attempts: %s", req, attempts, err.Error())
+			}
+			return "", nil, attempts, fmt.Errorf("could not execute request '%s': %s", req, err.Error())
+		}
+		evaluation := req.Response.Evaluate(*response)
+		if attempts > 1 && evaluation.Result != keptnv2.ResultPass {
+			evaluation.Messages = append([]string{fmt.Sprintf("request '%s' took %d attempts", req, attempts)}, evaluation.Messages...)
 		}
-		executedRequests = executedRequests + 1
-		responses = append(responses, response)
+		return response.Body, &evaluation, attempts, nil
 	}
-	return responses, nil
+	return "", nil, 0, fmt.Errorf("could not execute request: invalid request type")
+}
+
+// executeWithRetry executes the request until it succeeds, its outcome is not retryable, or the attempts of its retry config are exhausted
+func (th *TaskHandler) executeWithRetry(request lib.Request) (*lib.HTTPResponse, int, error) {
+	if request.Retry != nil && request.Retry.IdempotencyHeader != "" {
+		// the key is identical for all attempts, so that the called system can detect repeated requests
+		key, err := lib.NewIdempotencyKey()
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not create idempotency key: %w", err)
+		}
+		request.Headers = append(request.Headers, lib.Header{Key: request.Retry.IdempotencyHeader, Value: key})
+	}
+	maxAttempts := request.Retry.GetAttempts()
+	for attempt := 1; ; attempt++ {
+		response, err := th.httpExecutor.Execute(request)
+		if attempt >= maxAttempts || !request.Retry.IsRetryable(response, err, request.Response) {
+			return response, attempt, err
+		}
+		backoff := request.Retry.GetBackoff(attempt)
+		logger.Infof("attempt %d of %d for request '%s' failed, retrying in %s", attempt, maxAttempts, request, backoff)
+		time.Sleep(backoff)
+	}
+}
+
+// parseRequestTemplate renders all templated properties of a v1beta1 request
+func (th *TaskHandler) parseRequestTemplate(data interface{}, request lib.Request) (lib.Request, error) {
+	parse := func(value string) (string, error) {
+		if value == "" {
+			return value, nil
+		}
+		return th.templateEngine.ParseTemplate(data, value)
+	}
+
+	parsedRequest := request
+	parsedRequest.Headers = make([]lib.Header, len(request.Headers))
+	var err error
+	if parsedRequest.URL, err = parse(request.URL); err != nil {
+		return lib.Request{}, err
+	}
+	for i, header := range request.Headers {
+		if parsedRequest.Headers[i].Key, err = parse(header.Key); err != nil {
+			return lib.Request{}, err
+		}
+		if parsedRequest.Headers[i].Value, err = parse(header.Value); err != nil {
+			return lib.Request{}, err
+		}
+	}
+	if request.PayloadMode == lib.PayloadModeJSON && request.Payload != "" {
+		if parsedRequest.Payload, err = th.templateEngine.ParseJSONTemplate(data, request.Payload); err != nil {
+			return lib.Request{}, err
+		}
+	} else if parsedRequest.Payload, err = parse(request.Payload); err != nil {
+		return lib.Request{}, err
+	}
+	if parsedRequest.Options, err = parse(request.Options); err != nil {
+		return lib.Request{}, err
+	}
+	if parsedRequest.Timeout, err = parse(request.Timeout); err != nil {
+		return lib.Request{}, err
+	}
+	return parsedRequest, nil
 }
 
 func (th *TaskHandler) gatherSecretEnvVars(webhook lib.Webhook) (map[string]string, error) {
@@ -230,36 +562,38 @@ func (th *TaskHandler) gatherSecretEnvVars(webhook lib.Webhook) (map[string]stri
 	for _, secretRef := range webhook.EnvFrom {
 		secretValue, err := th.secretReader.ReadSecret(secretRef.SecretRef.Name, secretRef.SecretRef.Key)
 		if err != nil {
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not read secret %s.%s", secretRef.SecretRef.Name, secretRef.SecretRef.Key))
+			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not read secret %s.%s: %w", secretRef.SecretRef.Name, secretRef.SecretRef.Key, err))
 		}
 		secretEnvVars[secretRef.Name] = secretValue
 	}
 	return secretEnvVars, nil
 }
 
-func (th *TaskHandler) CreateRequest(request interface{}) (string, error) {
+// CreateRequest validates a request of a webhook config and returns either the curl command of a v1alpha1 request,
+// or the lib.Request of a v1beta1 request
+func (th *TaskHandler) CreateRequest(request interface{}) (interface{}, error) {
 	switch req := request.(type) {
 	// v1alpha1 version
 	case string:
 		logger.Debug("creating CURL request from type string")
 		if err := th.validateAlphaCurlRequest(req); err != nil {
-			return "", err
+			return nil, err
 		}
 		return req, nil
 	// v1beta1 version
 	default:
-		logger.Debug("creating CURL request from type Request")
+		logger.Debug("creating HTTP request from type Request")
 		convertedRequest := lib.ConvertToRequest(request)
-		if err := th.requestValidator.Validate(convertedRequest); err != nil {
-			return "", err
+		if convertedRequest.URL == "" && convertedRequest.Method == "" {
+			break
 		}
-		betaRequest := buildBetaCurlRequest(convertedRequest)
-		if betaRequest != "" {
-			return betaRequest, nil
+		if err := th.requestValidator.Validate(convertedRequest); err != nil {
+			return nil, err
 		}
+		return convertedRequest, nil
 	}
 
-	return "", fmt.Errorf("could not create request: invalid request type")
+	return nil, fmt.Errorf("could not create request: invalid request type")
 }
 
 func (th *TaskHandler) validateAlphaCurlRequest(curlCmd string) error {
@@ -273,28 +607,6 @@ func (th *TaskHandler) validateAlphaCurlRequest(curlCmd string) error {
 	return nil
 }
 
-func buildBetaCurlRequest(req lib.Request) string {
-	tmpReq := ""
-	if req.Method != "" {
-		tmpReq = fmt.Sprintf("curl --request %s", req.Method)
-	}
-	if len(req.Headers) > 0 {
-		for _, header := range req.Headers {
-			tmpReq = fmt.Sprintf(tmpReq+" --header '%s: %s'", header.Key, header.Value)
-		}
-	}
-	if req.Payload != "" {
-		tmpReq = fmt.Sprintf(tmpReq+" --data '%s'", req.Payload)
-	}
-	if req.Options != "" {
-		tmpReq = fmt.Sprintf(tmpReq+" %s", req.Options)
-	}
-	if req.URL != "" {
-		tmpReq = fmt.Sprintf(tmpReq+" %s", req.URL)
-	}
-	return tmpReq
-}
-
 func sdkError(msg string, err error) *sdk.Error {
 	return &sdk.Error{
 		StatusType: keptnv2.StatusErrored,
@@ -304,14 +616,14 @@ func sdkError(msg string, err error) *sdk.Error {
 	}
 }
 
-func (th *TaskHandler) getWebHookConfig(keptnHandler sdk.IKeptn, eventAdapter *lib.EventDataAdapter, subscriptionID string, commitID string) (*lib.Webhook, error) {
+func (th *TaskHandler) getWebHookConfig(resourceHandler sdk.ResourceHandler, eventAdapter *lib.EventDataAdapter, subscriptionID string, commitID string) (*lib.Webhook, error) {
 	// first try to retrieve the webhook config at the service level
 	commitOption := url.Values{}
 	if commitID != "" {
 		commitOption.Add("commitID", commitID)
 	}
 	resourceScope := *keptn.NewResourceScope().Project(eventAdapter.Project()).Stage(eventAdapter.Stage()).Service(eventAdapter.Service()).Resource(webhookConfigFileName)
-	resource, err := keptnHandler.GetResourceHandler().GetResource(resourceScope, keptn.AppendQuery(commitOption))
+	resource, err := resourceHandler.GetResource(resourceScope, keptn.AppendQuery(commitOption))
 	logger.Debug("searching for webhook config at service level...")
 	if err == nil && resource != nil {
 		if matchingWebhook := getMatchingWebhookFromResource(resource, subscriptionID); matchingWebhook != nil {
@@ -321,7 +633,7 @@ func (th *TaskHandler) getWebHookConfig(keptnHandler sdk.IKeptn, eventAdapter *l
 
 	// if we didn't find a config in the service directory, look at the stage
 	resourceScope = *keptn.NewResourceScope().Project(eventAdapter.Project()).Stage(eventAdapter.Stage()).Resource(webhookConfigFileName)
-	resource, err = keptnHandler.GetResourceHandler().GetResource(resourceScope, keptn.AppendQuery(commitOption))
+	resource, err = resourceHandler.GetResource(resourceScope, keptn.AppendQuery(commitOption))
 	logger.Debug("searching for webhook config at stage level...")
 	if err == nil && resource != nil {
 		if matchingWebhook := getMatchingWebhookFromResource(resource, subscriptionID); matchingWebhook != nil {
@@ -331,7 +643,7 @@ func (th *TaskHandler) getWebHookConfig(keptnHandler sdk.IKeptn, eventAdapter *l
 
 	// finally, look at project level
 	resourceScope = *keptn.NewResourceScope().Project(eventAdapter.Project()).Resource(webhookConfigFileName)
-	resource, err = keptnHandler.GetResourceHandler().GetResource(resourceScope, keptn.AppendQuery(commitOption))
+	resource, err = resourceHandler.GetResource(resourceScope, keptn.AppendQuery(commitOption))
 	logger.Debug("searching for webhook config at project level...")
 	if err == nil && resource != nil {
 		if matchingWebhook := getMatchingWebhookFromResource(resource, subscriptionID); matchingWebhook != nil {
@@ -344,6 +656,32 @@ func (th *TaskHandler) getWebHookConfig(keptnHandler sdk.IKeptn, eventAdapter *l
 	return nil, errors.New("no webhook config found")
 }
 
+// loadPayloadFiles sets the payload of all requests referencing a payload file to the content of the resource of the service,
+// using the same version of the resources as the webhook config
+func loadPayloadFiles(resourceHandler sdk.ResourceHandler, eventAdapter *lib.EventDataAdapter, webhook *lib.Webhook, commitID string) error {
+	commitOption := url.Values{}
+	if commitID != "" {
+		commitOption.Add("commitID", commitID)
+	}
+	for i, req := range webhook.Requests {
+		request, ok := req.(lib.Request)
+		if !ok || request.PayloadFile == "" {
+			continue
+		}
+		resourceScope := *keptn.NewResourceScope().Project(eventAdapter.Project()).Stage(eventAdapter.Stage()).Service(eventAdapter.Service()).Resource(request.PayloadFile)
+		resource, err := resourceHandler.GetResource(resourceScope, keptn.AppendQuery(commitOption))
+		if err != nil {
+			return fmt.Errorf("could not load payload file '%s': %w", request.PayloadFile, err)
+		}
+		if resource == nil {
+			return fmt.Errorf("could not load payload file '%s': resource not found", request.PayloadFile)
+		}
+		request.Payload = resource.ResourceContent
+		webhook.Requests[i] = request
+	}
+	return nil
+}
+
 func getMatchingWebhookFromResource(resource *models.Resource, subscriptionID string) *lib.Webhook {
 	whConfig, err := lib.DecodeWebHookConfigYAML([]byte(resource.ResourceContent))
 	if err != nil {
@@ -5,7 +5,10 @@ import (
 	"errors"
 	"io/ioutil"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -342,7 +345,7 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContent1_ALPHA})
@@ -373,16 +376,16 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 			return "my-secret-value", nil
 		}
 
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContent1_BETA})
@@ -390,9 +393,9 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 		fakeKeptn.SetAutomaticResponse(false)
 
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
 		require.Eventually(t, func() bool {
-			return curlExecutorMock.CurlCalls()[0].CurlCmd == "curl --request GET http://local:8080 myproject my-secret-value"
+			return httpExecutorMock.ExecuteCalls()[0].Request.URL == "http://local:8080 myproject my-secret-value"
 		}, 30*time.Second, time.Millisecond*10)
 
 		//verify sent events
@@ -425,7 +428,7 @@ func Test_HandleIncomingStartedEvent(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithStartedEvent_ALPHA})
@@ -450,25 +453,25 @@ func Test_HandleIncomingStartedEvent(t *testing.T) {
 			return "my-secret-value", nil
 		}
 
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
 		}
 
-		requestValidatorMock := &fake.RequestValidatorMock{func(request lib.Request) error {
+		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithStartedEvent_BETA})
 		fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.started", taskHandler, "my-subscription-id")
 		fakeKeptn.SetAutomaticResponse(false)
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.started.json"))
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
 		require.Eventually(t, func() bool {
-			return curlExecutorMock.CurlCalls()[0].CurlCmd == "curl --request GET http://local:8080 myproject my-secret-value"
+			return httpExecutorMock.ExecuteCalls()[0].Request.URL == "http://local:8080 myproject my-secret-value"
 		}, 30*time.Second, time.Millisecond*10)
 		fakeKeptn.AssertNumberOfEventSent(t, 0)
 	})
@@ -495,7 +498,7 @@ func Test_HandleIncomingStartedEventWithResultingError(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -523,16 +526,16 @@ func Test_HandleIncomingStartedEventWithResultingError(t *testing.T) {
 			return "my-secret-value", nil
 		}
 
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "", errors.New("oops")
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return nil, errors.New("oops")
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -541,8 +544,8 @@ func Test_HandleIncomingStartedEventWithResultingError(t *testing.T) {
 		fakeKeptn.SetAutomaticResponse(false)
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.started.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 0)
@@ -569,7 +572,7 @@ func Test_HandleIncomingFinishedEvent(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -596,16 +599,16 @@ func Test_HandleIncomingFinishedEvent(t *testing.T) {
 			return "my-secret-value", nil
 		}
 
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -614,8 +617,8 @@ func Test_HandleIncomingFinishedEvent(t *testing.T) {
 		fakeKeptn.SetAutomaticResponse(false)
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.finished.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 0)
@@ -643,7 +646,7 @@ func Test_HandleIncomingFinishedEventWithResultingError(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -671,16 +674,16 @@ func Test_HandleIncomingFinishedEventWithResultingError(t *testing.T) {
 			return "my-secret-value", nil
 		}
 
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "", errors.New("oops")
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return nil, errors.New("oops")
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -689,8 +692,8 @@ func Test_HandleIncomingFinishedEventWithResultingError(t *testing.T) {
 		fakeKeptn.SetAutomaticResponse(false)
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.finished.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 0)
@@ -718,7 +721,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequests(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -751,16 +754,16 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequests(t *testing.T) {
 			return "my-secret-value", nil
 		}
 
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -770,9 +773,9 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequests(t *testing.T) {
 
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 2 }, 30*time.Second, time.Millisecond*10)
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[1].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 2 }, 30*time.Second, time.Millisecond*10)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[1].Request.URL)
 
 		////verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 2)
@@ -805,7 +808,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinished(t *te
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -841,16 +844,16 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinished(t *te
 			return "my-secret-value", nil
 		}
 
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -860,11 +863,11 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinished(t *te
 
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 4 }, 30*time.Second, time.Millisecond*10)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[1].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[2].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[3].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 4 }, 30*time.Second, time.Millisecond*10)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[1].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[2].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[3].Request.URL)
 
 		////verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 4)
@@ -895,7 +898,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableStarted(t *tes
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -926,16 +929,16 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableStarted(t *tes
 			return "my-secret-value", nil
 		}
 
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -945,11 +948,11 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableStarted(t *tes
 
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 4 }, 30*time.Second, time.Millisecond*10)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[1].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[2].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[3].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 4 }, 30*time.Second, time.Millisecond*10)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[1].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[2].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[3].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 0)
@@ -980,7 +983,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinishedOneReq
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1019,20 +1022,20 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinishedOneReq
 			return "my-secret-value", nil
 		}
 
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
 			// make the second request fail
-			if len(curlExecutorMock.CurlCalls()) == 2 {
-				return "", errors.New("oops")
+			if len(httpExecutorMock.ExecuteCalls()) == 2 {
+				return nil, errors.New("oops")
 			}
-			return "success", nil
+			return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1042,9 +1045,9 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinishedOneReq
 
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 2 }, 30*time.Second, time.Millisecond*10)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[1].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 2 }, 30*time.Second, time.Millisecond*10)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[1].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 7)
@@ -1081,7 +1084,7 @@ func Test_HandleIncomingTriggeredEvent_NoMatchingWebhookFound(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1112,16 +1115,16 @@ func Test_HandleIncomingTriggeredEvent_NoMatchingWebhookFound(t *testing.T) {
 			return "my-secret-value", nil
 		}
 
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1131,7 +1134,7 @@ func Test_HandleIncomingTriggeredEvent_NoMatchingWebhookFound(t *testing.T) {
 
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 0 }, 30*time.Second, time.Millisecond*10)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 0 }, 30*time.Second, time.Millisecond*10)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 2)
@@ -1149,7 +1152,7 @@ func TestTaskHandler_Execute_WebhookCannotBeRetrieved(t *testing.T) {
 	curlExecutorMock := &fake.ICurlExecutorMock{}
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -1173,7 +1176,7 @@ func TestTaskHandler_Execute_NotATriggeredEventWebhookCannotBeRetrieved(t *testi
 	curlExecutorMock := &fake.ICurlExecutorMock{}
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -1194,7 +1197,7 @@ func TestTaskHandler_Execute_NoSubscriptionIDInEvent(t *testing.T) {
 	curlExecutorMock := &fake.ICurlExecutorMock{}
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -1214,7 +1217,7 @@ func TestTaskHandler_Execute_InvalidEvent(t *testing.T) {
 	curlExecutorMock := &fake.ICurlExecutorMock{}
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -1240,7 +1243,7 @@ func TestTaskHandler_CannotReadSecret(t *testing.T) {
 		curlExecutorMock := &fake.ICurlExecutorMock{}
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1264,12 +1267,12 @@ func TestTaskHandler_CannotReadSecret(t *testing.T) {
 		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
 			return "", errors.New("unable to read secret :(")
 		}
-		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1285,6 +1288,13 @@ func TestTaskHandler_CannotReadSecret(t *testing.T) {
 		fakeKeptn.AssertSentEventType(t, 1, "sh.keptn.event.webhook.finished")
 		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
 		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
+		fakeKeptn.AssertSentEvent(t, 1, func(ce models.KeptnContextExtendedCE) bool {
+			data := keptnv2.EventData{}
+			require.Nil(t, ce.DataAs(&data))
+			require.Contains(t, data.Message, "could not read secret")
+			require.Contains(t, data.Message, "unable to read secret :(")
+			return true
+		})
 	})
 
 }
@@ -1303,7 +1313,7 @@ func TestTaskHandler_IncompleteDataForTemplate(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithMissingTemplateData_ALPHA})
@@ -1335,13 +1345,13 @@ func TestTaskHandler_IncompleteDataForTemplate(t *testing.T) {
 		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
 			return "my-secret-value", nil
 		}
-		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithMissingTemplateData_BETA})
@@ -1354,7 +1364,7 @@ func TestTaskHandler_IncompleteDataForTemplate(t *testing.T) {
 			return len(secretReaderMock.ReadSecretCalls()) > 0
 		}, 30*time.Second, 10*time.Millisecond)
 		require.NotEmpty(t, templateEngineMock.ParseTemplateCalls())
-		require.Empty(t, curlExecutorMock.CurlCalls())
+		require.Empty(t, httpExecutorMock.ExecuteCalls())
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 2)
@@ -1382,7 +1392,7 @@ func TestTaskHandler_CurlExecutorFails(t *testing.T) {
 			return "", errors.New("unable to execute curl call")
 		}
 		requestValidatorMock := &fake.RequestValidatorMock{}
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1415,14 +1425,14 @@ func TestTaskHandler_CurlExecutorFails(t *testing.T) {
 		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
 			return "my-secret-value", nil
 		}
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "", errors.New("unable to execute curl call")
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return nil, errors.New("unable to execute curl call")
 		}
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1436,7 +1446,7 @@ func TestTaskHandler_CurlExecutorFails(t *testing.T) {
 			return len(secretReaderMock.ReadSecretCalls()) > 0
 		}, 30*time.Second, 10*time.Millisecond)
 		require.NotEmpty(t, templateEngineMock.ParseTemplateCalls())
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 2)
@@ -1465,7 +1475,7 @@ func TestTaskHandler_RequestValidatorFails(t *testing.T) {
 	requestValidatorMock.ValidateFunc = func(request lib.Request) error {
 		return errors.New("validation failed")
 	}
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -1503,7 +1513,7 @@ func TestTaskHandler_CurlExecutorFailsHideSecret(t *testing.T) {
 			return "", errors.New("unable to execute curl call containing secret my-secret-value")
 		}
 		requestValidatorMock := fake.RequestValidatorMock{}
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1540,14 +1550,14 @@ func TestTaskHandler_CurlExecutorFailsHideSecret(t *testing.T) {
 		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
 			return "my-secret-value", nil
 		}
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "", errors.New("unable to execute curl call containing secret my-secret-value")
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return nil, errors.New("unable to execute curl call containing secret my-secret-value")
 		}
 		requestValidatorMock := fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1561,7 +1571,7 @@ func TestTaskHandler_CurlExecutorFailsHideSecret(t *testing.T) {
 			return len(secretReaderMock.ReadSecretCalls()) > 0
 		}, 30*time.Second, 10*time.Millisecond)
 		require.NotEmpty(t, templateEngineMock.ParseTemplateCalls())
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 2)
@@ -1604,7 +1614,7 @@ func TestTaskHandler_Execute_WebhookConfigInService(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1641,9 +1651,9 @@ func TestTaskHandler_Execute_WebhookConfigInService(t *testing.T) {
 		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
 			return "my-secret-value", nil
 		}
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
 		}
 
 		resourceHandlerMock := &fake2.IResourceHandlerMock{}
@@ -1659,7 +1669,7 @@ func TestTaskHandler_Execute_WebhookConfigInService(t *testing.T) {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1723,7 +1733,7 @@ func TestTaskHandler_Execute_WebhookConfigInStage(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1765,9 +1775,9 @@ func TestTaskHandler_Execute_WebhookConfigInStage(t *testing.T) {
 		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
 			return "my-secret-value", nil
 		}
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
 		}
 
 		resourceHandlerMock := &fake2.IResourceHandlerMock{}
@@ -1790,7 +1800,7 @@ func TestTaskHandler_Execute_WebhookConfigInStage(t *testing.T) {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1858,7 +1868,7 @@ func TestTaskHandler_Execute_WebhookConfigInProject(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1906,9 +1916,9 @@ func TestTaskHandler_Execute_WebhookConfigInProject(t *testing.T) {
 		secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
 			return "my-secret-value", nil
 		}
-		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
 		}
 
 		resourceHandlerMock := &fake2.IResourceHandlerMock{}
@@ -1931,7 +1941,7 @@ func TestTaskHandler_Execute_WebhookConfigInProject(t *testing.T) {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1981,12 +1991,12 @@ func Test_createRequest(t *testing.T) {
 		return nil
 	}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	tests := []struct {
 		name    string
 		data    interface{}
-		want    string
+		want    interface{}
 		wantErr bool
 	}{
 		{
@@ -1998,13 +2008,13 @@ func Test_createRequest(t *testing.T) {
 		{
 			name:    "invalid alpha input #1",
 			data:    "curl http:localhost",
-			want:    "",
+			want:    nil,
 			wantErr: true,
 		},
 		{
 			name:    "invalid alpha input #2",
 			data:    "curl kubernetes.svc",
-			want:    "",
+			want:    nil,
 			wantErr: true,
 		},
 		{
@@ -2021,7 +2031,18 @@ func Test_createRequest(t *testing.T) {
 				Payload: "some payload",
 				URL:     "http://local:8080",
 			},
-			want:    "curl --request POST --header 'key: value' --data 'some payload' --some-options http://local:8080",
+			want: lib.Request{
+				Headers: []lib.Header{
+					{
+						Key:   "key",
+						Value: "value",
+					},
+				},
+				Method:  "POST",
+				Options: "--some-options",
+				Payload: "some payload",
+				URL:     "http://local:8080",
+			},
 			wantErr: false,
 		},
 		{
@@ -2036,7 +2057,16 @@ func Test_createRequest(t *testing.T) {
 				Method: "POST",
 				URL:    "http://local:8080",
 			},
-			want:    "curl --request POST --header 'key: value' http://local:8080",
+			want: lib.Request{
+				Headers: []lib.Header{
+					{
+						Key:   "key",
+						Value: "value",
+					},
+				},
+				Method: "POST",
+				URL:    "http://local:8080",
+			},
 			wantErr: false,
 		},
 		{
@@ -2045,13 +2075,16 @@ func Test_createRequest(t *testing.T) {
 				Method: "POST",
 				URL:    "http://local:8080",
 			},
-			want:    "curl --request POST http://local:8080",
+			want: lib.Request{
+				Method: "POST",
+				URL:    "http://local:8080",
+			},
 			wantErr: false,
 		},
 		{
 			name:    "invalid input",
 			data:    1,
-			want:    "",
+			want:    nil,
 			wantErr: true,
 		},
 	}
@@ -2068,3 +2101,648 @@ func Test_createRequest(t *testing.T) {
 		})
 	}
 }
+
+const webHookContentWithResponseEvaluation = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      requests:
+      - url: http://local:8080/build
+        method: POST
+        response:
+          expectedStatusCodes: [201]
+          assertions:
+            - path: $.status
+              equals: SUCCESS
+              onFailure: warning
+          extract:
+            - name: buildId
+              path: $.id
+              type: string
+      - url: http://local:8080/deploy
+        method: POST
+        response:
+          assertions:
+            - path: $.deployed
+              equals: true
+          extract:
+            - name: replicas
+              path: $.replicas
+              type: number`
+
+func Test_HandleIncomingTriggeredEvent_ResponseEvaluation(t *testing.T) {
+	tests := []struct {
+		name             string
+		responses        map[string]lib.HTTPResponse
+		wantRequests     int
+		wantResult       keptnv2.ResultType
+		wantMessage      string
+		wantTaskProperty map[string]interface{}
+	}{
+		{
+			name: "pass",
+			responses: map[string]lib.HTTPResponse{
+				"http://local:8080/build":  {StatusCode: 201, Body: `{"id": 42, "status": "SUCCESS"}`},
+				"http://local:8080/deploy": {StatusCode: 200, Body: `{"deployed": true, "replicas": 3}`},
+			},
+			wantRequests: 2,
+			wantResult:   keptnv2.ResultPass,
+			wantTaskProperty: map[string]interface{}{
+				"buildId":   "42",
+				"replicas":  float64(3),
+				"responses": []interface{}{`{"id": 42, "status": "SUCCESS"}`, `{"deployed": true, "replicas": 3}`},
+			},
+		},
+		{
+			name: "warning",
+			responses: map[string]lib.HTTPResponse{
+				"http://local:8080/build":  {StatusCode: 201, Body: `{"id": 42, "status": "UNSTABLE"}`},
+				"http://local:8080/deploy": {StatusCode: 200, Body: `{"deployed": true, "replicas": 3}`},
+			},
+			wantRequests: 2,
+			wantResult:   keptnv2.ResultWarning,
+			wantMessage:  "assertion for '$.status' failed: expected 'SUCCESS' but was 'UNSTABLE'",
+			wantTaskProperty: map[string]interface{}{
+				"buildId":   "42",
+				"replicas":  float64(3),
+				"responses": []interface{}{`{"id": 42, "status": "UNSTABLE"}`, `{"deployed": true, "replicas": 3}`},
+			},
+		},
+		{
+			name: "fail",
+			responses: map[string]lib.HTTPResponse{
+				"http://local:8080/build": {StatusCode: 500, Body: `{"status": "ERROR"}`},
+			},
+			wantRequests: 1,
+			wantResult:   keptnv2.ResultFailed,
+			wantMessage:  "unexpected status code 500, expected one of [201]",
+			wantTaskProperty: map[string]interface{}{
+				"responses": []interface{}{`{"status": "ERROR"}`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+				tplE := &lib.TemplateEngine{}
+				return tplE.ParseTemplate(data, templateStr)
+			}}
+			httpExecutorMock := &fake.IHTTPExecutorMock{}
+			httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+				response := tt.responses[request.URL]
+				return &response, nil
+			}
+			requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+				return nil
+			}}
+
+			taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, &fake.ISecretReaderMock{})
+
+			fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+			fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithResponseEvaluation})
+			fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+			fakeKeptn.SetAutomaticResponse(false)
+
+			fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+			require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == tt.wantRequests }, 30*time.Second, time.Millisecond*10)
+
+			fakeKeptn.AssertNumberOfEventSent(t, 2)
+			fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+			fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+			fakeKeptn.AssertSentEventResult(t, 1, tt.wantResult)
+			fakeKeptn.AssertSentEvent(t, 1, func(ce models.KeptnContextExtendedCE) bool {
+				data := map[string]interface{}{}
+				require.Nil(t, ce.DataAs(&data))
+				require.Equal(t, tt.wantTaskProperty, data["webhook"])
+				if tt.wantMessage != "" {
+					require.Equal(t, tt.wantMessage, data["message"])
+				}
+				return true
+			})
+		})
+	}
+}
+
+const webHookContentAsync = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      async:
+        deadline: DEADLINE
+      requests:
+        - url: http://local:8080/tickets
+          method: POST
+          headers:
+            - key: x-callback-token
+              value: "{{.callback.token}}"
+          payload: "{\"callback\": \"{{.callback.url}}\"}"
+          response:
+            extract:
+              - name: ticketId
+                path: $.id`
+
+func Test_HandleIncomingTriggeredEvent_Async(t *testing.T) {
+	newFakeKeptn := func(deadline string, httpExecutorMock *fake.IHTTPExecutorMock, opts ...handler.TaskHandlerOption) *sdk.FakeKeptn {
+		templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+			tplE := &lib.TemplateEngine{}
+			return tplE.ParseTemplate(data, templateStr)
+		}}
+		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+			return nil
+		}}
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, &fake.ISecretReaderMock{}, opts...)
+
+		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: strings.Replace(webHookContentAsync, "DEADLINE", deadline, 1)})
+		fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+		fakeKeptn.SetAutomaticResponse(false)
+		require.Nil(t, taskHandler.StartCallbacks(fakeKeptn.Keptn))
+		return fakeKeptn
+	}
+
+	t.Run("callback received", func(t *testing.T) {
+		registry := lib.NewCallbackRegistry("http://webhook-service:8081", lib.NewInMemoryCallbackStore())
+		httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: 201, Body: `{"id": "T-1"}`}, nil
+		}}
+		fakeKeptn := newFakeKeptn("1h", httpExecutorMock, handler.WithCallbackRegistry(registry))
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+
+		// the callback URL and token are passed to the called system
+		request := httpExecutorMock.ExecuteCalls()[0].Request
+		token := request.Headers[0].Value
+		require.NotEmpty(t, token)
+		require.Regexp(t, `^\{"callback": "http://webhook-service:8081/v1/callback/[0-9a-f]+"\}$`, request.Payload)
+		callbackID := strings.TrimSuffix(strings.TrimPrefix(request.Payload, `{"callback": "http://webhook-service:8081/v1/callback/`), `"}`)
+
+		// the .finished event is only sent once the callback has been received
+		fakeKeptn.AssertNumberOfEventSent(t, 1)
+		fakeKeptn.AssertSentEventType(t, 0, keptnv2.GetStartedEventType("webhook"))
+
+		require.Nil(t, registry.Receive(callbackID, token, lib.CallbackResult{
+			Result:  keptnv2.ResultWarning,
+			Status:  keptnv2.StatusSucceeded,
+			Message: "resolved with workaround",
+			Data:    map[string]interface{}{"resolution": "workaround"},
+		}))
+
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultWarning)
+		fakeKeptn.AssertSentEvent(t, 1, func(ce models.KeptnContextExtendedCE) bool {
+			data := map[string]interface{}{}
+			require.Nil(t, ce.DataAs(&data))
+			require.Equal(t, "resolved with workaround", data["message"])
+			require.Equal(t, map[string]interface{}{
+				"ticketId":   "T-1",
+				"resolution": "workaround",
+				"responses":  []interface{}{`{"id": "T-1"}`},
+			}, data["webhook"])
+			return true
+		})
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		registry := lib.NewCallbackRegistry("http://webhook-service:8081", lib.NewInMemoryCallbackStore())
+		httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+			// the deadline expires while the request is executed, hence the .finished event is sent right after the request
+			time.Sleep(100 * time.Millisecond)
+			return &lib.HTTPResponse{StatusCode: 201, Body: `{"id": "T-1"}`}, nil
+		}}
+		fakeKeptn := newFakeKeptn("10ms", httpExecutorMock, handler.WithCallbackRegistry(registry))
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
+	})
+
+	t.Run("request fails", func(t *testing.T) {
+		registry := lib.NewCallbackRegistry("http://webhook-service:8081", lib.NewInMemoryCallbackStore())
+		httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+			return nil, errors.New("connection refused")
+		}}
+		fakeKeptn := newFakeKeptn("1h", httpExecutorMock, handler.WithCallbackRegistry(registry))
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
+	})
+
+	t.Run("no callback registry", func(t *testing.T) {
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		fakeKeptn := newFakeKeptn("1h", httpExecutorMock)
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		require.Empty(t, httpExecutorMock.ExecuteCalls())
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
+	})
+}
+
+const webHookContentWithRetry = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      requests:
+        - url: http://local:8080/deploy
+          method: POST
+          retry:
+            attempts: 3
+            backoff: 1ms
+            idempotencyHeader: Idempotency-Key`
+
+func Test_HandleIncomingTriggeredEvent_Retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCodes  []int
+		wantRequests int
+		wantStatus   keptnv2.StatusType
+		wantResult   keptnv2.ResultType
+		wantMessage  string
+		wantAttempts []interface{}
+	}{
+		{
+			name:         "succeeds after retry",
+			statusCodes:  []int{502, 503, 200},
+			wantRequests: 3,
+			wantStatus:   keptnv2.StatusSucceeded,
+			wantResult:   keptnv2.ResultPass,
+			wantAttempts: []interface{}{float64(3)},
+		},
+		{
+			name:         "attempts exhausted",
+			statusCodes:  []int{502, 502, 502},
+			wantRequests: 3,
+			wantStatus:   keptnv2.StatusErrored,
+			wantResult:   keptnv2.ResultFailed,
+			wantMessage:  "could not execute request 'POST http://local:8080/deploy' after 3 attempts: request failed with status code 502",
+		},
+		{
+			name:         "not retryable",
+			statusCodes:  []int{500},
+			wantRequests: 1,
+			wantStatus:   keptnv2.StatusErrored,
+			wantResult:   keptnv2.ResultFailed,
+			wantMessage:  "could not execute request 'POST http://local:8080/deploy': request failed with status code 500",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+				tplE := &lib.TemplateEngine{}
+				return tplE.ParseTemplate(data, templateStr)
+			}}
+			httpExecutorMock := &fake.IHTTPExecutorMock{}
+			httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+				statusCode := tt.statusCodes[len(httpExecutorMock.ExecuteCalls())-1]
+				if statusCode >= 400 {
+					// use the HTTPExecutor to create an error containing the status code of the response
+					server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(statusCode)
+					}))
+					defer server.Close()
+					return lib.NewHTTPExecutor(&fake.RequestValidatorMock{
+						ValidateFunc:        func(request lib.Request) error { return nil },
+						ValidateAddressFunc: func(address string) error { return nil },
+					}).Execute(lib.Request{URL: server.URL, Method: http.MethodPost})
+				}
+				return &lib.HTTPResponse{StatusCode: statusCode, Body: "deployed"}, nil
+			}
+			requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+				return nil
+			}}
+
+			taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, &fake.ISecretReaderMock{})
+
+			fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+			fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithRetry})
+			fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+			fakeKeptn.SetAutomaticResponse(false)
+
+			fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+			require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == tt.wantRequests }, 30*time.Second, time.Millisecond*10)
+
+			// all attempts are sent with the same idempotency key
+			idempotencyKey := httpExecutorMock.ExecuteCalls()[0].Request.Headers[0]
+			require.Equal(t, "Idempotency-Key", idempotencyKey.Key)
+			require.NotEmpty(t, idempotencyKey.Value)
+			for _, call := range httpExecutorMock.ExecuteCalls() {
+				require.Equal(t, []lib.Header{idempotencyKey}, call.Request.Headers)
+			}
+
+			fakeKeptn.AssertNumberOfEventSent(t, 2)
+			fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+			fakeKeptn.AssertSentEventStatus(t, 1, tt.wantStatus)
+			fakeKeptn.AssertSentEventResult(t, 1, tt.wantResult)
+			fakeKeptn.AssertSentEvent(t, 1, func(ce models.KeptnContextExtendedCE) bool {
+				data := map[string]interface{}{}
+				require.Nil(t, ce.DataAs(&data))
+				if tt.wantMessage != "" {
+					require.Contains(t, data["message"], tt.wantMessage)
+				}
+				if tt.wantAttempts != nil {
+					require.Equal(t, tt.wantAttempts, data["webhook"].(map[string]interface{})["attempts"])
+				}
+				return true
+			})
+		})
+	}
+}
+
+const webHookContentWithJSONPayload = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      envFrom:
+        - name: note
+          secretRef:
+            name: mysecret
+            key: note
+      requests:
+        - url: http://local:8080/notify
+          method: POST
+          payloadMode: json
+          payload: '{"project": "{{upper .data.project}}", "note": "{{.env.note}}", "owner": "{{dig "labels" "owner" "unknown" .data}}"}'`
+
+func Test_HandleIncomingTriggeredEvent_JSONPayload(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 200, Body: "ok"}, nil
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{ReadSecretFunc: func(name string, key string) (string, error) {
+		return "deployed \"v2\"\nto production", nil
+	}}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithJSONPayload})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+
+	payload := httpExecutorMock.ExecuteCalls()[0].Request.Payload
+	require.Equal(t, `{"project": "MYPROJECT", "note": "deployed \"v2\"\nto production", "owner": "unknown"}`, payload)
+	require.True(t, json.Valid([]byte(payload)))
+
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+}
+
+const webHookContentWithSignature = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      envFrom:
+        - name: signingKey
+          secretRef:
+            name: my-secret
+            key: signing-key
+      requests:
+        - url: SERVER_URL
+          method: POST
+          payload: '{"project": "{{.data.project}}"}'
+          signature:
+            secret: signingKey`
+
+func Test_HandleIncomingTriggeredEvent_Signature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		timestamp := r.Header.Get(lib.DefaultSignatureTimestampHeader)
+		if r.Header.Get(lib.DefaultSignatureHeader) != "sha256="+lib.ComputeSignature(lib.SignatureAlgorithmSHA256, []byte("my-signing-key"), timestamp, string(body)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("verified"))
+	}))
+	defer server.Close()
+
+	secretReaderMock := &fake.ISecretReaderMock{ReadSecretFunc: func(name string, key string) (string, error) {
+		return "my-signing-key", nil
+	}}
+	requestValidator := &fake.RequestValidatorMock{
+		ValidateFunc:        func(request lib.Request) error { return nil },
+		ValidateAddressFunc: func(address string) error { return nil },
+	}
+	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, &fake.ICurlExecutorMock{}, lib.NewHTTPExecutor(requestValidator), requestValidator, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: strings.Replace(webHookContentWithSignature, "SERVER_URL", server.URL, 1)})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+	fakeKeptn.AssertSentEvent(t, 1, func(ce models.KeptnContextExtendedCE) bool {
+		data := map[string]interface{}{}
+		require.Nil(t, ce.DataAs(&data))
+		require.Equal(t, []interface{}{"verified"}, data["webhook"].(map[string]interface{})["responses"])
+		return true
+	})
+}
+
+const webHookContentWithConditions = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      conditions:
+        - path: $.data.project
+          equals: WEBHOOK_PROJECT
+      requests:
+        - url: http://production
+          method: POST
+          conditions:
+            - path: $.data.stage
+              equals: production
+        - url: http://mystage
+          method: POST
+          conditions:
+            - path: $.data.stage
+              in: [mystage, dev]`
+
+func Test_HandleIncomingTriggeredEvent_Conditions(t *testing.T) {
+	newTaskHandler := func() (*handler.TaskHandler, *fake.IHTTPExecutorMock) {
+		httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: 200, Body: "sent to " + request.URL}, nil
+		}}
+		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error { return nil }}
+		return handler.NewTaskHandler(&lib.TemplateEngine{}, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, &fake.ISecretReaderMock{}), httpExecutorMock
+	}
+
+	t.Run("only requests meeting their conditions are executed", func(t *testing.T) {
+		taskHandler, httpExecutorMock := newTaskHandler()
+		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: strings.Replace(webHookContentWithConditions, "WEBHOOK_PROJECT", "myproject", 1)})
+		fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+		fakeKeptn.SetAutomaticResponse(false)
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		require.Len(t, httpExecutorMock.ExecuteCalls(), 1)
+		require.Equal(t, "http://mystage", httpExecutorMock.ExecuteCalls()[0].Request.URL)
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+		fakeKeptn.AssertSentEvent(t, 1, func(ce models.KeptnContextExtendedCE) bool {
+			data := map[string]interface{}{}
+			require.Nil(t, ce.DataAs(&data))
+			require.Equal(t, []interface{}{"sent to http://mystage"}, data["webhook"].(map[string]interface{})["responses"])
+			return true
+		})
+	})
+
+	t.Run("webhook is skipped if its conditions are not met", func(t *testing.T) {
+		taskHandler, httpExecutorMock := newTaskHandler()
+		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: strings.Replace(webHookContentWithConditions, "WEBHOOK_PROJECT", "otherproject", 1)})
+		fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+		fakeKeptn.SetAutomaticResponse(false)
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		require.Empty(t, httpExecutorMock.ExecuteCalls())
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventType(t, 0, keptnv2.GetStartedEventType("webhook"))
+		fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+		fakeKeptn.AssertSentEvent(t, 1, func(ce models.KeptnContextExtendedCE) bool {
+			data := keptnv2.EventData{}
+			require.Nil(t, ce.DataAs(&data))
+			require.Equal(t, "webhook skipped: conditions not met: '$.data.project' is 'myproject' instead of 'otherproject'", data.Message)
+			return true
+		})
+	})
+}
+
+const webHookContentWithPayloadFile = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      requests:
+        - url: http://my-deployments/{{.data.service}}
+          method: PATCH
+          payloadFile: webhook/patch.json
+          payloadMode: json
+        - url: http://my-tickets/{{.data.service}}
+          method: DELETE`
+
+func Test_HandleIncomingTriggeredEvent_PayloadFile(t *testing.T) {
+	newResourceHandler := func(payloadErr error) *fake2.IResourceHandlerMock {
+		return &fake2.IResourceHandlerMock{GetResourceFunc: func(scope api.ResourceScope, options ...api.URIOption) (*models.Resource, error) {
+			if reflect.ValueOf(scope).FieldByName("resource").String() == "webhook/patch.json" {
+				if payloadErr != nil {
+					return nil, payloadErr
+				}
+				return &models.Resource{ResourceContent: `{"stage": "{{.data.stage}}"}`}, nil
+			}
+			return &models.Resource{ResourceContent: webHookContentWithPayloadFile}, nil
+		}}
+	}
+	newTaskHandler := func() (*handler.TaskHandler, *fake.IHTTPExecutorMock) {
+		httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
+		}}
+		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error { return nil }}
+		return handler.NewTaskHandler(&lib.TemplateEngine{}, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, &fake.ISecretReaderMock{}), httpExecutorMock
+	}
+
+	t.Run("payload is loaded from resource of the service", func(t *testing.T) {
+		taskHandler, httpExecutorMock := newTaskHandler()
+		resourceHandlerMock := newResourceHandler(nil)
+		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+		fakeKeptn.SetResourceHandler(resourceHandlerMock)
+		fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+		fakeKeptn.SetAutomaticResponse(false)
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+		require.Len(t, httpExecutorMock.ExecuteCalls(), 2)
+		require.Equal(t, "PATCH", httpExecutorMock.ExecuteCalls()[0].Request.Method)
+		require.Equal(t, `{"stage": "mystage"}`, httpExecutorMock.ExecuteCalls()[0].Request.Payload)
+		require.Equal(t, "DELETE", httpExecutorMock.ExecuteCalls()[1].Request.Method)
+		require.Equal(t, "http://my-tickets/myservice", httpExecutorMock.ExecuteCalls()[1].Request.URL)
+
+		payloadCall := resourceHandlerMock.GetResourceCalls()[1]
+		scopeVals := reflect.ValueOf(payloadCall.Scope)
+		require.Equal(t, "myservice", scopeVals.FieldByName("service").String())
+		require.Equal(t, "mystage", scopeVals.FieldByName("stage").String())
+		require.Equal(t, "myproject", scopeVals.FieldByName("project").String())
+	})
+
+	t.Run("payload file not found", func(t *testing.T) {
+		taskHandler, httpExecutorMock := newTaskHandler()
+		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+		fakeKeptn.SetResourceHandler(newResourceHandler(errors.New("resource not found")))
+		fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+		fakeKeptn.SetAutomaticResponse(false)
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		require.Empty(t, httpExecutorMock.ExecuteCalls())
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
+		fakeKeptn.AssertSentEvent(t, 1, func(ce models.KeptnContextExtendedCE) bool {
+			data := keptnv2.EventData{}
+			require.Nil(t, ce.DataAs(&data))
+			require.Equal(t, "could not load payload file 'webhook/patch.json': resource not found", data.Message)
+			return true
+		})
+	})
+}
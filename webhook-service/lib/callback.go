@@ -0,0 +1,382 @@
+package lib
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/go-utils/pkg/sdk"
+	logger "github.com/sirupsen/logrus"
+)
+
+// DefaultCallbackDeadline is the time an async webhook waits for its callback, if no deadline is configured
+const DefaultCallbackDeadline = time.Hour
+
+// CallbackPath is the path of the endpoint receiving the callbacks of async webhooks, followed by the ID of the callback
+const CallbackPath = "/v1/callback/"
+
+var ErrCallbackNotFound = errors.New("callback not found")
+var ErrInvalidCallbackToken = errors.New("invalid callback token")
+var ErrCallbackAlreadyReceived = errors.New("callback has already been received")
+
+// AsyncConfig makes a webhook wait for a callback of the called system before its .finished event is sent
+type AsyncConfig struct {
+	// Deadline is the maximum time to wait for the callback, e.g. 30m
+	Deadline string `yaml:"deadline,omitempty"`
+}
+
+// GetDeadline returns the configured deadline, or DefaultCallbackDeadline if no deadline is set
+func (ac AsyncConfig) GetDeadline() time.Duration {
+	deadline, err := time.ParseDuration(ac.Deadline)
+	if err != nil || deadline <= 0 {
+		return DefaultCallbackDeadline
+	}
+	return deadline
+}
+
+func (ac AsyncConfig) validate() error {
+	if ac.Deadline == "" {
+		return nil
+	}
+	if deadline, err := time.ParseDuration(ac.Deadline); err != nil || deadline <= 0 {
+		return fmt.Errorf("invalid async deadline '%s'", ac.Deadline)
+	}
+	return nil
+}
+
+// Callback contains the data that is passed to the called system to report the result of an async webhook.
+// It is available as {{.callback.url}} and {{.callback.token}} within the requests of the webhook
+type Callback struct {
+	ID    string `json:"id"`
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+// CallbackResult is the payload of a callback, which is used as result of the .finished event of an async webhook
+type CallbackResult struct {
+	Result  keptnv2.ResultType     `json:"result,omitempty"`
+	Status  keptnv2.StatusType     `json:"status,omitempty"`
+	Message string                 `json:"message,omitempty"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// Validate checks the result and status of the callback, and sets them to pass/succeeded if they are empty
+func (cr *CallbackResult) Validate() error {
+	switch cr.Result {
+	case "":
+		cr.Result = keptnv2.ResultPass
+	case keptnv2.ResultPass, keptnv2.ResultWarning, keptnv2.ResultFailed:
+	default:
+		return fmt.Errorf("invalid result '%s', expected one of: pass, warning, fail", cr.Result)
+	}
+	switch cr.Status {
+	case "":
+		cr.Status = keptnv2.StatusSucceeded
+	case keptnv2.StatusSucceeded, keptnv2.StatusErrored:
+	default:
+		return fmt.Errorf("invalid status '%s', expected one of: succeeded, errored", cr.Status)
+	}
+	return nil
+}
+
+// CallbackState is the state of a pending callback
+type CallbackState string
+
+const (
+	// CallbackRegistered means that the requests of the webhook are still being executed
+	CallbackRegistered CallbackState = "registered"
+	// CallbackReceived means that the callback has been received (or has expired) while the requests were executed
+	CallbackReceived CallbackState = "received"
+	// CallbackAwaited means that the requests have been executed and the webhook is waiting for the callback
+	CallbackAwaited CallbackState = "awaited"
+)
+
+// PendingCallback is the persisted state of a callback. It contains everything that is required to send the
+// .finished event of the webhook, so that the callback can be received by any replica of the webhook service
+type PendingCallback struct {
+	ID        string        `bson:"_id"`
+	TokenHash string        `bson:"tokenHash"`
+	State     CallbackState `bson:"state"`
+	Deadline  time.Duration `bson:"deadline"`
+	ExpiresAt time.Time     `bson:"expiresAt"`
+	// Event is the JSON encoded <task>.triggered event of the webhook
+	Event []byte `bson:"event"`
+	// FinishedEventData is the JSON encoded data of the .finished event, which is completed with the result of the callback
+	FinishedEventData []byte `bson:"finishedEventData"`
+	// Result is the JSON encoded result of a callback that has been received while the requests were executed
+	Result []byte `bson:"result,omitempty"`
+}
+
+// CallbackStore persists the pending callbacks, so that they survive restarts of the webhook service
+type CallbackStore interface {
+	Create(callback PendingCallback) error
+	// Get returns the callback with the given ID, or ErrCallbackNotFound
+	Get(id string) (*PendingCallback, error)
+	List() ([]PendingCallback, error)
+	// Update replaces the callback if it is still in the given state, and returns false otherwise
+	Update(callback PendingCallback, state CallbackState) (bool, error)
+	// Delete removes the callback if it is in one of the given states, or in any state if none is given.
+	// It returns false if there is no such callback
+	Delete(id string, states ...CallbackState) (bool, error)
+}
+
+// CallbackCompletionFunc sends the .finished event for the given <task>.triggered event, which consists of the
+// given data and the result of the callback
+type CallbackCompletionFunc func(event sdk.KeptnEvent, finishedEventData map[string]interface{}, result CallbackResult)
+
+// CallbackRegistry keeps track of the callbacks async webhooks are waiting for. Each callback can only be received once,
+// and is completed with an errored result if it is not received before its deadline
+type CallbackRegistry struct {
+	baseURL     string
+	store       CallbackStore
+	onCompleted CallbackCompletionFunc
+	timers      map[string]*time.Timer
+	mutex       sync.Mutex
+}
+
+func NewCallbackRegistry(baseURL string, store CallbackStore) *CallbackRegistry {
+	return &CallbackRegistry{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		store:   store,
+		timers:  map[string]*time.Timer{},
+	}
+}
+
+// Start sets the function sending the .finished events of the callbacks, and re-arms the deadlines of the callbacks that
+// have been registered before the webhook service has been (re)started
+func (cr *CallbackRegistry) Start(onCompleted CallbackCompletionFunc) error {
+	cr.mutex.Lock()
+	cr.onCompleted = onCompleted
+	cr.mutex.Unlock()
+
+	callbacks, err := cr.store.List()
+	if err != nil {
+		return fmt.Errorf("could not restore pending callbacks: %w", err)
+	}
+	for _, callback := range callbacks {
+		// the requests of these callbacks have been executed by a previous instance of the webhook service, hence they
+		// are completed as soon as they expire
+		cr.arm(callback.ID, time.Until(callback.ExpiresAt), callback.Deadline, true)
+	}
+	return nil
+}
+
+// Register creates a new callback for the given <task>.triggered event that expires after the given deadline.
+// If the webhook service is restarted before the requests of the webhook have been executed, the .finished event of
+// the expired callback only consists of the given data
+func (cr *CallbackRegistry) Register(event sdk.KeptnEvent, finishedEventData map[string]interface{}, deadline time.Duration) (*Callback, error) {
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, fmt.Errorf("could not create callback ID: %w", err)
+	}
+	token, err := randomHex(32)
+	if err != nil {
+		return nil, fmt.Errorf("could not create callback token: %w", err)
+	}
+	encodedEvent, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode event of callback: %w", err)
+	}
+	encodedData, err := json.Marshal(finishedEventData)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode .finished event data of callback: %w", err)
+	}
+
+	err = cr.store.Create(PendingCallback{
+		ID:                id,
+		TokenHash:         hashToken(token),
+		State:             CallbackRegistered,
+		Deadline:          deadline,
+		ExpiresAt:         time.Now().UTC().Add(deadline),
+		Event:             encodedEvent,
+		FinishedEventData: encodedData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not store callback: %w", err)
+	}
+	cr.arm(id, deadline, deadline, false)
+	return &Callback{ID: id, URL: cr.baseURL + CallbackPath + id, Token: token}, nil
+}
+
+// Await stores the data of the .finished event once the requests of the webhook have been executed. The .finished event
+// is sent once the callback has been received or has expired. If this already happened, it is sent immediately
+func (cr *CallbackRegistry) Await(id string, finishedEventData map[string]interface{}) error {
+	encodedData, err := json.Marshal(finishedEventData)
+	if err != nil {
+		return fmt.Errorf("could not encode .finished event data of callback: %w", err)
+	}
+	for {
+		callback, err := cr.store.Get(id)
+		if errors.Is(err, ErrCallbackNotFound) {
+			// the callback has already been completed, e.g. by a restarted instance of the webhook service
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch callback.State {
+		case CallbackRegistered:
+			callback.State = CallbackAwaited
+			callback.FinishedEventData = encodedData
+			if updated, err := cr.store.Update(*callback, CallbackRegistered); err != nil || updated {
+				return err
+			}
+		case CallbackReceived:
+			result := CallbackResult{}
+			if err := json.Unmarshal(callback.Result, &result); err != nil {
+				return fmt.Errorf("could not decode result of callback: %w", err)
+			}
+			callback.FinishedEventData = encodedData
+			_, err := cr.finish(*callback, CallbackReceived, result)
+			return err
+		default:
+			return nil
+		}
+		// the callback has been received concurrently, hence its new state is processed
+	}
+}
+
+// Cancel removes the callback, e.g. because the requests of the webhook failed
+func (cr *CallbackRegistry) Cancel(id string) {
+	cr.disarm(id)
+	if _, err := cr.store.Delete(id); err != nil {
+		logger.WithError(err).Errorf("could not remove callback %s", id)
+	}
+}
+
+// Receive completes the callback with the given ID, if the token matches the token of the callback
+func (cr *CallbackRegistry) Receive(id string, token string, result CallbackResult) error {
+	callback, err := cr.store.Get(id)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(callback.TokenHash), []byte(hashToken(token))) != 1 {
+		return ErrInvalidCallbackToken
+	}
+	completed, err := cr.complete(id, result, true)
+	if err != nil {
+		return err
+	}
+	if !completed {
+		return ErrCallbackAlreadyReceived
+	}
+	return nil
+}
+
+// complete sends the .finished event of the callback, or stores the result until the requests of the webhook have
+// been executed. If these requests are not executed by this instance of the webhook service anymore, the callback is
+// completed with the data of the .finished event that is known so far
+func (cr *CallbackRegistry) complete(id string, result CallbackResult, requestsPending bool) (bool, error) {
+	for {
+		callback, err := cr.store.Get(id)
+		if errors.Is(err, ErrCallbackNotFound) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+
+		var completed bool
+		switch {
+		case callback.State == CallbackAwaited || (callback.State == CallbackRegistered && !requestsPending):
+			completed, err = cr.finish(*callback, callback.State, result)
+		case callback.State == CallbackRegistered:
+			encodedResult, err := json.Marshal(result)
+			if err != nil {
+				return false, fmt.Errorf("could not encode result of callback: %w", err)
+			}
+			callback.State = CallbackReceived
+			callback.Result = encodedResult
+			completed, err = cr.store.Update(*callback, CallbackRegistered)
+			if err != nil {
+				return false, err
+			}
+		case !requestsPending:
+			// the result has been received by a previous instance of the webhook service
+			if err := json.Unmarshal(callback.Result, &result); err != nil {
+				return false, fmt.Errorf("could not decode result of callback: %w", err)
+			}
+			completed, err = cr.finish(*callback, CallbackReceived, result)
+		default:
+			return false, nil
+		}
+		if err != nil || completed {
+			return completed, err
+		}
+		// the state of the callback has been changed concurrently, hence its new state is processed
+	}
+}
+
+// finish removes the callback if it is still in the given state, and sends its .finished event
+func (cr *CallbackRegistry) finish(callback PendingCallback, state CallbackState, result CallbackResult) (bool, error) {
+	deleted, err := cr.store.Delete(callback.ID, state)
+	if err != nil || !deleted {
+		return false, err
+	}
+	cr.disarm(callback.ID)
+
+	event := sdk.KeptnEvent{}
+	if err := json.Unmarshal(callback.Event, &event); err != nil {
+		return false, fmt.Errorf("could not decode event of callback: %w", err)
+	}
+	finishedEventData := map[string]interface{}{}
+	if err := json.Unmarshal(callback.FinishedEventData, &finishedEventData); err != nil {
+		return false, fmt.Errorf("could not decode .finished event data of callback: %w", err)
+	}
+	cr.mutex.Lock()
+	onCompleted := cr.onCompleted
+	cr.mutex.Unlock()
+	if onCompleted == nil {
+		return false, errors.New("callback registry has not been started")
+	}
+	onCompleted(event, finishedEventData, result)
+	return true, nil
+}
+
+// arm completes the callback with an errored result once it expires
+func (cr *CallbackRegistry) arm(id string, expiresIn time.Duration, deadline time.Duration, restored bool) {
+	cr.mutex.Lock()
+	defer cr.mutex.Unlock()
+	if _, ok := cr.timers[id]; ok {
+		return
+	}
+	cr.timers[id] = time.AfterFunc(expiresIn, func() {
+		result := CallbackResult{
+			Result:  keptnv2.ResultFailed,
+			Status:  keptnv2.StatusErrored,
+			Message: fmt.Sprintf("no callback received within the deadline of %s", deadline),
+		}
+		if _, err := cr.complete(id, result, !restored); err != nil {
+			logger.WithError(err).Errorf("could not complete expired callback %s", id)
+		}
+	})
+}
+
+func (cr *CallbackRegistry) disarm(id string) {
+	cr.mutex.Lock()
+	defer cr.mutex.Unlock()
+	if timer, ok := cr.timers[id]; ok {
+		timer.Stop()
+		delete(cr.timers, id)
+	}
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,203 @@
+package lib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	keptnmongoutils "github.com/keptn/go-utils/pkg/common/mongoutils"
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const callbackCollectionName = "webhook-callbacks"
+
+// InMemoryCallbackStore keeps the pending callbacks in memory, i.e. they are lost when the webhook service is restarted
+type InMemoryCallbackStore struct {
+	callbacks map[string]PendingCallback
+	mutex     sync.Mutex
+}
+
+func NewInMemoryCallbackStore() *InMemoryCallbackStore {
+	return &InMemoryCallbackStore{callbacks: map[string]PendingCallback{}}
+}
+
+func (s *InMemoryCallbackStore) Create(callback PendingCallback) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.callbacks[callback.ID] = callback
+	return nil
+}
+
+func (s *InMemoryCallbackStore) Get(id string) (*PendingCallback, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	callback, ok := s.callbacks[id]
+	if !ok {
+		return nil, ErrCallbackNotFound
+	}
+	return &callback, nil
+}
+
+func (s *InMemoryCallbackStore) List() ([]PendingCallback, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	callbacks := []PendingCallback{}
+	for _, callback := range s.callbacks {
+		callbacks = append(callbacks, callback)
+	}
+	return callbacks, nil
+}
+
+func (s *InMemoryCallbackStore) Update(callback PendingCallback, state CallbackState) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if existing, ok := s.callbacks[callback.ID]; !ok || existing.State != state {
+		return false, nil
+	}
+	s.callbacks[callback.ID] = callback
+	return true, nil
+}
+
+func (s *InMemoryCallbackStore) Delete(id string, states ...CallbackState) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	existing, ok := s.callbacks[id]
+	if !ok || !hasCallbackState(existing, states) {
+		return false, nil
+	}
+	delete(s.callbacks, id)
+	return true, nil
+}
+
+func hasCallbackState(callback PendingCallback, states []CallbackState) bool {
+	if len(states) == 0 {
+		return true
+	}
+	for _, state := range states {
+		if callback.State == state {
+			return true
+		}
+	}
+	return false
+}
+
+// MongoDBCallbackStore stores the pending callbacks in MongoDB, so that they survive restarts of the webhook service and
+// can be received by each of its replicas. The connection is established with the first request, using the MONGODB_* env vars
+type MongoDBCallbackStore struct {
+	mutex      sync.Mutex
+	collection *mongo.Collection
+}
+
+func NewMongoDBCallbackStore() *MongoDBCallbackStore {
+	return &MongoDBCallbackStore{}
+}
+
+func (s *MongoDBCallbackStore) Create(callback PendingCallback) error {
+	collection, err := s.getCollection()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+	_, err = collection.InsertOne(ctx, callback)
+	return err
+}
+
+func (s *MongoDBCallbackStore) Get(id string) (*PendingCallback, error) {
+	collection, err := s.getCollection()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+	callback := &PendingCallback{}
+	err = collection.FindOne(ctx, bson.M{"_id": id}).Decode(callback)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrCallbackNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return callback, nil
+}
+
+func (s *MongoDBCallbackStore) List() ([]PendingCallback, error) {
+	collection, err := s.getCollection()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	callbacks := []PendingCallback{}
+	if err := cursor.All(ctx, &callbacks); err != nil {
+		return nil, err
+	}
+	return callbacks, nil
+}
+
+func (s *MongoDBCallbackStore) Update(callback PendingCallback, state CallbackState) (bool, error) {
+	collection, err := s.getCollection()
+	if err != nil {
+		return false, err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+	result, err := collection.ReplaceOne(ctx, bson.M{"_id": callback.ID, "state": state}, callback)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (s *MongoDBCallbackStore) Delete(id string, states ...CallbackState) (bool, error) {
+	collection, err := s.getCollection()
+	if err != nil {
+		return false, err
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+	filter := bson.M{"_id": id}
+	if len(states) > 0 {
+		filter["state"] = bson.M{"$in": states}
+	}
+	result, err := collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (s *MongoDBCallbackStore) getCollection() (*mongo.Collection, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.collection != nil {
+		return s.collection, nil
+	}
+
+	connectionString, databaseName, err := keptnmongoutils.GetMongoConnectionStringFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mongo client: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(connectionString).SetConnectTimeout(30*time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect client to MongoDB: %w", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, fmt.Errorf("failed to connect client to MongoDB: %w", err)
+	}
+	logger.Info("Successfully connected to MongoDB")
+
+	s.collection = client.Database(databaseName).Collection(callbackCollectionName)
+	return s.collection, nil
+}
@@ -0,0 +1,148 @@
+package lib_test
+
+import (
+	"testing"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+)
+
+type completedCallback struct {
+	event             sdk.KeptnEvent
+	finishedEventData map[string]interface{}
+	result            lib.CallbackResult
+}
+
+func startCallbackRegistry(t *testing.T, registry *lib.CallbackRegistry) chan completedCallback {
+	completed := make(chan completedCallback, 1)
+	require.Nil(t, registry.Start(func(event sdk.KeptnEvent, finishedEventData map[string]interface{}, result lib.CallbackResult) {
+		completed <- completedCallback{event: event, finishedEventData: finishedEventData, result: result}
+	}))
+	return completed
+}
+
+func receiveCompletedCallback(t *testing.T, completed chan completedCallback) completedCallback {
+	select {
+	case callback := <-completed:
+		return callback
+	case <-time.After(5 * time.Second):
+		t.Fatal("callback has not been completed")
+	}
+	return completedCallback{}
+}
+
+var callbackEvent = sdk.KeptnEvent{ID: "triggered-id", Shkeptncontext: "context-id"}
+
+func TestCallbackRegistry_Receive(t *testing.T) {
+	registry := lib.NewCallbackRegistry("http://webhook-service:8081/", lib.NewInMemoryCallbackStore())
+	completed := startCallbackRegistry(t, registry)
+
+	callback, err := registry.Register(callbackEvent, map[string]interface{}{"project": "my-project"}, time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, "http://webhook-service:8081/v1/callback/"+callback.ID, callback.URL)
+	require.NotEmpty(t, callback.Token)
+	require.Nil(t, registry.Await(callback.ID, map[string]interface{}{"project": "my-project", "result": "pass"}))
+
+	require.ErrorIs(t, registry.Receive(callback.ID, "wrong-token", lib.CallbackResult{}), lib.ErrInvalidCallbackToken)
+	require.ErrorIs(t, registry.Receive("unknown", callback.Token, lib.CallbackResult{}), lib.ErrCallbackNotFound)
+
+	require.Nil(t, registry.Receive(callback.ID, callback.Token, lib.CallbackResult{Result: keptnv2.ResultWarning, Message: "done"}))
+	require.Equal(t, completedCallback{
+		event:             callbackEvent,
+		finishedEventData: map[string]interface{}{"project": "my-project", "result": "pass"},
+		result:            lib.CallbackResult{Result: keptnv2.ResultWarning, Message: "done"},
+	}, receiveCompletedCallback(t, completed))
+
+	// callbacks can only be received once
+	require.ErrorIs(t, registry.Receive(callback.ID, callback.Token, lib.CallbackResult{}), lib.ErrCallbackNotFound)
+}
+
+func TestCallbackRegistry_ReceiveBeforeAwait(t *testing.T) {
+	registry := lib.NewCallbackRegistry("http://webhook-service:8081", lib.NewInMemoryCallbackStore())
+	completed := startCallbackRegistry(t, registry)
+
+	callback, err := registry.Register(callbackEvent, map[string]interface{}{}, time.Minute)
+	require.Nil(t, err)
+
+	require.Nil(t, registry.Receive(callback.ID, callback.Token, lib.CallbackResult{Result: keptnv2.ResultPass}))
+	require.ErrorIs(t, registry.Receive(callback.ID, callback.Token, lib.CallbackResult{}), lib.ErrCallbackAlreadyReceived)
+	require.Empty(t, completed)
+
+	require.Nil(t, registry.Await(callback.ID, map[string]interface{}{"result": "pass"}))
+	require.Equal(t, lib.CallbackResult{Result: keptnv2.ResultPass}, receiveCompletedCallback(t, completed).result)
+}
+
+func TestCallbackRegistry_Deadline(t *testing.T) {
+	registry := lib.NewCallbackRegistry("http://webhook-service:8081", lib.NewInMemoryCallbackStore())
+	completed := startCallbackRegistry(t, registry)
+
+	callback, err := registry.Register(callbackEvent, map[string]interface{}{}, 10*time.Millisecond)
+	require.Nil(t, err)
+	require.Nil(t, registry.Await(callback.ID, map[string]interface{}{"result": "pass"}))
+
+	result := receiveCompletedCallback(t, completed).result
+	require.Equal(t, keptnv2.ResultFailed, result.Result)
+	require.Equal(t, keptnv2.StatusErrored, result.Status)
+	require.Equal(t, "no callback received within the deadline of 10ms", result.Message)
+	require.ErrorIs(t, registry.Receive(callback.ID, callback.Token, lib.CallbackResult{}), lib.ErrCallbackNotFound)
+}
+
+func TestCallbackRegistry_Cancel(t *testing.T) {
+	registry := lib.NewCallbackRegistry("http://webhook-service:8081", lib.NewInMemoryCallbackStore())
+	completed := startCallbackRegistry(t, registry)
+
+	callback, err := registry.Register(callbackEvent, map[string]interface{}{}, 10*time.Millisecond)
+	require.Nil(t, err)
+	registry.Cancel(callback.ID)
+
+	require.Nil(t, registry.Await(callback.ID, map[string]interface{}{}))
+	time.Sleep(50 * time.Millisecond)
+	require.Empty(t, completed)
+	require.ErrorIs(t, registry.Receive(callback.ID, callback.Token, lib.CallbackResult{}), lib.ErrCallbackNotFound)
+}
+
+func TestCallbackRegistry_Restart(t *testing.T) {
+	store := lib.NewInMemoryCallbackStore()
+	registry := lib.NewCallbackRegistry("http://webhook-service:8081", store)
+	startCallbackRegistry(t, registry)
+
+	awaited, err := registry.Register(callbackEvent, map[string]interface{}{}, time.Minute)
+	require.Nil(t, err)
+	require.Nil(t, registry.Await(awaited.ID, map[string]interface{}{"result": "pass"}))
+	// the webhook service is restarted while the requests of this callback are executed
+	pending, err := registry.Register(callbackEvent, map[string]interface{}{"project": "my-project"}, 50*time.Millisecond)
+	require.Nil(t, err)
+
+	restarted := lib.NewCallbackRegistry("http://webhook-service:8081", store)
+	completed := startCallbackRegistry(t, restarted)
+
+	// callbacks that have been awaited before the restart are still received
+	require.Nil(t, restarted.Receive(awaited.ID, awaited.Token, lib.CallbackResult{Result: keptnv2.ResultPass}))
+	require.Equal(t, completedCallback{
+		event:             callbackEvent,
+		finishedEventData: map[string]interface{}{"result": "pass"},
+		result:            lib.CallbackResult{Result: keptnv2.ResultPass},
+	}, receiveCompletedCallback(t, completed))
+
+	// callbacks whose requests have not been finished before the restart are completed with an error once they expire
+	callback := receiveCompletedCallback(t, completed)
+	require.Equal(t, map[string]interface{}{"project": "my-project"}, callback.finishedEventData)
+	require.Equal(t, keptnv2.StatusErrored, callback.result.Status)
+	require.Equal(t, "no callback received within the deadline of 50ms", callback.result.Message)
+	require.ErrorIs(t, restarted.Receive(pending.ID, pending.Token, lib.CallbackResult{}), lib.ErrCallbackNotFound)
+}
+
+func TestCallbackResult_Validate(t *testing.T) {
+	result := lib.CallbackResult{}
+	require.Nil(t, result.Validate())
+	require.Equal(t, lib.CallbackResult{Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded}, result)
+
+	result = lib.CallbackResult{Result: "ok"}
+	require.EqualError(t, result.Validate(), "invalid result 'ok', expected one of: pass, warning, fail")
+
+	result = lib.CallbackResult{Result: keptnv2.ResultFailed, Status: "done"}
+	require.EqualError(t, result.Validate(), "invalid status 'done', expected one of: succeeded, errored")
+}
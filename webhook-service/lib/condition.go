@@ -0,0 +1,123 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Condition restricts a webhook, or a request of a webhook, to events whose value at the given JSONPath meets the condition,
+// e.g. $.data.result equals fail. Exactly one of the operators must be set
+type Condition struct {
+	Path      string      `yaml:"path"`
+	Equals    interface{} `yaml:"equals,omitempty"`
+	NotEquals interface{} `yaml:"notEquals,omitempty"`
+	// In is met if the value equals one of the given values
+	In       []interface{} `yaml:"in,omitempty"`
+	Contains interface{}   `yaml:"contains,omitempty"`
+	// Exists is met if the event contains (true) or does not contain (false) a value at the path
+	Exists *bool `yaml:"exists,omitempty"`
+}
+
+func (c Condition) validate() error {
+	if _, err := parseJSONPath(c.Path); err != nil {
+		return fmt.Errorf("invalid condition: %w", err)
+	}
+	operators := 0
+	for _, set := range []bool{c.Equals != nil, c.NotEquals != nil, len(c.In) > 0, c.Contains != nil, c.Exists != nil} {
+		if set {
+			operators++
+		}
+	}
+	if operators != 1 {
+		return fmt.Errorf("invalid condition for '%s': exactly one of 'equals', 'notEquals', 'in', 'contains' or 'exists' must be set", c.Path)
+	}
+	return nil
+}
+
+// check returns an error describing why the condition is not met by the event
+func (c Condition) check(event interface{}) error {
+	value, err := EvaluateJSONPath(event, c.Path)
+	found := err == nil
+	switch {
+	case c.Exists != nil:
+		if found != *c.Exists {
+			if *c.Exists {
+				return fmt.Errorf("'%s' does not exist", c.Path)
+			}
+			return fmt.Errorf("'%s' exists", c.Path)
+		}
+	case c.NotEquals != nil:
+		if found && jsonValuesEqual(value, c.NotEquals) {
+			return fmt.Errorf("'%s' equals '%v'", c.Path, c.NotEquals)
+		}
+	case !found:
+		return fmt.Errorf("'%s' does not exist", c.Path)
+	case c.Equals != nil:
+		if !jsonValuesEqual(value, c.Equals) {
+			return fmt.Errorf("'%s' is '%v' instead of '%v'", c.Path, value, c.Equals)
+		}
+	case len(c.In) > 0:
+		for _, item := range c.In {
+			if jsonValuesEqual(value, item) {
+				return nil
+			}
+		}
+		return fmt.Errorf("'%s' is '%v', which is not one of %v", c.Path, value, c.In)
+	case c.Contains != nil:
+		if !jsonValueContains(value, c.Contains) {
+			return fmt.Errorf("'%s' does not contain '%v'", c.Path, c.Contains)
+		}
+	}
+	return nil
+}
+
+// CheckConditions returns an error listing all conditions that are not met by the event, which is the decoded JSON document of a Keptn event
+func CheckConditions(conditions []Condition, event interface{}) error {
+	unmet := []string{}
+	for _, condition := range conditions {
+		if err := condition.check(event); err != nil {
+			unmet = append(unmet, err.Error())
+		}
+	}
+	if len(unmet) > 0 {
+		return errors.New("conditions not met: " + strings.Join(unmet, "; "))
+	}
+	return nil
+}
+
+func validateConditions(conditions []Condition) error {
+	for _, condition := range conditions {
+		if err := condition.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyConditions returns a copy of the webhook that only contains the requests whose conditions are met by the event.
+// An error is returned if the conditions of the webhook itself are not met, or if none of its requests remain
+func (wh Webhook) ApplyConditions(event interface{}) (Webhook, error) {
+	if err := CheckConditions(wh.Conditions, event); err != nil {
+		return wh, err
+	}
+	requests := []interface{}{}
+	reasons := []string{}
+	for i, req := range wh.Requests {
+		request, ok := req.(Request)
+		if !ok {
+			requests = append(requests, req)
+			continue
+		}
+		if err := CheckConditions(request.Conditions, event); err != nil {
+			reasons = append(reasons, fmt.Sprintf("request %d: %s", i+1, err.Error()))
+			continue
+		}
+		requests = append(requests, req)
+	}
+	if len(requests) == 0 {
+		return wh, errors.New("no request meets its conditions: " + strings.Join(reasons, "; "))
+	}
+	wh.Requests = requests
+	return wh, nil
+}
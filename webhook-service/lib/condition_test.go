@@ -0,0 +1,102 @@
+package lib_test
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func newConditionTestEvent() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "sh.keptn.event.evaluation.finished",
+		"data": map[string]interface{}{
+			"project": "myproject",
+			"stage":   "production",
+			"result":  "fail",
+			"labels":  map[string]interface{}{"team": "checkout"},
+			"evaluation": map[string]interface{}{
+				"score": 42.0,
+			},
+		},
+	}
+}
+
+func TestCheckConditions(t *testing.T) {
+	exists := true
+	notExists := false
+	tests := []struct {
+		name       string
+		conditions []lib.Condition
+		wantErr    string
+	}{
+		{
+			name:       "no conditions",
+			conditions: nil,
+		},
+		{
+			name: "all conditions met",
+			conditions: []lib.Condition{
+				{Path: "$.data.result", Equals: "fail"},
+				{Path: "$.data.labels.team", In: []interface{}{"checkout", "payment"}},
+				{Path: "$.data.stage", NotEquals: "dev"},
+				{Path: "$.type", Contains: "evaluation"},
+				{Path: "$.data.evaluation.score", Equals: 42},
+				{Path: "$.data.labels.owner", Exists: &notExists},
+				{Path: "$.data.evaluation", Exists: &exists},
+				{Path: "$.data.unknown", NotEquals: "value"},
+			},
+		},
+		{
+			name: "conditions not met",
+			conditions: []lib.Condition{
+				{Path: "$.data.result", Equals: "pass"},
+				{Path: "$.data.labels.team", In: []interface{}{"payment"}},
+				{Path: "$.data.stage", NotEquals: "production"},
+				{Path: "$.data.labels.owner", Equals: "jane"},
+				{Path: "$.data.evaluation", Exists: &notExists},
+			},
+			wantErr: "conditions not met: '$.data.result' is 'fail' instead of 'pass'; '$.data.labels.team' is 'checkout', which is not one of [payment]; " +
+				"'$.data.stage' equals 'production'; '$.data.labels.owner' does not exist; '$.data.evaluation' exists",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := lib.CheckConditions(tt.conditions, newConditionTestEvent())
+			if tt.wantErr == "" {
+				require.Nil(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWebhook_ApplyConditions(t *testing.T) {
+	slackRequest := lib.Request{URL: "https://slack", Method: "POST", Conditions: []lib.Condition{{Path: "$.data.stage", Equals: "production"}}}
+	teamsRequest := lib.Request{URL: "https://teams", Method: "POST", Conditions: []lib.Condition{{Path: "$.data.stage", Equals: "dev"}}}
+	ticketRequest := lib.Request{URL: "https://tickets", Method: "POST"}
+
+	webhook := lib.Webhook{
+		Conditions: []lib.Condition{{Path: "$.data.result", Equals: "fail"}},
+		Requests:   []interface{}{slackRequest, teamsRequest, ticketRequest},
+	}
+	applicable, err := webhook.ApplyConditions(newConditionTestEvent())
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{slackRequest, ticketRequest}, applicable.Requests)
+	require.Len(t, webhook.Requests, 3)
+
+	webhook.Conditions = []lib.Condition{{Path: "$.data.result", Equals: "pass"}}
+	_, err = webhook.ApplyConditions(newConditionTestEvent())
+	require.EqualError(t, err, "conditions not met: '$.data.result' is 'fail' instead of 'pass'")
+
+	webhook = lib.Webhook{Requests: []interface{}{teamsRequest}}
+	_, err = webhook.ApplyConditions(newConditionTestEvent())
+	require.EqualError(t, err, "no request meets its conditions: request 1: conditions not met: '$.data.stage' is 'production' instead of 'dev'")
+
+	// v1alpha1 requests do not have conditions
+	webhook = lib.Webhook{Requests: []interface{}{"curl http://localhost"}}
+	applicable, err = webhook.ApplyConditions(newConditionTestEvent())
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{"curl http://localhost"}, applicable.Requests)
+}
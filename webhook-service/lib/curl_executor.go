@@ -18,12 +18,18 @@ const (
 type CurlError struct {
 	err    error
 	reason errType
+	// statusCode is the status code of the response, if the request failed due to its response status
+	statusCode int
 }
 
 func (c *CurlError) Error() string {
 	return c.err.Error()
 }
 
+func (c *CurlError) Unwrap() error {
+	return c.err
+}
+
 func NewCurlError(err error, reason errType) *CurlError {
 	return &CurlError{
 		err:    err,
@@ -63,6 +69,15 @@ func IsRequestError(err error) bool {
 	return false
 }
 
+// GetResponseStatusCode returns the status code of the response that caused the error, or 0 if the error was not caused by a response
+func GetResponseStatusCode(err error) int {
+	var curlErr *CurlError
+	if errors.As(err, &curlErr) {
+		return curlErr.statusCode
+	}
+	return 0
+}
+
 //go:generate moq  -pkg fake -out ./fake/curl_executor_mock.go . ICurlExecutor
 type ICurlExecutor interface {
 	Curl(curlCmd string) (string, error)
@@ -29,6 +29,10 @@ func NewDenyListProvider(kubeClient kubernetes.Interface) DenyListProvider {
 
 func (d denyListProvider) Get() []string {
 	denyList := d.getDeniedURLs(GetEnv())
+	// without a kubernetes client, e.g. when running outside of a cluster, only the default deny list is used
+	if d.kubeClient == nil {
+		return denyList
+	}
 
 	configMap, err := d.kubeClient.CoreV1().ConfigMaps(GetNamespaceFromEnvVar()).Get(context.TODO(), WebhookConfigMap, metav1.GetOptions{})
 	if err != nil {
@@ -48,6 +48,16 @@ func TestCannotGetConfigMap(t *testing.T) {
 
 }
 
+func TestDenyListWithoutKubeClient(t *testing.T) {
+	denyListProvider := denyListProvider{
+		getDeniedURLs: func(env map[string]string) []string {
+			return []string{"1.2.3.4"}
+		},
+	}
+
+	require.Equal(t, []string{"1.2.3.4"}, denyListProvider.Get())
+}
+
 func TestGetDenyList(t *testing.T) {
 	denyListString := "some\nurl\nip"
 	tests := []struct {
@@ -0,0 +1,75 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package fake
+
+import (
+	"github.com/keptn/keptn/webhook-service/lib"
+	"sync"
+)
+
+// Ensure, that IHTTPExecutorMock does implement lib.IHTTPExecutor.
+// If this is not the case, regenerate this file with moq.
+var _ lib.IHTTPExecutor = &IHTTPExecutorMock{}
+
+// IHTTPExecutorMock is a mock implementation of lib.IHTTPExecutor.
+//
+//	func TestSomethingThatUsesIHTTPExecutor(t *testing.T) {
+//
+//		// make and configure a mocked lib.IHTTPExecutor
+//		mockedIHTTPExecutor := &IHTTPExecutorMock{
+//			ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+//				panic("mock out the Execute method")
+//			},
+//		}
+//
+//		// use mockedIHTTPExecutor in code that requires lib.IHTTPExecutor
+//		// and then make assertions.
+//
+//	}
+type IHTTPExecutorMock struct {
+	// ExecuteFunc mocks the Execute method.
+	ExecuteFunc func(request lib.Request) (*lib.HTTPResponse, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Execute holds details about calls to the Execute method.
+		Execute []struct {
+			// Request is the request argument value.
+			Request lib.Request
+		}
+	}
+	lockExecute sync.RWMutex
+}
+
+// Execute calls ExecuteFunc.
+func (mock *IHTTPExecutorMock) Execute(request lib.Request) (*lib.HTTPResponse, error) {
+	if mock.ExecuteFunc == nil {
+		panic("IHTTPExecutorMock.ExecuteFunc: method is nil but IHTTPExecutor.Execute was just called")
+	}
+	callInfo := struct {
+		Request lib.Request
+	}{
+		Request: request,
+	}
+	mock.lockExecute.Lock()
+	mock.calls.Execute = append(mock.calls.Execute, callInfo)
+	mock.lockExecute.Unlock()
+	return mock.ExecuteFunc(request)
+}
+
+// ExecuteCalls gets all the calls that were made to Execute.
+// Check the length with:
+//
+//	len(mockedIHTTPExecutor.ExecuteCalls())
+func (mock *IHTTPExecutorMock) ExecuteCalls() []struct {
+	Request lib.Request
+} {
+	var calls []struct {
+		Request lib.Request
+	}
+	mock.lockExecute.RLock()
+	calls = mock.calls.Execute
+	mock.lockExecute.RUnlock()
+	return calls
+}
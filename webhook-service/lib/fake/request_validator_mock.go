@@ -3,7 +3,8 @@ package fake
 import "github.com/keptn/keptn/webhook-service/lib"
 
 type RequestValidatorMock struct {
-	ValidateFunc func(request lib.Request) error
+	ValidateFunc        func(request lib.Request) error
+	ValidateAddressFunc func(address string) error
 }
 
 func (r RequestValidatorMock) Validate(request lib.Request) error {
@@ -12,3 +13,10 @@ func (r RequestValidatorMock) Validate(request lib.Request) error {
 	}
 	panic("implement me")
 }
+
+func (r RequestValidatorMock) ValidateAddress(address string) error {
+	if r.ValidateAddressFunc != nil {
+		return r.ValidateAddressFunc(address)
+	}
+	panic("implement me")
+}
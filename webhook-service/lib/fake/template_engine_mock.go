@@ -14,63 +14,114 @@ var _ lib.ITemplateEngine = &ITemplateEngineMock{}
 
 // ITemplateEngineMock is a mock implementation of lib.ITemplateEngine.
 //
-// 	func TestSomethingThatUsesITemplateEngine(t *testing.T) {
+//	func TestSomethingThatUsesITemplateEngine(t *testing.T) {
 //
-// 		// make and configure a mocked lib.ITemplateEngine
-// 		mockedITemplateEngine := &ITemplateEngineMock{
-// 			ParseTemplateFunc: func(date interface{}, templateStr string) (string, error) {
-// 				panic("mock out the ParseTemplate method")
-// 			},
-// 		}
+//		// make and configure a mocked lib.ITemplateEngine
+//		mockedITemplateEngine := &ITemplateEngineMock{
+//			ParseJSONTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+//				panic("mock out the ParseJSONTemplate method")
+//			},
+//			ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+//				panic("mock out the ParseTemplate method")
+//			},
+//		}
 //
-// 		// use mockedITemplateEngine in code that requires lib.ITemplateEngine
-// 		// and then make assertions.
+//		// use mockedITemplateEngine in code that requires lib.ITemplateEngine
+//		// and then make assertions.
 //
-// 	}
+//	}
 type ITemplateEngineMock struct {
+	// ParseJSONTemplateFunc mocks the ParseJSONTemplate method.
+	ParseJSONTemplateFunc func(data interface{}, templateStr string) (string, error)
+
 	// ParseTemplateFunc mocks the ParseTemplate method.
-	ParseTemplateFunc func(date interface{}, templateStr string) (string, error)
+	ParseTemplateFunc func(data interface{}, templateStr string) (string, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ParseJSONTemplate holds details about calls to the ParseJSONTemplate method.
+		ParseJSONTemplate []struct {
+			// Data is the data argument value.
+			Data interface{}
+			// TemplateStr is the templateStr argument value.
+			TemplateStr string
+		}
 		// ParseTemplate holds details about calls to the ParseTemplate method.
 		ParseTemplate []struct {
-			// Date is the date argument value.
-			Date interface{}
+			// Data is the data argument value.
+			Data interface{}
 			// TemplateStr is the templateStr argument value.
 			TemplateStr string
 		}
 	}
-	lockParseTemplate sync.RWMutex
+	lockParseJSONTemplate sync.RWMutex
+	lockParseTemplate     sync.RWMutex
+}
+
+// ParseJSONTemplate calls ParseJSONTemplateFunc.
+func (mock *ITemplateEngineMock) ParseJSONTemplate(data interface{}, templateStr string) (string, error) {
+	if mock.ParseJSONTemplateFunc == nil {
+		panic("ITemplateEngineMock.ParseJSONTemplateFunc: method is nil but ITemplateEngine.ParseJSONTemplate was just called")
+	}
+	callInfo := struct {
+		Data        interface{}
+		TemplateStr string
+	}{
+		Data:        data,
+		TemplateStr: templateStr,
+	}
+	mock.lockParseJSONTemplate.Lock()
+	mock.calls.ParseJSONTemplate = append(mock.calls.ParseJSONTemplate, callInfo)
+	mock.lockParseJSONTemplate.Unlock()
+	return mock.ParseJSONTemplateFunc(data, templateStr)
+}
+
+// ParseJSONTemplateCalls gets all the calls that were made to ParseJSONTemplate.
+// Check the length with:
+//
+//	len(mockedITemplateEngine.ParseJSONTemplateCalls())
+func (mock *ITemplateEngineMock) ParseJSONTemplateCalls() []struct {
+	Data        interface{}
+	TemplateStr string
+} {
+	var calls []struct {
+		Data        interface{}
+		TemplateStr string
+	}
+	mock.lockParseJSONTemplate.RLock()
+	calls = mock.calls.ParseJSONTemplate
+	mock.lockParseJSONTemplate.RUnlock()
+	return calls
 }
 
 // ParseTemplate calls ParseTemplateFunc.
-func (mock *ITemplateEngineMock) ParseTemplate(date interface{}, templateStr string) (string, error) {
+func (mock *ITemplateEngineMock) ParseTemplate(data interface{}, templateStr string) (string, error) {
 	if mock.ParseTemplateFunc == nil {
 		panic("ITemplateEngineMock.ParseTemplateFunc: method is nil but ITemplateEngine.ParseTemplate was just called")
 	}
 	callInfo := struct {
-		Date        interface{}
+		Data        interface{}
 		TemplateStr string
 	}{
-		Date:        date,
+		Data:        data,
 		TemplateStr: templateStr,
 	}
 	mock.lockParseTemplate.Lock()
 	mock.calls.ParseTemplate = append(mock.calls.ParseTemplate, callInfo)
 	mock.lockParseTemplate.Unlock()
-	return mock.ParseTemplateFunc(date, templateStr)
+	return mock.ParseTemplateFunc(data, templateStr)
 }
 
 // ParseTemplateCalls gets all the calls that were made to ParseTemplate.
 // Check the length with:
-//     len(mockedITemplateEngine.ParseTemplateCalls())
+//
+//	len(mockedITemplateEngine.ParseTemplateCalls())
 func (mock *ITemplateEngineMock) ParseTemplateCalls() []struct {
-	Date        interface{}
+	Data        interface{}
 	TemplateStr string
 } {
 	var calls []struct {
-		Date        interface{}
+		Data        interface{}
 		TemplateStr string
 	}
 	mock.lockParseTemplate.RLock()
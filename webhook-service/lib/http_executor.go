@@ -0,0 +1,272 @@
+package lib
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultRequestTimeout is the maximum duration of a webhook request, if no timeout is configured for the request
+	DefaultRequestTimeout = 30 * time.Second
+	// DefaultMaxResponseSize is the maximum size of a webhook response in bytes, if no size limit is configured for the request
+	DefaultMaxResponseSize int64 = 10 * 1024 * 1024
+	// curlDefaultMaxRedirects is the number of redirects followed if the --location option is set without --max-redirs
+	curlDefaultMaxRedirects = 50
+	dialTimeout             = 10 * time.Second
+)
+
+//go:generate moq  -pkg fake -out ./fake/http_executor_mock.go . IHTTPExecutor
+type IHTTPExecutor interface {
+	Execute(request Request) (*HTTPResponse, error)
+}
+
+// HTTPExecutor performs v1beta1 webhook requests using the net/http client.
+// The addresses of all connections, including those of redirects and proxies, are validated right before
+// dialing, so that host names can not be rebound to denied addresses after the request has been validated
+type HTTPExecutor struct {
+	requestValidator RequestValidator
+	timeout          time.Duration
+	maxResponseSize  int64
+}
+
+type HTTPExecutorOption func(executor *HTTPExecutor)
+
+// WithDefaultTimeout sets the timeout for requests that do not configure their own timeout
+func WithDefaultTimeout(timeout time.Duration) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.timeout = timeout
+	}
+}
+
+// WithDefaultMaxResponseSize sets the response size limit for requests that do not configure their own limit
+func WithDefaultMaxResponseSize(maxResponseSize int64) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.maxResponseSize = maxResponseSize
+	}
+}
+
+func NewHTTPExecutor(requestValidator RequestValidator, opts ...HTTPExecutorOption) *HTTPExecutor {
+	executor := &HTTPExecutor{
+		requestValidator: requestValidator,
+		timeout:          DefaultRequestTimeout,
+		maxResponseSize:  DefaultMaxResponseSize,
+	}
+	for _, o := range opts {
+		o(executor)
+	}
+	return executor
+}
+
+// requestSettings contains the transport settings of a single request, derived from the request and its options
+type requestSettings struct {
+	timeout         time.Duration
+	maxResponseSize int64
+	maxRedirects    int
+	proxy           *url.URL
+	insecure        bool
+	tlsConfig       *tls.Config
+}
+
+func (he *HTTPExecutor) Execute(request Request) (*HTTPResponse, error) {
+	settings, err := he.getRequestSettings(request)
+	if err != nil {
+		return nil, &CurlError{err: err, reason: InvalidCommandError}
+	}
+	if err := he.requestValidator.Validate(request); err != nil {
+		return nil, &CurlError{err: err, reason: DeniedURLError}
+	}
+
+	httpRequest, err := http.NewRequest(request.Method, request.URL, strings.NewReader(request.Payload))
+	if err != nil {
+		return nil, &CurlError{err: fmt.Errorf("could not create request: %w", err), reason: InvalidCommandError}
+	}
+	for _, header := range request.Headers {
+		httpRequest.Header.Add(header.Key, header.Value)
+	}
+	if request.Payload != "" && httpRequest.Header.Get("Content-Type") == "" {
+		// same default as used by curl for --data
+		httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if request.Signature != nil {
+		if request.credentials == nil || len(request.credentials.signingKey) == 0 {
+			return nil, &CurlError{err: errors.New("signing key of request is missing"), reason: InvalidCommandError}
+		}
+		// the signature is created for every attempt, so that the timestamp of retried requests is up to date
+		request.Signature.sign(httpRequest, request.credentials.signingKey, request.Payload, time.Now())
+	}
+
+	resp, err := he.newClient(settings).Do(httpRequest)
+	if err != nil {
+		var curlErr *CurlError
+		if errors.As(err, &curlErr) {
+			return nil, curlErr
+		}
+		return nil, &CurlError{err: fmt.Errorf("error during request execution: %w", err), reason: RequestError}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, settings.maxResponseSize+1))
+	if err != nil {
+		return nil, &CurlError{err: fmt.Errorf("could not read response: %w", err), reason: RequestError}
+	}
+	if int64(len(body)) > settings.maxResponseSize {
+		return nil, &CurlError{err: fmt.Errorf("response exceeds the maximum size of %d bytes", settings.maxResponseSize), reason: RequestError}
+	}
+	// if the request declares the status codes it expects, the status code is checked when evaluating the response
+	if !request.Response.hasExpectedStatusCodes() && resp.StatusCode >= http.StatusBadRequest {
+		return nil, &CurlError{err: fmt.Errorf("request failed with status code %d.\nResponse: \n%s", resp.StatusCode, body), reason: RequestError, statusCode: resp.StatusCode}
+	}
+	return &HTTPResponse{StatusCode: resp.StatusCode, Body: string(body)}, nil
+}
+
+func (he *HTTPExecutor) newClient(settings requestSettings) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: dialTimeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			return he.requestValidator.ValidateAddress(address)
+		},
+	}
+	proxy := http.ProxyFromEnvironment
+	if settings.proxy != nil {
+		proxy = http.ProxyURL(settings.proxy)
+	}
+
+	return &http.Client{
+		Timeout: settings.timeout,
+		Transport: &http.Transport{
+			Proxy:               proxy,
+			DialContext:         dialer.DialContext,
+			TLSClientConfig:     settings.tlsConfig,
+			TLSHandshakeTimeout: dialTimeout,
+			DisableKeepAlives:   true,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			// like curl, redirects are only followed if explicitly requested
+			if settings.maxRedirects == 0 {
+				return http.ErrUseLastResponse
+			}
+			if len(via) > settings.maxRedirects {
+				return &CurlError{err: fmt.Errorf("stopped after %d redirects", settings.maxRedirects), reason: RequestError}
+			}
+			if err := he.requestValidator.Validate(Request{URL: req.URL.String()}); err != nil {
+				return &CurlError{err: fmt.Errorf("redirect to %s denied: %w", req.URL.Redacted(), err), reason: DeniedURLError}
+			}
+			return nil
+		},
+	}
+}
+
+func (he *HTTPExecutor) getRequestSettings(request Request) (requestSettings, error) {
+	settings := requestSettings{
+		timeout:         he.timeout,
+		maxResponseSize: he.maxResponseSize,
+	}
+	if err := applyRequestOptions(&settings, request.Options); err != nil {
+		return settings, err
+	}
+	if request.Timeout != "" {
+		timeout, err := time.ParseDuration(request.Timeout)
+		if err != nil || timeout <= 0 {
+			return settings, fmt.Errorf("invalid timeout '%s'", request.Timeout)
+		}
+		settings.timeout = timeout
+	}
+	if request.MaxResponseSize > 0 {
+		settings.maxResponseSize = request.MaxResponseSize
+	}
+	if request.MaxRedirects != nil {
+		settings.maxRedirects = *request.MaxRedirects
+	}
+	settings.tlsConfig = &tls.Config{InsecureSkipVerify: settings.insecure} //nolint:gosec
+	if request.credentials != nil {
+		if request.credentials.clientCertificate != nil {
+			settings.tlsConfig.Certificates = []tls.Certificate{*request.credentials.clientCertificate}
+		}
+		settings.tlsConfig.RootCAs = request.credentials.rootCAs
+	}
+	return settings, nil
+}
+
+// ValidateRequestOptions checks that the options of a v1beta1 request only contain curl options supported by the HTTPExecutor
+func ValidateRequestOptions(options string) error {
+	return applyRequestOptions(&requestSettings{}, options)
+}
+
+// applyRequestOptions translates the curl options that were supported by v1beta1 requests before they were executed natively
+func applyRequestOptions(settings *requestSettings, options string) error {
+	if strings.TrimSpace(options) == "" {
+		return nil
+	}
+	args, err := parseCommandLine(options)
+	if err != nil {
+		return fmt.Errorf("could not parse request options: %w", err)
+	}
+
+	followRedirects := false
+	maxRedirects := -1
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		value := ""
+		if optionRequiresValue(arg) {
+			if i+1 >= len(args) {
+				return fmt.Errorf("option '%s' requires a value", arg)
+			}
+			i++
+			value = args[i]
+		}
+
+		switch arg {
+		case "-x", "--proxy":
+			proxyURL, err := url.Parse(value)
+			if err != nil || proxyURL.Host == "" {
+				return fmt.Errorf("invalid proxy '%s'", value)
+			}
+			settings.proxy = proxyURL
+		case "-k", "--insecure":
+			settings.insecure = true
+		case "-m", "--max-time":
+			seconds, err := strconv.ParseFloat(value, 64)
+			if err != nil || seconds <= 0 {
+				return fmt.Errorf("invalid value '%s' for option '%s'", value, arg)
+			}
+			settings.timeout = time.Duration(seconds * float64(time.Second))
+		case "--max-redirs":
+			redirects, err := strconv.Atoi(value)
+			if err != nil || redirects < 0 {
+				return fmt.Errorf("invalid value '%s' for option '%s'", value, arg)
+			}
+			maxRedirects = redirects
+		case "-L", "--location":
+			followRedirects = true
+		case "-f", "--fail", "--fail-with-body", "-s", "--silent", "-S", "--show-error":
+			// requests failing with a status code >= 400 always result in an error, and no progress is reported
+		default:
+			return fmt.Errorf("unsupported request option '%s'", arg)
+		}
+	}
+
+	if followRedirects {
+		settings.maxRedirects = curlDefaultMaxRedirects
+		if maxRedirects >= 0 {
+			settings.maxRedirects = maxRedirects
+		}
+	}
+	return nil
+}
+
+func optionRequiresValue(option string) bool {
+	switch option {
+	case "-x", "--proxy", "-m", "--max-time", "--max-redirs":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package lib_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func newAllowAllRequestValidator() *fake.RequestValidatorMock {
+	return &fake.RequestValidatorMock{
+		ValidateFunc: func(request lib.Request) error {
+			return nil
+		},
+		ValidateAddressFunc: func(address string) error {
+			return nil
+		},
+	}
+}
+
+func TestHTTPExecutor_Execute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/hook", r.URL.Path)
+		require.Equal(t, "my-token", r.Header.Get("x-token"))
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := ioutil.ReadAll(r.Body)
+		require.Equal(t, `{"project":"my-project"}`, string(body))
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	executor := lib.NewHTTPExecutor(newAllowAllRequestValidator())
+
+	response, err := executor.Execute(lib.Request{
+		URL:    server.URL + "/hook",
+		Method: http.MethodPost,
+		Headers: []lib.Header{
+			{Key: "x-token", Value: "my-token"},
+			{Key: "Content-Type", Value: "application/json"},
+		},
+		Payload: `{"project":"my-project"}`,
+		Options: "--fail-with-body --silent",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &lib.HTTPResponse{StatusCode: http.StatusOK, Body: `{"status":"ok"}`}, response)
+}
+
+func TestHTTPExecutor_Execute_ErrorStatusCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("something went wrong"))
+	}))
+	defer server.Close()
+
+	executor := lib.NewHTTPExecutor(newAllowAllRequestValidator())
+
+	response, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+
+	require.True(t, lib.IsRequestError(err))
+	require.Equal(t, "request failed with status code 500.\nResponse: \nsomething went wrong", err.Error())
+	require.Equal(t, http.StatusInternalServerError, lib.GetResponseStatusCode(err))
+	require.Nil(t, response)
+
+	// requests expecting specific status codes leave the evaluation of the status code to the response evaluation
+	response, err = executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet, Response: &lib.ResponseConfig{ExpectedStatusCodes: []int{200}}})
+	require.Nil(t, err)
+	require.Equal(t, &lib.HTTPResponse{StatusCode: http.StatusInternalServerError, Body: "something went wrong"}, response)
+}
+
+func TestHTTPExecutor_Execute_ResponseTooLarge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer server.Close()
+
+	executor := lib.NewHTTPExecutor(newAllowAllRequestValidator(), lib.WithDefaultMaxResponseSize(10))
+
+	_, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+	require.True(t, lib.IsRequestError(err))
+	require.Contains(t, err.Error(), "maximum size of 10 bytes")
+
+	response, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet, MaxResponseSize: 100})
+	require.Nil(t, err)
+	require.Len(t, response.Body, 100)
+}
+
+func TestHTTPExecutor_Execute_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	executor := lib.NewHTTPExecutor(newAllowAllRequestValidator())
+
+	_, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet, Timeout: "50ms"})
+	require.True(t, lib.IsRequestError(err))
+
+	_, err = executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet, Options: "--max-time 0.05"})
+	require.True(t, lib.IsRequestError(err))
+}
+
+func TestHTTPExecutor_Execute_Redirects(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redirect":
+			http.Redirect(w, r, server.URL+"/target", http.StatusFound)
+		case "/target":
+			w.Write([]byte("target"))
+		}
+	}))
+	defer server.Close()
+
+	executor := lib.NewHTTPExecutor(newAllowAllRequestValidator())
+
+	// redirects are not followed by default
+	response, err := executor.Execute(lib.Request{URL: server.URL + "/redirect", Method: http.MethodGet})
+	require.Nil(t, err)
+	require.Equal(t, http.StatusFound, response.StatusCode)
+
+	maxRedirects := 1
+	response, err = executor.Execute(lib.Request{URL: server.URL + "/redirect", Method: http.MethodGet, MaxRedirects: &maxRedirects})
+	require.Nil(t, err)
+	require.Equal(t, "target", response.Body)
+
+	response, err = executor.Execute(lib.Request{URL: server.URL + "/redirect", Method: http.MethodGet, Options: "--location"})
+	require.Nil(t, err)
+	require.Equal(t, "target", response.Body)
+
+	// the target of a redirect must pass the validation as well
+	validator := newAllowAllRequestValidator()
+	validator.ValidateFunc = func(request lib.Request) error {
+		if strings.HasSuffix(request.URL, "/target") {
+			return errors.New("denied")
+		}
+		return nil
+	}
+	executor = lib.NewHTTPExecutor(validator)
+
+	_, err = executor.Execute(lib.Request{URL: server.URL + "/redirect", Method: http.MethodGet, MaxRedirects: &maxRedirects})
+	require.True(t, lib.IsDeniedURLError(err))
+}
+
+func TestHTTPExecutor_Execute_DeniedAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request must not be sent to a denied address")
+	}))
+	defer server.Close()
+
+	validator := newAllowAllRequestValidator()
+	validator.ValidateAddressFunc = func(address string) error {
+		return lib.NewCurlError(errors.New("connection to denied address '127.0.0.1'"), lib.DeniedURLError)
+	}
+	executor := lib.NewHTTPExecutor(validator)
+
+	_, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+	require.True(t, lib.IsDeniedURLError(err))
+	require.Equal(t, "connection to denied address '127.0.0.1'", err.Error())
+}
+
+func TestHTTPExecutor_Execute_DeniedURL(t *testing.T) {
+	validator := newAllowAllRequestValidator()
+	validator.ValidateFunc = func(request lib.Request) error {
+		return errors.New("curl command contains denied URL 'kubernetes'")
+	}
+	executor := lib.NewHTTPExecutor(validator)
+
+	_, err := executor.Execute(lib.Request{URL: "http://kubernetes", Method: http.MethodGet})
+	require.True(t, lib.IsDeniedURLError(err))
+}
+
+func TestHTTPExecutor_Execute_InvalidOptions(t *testing.T) {
+	executor := lib.NewHTTPExecutor(newAllowAllRequestValidator())
+
+	_, err := executor.Execute(lib.Request{URL: "http://my-webhook", Method: http.MethodGet, Options: "--output /etc/passwd"})
+	require.True(t, lib.IsInvalidCommandError(err))
+	require.Equal(t, "unsupported request option '--output'", err.Error())
+
+	_, err = executor.Execute(lib.Request{URL: "http://my-webhook", Method: http.MethodGet, Options: "--proxy"})
+	require.True(t, lib.IsInvalidCommandError(err))
+
+	_, err = executor.Execute(lib.Request{URL: "http://my-webhook", Method: http.MethodGet, Timeout: "soon"})
+	require.True(t, lib.IsInvalidCommandError(err))
+}
+
+func TestHTTPExecutor_Execute_Proxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// requests sent via a proxy contain the absolute URL of the target
+		require.Equal(t, "http://my-webhook.com/hook", r.URL.String())
+		w.Write([]byte("proxied"))
+	}))
+	defer proxy.Close()
+
+	executor := lib.NewHTTPExecutor(newAllowAllRequestValidator())
+
+	response, err := executor.Execute(lib.Request{URL: "http://my-webhook.com/hook", Method: http.MethodGet, Options: "--proxy " + proxy.URL})
+	require.Nil(t, err)
+	require.Equal(t, "proxied", response.Body)
+}
+
+func TestValidateRequestOptions(t *testing.T) {
+	require.Nil(t, lib.ValidateRequestOptions(""))
+	require.Nil(t, lib.ValidateRequestOptions("--proxy http://proxy:8080 -k --max-time 10 -L --max-redirs 3"))
+	require.NotNil(t, lib.ValidateRequestOptions("--max-redirs -1"))
+	require.NotNil(t, lib.ValidateRequestOptions("--proxy not-a-url"))
+	require.NotNil(t, lib.ValidateRequestOptions("-o /tmp/out"))
+	require.NotNil(t, lib.ValidateRequestOptions("--data '{}'"))
+}
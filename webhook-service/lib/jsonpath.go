@@ -0,0 +1,85 @@
+package lib
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPathSegment is a single step of a JSONPath expression, i.e. either the key of an object, or the index of an array
+type jsonPathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parseJSONPath parses the subset of JSONPath that is supported for webhook responses, i.e. expressions like
+// $.data.items[0].name or $['my-key'].value
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("invalid JSONPath '%s': must start with '$'", path)
+	}
+	segments := []jsonPathSegment{}
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("invalid JSONPath '%s': empty key", path)
+			}
+			segments = append(segments, jsonPathSegment{key: key})
+			rest = rest[end+1:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSONPath '%s': missing ']'", path)
+			}
+			selector := rest[1:end]
+			if len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0] {
+				segments = append(segments, jsonPathSegment{key: selector[1 : len(selector)-1]})
+			} else {
+				index, err := strconv.Atoi(selector)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid JSONPath '%s': unsupported selector '[%s]'", path, selector)
+				}
+				segments = append(segments, jsonPathSegment{index: index, isIndex: true})
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid JSONPath '%s': unexpected character '%c'", path, rest[0])
+		}
+	}
+	return segments, nil
+}
+
+// EvaluateJSONPath returns the value the JSONPath expression points to within the decoded JSON document
+func EvaluateJSONPath(document interface{}, path string) (interface{}, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	current := document
+	for _, segment := range segments {
+		if segment.isIndex {
+			items, ok := current.([]interface{})
+			if !ok || segment.index >= len(items) {
+				return nil, fmt.Errorf("no value found for JSONPath '%s'", path)
+			}
+			current = items[segment.index]
+			continue
+		}
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("no value found for JSONPath '%s'", path)
+		}
+		if current, ok = object[segment.key]; !ok {
+			return nil, fmt.Errorf("no value found for JSONPath '%s'", path)
+		}
+	}
+	return current, nil
+}
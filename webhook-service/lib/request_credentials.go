@@ -0,0 +1,146 @@
+package lib
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultSignatureHeader is the header containing the HMAC signature of a request
+	DefaultSignatureHeader = "X-Keptn-Signature"
+	// DefaultSignatureTimestampHeader is the header containing the unix timestamp the signature was created at
+	DefaultSignatureTimestampHeader = "X-Keptn-Signature-Timestamp"
+
+	SignatureAlgorithmSHA256 = "sha256"
+	SignatureAlgorithmSHA512 = "sha512"
+)
+
+// SignatureConfig makes the webhook service sign a request with an HMAC over '<timestamp>.<payload>', using a secret of the webhook.
+// The receiver can reject requests with outdated timestamps to prevent replay attacks
+type SignatureConfig struct {
+	// Secret is the name of the envFrom entry containing the signing key
+	Secret string `yaml:"secret"`
+	// Algorithm is the hash function of the HMAC, either sha256 (default) or sha512
+	Algorithm string `yaml:"algorithm,omitempty"`
+	// Header is the header containing the signature in the format '<algorithm>=<hex encoded HMAC>'
+	Header string `yaml:"header,omitempty"`
+	// TimestampHeader is the header containing the unix timestamp that is part of the signed content
+	TimestampHeader string `yaml:"timestampHeader,omitempty"`
+}
+
+// TLSConfig configures a client certificate and the trusted certificate authorities of a request.
+// All properties are names of envFrom entries containing PEM encoded values
+type TLSConfig struct {
+	ClientCert string `yaml:"clientCert,omitempty"`
+	ClientKey  string `yaml:"clientKey,omitempty"`
+	CABundle   string `yaml:"caBundle,omitempty"`
+}
+
+// requestCredentials contains the secret values referenced by the signature and TLS config of a request
+type requestCredentials struct {
+	signingKey        []byte
+	clientCertificate *tls.Certificate
+	rootCAs           *x509.CertPool
+}
+
+func (sc SignatureConfig) validate(envFrom map[string]bool) error {
+	if sc.Secret == "" {
+		return errors.New("webhook request signature secret empty")
+	}
+	if !envFrom[sc.Secret] {
+		return fmt.Errorf("webhook request signature secret '%s' is not defined in envFrom", sc.Secret)
+	}
+	switch sc.Algorithm {
+	case "", SignatureAlgorithmSHA256, SignatureAlgorithmSHA512:
+	default:
+		return fmt.Errorf("unsupported webhook request signature algorithm '%s'", sc.Algorithm)
+	}
+	return nil
+}
+
+func (tc TLSConfig) validate(envFrom map[string]bool) error {
+	if (tc.ClientCert == "") != (tc.ClientKey == "") {
+		return errors.New("webhook request tls clientCert and clientKey must be set together")
+	}
+	for _, name := range []string{tc.ClientCert, tc.ClientKey, tc.CABundle} {
+		if name != "" && !envFrom[name] {
+			return fmt.Errorf("webhook request tls secret '%s' is not defined in envFrom", name)
+		}
+	}
+	return nil
+}
+
+// ResolveCredentials returns a copy of the request containing the signing key, client certificate and certificate authorities
+// referenced by its signature and TLS config, taken from the given secrets of the webhook
+func (r Request) ResolveCredentials(secrets map[string]string) (Request, error) {
+	if r.Signature == nil && r.TLS == nil {
+		return r, nil
+	}
+	credentials := &requestCredentials{}
+	if r.Signature != nil {
+		key, ok := secrets[r.Signature.Secret]
+		if !ok || key == "" {
+			return r, fmt.Errorf("signing key '%s' of request is missing", r.Signature.Secret)
+		}
+		credentials.signingKey = []byte(key)
+	}
+	if r.TLS != nil {
+		if r.TLS.ClientCert != "" {
+			certificate, err := tls.X509KeyPair([]byte(secrets[r.TLS.ClientCert]), []byte(secrets[r.TLS.ClientKey]))
+			if err != nil {
+				return r, fmt.Errorf("could not load client certificate '%s' of request: %w", r.TLS.ClientCert, err)
+			}
+			credentials.clientCertificate = &certificate
+		}
+		if r.TLS.CABundle != "" {
+			rootCAs, err := x509.SystemCertPool()
+			if err != nil {
+				rootCAs = x509.NewCertPool()
+			}
+			if !rootCAs.AppendCertsFromPEM([]byte(secrets[r.TLS.CABundle])) {
+				return r, fmt.Errorf("could not load CA bundle '%s' of request: no valid certificates found", r.TLS.CABundle)
+			}
+			credentials.rootCAs = rootCAs
+		}
+	}
+	r.credentials = credentials
+	return r, nil
+}
+
+// sign adds the signature and timestamp headers to the request
+func (sc SignatureConfig) sign(httpRequest *http.Request, key []byte, payload string, now time.Time) {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	algorithm := sc.Algorithm
+	if algorithm == "" {
+		algorithm = SignatureAlgorithmSHA256
+	}
+	httpRequest.Header.Set(headerOrDefault(sc.TimestampHeader, DefaultSignatureTimestampHeader), timestamp)
+	httpRequest.Header.Set(headerOrDefault(sc.Header, DefaultSignatureHeader), algorithm+"="+ComputeSignature(algorithm, key, timestamp, payload))
+}
+
+// ComputeSignature returns the hex encoded HMAC of '<timestamp>.<payload>', which can be used by receivers to verify the signature of a request
+func ComputeSignature(algorithm string, key []byte, timestamp string, payload string) string {
+	hashFunc := sha256.New
+	if algorithm == SignatureAlgorithmSHA512 {
+		hashFunc = sha512.New
+	}
+	mac := hmac.New(hashFunc, key)
+	mac.Write([]byte(timestamp + "." + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func headerOrDefault(header string, defaultHeader string) string {
+	if header == "" {
+		return defaultHeader
+	}
+	return header
+}
@@ -0,0 +1,134 @@
+package lib_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCertificate creates a self-signed certificate and returns the PEM encoded certificate and key
+func newTestCertificate(t *testing.T, commonName string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestComputeSignature(t *testing.T) {
+	// echo -n '1657794600.{"project":"my-project"}' | openssl dgst -sha256 -hmac my-key
+	require.Equal(t, "17401256b96d6b0337f67a9e7277802d0121941e36642484013987f09831e895", lib.ComputeSignature(lib.SignatureAlgorithmSHA256, []byte("my-key"), "1657794600", `{"project":"my-project"}`))
+	require.Len(t, lib.ComputeSignature(lib.SignatureAlgorithmSHA512, []byte("my-key"), "1657794600", `{"project":"my-project"}`), 128)
+}
+
+func TestRequest_ResolveCredentials(t *testing.T) {
+	cert, key := newTestCertificate(t, "client")
+
+	request, err := lib.Request{}.ResolveCredentials(nil)
+	require.Nil(t, err)
+	require.Equal(t, lib.Request{}, request)
+
+	_, err = lib.Request{Signature: &lib.SignatureConfig{Secret: "signingKey"}}.ResolveCredentials(map[string]string{})
+	require.EqualError(t, err, "signing key 'signingKey' of request is missing")
+
+	_, err = lib.Request{TLS: &lib.TLSConfig{ClientCert: "cert", ClientKey: "key"}}.ResolveCredentials(map[string]string{"cert": cert, "key": "invalid"})
+	require.ErrorContains(t, err, "could not load client certificate 'cert' of request")
+
+	_, err = lib.Request{TLS: &lib.TLSConfig{CABundle: "ca"}}.ResolveCredentials(map[string]string{"ca": "invalid"})
+	require.EqualError(t, err, "could not load CA bundle 'ca' of request: no valid certificates found")
+
+	_, err = lib.Request{
+		Signature: &lib.SignatureConfig{Secret: "signingKey"},
+		TLS:       &lib.TLSConfig{ClientCert: "cert", ClientKey: "key", CABundle: "ca"},
+	}.ResolveCredentials(map[string]string{"signingKey": "my-key", "cert": cert, "key": key, "ca": cert})
+	require.Nil(t, err)
+}
+
+func TestHTTPExecutor_Execute_Signature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamp := r.Header.Get("x-timestamp")
+		unixTime, err := strconv.ParseInt(timestamp, 10, 64)
+		require.Nil(t, err)
+		require.WithinDuration(t, time.Now(), time.Unix(unixTime, 0), time.Minute)
+		require.Equal(t, "sha512="+lib.ComputeSignature(lib.SignatureAlgorithmSHA512, []byte("my-key"), timestamp, `{"project":"my-project"}`), r.Header.Get(lib.DefaultSignatureHeader))
+		w.Write([]byte("verified"))
+	}))
+	defer server.Close()
+
+	request, err := lib.Request{
+		URL:       server.URL,
+		Method:    http.MethodPost,
+		Payload:   `{"project":"my-project"}`,
+		Signature: &lib.SignatureConfig{Secret: "signingKey", Algorithm: lib.SignatureAlgorithmSHA512, TimestampHeader: "x-timestamp"},
+	}.ResolveCredentials(map[string]string{"signingKey": "my-key"})
+	require.Nil(t, err)
+
+	response, err := lib.NewHTTPExecutor(newAllowAllRequestValidator()).Execute(request)
+	require.Nil(t, err)
+	require.Equal(t, "verified", response.Body)
+
+	// requests whose credentials have not been resolved are not sent unsigned
+	_, err = lib.NewHTTPExecutor(newAllowAllRequestValidator()).Execute(lib.Request{URL: server.URL, Method: http.MethodPost, Signature: &lib.SignatureConfig{Secret: "signingKey"}})
+	require.True(t, lib.IsInvalidCommandError(err))
+}
+
+func TestHTTPExecutor_Execute_MutualTLS(t *testing.T) {
+	clientCert, clientKey := newTestCertificate(t, "client")
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM([]byte(clientCert)))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello " + r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	executor := lib.NewHTTPExecutor(newAllowAllRequestValidator())
+	secrets := map[string]string{"cert": clientCert, "key": clientKey, "ca": serverCA}
+
+	request, err := lib.Request{URL: server.URL, Method: http.MethodGet, TLS: &lib.TLSConfig{ClientCert: "cert", ClientKey: "key", CABundle: "ca"}}.ResolveCredentials(secrets)
+	require.Nil(t, err)
+	response, err := executor.Execute(request)
+	require.Nil(t, err)
+	require.Equal(t, "hello client", response.Body)
+
+	// without the custom CA, the certificate of the server is not trusted
+	request, err = lib.Request{URL: server.URL, Method: http.MethodGet, TLS: &lib.TLSConfig{ClientCert: "cert", ClientKey: "key"}}.ResolveCredentials(secrets)
+	require.Nil(t, err)
+	_, err = executor.Execute(request)
+	require.ErrorContains(t, err, "certificate")
+
+	// without the client certificate, the server rejects the connection
+	request, err = lib.Request{URL: server.URL, Method: http.MethodGet, TLS: &lib.TLSConfig{CABundle: "ca"}}.ResolveCredentials(secrets)
+	require.Nil(t, err)
+	_, err = executor.Execute(request)
+	require.NotNil(t, err)
+}
@@ -2,6 +2,7 @@ package lib
 
 import (
 	"fmt"
+	"net"
 	"strings"
 )
 
@@ -12,6 +13,8 @@ type requestValidator struct {
 
 type RequestValidator interface {
 	Validate(request Request) error
+	// ValidateAddress checks the host:port address a connection is about to be established with against the deny list
+	ValidateAddress(address string) error
 }
 
 func NewRequestValidator(denyListProvider DenyListProvider, ipResolver IPResolver) RequestValidator {
@@ -43,6 +46,26 @@ func (c requestValidator) Validate(request Request) error {
 	return nil
 }
 
+func (c requestValidator) ValidateAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid address '%s': %w", address, err)
+	}
+	ipAddresses, err := c.ipResolver.Resolve("//" + address)
+	if err != nil {
+		return err
+	}
+	for _, url := range c.denyListProvider.Get() {
+		if strings.Contains(host, url) {
+			return NewCurlError(fmt.Errorf("connection to denied address '%s'", url), DeniedURLError)
+		}
+		if err := validateIPDomain(ipAddresses, url); err != nil {
+			return NewCurlError(err, DeniedURLError)
+		}
+	}
+	return nil
+}
+
 func validateIPDomain(ipAddresses AdrDomainNameMapping, url string) error {
 	for ip, hosts := range ipAddresses {
 		if strings.Contains(ip, url) {
@@ -179,3 +179,42 @@ func TestRequestValidator_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestValidator_ValidateAddress(t *testing.T) {
+	ipResolver := fake.IPResolverMock{
+		ResolveIPAdressesFunc: func(curlURL string) (lib.AdrDomainNameMapping, error) {
+			require.Equal(t, "//1.1.1.1:443", curlURL)
+			res := make(lib.AdrDomainNameMapping)
+			res["1.1.1.1"] = []string{"shipyard-controller.svc.cluster.local."}
+			return res, nil
+		},
+	}
+
+	requestValidator := lib.NewRequestValidator(fake.DenyListProviderMock{
+		GetDenyListFunc: func() []string {
+			return []string{"1.1.1.2"}
+		},
+	}, ipResolver)
+	require.Nil(t, requestValidator.ValidateAddress("1.1.1.1:443"))
+
+	requestValidator = lib.NewRequestValidator(fake.DenyListProviderMock{
+		GetDenyListFunc: func() []string {
+			return []string{"1.1.1.1"}
+		},
+	}, ipResolver)
+	err := requestValidator.ValidateAddress("1.1.1.1:443")
+	require.True(t, lib.IsDeniedURLError(err))
+	require.EqualError(t, err, "connection to denied address '1.1.1.1'")
+
+	requestValidator = lib.NewRequestValidator(fake.DenyListProviderMock{
+		GetDenyListFunc: func() []string {
+			return []string{"svc.cluster.local"}
+		},
+	}, ipResolver)
+	err = requestValidator.ValidateAddress("1.1.1.1:443")
+	require.True(t, lib.IsDeniedURLError(err))
+	require.EqualError(t, err, "curl command url resolves to denied host 'svc.cluster.local'")
+
+	err = requestValidator.ValidateAddress("1.1.1.1")
+	require.NotNil(t, err)
+}
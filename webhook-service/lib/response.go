@@ -0,0 +1,262 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+const (
+	ExtractTypeString  = "string"
+	ExtractTypeNumber  = "number"
+	ExtractTypeBoolean = "boolean"
+	ExtractTypeObject  = "object"
+)
+
+// HTTPResponse is the response of a webhook request executed by the IHTTPExecutor
+type HTTPResponse struct {
+	StatusCode int
+	Body       string
+}
+
+// ResponseConfig describes how the response of a v1beta1 webhook request is interpreted
+type ResponseConfig struct {
+	// ExpectedStatusCodes are the status codes of a successful response. If empty, all status codes below 400 are accepted
+	ExpectedStatusCodes []int `yaml:"expectedStatusCodes,omitempty"`
+	// Assertions are checked against the JSON body of the response and determine the result of the request
+	Assertions []ResponseAssertion `yaml:"assertions,omitempty"`
+	// Extract contains the properties that are copied from the JSON body of the response into the .finished event
+	Extract []ResponseExtraction `yaml:"extract,omitempty"`
+}
+
+// ResponseAssertion checks the value at the given JSONPath of the response body
+type ResponseAssertion struct {
+	Path     string      `yaml:"path"`
+	Equals   interface{} `yaml:"equals,omitempty"`
+	Contains interface{} `yaml:"contains,omitempty"`
+	// OnFailure is the result of the request if the assertion is not met, either 'fail' (default) or 'warning'
+	OnFailure string `yaml:"onFailure,omitempty"`
+}
+
+// ResponseExtraction copies the value at the given JSONPath of the response body into the property with the given name
+type ResponseExtraction struct {
+	Name string `yaml:"name"`
+	Path string `yaml:"path"`
+	// Type is the type the value is converted to, i.e. string, number, boolean or object. If empty, the value is copied as it is
+	Type string `yaml:"type,omitempty"`
+}
+
+// ResponseEvaluation is the outcome of evaluating a response against its ResponseConfig
+type ResponseEvaluation struct {
+	Result     keptnv2.ResultType
+	Messages   []string
+	Properties map[string]interface{}
+}
+
+func (rc ResponseConfig) validate() error {
+	for _, assertion := range rc.Assertions {
+		if _, err := parseJSONPath(assertion.Path); err != nil {
+			return fmt.Errorf("invalid response assertion: %w", err)
+		}
+		if assertion.Equals == nil && assertion.Contains == nil {
+			return fmt.Errorf("invalid response assertion for '%s': either 'equals' or 'contains' must be set", assertion.Path)
+		}
+		if assertion.OnFailure != "" && assertion.OnFailure != string(keptnv2.ResultFailed) && assertion.OnFailure != string(keptnv2.ResultWarning) {
+			return fmt.Errorf("invalid response assertion for '%s': unsupported onFailure value '%s'", assertion.Path, assertion.OnFailure)
+		}
+	}
+	names := map[string]bool{}
+	for _, extraction := range rc.Extract {
+		if extraction.Name == "" {
+			return fmt.Errorf("invalid response extraction: name must not be empty")
+		}
+		if extraction.Name == "responses" || extraction.Name == "attempts" || names[extraction.Name] {
+			return fmt.Errorf("invalid response extraction: duplicate property '%s'", extraction.Name)
+		}
+		names[extraction.Name] = true
+		if _, err := parseJSONPath(extraction.Path); err != nil {
+			return fmt.Errorf("invalid response extraction: %w", err)
+		}
+		switch extraction.Type {
+		case "", ExtractTypeString, ExtractTypeNumber, ExtractTypeBoolean, ExtractTypeObject:
+		default:
+			return fmt.Errorf("invalid response extraction for '%s': unsupported type '%s'", extraction.Name, extraction.Type)
+		}
+	}
+	return nil
+}
+
+func (rc *ResponseConfig) hasExpectedStatusCodes() bool {
+	return rc != nil && len(rc.ExpectedStatusCodes) > 0
+}
+
+// AcceptsStatusCode returns true if the status code denotes a successful response
+func (rc *ResponseConfig) AcceptsStatusCode(statusCode int) bool {
+	if !rc.hasExpectedStatusCodes() {
+		return statusCode < 400
+	}
+	for _, code := range rc.ExpectedStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate checks the response against the expected status codes and assertions, and extracts the configured properties
+func (rc *ResponseConfig) Evaluate(response HTTPResponse) ResponseEvaluation {
+	evaluation := ResponseEvaluation{Result: keptnv2.ResultPass, Properties: map[string]interface{}{}}
+	if rc == nil {
+		return evaluation
+	}
+	if !rc.AcceptsStatusCode(response.StatusCode) {
+		evaluation.fail(keptnv2.ResultFailed, fmt.Sprintf("unexpected status code %d, expected one of %v", response.StatusCode, rc.ExpectedStatusCodes))
+		return evaluation
+	}
+	if len(rc.Assertions) == 0 && len(rc.Extract) == 0 {
+		return evaluation
+	}
+
+	var body interface{}
+	if err := json.Unmarshal([]byte(response.Body), &body); err != nil {
+		evaluation.fail(keptnv2.ResultFailed, fmt.Sprintf("response is not a valid JSON document: %s", err.Error()))
+		return evaluation
+	}
+
+	for _, assertion := range rc.Assertions {
+		if err := assertion.check(body); err != nil {
+			onFailure := keptnv2.ResultFailed
+			if assertion.OnFailure == string(keptnv2.ResultWarning) {
+				onFailure = keptnv2.ResultWarning
+			}
+			evaluation.fail(onFailure, err.Error())
+		}
+	}
+	for _, extraction := range rc.Extract {
+		value, err := extraction.extract(body)
+		if err != nil {
+			evaluation.fail(keptnv2.ResultFailed, err.Error())
+			continue
+		}
+		evaluation.Properties[extraction.Name] = value
+	}
+	return evaluation
+}
+
+func (re *ResponseEvaluation) fail(result keptnv2.ResultType, message string) {
+	re.Messages = append(re.Messages, message)
+	re.Result = WorseResult(re.Result, result)
+}
+
+// WorseResult returns the worse of the two results, where fail is worse than warning, and warning is worse than pass
+func WorseResult(a, b keptnv2.ResultType) keptnv2.ResultType {
+	rank := func(result keptnv2.ResultType) int {
+		switch result {
+		case keptnv2.ResultFailed:
+			return 2
+		case keptnv2.ResultWarning:
+			return 1
+		}
+		return 0
+	}
+	if rank(b) > rank(a) {
+		return b
+	}
+	return a
+}
+
+func (ra ResponseAssertion) check(body interface{}) error {
+	value, err := EvaluateJSONPath(body, ra.Path)
+	if err != nil {
+		return fmt.Errorf("assertion for '%s' failed: %w", ra.Path, err)
+	}
+	if ra.Equals != nil && !jsonValuesEqual(value, ra.Equals) {
+		return fmt.Errorf("assertion for '%s' failed: expected '%v' but was '%v'", ra.Path, ra.Equals, value)
+	}
+	if ra.Contains != nil && !jsonValueContains(value, ra.Contains) {
+		return fmt.Errorf("assertion for '%s' failed: '%v' does not contain '%v'", ra.Path, value, ra.Contains)
+	}
+	return nil
+}
+
+func (re ResponseExtraction) extract(body interface{}) (interface{}, error) {
+	value, err := EvaluateJSONPath(body, re.Path)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract property '%s': %w", re.Name, err)
+	}
+	switch re.Type {
+	case ExtractTypeString:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case map[string]interface{}, []interface{}:
+			encoded, _ := json.Marshal(v)
+			return string(encoded), nil
+		}
+		return fmt.Sprint(value), nil
+	case ExtractTypeNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case string:
+			if number, err := strconv.ParseFloat(v, 64); err == nil {
+				return number, nil
+			}
+		}
+	case ExtractTypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if boolean, err := strconv.ParseBool(v); err == nil {
+				return boolean, nil
+			}
+		}
+	case ExtractTypeObject:
+		if _, ok := value.(map[string]interface{}); ok {
+			return value, nil
+		}
+	default:
+		return value, nil
+	}
+	return nil, fmt.Errorf("could not extract property '%s': value '%v' is not of type %s", re.Name, value, re.Type)
+}
+
+// jsonValuesEqual compares a value of a JSON document with a value of the webhook configuration
+func jsonValuesEqual(actual interface{}, expected interface{}) bool {
+	switch actual.(type) {
+	case map[string]interface{}, []interface{}:
+		// normalize the expected value to the types used by encoding/json
+		encoded, err := json.Marshal(expected)
+		if err != nil {
+			return false
+		}
+		var normalized interface{}
+		if err := json.Unmarshal(encoded, &normalized); err != nil {
+			return false
+		}
+		return reflect.DeepEqual(actual, normalized)
+	}
+	return fmt.Sprint(actual) == fmt.Sprint(expected)
+}
+
+func jsonValueContains(actual interface{}, expected interface{}) bool {
+	switch v := actual.(type) {
+	case string:
+		return strings.Contains(v, fmt.Sprint(expected))
+	case []interface{}:
+		for _, item := range v {
+			if jsonValuesEqual(item, expected) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		_, ok := v[fmt.Sprint(expected)]
+		return ok
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package lib_test
+
+import (
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+)
+
+const testResponseBody = `{
+  "status": "SUCCESS",
+  "build": {"id": 42, "url": "https://ci/builds/42", "labels": ["nightly", "release"], "green": "true"},
+  "my-key": {"value": "1.5"}
+}`
+
+func TestEvaluateJSONPath(t *testing.T) {
+	document := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "first"},
+		},
+		"my-key": "value",
+	}
+
+	value, err := lib.EvaluateJSONPath(document, "$.items[0].name")
+	require.Nil(t, err)
+	require.Equal(t, "first", value)
+
+	value, err = lib.EvaluateJSONPath(document, "$['my-key']")
+	require.Nil(t, err)
+	require.Equal(t, "value", value)
+
+	value, err = lib.EvaluateJSONPath(document, "$")
+	require.Nil(t, err)
+	require.Equal(t, document, value)
+
+	_, err = lib.EvaluateJSONPath(document, "$.items[1].name")
+	require.EqualError(t, err, "no value found for JSONPath '$.items[1].name'")
+
+	_, err = lib.EvaluateJSONPath(document, "items")
+	require.NotNil(t, err)
+
+	_, err = lib.EvaluateJSONPath(document, "$.items[*]")
+	require.NotNil(t, err)
+}
+
+func TestResponseConfig_Evaluate(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         *lib.ResponseConfig
+		response       lib.HTTPResponse
+		wantResult     keptnv2.ResultType
+		wantMessages   []string
+		wantProperties map[string]interface{}
+	}{
+		{
+			name:           "no config",
+			response:       lib.HTTPResponse{StatusCode: 200, Body: "not json"},
+			wantResult:     keptnv2.ResultPass,
+			wantProperties: map[string]interface{}{},
+		},
+		{
+			name:           "expected status code",
+			config:         &lib.ResponseConfig{ExpectedStatusCodes: []int{200, 202}},
+			response:       lib.HTTPResponse{StatusCode: 202},
+			wantResult:     keptnv2.ResultPass,
+			wantProperties: map[string]interface{}{},
+		},
+		{
+			name:           "unexpected status code",
+			config:         &lib.ResponseConfig{ExpectedStatusCodes: []int{200, 202}},
+			response:       lib.HTTPResponse{StatusCode: 404},
+			wantResult:     keptnv2.ResultFailed,
+			wantMessages:   []string{"unexpected status code 404, expected one of [200 202]"},
+			wantProperties: map[string]interface{}{},
+		},
+		{
+			name: "assertions met",
+			config: &lib.ResponseConfig{Assertions: []lib.ResponseAssertion{
+				{Path: "$.status", Equals: "SUCCESS"},
+				{Path: "$.build.id", Equals: 42},
+				{Path: "$.build.url", Contains: "/builds/"},
+				{Path: "$.build.labels", Contains: "release"},
+				{Path: "$.build", Contains: "labels"},
+			}},
+			response:       lib.HTTPResponse{StatusCode: 200, Body: testResponseBody},
+			wantResult:     keptnv2.ResultPass,
+			wantProperties: map[string]interface{}{},
+		},
+		{
+			name: "assertions not met",
+			config: &lib.ResponseConfig{Assertions: []lib.ResponseAssertion{
+				{Path: "$.status", Equals: "FAILED", OnFailure: "warning"},
+				{Path: "$.build.labels", Contains: "hotfix"},
+				{Path: "$.unknown", Equals: "x", OnFailure: "warning"},
+			}},
+			response:   lib.HTTPResponse{StatusCode: 200, Body: testResponseBody},
+			wantResult: keptnv2.ResultFailed,
+			wantMessages: []string{
+				"assertion for '$.status' failed: expected 'FAILED' but was 'SUCCESS'",
+				"assertion for '$.build.labels' failed: '[nightly release]' does not contain 'hotfix'",
+				"assertion for '$.unknown' failed: no value found for JSONPath '$.unknown'",
+			},
+			wantProperties: map[string]interface{}{},
+		},
+		{
+			name: "warning",
+			config: &lib.ResponseConfig{Assertions: []lib.ResponseAssertion{
+				{Path: "$.status", Equals: "FAILED", OnFailure: "warning"},
+			}},
+			response:       lib.HTTPResponse{StatusCode: 200, Body: testResponseBody},
+			wantResult:     keptnv2.ResultWarning,
+			wantMessages:   []string{"assertion for '$.status' failed: expected 'FAILED' but was 'SUCCESS'"},
+			wantProperties: map[string]interface{}{},
+		},
+		{
+			name: "extract properties",
+			config: &lib.ResponseConfig{Extract: []lib.ResponseExtraction{
+				{Name: "buildId", Path: "$.build.id", Type: "string"},
+				{Name: "buildNumber", Path: "$.build.id", Type: "number"},
+				{Name: "version", Path: "$['my-key'].value", Type: "number"},
+				{Name: "green", Path: "$.build.green", Type: "boolean"},
+				{Name: "build", Path: "$.build", Type: "object"},
+				{Name: "labels", Path: "$.build.labels"},
+			}},
+			response:   lib.HTTPResponse{StatusCode: 200, Body: testResponseBody},
+			wantResult: keptnv2.ResultPass,
+			wantProperties: map[string]interface{}{
+				"buildId":     "42",
+				"buildNumber": float64(42),
+				"version":     1.5,
+				"green":       true,
+				"build": map[string]interface{}{
+					"id":     float64(42),
+					"url":    "https://ci/builds/42",
+					"labels": []interface{}{"nightly", "release"},
+					"green":  "true",
+				},
+				"labels": []interface{}{"nightly", "release"},
+			},
+		},
+		{
+			name: "extract properties with wrong type",
+			config: &lib.ResponseConfig{Extract: []lib.ResponseExtraction{
+				{Name: "status", Path: "$.status", Type: "number"},
+				{Name: "url", Path: "$.build.url"},
+			}},
+			response:       lib.HTTPResponse{StatusCode: 200, Body: testResponseBody},
+			wantResult:     keptnv2.ResultFailed,
+			wantMessages:   []string{"could not extract property 'status': value 'SUCCESS' is not of type number"},
+			wantProperties: map[string]interface{}{"url": "https://ci/builds/42"},
+		},
+		{
+			name: "invalid JSON",
+			config: &lib.ResponseConfig{Extract: []lib.ResponseExtraction{
+				{Name: "status", Path: "$.status"},
+			}},
+			response:       lib.HTTPResponse{StatusCode: 200, Body: "<html></html>"},
+			wantResult:     keptnv2.ResultFailed,
+			wantMessages:   []string{"response is not a valid JSON document: invalid character '<' looking for beginning of value"},
+			wantProperties: map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluation := tt.config.Evaluate(tt.response)
+			require.Equal(t, tt.wantResult, evaluation.Result)
+			require.Equal(t, tt.wantMessages, evaluation.Messages)
+			require.Equal(t, tt.wantProperties, evaluation.Properties)
+		})
+	}
+}
+
+func TestWorseResult(t *testing.T) {
+	require.Equal(t, keptnv2.ResultWarning, lib.WorseResult(keptnv2.ResultPass, keptnv2.ResultWarning))
+	require.Equal(t, keptnv2.ResultFailed, lib.WorseResult(keptnv2.ResultFailed, keptnv2.ResultWarning))
+	require.Equal(t, keptnv2.ResultPass, lib.WorseResult(keptnv2.ResultPass, keptnv2.ResultPass))
+}
@@ -0,0 +1,137 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// DefaultRetryAttempts is the number of attempts of a request with a retry config that does not set the attempts
+	DefaultRetryAttempts = 3
+	// DefaultRetryBackoff is the delay before the first retry, if no backoff is configured
+	DefaultRetryBackoff = time.Second
+	// DefaultRetryMaxBackoff is the maximum delay between two attempts, if no maximum is configured
+	DefaultRetryMaxBackoff = 30 * time.Second
+	// maxRetryAttempts limits the number of attempts, so that a webhook can not block the webhook service for too long
+	maxRetryAttempts = 10
+)
+
+// DefaultRetryStatusCodes are the status codes that are retried, if no status codes are configured
+var DefaultRetryStatusCodes = []int{429, 502, 503, 504}
+
+// RetryConfig defines if, and how often, a failed request is repeated
+type RetryConfig struct {
+	// Attempts is the maximum number of attempts, including the first one
+	Attempts int `yaml:"attempts,omitempty"`
+	// Backoff is the delay before the first retry, e.g. 1s. The delay is doubled for every further retry
+	Backoff string `yaml:"backoff,omitempty"`
+	// MaxBackoff is the maximum delay between two attempts
+	MaxBackoff string `yaml:"maxBackoff,omitempty"`
+	// StatusCodes are the status codes of responses that are retried
+	StatusCodes []int `yaml:"statusCodes,omitempty"`
+	// NetworkErrors defines if connection errors and timeouts are retried (default: true)
+	NetworkErrors *bool `yaml:"networkErrors,omitempty"`
+	// IdempotencyHeader is the name of a header that contains the same random key for all attempts of the request
+	IdempotencyHeader string `yaml:"idempotencyHeader,omitempty"`
+}
+
+func (rc RetryConfig) validate() error {
+	if rc.Attempts < 0 || rc.Attempts > maxRetryAttempts {
+		return fmt.Errorf("invalid retry attempts %d, must be between 0 (default) and %d", rc.Attempts, maxRetryAttempts)
+	}
+	for name, value := range map[string]string{"backoff": rc.Backoff, "maxBackoff": rc.MaxBackoff} {
+		if value == "" {
+			continue
+		}
+		if duration, err := time.ParseDuration(value); err != nil || duration < 0 {
+			return fmt.Errorf("invalid retry %s '%s'", name, value)
+		}
+	}
+	for _, code := range rc.StatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("invalid retry status code %d", code)
+		}
+	}
+	return nil
+}
+
+// GetAttempts returns the maximum number of attempts of a request with the given retry config, which is 1 if the config is nil
+func (rc *RetryConfig) GetAttempts() int {
+	if rc == nil {
+		return 1
+	}
+	if rc.Attempts <= 0 {
+		return DefaultRetryAttempts
+	}
+	return rc.Attempts
+}
+
+// GetBackoff returns the delay before the given retry, starting with 1 for the first retry
+func (rc *RetryConfig) GetBackoff(retry int) time.Duration {
+	backoff := parseDurationOrDefault(rc.Backoff, DefaultRetryBackoff)
+	maxBackoff := parseDurationOrDefault(rc.MaxBackoff, DefaultRetryMaxBackoff)
+	for i := 1; i < retry && backoff < maxBackoff; i++ {
+		backoff = backoff * 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+// IsRetryable returns true if the outcome of an attempt should be retried, i.e. if the request failed with a network error,
+// or if the response has one of the retried status codes. Responses that are accepted by the response config are not retried
+func (rc *RetryConfig) IsRetryable(response *HTTPResponse, err error, responseConfig *ResponseConfig) bool {
+	if rc == nil {
+		return false
+	}
+	statusCode := 0
+	if err != nil {
+		statusCode = GetResponseStatusCode(err)
+		if statusCode == 0 {
+			return rc.retriesNetworkErrors() && isNetworkError(err)
+		}
+	} else if response != nil {
+		if responseConfig.AcceptsStatusCode(response.StatusCode) {
+			return false
+		}
+		statusCode = response.StatusCode
+	}
+	statusCodes := rc.StatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = DefaultRetryStatusCodes
+	}
+	for _, code := range statusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+func (rc *RetryConfig) retriesNetworkErrors() bool {
+	return rc.NetworkErrors == nil || *rc.NetworkErrors
+}
+
+// NewIdempotencyKey creates a random key that is sent with all attempts of a request
+func NewIdempotencyKey() (string, error) {
+	return randomHex(16)
+}
+
+func isNetworkError(err error) bool {
+	if IsDeniedURLError(err) {
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+func parseDurationOrDefault(value string, defaultValue time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return defaultValue
+	}
+	return duration
+}
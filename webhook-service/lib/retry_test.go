@@ -0,0 +1,118 @@
+package lib_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryConfig_GetAttempts(t *testing.T) {
+	var noRetry *lib.RetryConfig
+	require.Equal(t, 1, noRetry.GetAttempts())
+	require.Equal(t, lib.DefaultRetryAttempts, (&lib.RetryConfig{}).GetAttempts())
+	require.Equal(t, 5, (&lib.RetryConfig{Attempts: 5}).GetAttempts())
+}
+
+func TestRetryConfig_GetBackoff(t *testing.T) {
+	retry := &lib.RetryConfig{Backoff: "2s", MaxBackoff: "10s"}
+	require.Equal(t, 2*time.Second, retry.GetBackoff(1))
+	require.Equal(t, 4*time.Second, retry.GetBackoff(2))
+	require.Equal(t, 8*time.Second, retry.GetBackoff(3))
+	require.Equal(t, 10*time.Second, retry.GetBackoff(4))
+	require.Equal(t, 10*time.Second, retry.GetBackoff(100))
+
+	require.Equal(t, lib.DefaultRetryBackoff, (&lib.RetryConfig{}).GetBackoff(1))
+}
+
+func TestRetryConfig_IsRetryable(t *testing.T) {
+	networkErr := lib.NewCurlError(&url.Error{Op: "Post", URL: "http://local", Err: errors.New("connection refused")}, lib.RequestError)
+	noNetworkErrors := false
+
+	tests := []struct {
+		name           string
+		retry          *lib.RetryConfig
+		response       *lib.HTTPResponse
+		err            error
+		responseConfig *lib.ResponseConfig
+		want           bool
+	}{
+		{
+			name:     "no retry config",
+			response: &lib.HTTPResponse{StatusCode: 503},
+			want:     false,
+		},
+		{
+			name:     "successful response",
+			retry:    &lib.RetryConfig{},
+			response: &lib.HTTPResponse{StatusCode: 200},
+			want:     false,
+		},
+		{
+			name:     "default status code",
+			retry:    &lib.RetryConfig{},
+			response: &lib.HTTPResponse{StatusCode: 503},
+			want:     true,
+		},
+		{
+			name:  "other error",
+			retry: &lib.RetryConfig{StatusCodes: []int{500}},
+			err:   errors.New("could not read response"),
+			want:  false,
+		},
+		{
+			name:     "status code not retried",
+			retry:    &lib.RetryConfig{StatusCodes: []int{500}},
+			response: &lib.HTTPResponse{StatusCode: 503},
+			want:     false,
+		},
+		{
+			name:           "expected status code",
+			retry:          &lib.RetryConfig{StatusCodes: []int{503}},
+			response:       &lib.HTTPResponse{StatusCode: 503},
+			responseConfig: &lib.ResponseConfig{ExpectedStatusCodes: []int{503}},
+			want:           false,
+		},
+		{
+			name:  "network error",
+			retry: &lib.RetryConfig{},
+			err:   networkErr,
+			want:  true,
+		},
+		{
+			name:  "network errors disabled",
+			retry: &lib.RetryConfig{NetworkErrors: &noNetworkErrors},
+			err:   networkErr,
+			want:  false,
+		},
+		{
+			name:  "denied URL",
+			retry: &lib.RetryConfig{},
+			err:   lib.NewCurlError(&url.Error{Op: "Post", URL: "http://local", Err: errors.New("denied")}, lib.DeniedURLError),
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.retry.IsRetryable(tt.response, tt.err, tt.responseConfig))
+		})
+	}
+}
+
+func TestRetryConfig_IsRetryable_ErrorStatusCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := lib.NewHTTPExecutor(newAllowAllRequestValidator()).Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+	require.NotNil(t, err)
+
+	require.True(t, (&lib.RetryConfig{}).IsRetryable(nil, err, nil))
+	require.False(t, (&lib.RetryConfig{StatusCodes: []int{503}}).IsRetryable(nil, err, nil))
+}
@@ -3,10 +3,25 @@ package lib
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
+// DefaultSecretEnvPrefix is the prefix of the environment variables read by the EnvSecretReader, if no prefix is configured
+const DefaultSecretEnvPrefix = "WEBHOOK_SECRET_"
+
+var ErrSecretNotFound = errors.New("secret not found")
+var ErrSecretKeyNotFound = errors.New("key not found in secret")
+
+var invalidEnvVarChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
 //go:generate moq  -pkg fake -out ./fake/secret_reader_mock.go . ISecretReader
 type ISecretReader interface {
 	ReadSecret(name, key string) (string, error)
@@ -23,11 +38,76 @@ func NewK8sSecretReader(k8sClient kubernetes.Interface) *K8sSecretReater {
 func (sr *K8sSecretReater) ReadSecret(name, key string) (string, error) {
 	secret, err := sr.k8sClient.CoreV1().Secrets(GetNamespaceFromEnvVar()).Get(context.TODO(), name, metav1.GetOptions{})
 	if err != nil {
-		return "", err
+		if k8serr.IsNotFound(err) {
+			return "", fmt.Errorf("%w in namespace %s", ErrSecretNotFound, GetNamespaceFromEnvVar())
+		}
+		return "", fmt.Errorf("could not get kubernetes secret: %w", err)
 	}
 	// only allow reading from secrets that are managed by Keptn's secret-service
 	if secret.Labels["app.kubernetes.io/managed-by"] != "keptn-secret-service" {
 		return "", errors.New("only secrets managed by Keptn's secret-service can be referenced")
 	}
-	return string(secret.Data[key]), nil
+	value, ok := secret.Data[key]
+	if !ok {
+		return "", ErrSecretKeyNotFound
+	}
+	return string(value), nil
+}
+
+// FileSecretReader reads secrets from files structured as <directory>/<secret name>/<key>,
+// which is the layout of Kubernetes secrets mounted as volumes into the directory
+type FileSecretReader struct {
+	directory string
+}
+
+func NewFileSecretReader(directory string) *FileSecretReader {
+	return &FileSecretReader{directory: directory}
+}
+
+// ReadSecret returns the content of the file of the secret key. A trailing line break is removed from the content
+func (sr *FileSecretReader) ReadSecret(name, key string) (string, error) {
+	if !isPlainFileName(name) || !isPlainFileName(key) {
+		return "", fmt.Errorf("invalid secret reference: name and key must not contain path elements")
+	}
+	path := filepath.Join(sr.directory, name, key)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			if _, statErr := os.Stat(filepath.Join(sr.directory, name)); statErr != nil {
+				return "", fmt.Errorf("%w in directory %s", ErrSecretNotFound, sr.directory)
+			}
+			return "", fmt.Errorf("%w: file %s does not exist", ErrSecretKeyNotFound, path)
+		}
+		return "", fmt.Errorf("could not read file %s: %w", path, err)
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(string(content), "\n"), "\r"), nil
+}
+
+func isPlainFileName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// EnvSecretReader reads secrets from environment variables named <prefix><SECRET NAME>_<KEY>,
+// with all characters that are not allowed within environment variable names replaced by '_',
+// e.g. WEBHOOK_SECRET_MY_SECRET_TOKEN for the key token of the secret my-secret
+type EnvSecretReader struct {
+	prefix string
+}
+
+func NewEnvSecretReader(prefix string) *EnvSecretReader {
+	return &EnvSecretReader{prefix: prefix}
+}
+
+func (sr *EnvSecretReader) ReadSecret(name, key string) (string, error) {
+	envVarName := sr.EnvVarName(name, key)
+	value, ok := os.LookupEnv(envVarName)
+	if !ok {
+		return "", fmt.Errorf("%w: environment variable %s is not set", ErrSecretNotFound, envVarName)
+	}
+	return value, nil
+}
+
+// EnvVarName returns the name of the environment variable containing the value of the secret key
+func (sr *EnvSecretReader) EnvVarName(name, key string) string {
+	return sr.prefix + invalidEnvVarChars.ReplaceAllString(strings.ToUpper(name+"_"+key), "_")
 }
@@ -0,0 +1,58 @@
+package lib
+
+import (
+	"sync"
+	"time"
+)
+
+type cachedSecret struct {
+	value     string
+	expiresAt time.Time
+}
+
+// CachingSecretReader caches the secrets read by another ISecretReader for a fixed time.
+// Failed reads are not cached, so that missing secrets can be added without waiting for the cache to expire
+type CachingSecretReader struct {
+	reader  ISecretReader
+	ttl     time.Duration
+	now     func() time.Time
+	mutex   sync.Mutex
+	secrets map[string]cachedSecret
+}
+
+func NewCachingSecretReader(reader ISecretReader, ttl time.Duration) *CachingSecretReader {
+	return &CachingSecretReader{
+		reader:  reader,
+		ttl:     ttl,
+		now:     time.Now,
+		secrets: map[string]cachedSecret{},
+	}
+}
+
+func (sr *CachingSecretReader) ReadSecret(name, key string) (string, error) {
+	// the name may contain slashes, e.g. the path of a vault secret
+	cacheKey := name + "\x00" + key
+
+	sr.mutex.Lock()
+	cached, ok := sr.secrets[cacheKey]
+	sr.mutex.Unlock()
+	if ok && sr.now().Before(cached.expiresAt) {
+		return cached.value, nil
+	}
+
+	value, err := sr.reader.ReadSecret(name, key)
+	if err != nil {
+		return "", err
+	}
+
+	sr.mutex.Lock()
+	defer sr.mutex.Unlock()
+	sr.secrets[cacheKey] = cachedSecret{value: value, expiresAt: sr.now().Add(sr.ttl)}
+	// remove expired secrets, so that secrets that are no longer referenced do not stay in memory
+	for k, secret := range sr.secrets {
+		if !sr.now().Before(secret.expiresAt) {
+			delete(sr.secrets, k)
+		}
+	}
+	return value, nil
+}
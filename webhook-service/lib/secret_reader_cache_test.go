@@ -0,0 +1,63 @@
+package lib
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingSecretReader struct {
+	reads int
+	err   error
+}
+
+func (sr *countingSecretReader) ReadSecret(name, key string) (string, error) {
+	sr.reads++
+	if sr.err != nil {
+		return "", sr.err
+	}
+	return name + "/" + key, nil
+}
+
+func TestCachingSecretReader_ReadSecret(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	reader := &countingSecretReader{}
+	secretReader := NewCachingSecretReader(reader, time.Minute)
+	secretReader.now = func() time.Time { return now }
+
+	secret, err := secretReader.ReadSecret("my-secret", "token")
+	require.Nil(t, err)
+	require.Equal(t, "my-secret/token", secret)
+
+	secret, err = secretReader.ReadSecret("my-secret", "token")
+	require.Nil(t, err)
+	require.Equal(t, "my-secret/token", secret)
+	require.Equal(t, 1, reader.reads)
+
+	_, err = secretReader.ReadSecret("my-secret", "password")
+	require.Nil(t, err)
+	require.Equal(t, 2, reader.reads)
+
+	now = now.Add(time.Minute)
+	_, err = secretReader.ReadSecret("my-secret", "token")
+	require.Nil(t, err)
+	require.Equal(t, 3, reader.reads)
+	// the expired secret has been removed from the cache
+	require.Len(t, secretReader.secrets, 1)
+}
+
+func TestCachingSecretReader_ReadSecretFails(t *testing.T) {
+	reader := &countingSecretReader{err: errors.New("unavailable")}
+	secretReader := NewCachingSecretReader(reader, time.Minute)
+
+	_, err := secretReader.ReadSecret("my-secret", "token")
+	require.EqualError(t, err, "unavailable")
+
+	reader.err = nil
+	secret, err := secretReader.ReadSecret("my-secret", "token")
+	require.Nil(t, err)
+	require.Equal(t, "my-secret/token", secret)
+	require.Equal(t, 2, reader.reads)
+}
@@ -0,0 +1,166 @@
+package lib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultServiceAccountTokenFile is the token the webhook service authenticates with at the secret-service
+	DefaultServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	// DefaultVaultMount is the mount path of the KV secrets engine, if no mount path is configured
+	DefaultVaultMount   = "secret"
+	secretServiceSource = "secret-service"
+	vaultSource         = "vault"
+	secretReadTimeout   = 10 * time.Second
+	maxSecretReadSize   = 1024 * 1024
+)
+
+// SecretServiceReader reads secrets from the secret-service. The webhook service authenticates with the token of its
+// service account, so only secrets within the scope of the service account can be read
+type SecretServiceReader struct {
+	baseURL   string
+	tokenFile string
+	client    *http.Client
+}
+
+func NewSecretServiceReader(baseURL string, tokenFile string) *SecretServiceReader {
+	return &SecretServiceReader{
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		tokenFile: tokenFile,
+		client:    &http.Client{Timeout: secretReadTimeout},
+	}
+}
+
+func (sr *SecretServiceReader) ReadSecret(name, key string) (string, error) {
+	// the token is read for every request, since projected service account tokens are rotated
+	token, err := os.ReadFile(sr.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("could not read service account token: %w", err)
+	}
+	query := url.Values{"name": {name}, "key": {key}}
+	req, err := http.NewRequest(http.MethodGet, sr.baseURL+"/v1/secret/value?"+query.Encode(), nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid secret-service URL: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+
+	result := struct {
+		Value   string `json:"value"`
+		Message string `json:"message"`
+	}{}
+	status, err := sendSecretRequest(sr.client, req, &result)
+	if err != nil {
+		return "", fmt.Errorf("could not reach %s: %w", secretServiceSource, err)
+	}
+	switch status {
+	case http.StatusOK:
+		return result.Value, nil
+	case http.StatusNotFound:
+		if strings.Contains(result.Message, ErrSecretKeyNotFound.Error()) {
+			return "", ErrSecretKeyNotFound
+		}
+		return "", fmt.Errorf("%w in %s", ErrSecretNotFound, secretServiceSource)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", fmt.Errorf("access denied by %s: %s", secretServiceSource, result.Message)
+	default:
+		return "", fmt.Errorf("%s responded with status %d: %s", secretServiceSource, status, result.Message)
+	}
+}
+
+// VaultSecretReader reads secrets from a key/value secrets engine (version 2) of a Vault compatible API.
+// The name of a secret is its path within the secrets engine
+type VaultSecretReader struct {
+	address   string
+	mount     string
+	token     string
+	namespace string
+	client    *http.Client
+}
+
+func NewVaultSecretReader(address, mount, token, namespace string) *VaultSecretReader {
+	if mount == "" {
+		mount = DefaultVaultMount
+	}
+	return &VaultSecretReader{
+		address:   strings.TrimSuffix(address, "/"),
+		mount:     strings.Trim(mount, "/"),
+		token:     token,
+		namespace: namespace,
+		client:    &http.Client{Timeout: secretReadTimeout},
+	}
+}
+
+func (sr *VaultSecretReader) ReadSecret(name, key string) (string, error) {
+	path := fmt.Sprintf("/v1/%s/data/%s", sr.mount, strings.Trim(name, "/"))
+	req, err := http.NewRequest(http.MethodGet, sr.address+path, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid vault address: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", sr.token)
+	if sr.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", sr.namespace)
+	}
+
+	result := struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+		Errors []string `json:"errors"`
+	}{}
+	status, err := sendSecretRequest(sr.client, req, &result)
+	if err != nil {
+		return "", fmt.Errorf("could not reach %s: %w", vaultSource, err)
+	}
+	switch status {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", fmt.Errorf("%w in %s at %s", ErrSecretNotFound, vaultSource, path)
+	case http.StatusForbidden:
+		return "", fmt.Errorf("access denied by %s: %s", vaultSource, strings.Join(result.Errors, "; "))
+	default:
+		return "", fmt.Errorf("%s responded with status %d: %s", vaultSource, status, strings.Join(result.Errors, "; "))
+	}
+
+	value, ok := result.Data.Data[key]
+	if !ok {
+		return "", ErrSecretKeyNotFound
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	// values that are not strings are passed on as JSON
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("could not encode value: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// sendSecretRequest sends the request and decodes the JSON response into result, returning the status code of the response
+func sendSecretRequest(client *http.Client, req *http.Request, result interface{}) (int, error) {
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSecretReadSize))
+	if err != nil {
+		return 0, fmt.Errorf("could not read response: %w", err)
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, result); err != nil && resp.StatusCode == http.StatusOK {
+			return 0, errors.New("invalid response: " + err.Error())
+		}
+	}
+	return resp.StatusCode, nil
+}
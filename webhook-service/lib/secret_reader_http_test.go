@@ -0,0 +1,119 @@
+package lib_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+)
+
+// newSecretServiceStandIn serves the secret value endpoint of the secret-service for the service account token my-token
+func newSecretServiceStandIn(t *testing.T, secrets map[string]map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/secret/value", r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer my-token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"code":403,"message":"Unable to read secret: access denied"}`))
+			return
+		}
+		secret, ok := secrets[r.URL.Query().Get("name")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":404,"message":"Unable to read secret: secret not found"}`))
+			return
+		}
+		value, ok := secret[r.URL.Query().Get("key")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":404,"message":"Unable to read secret: key not found in secret"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"value": value})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newVaultStandIn serves secrets of a key/value secrets engine (version 2) mounted at secret/ for the vault token my-vault-token
+func newVaultStandIn(t *testing.T, secrets map[string]map[string]interface{}) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "my-vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		secret, ok := secrets[strings.TrimPrefix(r.URL.Path, "/v1/secret/data/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"data": secret, "metadata": map[string]interface{}{"version": 1}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func writeTokenFile(t *testing.T, token string) string {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.Nil(t, os.WriteFile(tokenFile, []byte(token), 0600))
+	return tokenFile
+}
+
+func TestSecretServiceReader_ReadSecret(t *testing.T) {
+	server := newSecretServiceStandIn(t, map[string]map[string]string{"my-secret": {"token": "my-secret-token"}})
+	secretReader := lib.NewSecretServiceReader(server.URL+"/", writeTokenFile(t, "my-token\n"))
+
+	secret, err := secretReader.ReadSecret("my-secret", "token")
+	require.Nil(t, err)
+	require.Equal(t, "my-secret-token", secret)
+
+	_, err = secretReader.ReadSecret("my-secret", "password")
+	require.ErrorIs(t, err, lib.ErrSecretKeyNotFound)
+
+	_, err = secretReader.ReadSecret("my-missing-secret", "token")
+	require.ErrorIs(t, err, lib.ErrSecretNotFound)
+
+	_, err = lib.NewSecretServiceReader(server.URL, writeTokenFile(t, "other-token")).ReadSecret("my-secret", "token")
+	require.EqualError(t, err, "access denied by secret-service: Unable to read secret: access denied")
+
+	_, err = lib.NewSecretServiceReader(server.URL, filepath.Join(t.TempDir(), "missing")).ReadSecret("my-secret", "token")
+	require.ErrorContains(t, err, "could not read service account token")
+
+	server.Close()
+	_, err = secretReader.ReadSecret("my-secret", "token")
+	require.ErrorContains(t, err, "could not reach secret-service")
+}
+
+func TestVaultSecretReader_ReadSecret(t *testing.T) {
+	server := newVaultStandIn(t, map[string]map[string]interface{}{
+		"webhooks/my-secret": {"token": "my-secret-token", "port": 8080},
+	})
+	secretReader := lib.NewVaultSecretReader(server.URL, "", "my-vault-token", "")
+
+	secret, err := secretReader.ReadSecret("webhooks/my-secret", "token")
+	require.Nil(t, err)
+	require.Equal(t, "my-secret-token", secret)
+
+	secret, err = secretReader.ReadSecret("webhooks/my-secret", "port")
+	require.Nil(t, err)
+	require.Equal(t, "8080", secret)
+
+	_, err = secretReader.ReadSecret("webhooks/my-secret", "password")
+	require.ErrorIs(t, err, lib.ErrSecretKeyNotFound)
+
+	_, err = secretReader.ReadSecret("webhooks/my-missing-secret", "token")
+	require.ErrorIs(t, err, lib.ErrSecretNotFound)
+	require.EqualError(t, err, "secret not found in vault at /v1/secret/data/webhooks/my-missing-secret")
+
+	_, err = lib.NewVaultSecretReader(server.URL, "secret", "invalid-token", "").ReadSecret("webhooks/my-secret", "token")
+	require.EqualError(t, err, "access denied by vault: permission denied")
+}
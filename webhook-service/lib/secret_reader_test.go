@@ -1,6 +1,8 @@
 package lib_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/keptn/keptn/webhook-service/lib"
@@ -25,7 +27,12 @@ func TestK8sSecretReater_ReadSecret(t *testing.T) {
 
 	secret, err = secretReader.ReadSecret("my-missing-secret", "foo")
 
-	require.NotNil(t, err)
+	require.ErrorIs(t, err, lib.ErrSecretNotFound)
+	require.Empty(t, secret)
+
+	secret, err = secretReader.ReadSecret("my-secret", "missing-key")
+
+	require.ErrorIs(t, err, lib.ErrSecretKeyNotFound)
 	require.Empty(t, secret)
 }
 
@@ -41,6 +48,41 @@ func TestK8sSecretReater_ReadSecretWithInvalidScope(t *testing.T) {
 	require.Equal(t, "", secret)
 }
 
+func TestFileSecretReader_ReadSecret(t *testing.T) {
+	directory := t.TempDir()
+	require.Nil(t, os.MkdirAll(filepath.Join(directory, "my-secret"), 0700))
+	require.Nil(t, os.WriteFile(filepath.Join(directory, "my-secret", "token"), []byte("my-token\n"), 0600))
+	secretReader := lib.NewFileSecretReader(directory)
+
+	secret, err := secretReader.ReadSecret("my-secret", "token")
+	require.Nil(t, err)
+	require.Equal(t, "my-token", secret)
+
+	_, err = secretReader.ReadSecret("my-secret", "missing-key")
+	require.ErrorIs(t, err, lib.ErrSecretKeyNotFound)
+
+	_, err = secretReader.ReadSecret("my-missing-secret", "token")
+	require.ErrorIs(t, err, lib.ErrSecretNotFound)
+
+	_, err = secretReader.ReadSecret("..", "my-secret/token")
+	require.EqualError(t, err, "invalid secret reference: name and key must not contain path elements")
+}
+
+func TestEnvSecretReader_ReadSecret(t *testing.T) {
+	t.Setenv("WEBHOOK_SECRET_MY_SECRET_API_TOKEN", "my-token")
+	secretReader := lib.NewEnvSecretReader(lib.DefaultSecretEnvPrefix)
+
+	require.Equal(t, "WEBHOOK_SECRET_MY_SECRET_API_TOKEN", secretReader.EnvVarName("my-secret", "api.token"))
+
+	secret, err := secretReader.ReadSecret("my-secret", "api.token")
+	require.Nil(t, err)
+	require.Equal(t, "my-token", secret)
+
+	_, err = secretReader.ReadSecret("my-secret", "password")
+	require.ErrorIs(t, err, lib.ErrSecretNotFound)
+	require.Contains(t, err.Error(), "WEBHOOK_SECRET_MY_SECRET_PASSWORD")
+}
+
 func getK8sSecret(labels map[string]string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
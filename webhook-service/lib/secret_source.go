@@ -0,0 +1,114 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	SecretSourceKubernetes    = "kubernetes"
+	SecretSourceFile          = "file"
+	SecretSourceEnv           = "env"
+	SecretSourceSecretService = "secret-service"
+	SecretSourceVault         = "vault"
+
+	// DefaultSecretCacheTTL is the time secrets are cached, if no TTL is configured
+	DefaultSecretCacheTTL   = 30 * time.Second
+	DefaultSecretDirectory  = "/keptn/secrets"
+	DefaultSecretServiceURL = "http://secret-service:8080"
+
+	envVarSecretSource       = "SECRET_SOURCE"
+	envVarSecretCacheTTL     = "SECRET_CACHE_TTL"
+	envVarSecretDirectory    = "SECRET_DIRECTORY"
+	envVarSecretEnvPrefix    = "SECRET_ENV_PREFIX"
+	envVarSecretServiceURL   = "SECRET_SERVICE_URL"
+	envVarSecretServiceToken = "SECRET_SERVICE_TOKEN_FILE"
+	envVarVaultAddress       = "VAULT_ADDR"
+	envVarVaultToken         = "VAULT_TOKEN"
+	envVarVaultNamespace     = "VAULT_NAMESPACE"
+	envVarVaultMount         = "VAULT_KV_MOUNT"
+)
+
+// SecretSourceConfig determines where the secrets referenced by webhooks are read from
+type SecretSourceConfig struct {
+	// Source is one of kubernetes, file, env, secret-service or vault
+	Source string
+	// CacheTTL is the time secrets are cached. Caching is disabled if the TTL is 0
+	CacheTTL time.Duration
+
+	Directory string
+	EnvPrefix string
+
+	SecretServiceURL       string
+	SecretServiceTokenFile string
+
+	VaultAddress   string
+	VaultToken     string
+	VaultNamespace string
+	VaultMount     string
+}
+
+// GetSecretSourceConfig returns the secret source configured by the given environment variables
+func GetSecretSourceConfig(env map[string]string) (SecretSourceConfig, error) {
+	config := SecretSourceConfig{
+		Source:                 valueOrDefault(env[envVarSecretSource], SecretSourceKubernetes),
+		CacheTTL:               DefaultSecretCacheTTL,
+		Directory:              valueOrDefault(env[envVarSecretDirectory], DefaultSecretDirectory),
+		EnvPrefix:              valueOrDefault(env[envVarSecretEnvPrefix], DefaultSecretEnvPrefix),
+		SecretServiceURL:       valueOrDefault(env[envVarSecretServiceURL], DefaultSecretServiceURL),
+		SecretServiceTokenFile: valueOrDefault(env[envVarSecretServiceToken], DefaultServiceAccountTokenFile),
+		VaultAddress:           env[envVarVaultAddress],
+		VaultToken:             env[envVarVaultToken],
+		VaultNamespace:         env[envVarVaultNamespace],
+		VaultMount:             valueOrDefault(env[envVarVaultMount], DefaultVaultMount),
+	}
+	if ttl := env[envVarSecretCacheTTL]; ttl != "" {
+		cacheTTL, err := time.ParseDuration(ttl)
+		if err != nil || cacheTTL < 0 {
+			return config, fmt.Errorf("invalid %s '%s': must be a duration like 30s or 0 to disable caching", envVarSecretCacheTTL, ttl)
+		}
+		config.CacheTTL = cacheTTL
+	}
+	return config, nil
+}
+
+// NewSecretReader creates the ISecretReader of the configured secret source. The kubernetes client is only required
+// if secrets are read from kubernetes
+func NewSecretReader(config SecretSourceConfig, kubeClient kubernetes.Interface) (ISecretReader, error) {
+	var reader ISecretReader
+	switch config.Source {
+	case SecretSourceKubernetes:
+		if kubeClient == nil {
+			return nil, errors.New("a kubernetes client is required to read secrets from kubernetes")
+		}
+		reader = NewK8sSecretReader(kubeClient)
+	case SecretSourceFile:
+		reader = NewFileSecretReader(config.Directory)
+	case SecretSourceEnv:
+		reader = NewEnvSecretReader(config.EnvPrefix)
+	case SecretSourceSecretService:
+		reader = NewSecretServiceReader(config.SecretServiceURL, config.SecretServiceTokenFile)
+	case SecretSourceVault:
+		if config.VaultAddress == "" || config.VaultToken == "" {
+			return nil, fmt.Errorf("%s and %s must be set to read secrets from vault", envVarVaultAddress, envVarVaultToken)
+		}
+		reader = NewVaultSecretReader(config.VaultAddress, config.VaultMount, config.VaultToken, config.VaultNamespace)
+	default:
+		return nil, fmt.Errorf("unknown secret source '%s': must be one of %s, %s, %s, %s or %s", config.Source,
+			SecretSourceKubernetes, SecretSourceFile, SecretSourceEnv, SecretSourceSecretService, SecretSourceVault)
+	}
+	if config.CacheTTL > 0 {
+		return NewCachingSecretReader(reader, config.CacheTTL), nil
+	}
+	return reader, nil
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
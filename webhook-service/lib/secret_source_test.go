@@ -0,0 +1,95 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetSecretSourceConfig(t *testing.T) {
+	config, err := GetSecretSourceConfig(map[string]string{})
+	require.Nil(t, err)
+	require.Equal(t, SecretSourceKubernetes, config.Source)
+	require.Equal(t, DefaultSecretCacheTTL, config.CacheTTL)
+
+	config, err = GetSecretSourceConfig(map[string]string{
+		"SECRET_SOURCE":    "vault",
+		"SECRET_CACHE_TTL": "0",
+		"VAULT_ADDR":       "http://vault:8200",
+		"VAULT_TOKEN":      "my-token",
+	})
+	require.Nil(t, err)
+	require.Equal(t, SecretSourceVault, config.Source)
+	require.Equal(t, time.Duration(0), config.CacheTTL)
+	require.Equal(t, "http://vault:8200", config.VaultAddress)
+	require.Equal(t, DefaultVaultMount, config.VaultMount)
+
+	_, err = GetSecretSourceConfig(map[string]string{"SECRET_CACHE_TTL": "1 minute"})
+	require.EqualError(t, err, "invalid SECRET_CACHE_TTL '1 minute': must be a duration like 30s or 0 to disable caching")
+}
+
+func TestNewSecretReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   SecretSourceConfig
+		expected ISecretReader
+		wantErr  string
+	}{
+		{
+			name:     "kubernetes",
+			config:   SecretSourceConfig{Source: SecretSourceKubernetes},
+			expected: &K8sSecretReater{},
+		},
+		{
+			name:     "file",
+			config:   SecretSourceConfig{Source: SecretSourceFile},
+			expected: &FileSecretReader{},
+		},
+		{
+			name:     "env",
+			config:   SecretSourceConfig{Source: SecretSourceEnv},
+			expected: &EnvSecretReader{},
+		},
+		{
+			name:     "secret-service",
+			config:   SecretSourceConfig{Source: SecretSourceSecretService},
+			expected: &SecretServiceReader{},
+		},
+		{
+			name:     "vault",
+			config:   SecretSourceConfig{Source: SecretSourceVault, VaultAddress: "http://vault:8200", VaultToken: "my-token"},
+			expected: &VaultSecretReader{},
+		},
+		{
+			name:     "cached",
+			config:   SecretSourceConfig{Source: SecretSourceEnv, CacheTTL: time.Minute},
+			expected: &CachingSecretReader{},
+		},
+		{
+			name:    "vault without token",
+			config:  SecretSourceConfig{Source: SecretSourceVault, VaultAddress: "http://vault:8200"},
+			wantErr: "VAULT_ADDR and VAULT_TOKEN must be set to read secrets from vault",
+		},
+		{
+			name:    "unknown source",
+			config:  SecretSourceConfig{Source: "aws"},
+			wantErr: "unknown secret source 'aws': must be one of kubernetes, file, env, secret-service or vault",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewSecretReader(tt.config, fake.NewSimpleClientset())
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.IsType(t, tt.expected, reader)
+		})
+	}
+
+	_, err := NewSecretReader(SecretSourceConfig{Source: SecretSourceKubernetes}, nil)
+	require.EqualError(t, err, "a kubernetes client is required to read secrets from kubernetes")
+}
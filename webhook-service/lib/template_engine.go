@@ -2,24 +2,110 @@ package lib
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"text/template"
+	"text/template/parse"
 )
 
 //go:generate moq  -pkg fake -out ./fake/template_engine_mock.go . ITemplateEngine
 type ITemplateEngine interface {
 	ParseTemplate(data interface{}, templateStr string) (string, error)
+	// ParseJSONTemplate renders the template of a JSON document. The output of each action is escaped, so that
+	// it can safely be placed within a JSON string
+	ParseJSONTemplate(data interface{}, templateStr string) (string, error)
 }
 
+// rawJSONFunctions are functions whose output is valid JSON, and hence not escaped within JSON templates
+var rawJSONFunctions = map[string]bool{"toJson": true, "jsonEscape": true}
+
 type TemplateEngine struct{}
 
 func (t *TemplateEngine) ParseTemplate(data interface{}, templateStr string) (string, error) {
-	tmpl, err := template.New("").Option("missingkey=error").Parse(templateStr)
+	tmpl, err := newTemplate(templateStr)
+	if err != nil {
+		return "", err
+	}
+	return executeTemplate(tmpl, data)
+}
+
+func (t *TemplateEngine) ParseJSONTemplate(data interface{}, templateStr string) (string, error) {
+	tmpl, err := newTemplate(templateStr)
 	if err != nil {
 		return "", err
 	}
+	for _, associated := range tmpl.Templates() {
+		escapeJSONActions(associated.Tree.Root)
+	}
+	result, err := executeTemplate(tmpl, data)
+	if err != nil {
+		return "", err
+	}
+	if !json.Valid([]byte(result)) {
+		return "", errors.New("rendered payload is not valid JSON")
+	}
+	return result, nil
+}
+
+// ValidateTemplate checks the syntax of a template, and that it only uses available functions
+func ValidateTemplate(templateStr string) error {
+	_, err := newTemplate(templateStr)
+	return err
+}
+
+func newTemplate(templateStr string) (*template.Template, error) {
+	return template.New("").Option("missingkey=error").Funcs(templateFunctions()).Parse(templateStr)
+}
+
+func executeTemplate(tmpl *template.Template, data interface{}) (string, error) {
 	var tpl bytes.Buffer
 	if err := tmpl.Execute(&tpl, data); err != nil {
 		return "", err
 	}
 	return tpl.String(), nil
 }
+
+// escapeJSONActions appends the jsonEscape function to the pipeline of every action printing a value,
+// similar to the escaping of html/template
+func escapeJSONActions(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			escapeJSONActions(child)
+		}
+	case *parse.ActionNode:
+		// actions declaring variables do not print anything
+		if len(n.Pipe.Decl) > 0 || printsJSON(n.Pipe) {
+			return
+		}
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      n.Pos,
+			Args:     []parse.Node{parse.NewIdentifier("jsonEscape").SetPos(n.Pos)},
+		})
+	case *parse.IfNode:
+		escapeJSONActions(n.List)
+		escapeJSONActions(n.ElseList)
+	case *parse.RangeNode:
+		escapeJSONActions(n.List)
+		escapeJSONActions(n.ElseList)
+	case *parse.WithNode:
+		escapeJSONActions(n.List)
+		escapeJSONActions(n.ElseList)
+	}
+}
+
+func printsJSON(pipe *parse.PipeNode) bool {
+	if len(pipe.Cmds) == 0 {
+		return false
+	}
+	lastCmd := pipe.Cmds[len(pipe.Cmds)-1]
+	if len(lastCmd.Args) == 0 {
+		return false
+	}
+	identifier, ok := lastCmd.Args[0].(*parse.IdentifierNode)
+	return ok && rawJSONFunctions[identifier.Ident]
+}
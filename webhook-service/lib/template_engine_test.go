@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTemplateEngine_ParseTemplate(t1 *testing.T) {
@@ -93,3 +94,88 @@ func TestTemplateEngine_ParseTemplate(t1 *testing.T) {
 		})
 	}
 }
+
+func TestTemplateEngine_ParseTemplate_Functions(t *testing.T) {
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"project": "my-project",
+			"message": `deployment "v2" failed`,
+			"labels": map[string]interface{}{
+				"team": "sre",
+			},
+			"tags":  []interface{}{"a", "b"},
+			"empty": "",
+		},
+		"time": "2022-07-14T10:30:00Z",
+	}
+	tests := []struct {
+		name        string
+		templateStr string
+		want        string
+	}{
+		{name: "upper", templateStr: `{{upper .data.project}}`, want: "MY-PROJECT"},
+		{name: "replace", templateStr: `{{replace "-" "_" .data.project}}`, want: "my_project"},
+		{name: "trimPrefix", templateStr: `{{trimPrefix "my-" .data.project}}`, want: "project"},
+		{name: "join", templateStr: `{{join "," .data.tags}}`, want: "a,b"},
+		{name: "trunc", templateStr: `{{trunc 2 .data.project}}`, want: "my"},
+		{name: "b64enc", templateStr: `{{b64enc "user:pass"}}`, want: "dXNlcjpwYXNz"},
+		{name: "b64dec", templateStr: `{{b64dec "dXNlcjpwYXNz"}}`, want: "user:pass"},
+		{name: "urlquery", templateStr: `{{urlquery .data.message}}`, want: "deployment+%22v2%22+failed"},
+		{name: "urlPathEscape", templateStr: `{{urlPathEscape "a b/c"}}`, want: "a%20b%2Fc"},
+		{name: "toJson", templateStr: `{{toJson .data.labels}}`, want: `{"team":"sre"}`},
+		{name: "jsonEscape", templateStr: `{{jsonEscape .data.message}}`, want: `deployment \"v2\" failed`},
+		{name: "default for empty value", templateStr: `{{default "none" .data.empty}}`, want: "none"},
+		{name: "default for missing label", templateStr: `{{default "none" (get .data.labels "owner")}}`, want: "none"},
+		{name: "dig", templateStr: `{{dig "labels" "team" "none" .data}}`, want: "sre"},
+		{name: "dig missing key", templateStr: `{{dig "annotations" "owner" "none" .data}}`, want: "none"},
+		{name: "get", templateStr: `{{get .data.labels "team"}}`, want: "sre"},
+		{name: "hasKey", templateStr: `{{if hasKey .data.labels "team"}}team {{.data.labels.team}}{{else}}no team{{end}}`, want: "team sre"},
+		{name: "missing key", templateStr: `{{if hasKey .data.labels "owner"}}owner{{else}}no owner{{end}}`, want: "no owner"},
+		{name: "coalesce", templateStr: `{{coalesce .data.empty .data.project}}`, want: "my-project"},
+		{name: "ternary", templateStr: `{{ternary "yes" "no" (contains "my" .data.project)}}`, want: "yes"},
+		{name: "date", templateStr: `{{date "2006-01-02" .time}}`, want: "2022-07-14"},
+		{name: "unix timestamp", templateStr: `{{unixEpoch .time}}`, want: "1657794600"},
+		{name: "sha256sum", templateStr: `{{sha256sum "keptn"}}`, want: "7cbdc2941fa96c43c11f76ac36a505efd488dba0a5302d411b1f82c4063e1a47"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := &lib.TemplateEngine{}
+			got, err := engine.ParseTemplate(data, tt.templateStr)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTemplateEngine_ParseTemplate_NoEnvironmentAccess(t *testing.T) {
+	engine := &lib.TemplateEngine{}
+	for _, templateStr := range []string{`{{env "HOME"}}`, `{{readFile "/etc/passwd"}}`, `{{exec "ls"}}`} {
+		_, err := engine.ParseTemplate(map[string]interface{}{}, templateStr)
+		require.ErrorContains(t, err, "not defined")
+	}
+}
+
+func TestTemplateEngine_ParseJSONTemplate(t *testing.T) {
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"message": "deployment \"v2\" failed\nsee logs",
+			"labels": map[string]interface{}{
+				"team": "sre",
+			},
+		},
+	}
+	engine := &lib.TemplateEngine{}
+
+	got, err := engine.ParseJSONTemplate(data, `{"text": "{{.data.message}}", "labels": {{toJson .data.labels}}{{if hasKey .data.labels "team"}}, "team": "{{.data.labels.team}}"{{end}}}`)
+	require.Nil(t, err)
+	require.Equal(t, `{"text": "deployment \"v2\" failed\nsee logs", "labels": {"team":"sre"}, "team": "sre"}`, got)
+
+	_, err = engine.ParseJSONTemplate(data, `{"text": {{.data.message}}}`)
+	require.EqualError(t, err, "rendered payload is not valid JSON")
+}
+
+func TestValidateTemplate(t *testing.T) {
+	require.Nil(t, lib.ValidateTemplate(`{{default "none" .data.labels.owner | upper}}`))
+	require.ErrorContains(t, lib.ValidateTemplate(`{{.data.project`), "unclosed action")
+	require.ErrorContains(t, lib.ValidateTemplate(`{{env "HOME"}}`), `function "env" not defined`)
+}
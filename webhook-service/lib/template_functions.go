@@ -0,0 +1,235 @@
+package lib
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateFunctions returns the functions available within webhook templates. The function names and argument orders follow
+// the sprig library, but only functions without access to the environment, files or the network are provided
+func templateFunctions() template.FuncMap {
+	return template.FuncMap{
+		// strings
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"splitList":  func(sep, s string) []string { return strings.Split(s, sep) },
+		"join":       join,
+		"trunc":      trunc,
+		"toString":   toString,
+		// encodings
+		"b64enc":        func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+		"b64dec":        b64dec,
+		"urlPathEscape": url.PathEscape,
+		"sha256sum":     func(s string) string { sum := sha256.Sum256([]byte(s)); return hex.EncodeToString(sum[:]) },
+		"toJson":        toJSON,
+		"jsonEscape":    jsonEscape,
+		// defaults and conditionals
+		"default":  defaultValue,
+		"empty":    empty,
+		"coalesce": coalesce,
+		"ternary":  ternary,
+		"required": required,
+		"hasKey":   hasKey,
+		"get":      get,
+		"dig":      dig,
+		// dates
+		"now":       time.Now,
+		"date":      formatDate,
+		"unixEpoch": func(value interface{}) (string, error) { t, err := toTime(value); return fmt.Sprint(t.Unix()), err },
+	}
+}
+
+func join(sep string, list interface{}) string {
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return toString(list)
+	}
+	items := make([]string, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		items[i] = toString(value.Index(i).Interface())
+	}
+	return strings.Join(items, sep)
+}
+
+func trunc(length int, s string) string {
+	if length < 0 || len(s) <= length {
+		return s
+	}
+	return s[:length]
+}
+
+func toString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+func b64dec(s string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("could not decode base64 value: %w", err)
+	}
+	return string(decoded), nil
+}
+
+func toJSON(value interface{}) (string, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", fmt.Errorf("could not encode value as JSON: %w", err)
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+// jsonEscape escapes the string representation of a value, so that it can be placed within a JSON string
+func jsonEscape(value interface{}) (string, error) {
+	encoded, err := toJSON(toString(value))
+	if err != nil {
+		return "", err
+	}
+	return encoded[1 : len(encoded)-1], nil
+}
+
+func defaultValue(defaultVal interface{}, value ...interface{}) interface{} {
+	if len(value) == 0 || empty(value[0]) {
+		return defaultVal
+	}
+	return value[0]
+}
+
+func empty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Array, reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+func coalesce(values ...interface{}) interface{} {
+	for _, value := range values {
+		if !empty(value) {
+			return value
+		}
+	}
+	return nil
+}
+
+func ternary(trueVal interface{}, falseVal interface{}, condition bool) interface{} {
+	if condition {
+		return trueVal
+	}
+	return falseVal
+}
+
+func required(message string, value interface{}) (interface{}, error) {
+	if empty(value) {
+		return nil, errors.New(message)
+	}
+	return value, nil
+}
+
+// hasKey returns true if the map contains the key. In contrast to {{if .map.key}}, this does not fail if the key is missing
+func hasKey(m interface{}, key string) bool {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return false
+	}
+	return v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key())).IsValid()
+}
+
+// get returns the value of the key, or an empty string if the map does not contain the key
+func get(m interface{}, key string) interface{} {
+	if !hasKey(m, key) {
+		return ""
+	}
+	v := reflect.ValueOf(m)
+	return v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key())).Interface()
+}
+
+// dig returns the value of a nested key, e.g. {{dig "labels" "owner" "unknown" .data}}, or the default value if any of the keys is missing
+func dig(args ...interface{}) (interface{}, error) {
+	if len(args) < 3 {
+		return nil, errors.New("dig requires at least one key, a default value and a map")
+	}
+	keys := args[:len(args)-2]
+	defaultVal := args[len(args)-2]
+	current := args[len(args)-1]
+	for _, key := range keys {
+		keyStr, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("dig requires string keys, got %v", key)
+		}
+		if !hasKey(current, keyStr) {
+			return defaultVal, nil
+		}
+		current = get(current, keyStr)
+	}
+	return current, nil
+}
+
+// formatDate formats a time, an RFC3339 timestamp or a unix timestamp using the Go time layout, e.g. 2006-01-02
+func formatDate(layout string, value interface{}) (string, error) {
+	t, err := toTime(value)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(layout), nil
+}
+
+func toTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		return *v, nil
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("could not parse time '%s'", v)
+		}
+		return t, nil
+	case int:
+		return time.Unix(int64(v), 0).UTC(), nil
+	case int64:
+		return time.Unix(v, 0).UTC(), nil
+	case float64:
+		return time.Unix(int64(v), 0).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("could not convert %v to a time", value)
+}
@@ -3,6 +3,9 @@ package lib
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"gopkg.in/yaml.v3"
@@ -31,6 +34,10 @@ type Webhook struct {
 	SendStarted    *bool         `yaml:"sendStarted,omitempty"`
 	EnvFrom        []EnvFrom     `yaml:"envFrom"`
 	Requests       []interface{} `yaml:"requests"`
+	// Async makes the webhook wait for a callback of the called system before sending the .finished event
+	Async *AsyncConfig `yaml:"async,omitempty"`
+	// Conditions must all be met by an event for the webhook to be executed
+	Conditions []Condition `yaml:"conditions,omitempty"`
 }
 
 type EnvFrom struct {
@@ -43,7 +50,33 @@ type Request struct {
 	Method  string   `yaml:"method"`
 	Headers []Header `yaml:"headers,omitempty"`
 	Payload string   `yaml:"payload,omitempty"`
-	Options string   `yaml:"options,omitempty"`
+	// PayloadFile is the URI of a resource of the service containing the payload template. It is loaded at the gitCommitID of the event
+	PayloadFile string `yaml:"payloadFile,omitempty"`
+	// PayloadMode defines how placeholders within the payload are rendered. With PayloadModeJSON, the values are escaped for JSON strings
+	PayloadMode string `yaml:"payloadMode,omitempty"`
+	Options     string `yaml:"options,omitempty"`
+	// Timeout is the maximum duration of the request, e.g. 10s
+	Timeout string `yaml:"timeout,omitempty"`
+	// MaxResponseSize is the maximum size of the response body in bytes
+	MaxResponseSize int64 `yaml:"maxResponseSize,omitempty"`
+	// MaxRedirects is the number of redirects that are followed. By default, redirects are not followed
+	MaxRedirects *int `yaml:"maxRedirects,omitempty"`
+	// Response describes how the response of the request is evaluated, and which of its properties are added to the .finished event
+	Response *ResponseConfig `yaml:"response,omitempty"`
+	// Retry defines if, and how often, the request is repeated if it fails
+	Retry *RetryConfig `yaml:"retry,omitempty"`
+	// Signature makes the webhook service sign the request with an HMAC
+	Signature *SignatureConfig `yaml:"signature,omitempty"`
+	// TLS configures a client certificate and custom certificate authorities for the request
+	TLS *TLSConfig `yaml:"tls,omitempty"`
+	// Conditions must all be met by an event for the request to be executed
+	Conditions []Condition `yaml:"conditions,omitempty"`
+
+	credentials *requestCredentials
+}
+
+func (r Request) String() string {
+	return fmt.Sprintf("%s %s", r.Method, r.URL)
 }
 
 type Header struct {
@@ -57,10 +90,26 @@ type WebHookSecretRef struct {
 }
 
 const webhookConfInvalid = "Webhook configuration invalid: "
+
+const (
+	// PayloadModeText renders the payload as plain text
+	PayloadModeText = "text"
+	// PayloadModeJSON escapes the values of all placeholders within the payload, so that the rendered payload is valid JSON
+	PayloadModeJSON = "json"
+)
 const betaApiVersion = "webhookconfig.keptn.sh/v1beta1"
 const alphaApiVersion = "webhookconfig.keptn.sh/v1alpha1"
 
-var supportedCurlMethods = [4]string{"POST", "PUT", "GET", "HEAD"}
+// supportedHTTPMethods are the methods of v1beta1 requests. CONNECT and TRACE are not supported, since they are not used by APIs
+var supportedHTTPMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
 
 // DecodeWebHookConfigYAML takes a webhook config string formatted as YAML and decodes it to
 // Shipyard value
@@ -91,12 +140,24 @@ func DecodeWebHookConfigYAML(webhookConfigYaml []byte) (*WebHookConfig, error) {
 		if len(webhook.Requests) == 0 {
 			return nil, errors.New(webhookConfInvalid + "missing 'webhooks[].Requests[]' part")
 		}
+
+		if webhook.Async != nil {
+			if err := webhook.Async.validate(); err != nil {
+				return nil, errors.New(webhookConfInvalid + err.Error())
+			}
+		}
+
+		if err := validateConditions(webhook.Conditions); err != nil {
+			return nil, errors.New(webhookConfInvalid + err.Error())
+		}
 	}
 
 	if webHookConfig.ApiVersion == betaApiVersion {
 		if err := normalizeBeta1Requests(webHookConfig.Spec.Webhooks); err != nil {
 			return nil, err
 		}
+	} else if err := verifyAlpha1Requests(webHookConfig.Spec.Webhooks); err != nil {
+		return nil, err
 	}
 
 	return webHookConfig, nil
@@ -104,17 +165,39 @@ func DecodeWebHookConfigYAML(webhookConfigYaml []byte) (*WebHookConfig, error) {
 
 func normalizeBeta1Requests(webhooks []Webhook) error {
 	for i, webhook := range webhooks {
+		envFrom := map[string]bool{}
+		for _, env := range webhook.EnvFrom {
+			envFrom[env.Name] = true
+		}
 		for j, request := range webhook.Requests {
 			convertedRequest := ConvertToRequest(request)
 			if err := verifyBeta1Request(convertedRequest); err != nil {
 				return err
 			}
+			if err := verifyRequestCredentials(convertedRequest, envFrom); err != nil {
+				return err
+			}
 			webhooks[i].Requests[j] = convertedRequest
 		}
 	}
 	return nil
 }
 
+func verifyAlpha1Requests(webhooks []Webhook) error {
+	for _, webhook := range webhooks {
+		for _, request := range webhook.Requests {
+			curlCmd, ok := request.(string)
+			if !ok {
+				continue
+			}
+			if err := ValidateTemplate(curlCmd); err != nil {
+				return fmt.Errorf(webhookConfInvalid+"invalid template in webhook request: %s", err.Error())
+			}
+		}
+	}
+	return nil
+}
+
 func verifyBeta1Request(request Request) error {
 	if request.URL == "" {
 		return fmt.Errorf(webhookConfInvalid + "webhook request URL empty")
@@ -132,11 +215,87 @@ func verifyBeta1Request(request Request) error {
 			}
 		}
 	}
+	if err := verifyRequestTemplates(request); err != nil {
+		return err
+	}
+	if request.Payload != "" && request.PayloadFile != "" {
+		return fmt.Errorf(webhookConfInvalid + "webhook request payload and payloadFile must not be set together")
+	}
+	if isTemplate(request.PayloadFile) {
+		return fmt.Errorf(webhookConfInvalid+"webhook request payloadFile '%s' must not contain template expressions", request.PayloadFile)
+	}
+	if request.PayloadMode != "" && request.PayloadMode != PayloadModeText && request.PayloadMode != PayloadModeJSON {
+		return fmt.Errorf(webhookConfInvalid+"unsupported webhook request payloadMode '%s'", request.PayloadMode)
+	}
+	// options and timeouts containing template expressions can only be checked once they have been rendered
+	if !isTemplate(request.Options) {
+		if err := ValidateRequestOptions(request.Options); err != nil {
+			return fmt.Errorf(webhookConfInvalid+"%s", err.Error())
+		}
+	}
+	if request.Timeout != "" && !isTemplate(request.Timeout) {
+		if timeout, err := time.ParseDuration(request.Timeout); err != nil || timeout <= 0 {
+			return fmt.Errorf(webhookConfInvalid+"invalid webhook request timeout '%s'", request.Timeout)
+		}
+	}
+	if request.MaxResponseSize < 0 {
+		return fmt.Errorf(webhookConfInvalid + "webhook request maxResponseSize must not be negative")
+	}
+	if request.MaxRedirects != nil && *request.MaxRedirects < 0 {
+		return fmt.Errorf(webhookConfInvalid + "webhook request maxRedirects must not be negative")
+	}
+	if request.Response != nil {
+		if err := request.Response.validate(); err != nil {
+			return fmt.Errorf(webhookConfInvalid+"%s", err.Error())
+		}
+	}
+	if request.Retry != nil {
+		if err := request.Retry.validate(); err != nil {
+			return fmt.Errorf(webhookConfInvalid+"%s", err.Error())
+		}
+	}
+	if err := validateConditions(request.Conditions); err != nil {
+		return fmt.Errorf(webhookConfInvalid+"%s", err.Error())
+	}
 	return nil
 }
 
+// verifyRequestCredentials checks that the secrets referenced by the signature and TLS config of a request are defined by the webhook
+func verifyRequestCredentials(request Request, envFrom map[string]bool) error {
+	if request.Signature != nil {
+		if err := request.Signature.validate(envFrom); err != nil {
+			return fmt.Errorf(webhookConfInvalid+"%s", err.Error())
+		}
+	}
+	if request.TLS != nil {
+		if err := request.TLS.validate(envFrom); err != nil {
+			return fmt.Errorf(webhookConfInvalid+"%s", err.Error())
+		}
+	}
+	return nil
+}
+
+func verifyRequestTemplates(request Request) error {
+	names := []string{"url", "payload", "options", "timeout"}
+	templates := []string{request.URL, request.Payload, request.Options, request.Timeout}
+	for i, header := range request.Headers {
+		names = append(names, fmt.Sprintf("headers[%d].key", i), fmt.Sprintf("headers[%d].value", i))
+		templates = append(templates, header.Key, header.Value)
+	}
+	for i, templateStr := range templates {
+		if err := ValidateTemplate(templateStr); err != nil {
+			return fmt.Errorf(webhookConfInvalid+"invalid template in webhook request %s: %s", names[i], err.Error())
+		}
+	}
+	return nil
+}
+
+func isTemplate(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
 func isMethodSupported(method string) bool {
-	for _, m := range supportedCurlMethods {
+	for _, m := range supportedHTTPMethods {
 		if m == method {
 			return true
 		}
@@ -155,6 +314,11 @@ func (wh Webhook) ShouldSendFinishedEvent() bool {
 	return wh.SendFinished
 }
 
+// IsAsync returns true if the .finished event of the webhook is sent once the called system reports its result via a callback
+func (wh Webhook) IsAsync() bool {
+	return wh.Async != nil
+}
+
 func ConvertToRequest(data interface{}) Request {
 	requestStruct := Request{}
 	mapstructure.Decode(data, &requestStruct)
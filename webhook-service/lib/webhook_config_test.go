@@ -7,6 +7,8 @@ import (
 )
 
 func TestDecodeWebHookConfigYAML(t *testing.T) {
+	maxRedirects := 3
+	noNetworkErrors := false
 	type args struct {
 		webhookConfigYaml []byte
 	}
@@ -122,7 +124,10 @@ spec:
         - url: http://localhost:8080/{{.env.secretKey}}
           method: POST
           payload: "some payload"
-          options: "some options"
+          options: "--max-time 5"
+          timeout: 10s
+          maxResponseSize: 1024
+          maxRedirects: 3
           headers:
             - value: "{{.env.secretKey}}"
               key: key`),
@@ -151,10 +156,13 @@ spec:
 											Value: "{{.env.secretKey}}",
 										},
 									},
-									Method:  "POST",
-									Options: "some options",
-									Payload: "some payload",
-									URL:     "http://localhost:8080/{{.env.secretKey}}",
+									Method:          "POST",
+									Options:         "--max-time 5",
+									Payload:         "some payload",
+									URL:             "http://localhost:8080/{{.env.secretKey}}",
+									Timeout:         "10s",
+									MaxResponseSize: 1024,
+									MaxRedirects:    &maxRedirects,
 								},
 							},
 						},
@@ -218,7 +226,7 @@ spec:
           name: mysecret
       requests:
         - url: http://localhost:8080
-          method: DELETE`),
+          method: TRACE`),
 			},
 			want:    nil,
 			wantErr: true,
@@ -309,6 +317,721 @@ spec:
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Beta1 version input - unsupported option",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          options: "--output /tmp/response"`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - invalid timeout",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          timeout: ten seconds`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - negative maxRedirects",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          maxRedirects: -1`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - response config",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          response:
+            expectedStatusCodes: [200, 201]
+            assertions:
+              - path: $.status
+                equals: SUCCESS
+                onFailure: warning
+            extract:
+              - name: buildId
+                path: $.build.id
+                type: string`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Requests: []interface{}{
+								Request{
+									Method: "POST",
+									URL:    "http://localhost:8080",
+									Response: &ResponseConfig{
+										ExpectedStatusCodes: []int{200, 201},
+										Assertions: []ResponseAssertion{
+											{Path: "$.status", Equals: "SUCCESS", OnFailure: "warning"},
+										},
+										Extract: []ResponseExtraction{
+											{Name: "buildId", Path: "$.build.id", Type: "string"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Beta1 version input - invalid response assertion",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          response:
+            assertions:
+              - path: status
+                equals: SUCCESS`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - invalid response extraction type",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          response:
+            extract:
+              - name: buildId
+                path: $.build.id
+                type: date`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - signature and tls",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      envFrom:
+        - name: signingKey
+          secretRef:
+            name: my-secret
+            key: signing-key
+        - name: clientCert
+          secretRef:
+            name: my-tls-secret
+            key: tls.crt
+        - name: clientKey
+          secretRef:
+            name: my-tls-secret
+            key: tls.key
+      requests:
+        - url: https://localhost:8443
+          method: POST
+          signature:
+            secret: signingKey
+            algorithm: sha512
+          tls:
+            clientCert: clientCert
+            clientKey: clientKey`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							EnvFrom: []EnvFrom{
+								{Name: "signingKey", SecretRef: WebHookSecretRef{Name: "my-secret", Key: "signing-key"}},
+								{Name: "clientCert", SecretRef: WebHookSecretRef{Name: "my-tls-secret", Key: "tls.crt"}},
+								{Name: "clientKey", SecretRef: WebHookSecretRef{Name: "my-tls-secret", Key: "tls.key"}},
+							},
+							Requests: []interface{}{
+								Request{
+									Method:    "POST",
+									URL:       "https://localhost:8443",
+									Signature: &SignatureConfig{Secret: "signingKey", Algorithm: "sha512"},
+									TLS:       &TLSConfig{ClientCert: "clientCert", ClientKey: "clientKey"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Beta1 version input - signature secret not in envFrom",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          signature:
+            secret: signingKey`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - tls client certificate without key",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      envFrom:
+        - name: clientCert
+          secretRef:
+            name: my-tls-secret
+            key: tls.crt
+      requests:
+        - url: https://localhost:8443
+          method: POST
+          tls:
+            clientCert: clientCert`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - json payload mode",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          payload: '{"text": "{{.data.message}}"}'
+          payloadMode: json`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Requests: []interface{}{
+								Request{
+									Method:      "POST",
+									URL:         "http://localhost:8080",
+									Payload:     `{"text": "{{.data.message}}"}`,
+									PayloadMode: "json",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Beta1 version input - unsupported payload mode",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          payloadMode: xml`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - invalid payload template",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          payload: '{"text": "{{.data.message"}'`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - unknown template function in header",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          headers:
+            - key: x-token
+              value: '{{env "TOKEN"}}'`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Alpha1 version input - invalid template",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - "curl http://localhost:8080 {{.data.project}"`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - retry",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          retry:
+            attempts: 5
+            backoff: 2s
+            maxBackoff: 1m
+            statusCodes: [502, 503]
+            networkErrors: false
+            idempotencyHeader: Idempotency-Key`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Requests: []interface{}{
+								Request{
+									Method: "POST",
+									URL:    "http://localhost:8080",
+									Retry: &RetryConfig{
+										Attempts:          5,
+										Backoff:           "2s",
+										MaxBackoff:        "1m",
+										StatusCodes:       []int{502, 503},
+										NetworkErrors:     &noNetworkErrors,
+										IdempotencyHeader: "Idempotency-Key",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Beta1 version input - invalid retry attempts",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          retry:
+            attempts: 100`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - invalid retry backoff",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          retry:
+            backoff: soon`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - async",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      async:
+        deadline: 30m
+      requests:
+        - url: http://localhost:8080
+          method: POST`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Async:          &AsyncConfig{Deadline: "30m"},
+							Requests: []interface{}{
+								Request{
+									Method: "POST",
+									URL:    "http://localhost:8080",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Beta1 version input - invalid async deadline",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      async:
+        deadline: tomorrow
+      requests:
+        - url: http://localhost:8080
+          method: POST`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - DELETE and PATCH with payload file",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080/tickets/1
+          method: DELETE
+        - url: http://localhost:8080/deployments/1
+          method: PATCH
+          payloadFile: webhook/patch.json
+          payloadMode: json`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Requests: []interface{}{
+								Request{
+									Method: "DELETE",
+									URL:    "http://localhost:8080/tickets/1",
+								},
+								Request{
+									Method:      "PATCH",
+									URL:         "http://localhost:8080/deployments/1",
+									PayloadFile: "webhook/patch.json",
+									PayloadMode: PayloadModeJSON,
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Beta1 version input - payload and payload file",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          payload: "{}"
+          payloadFile: webhook/payload.json`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - templated payload file",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          payloadFile: "webhook/{{.data.stage}}.json"`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - conditions",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.evaluation.finished"
+      subscriptionID: "my-subscription-id"
+      conditions:
+        - path: $.data.result
+          equals: fail
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          conditions:
+            - path: $.data.stage
+              in: [production, hardening]`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.evaluation.finished",
+							SubscriptionID: "my-subscription-id",
+							Conditions:     []Condition{{Path: "$.data.result", Equals: "fail"}},
+							Requests: []interface{}{
+								Request{
+									Method:     "POST",
+									URL:        "http://localhost:8080",
+									Conditions: []Condition{{Path: "$.data.stage", In: []interface{}{"production", "hardening"}}},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Beta1 version input - invalid condition",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: POST
+          conditions:
+            - path: $.data.result
+              equals: fail
+              notEquals: pass`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Alpha1 version input - invalid webhook condition",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      conditions:
+        - path: data.result
+          equals: fail
+      requests:
+        - "curl http://localhost:8080"`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "invalid input",
 			args: args{
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"fmt"
+	"net/http"
 	"os"
 
+	keptnmongoutils "github.com/keptn/go-utils/pkg/common/mongoutils"
 	"github.com/keptn/go-utils/pkg/sdk"
 	"github.com/keptn/keptn/webhook-service/handler"
 	"github.com/keptn/keptn/webhook-service/lib"
@@ -14,6 +17,9 @@ import (
 const eventTypeWildcard = "*"
 const serviceName = "webhook-service"
 const envVarLogLevel = "LOG_LEVEL"
+const envVarCallbackPort = "CALLBACK_PORT"
+const envVarCallbackBaseURL = "CALLBACK_BASE_URL"
+const defaultCallbackPort = "8081"
 
 func main() {
 	if os.Getenv(envVarLogLevel) != "" {
@@ -24,11 +30,27 @@ func main() {
 			log.SetLevel(logLevel)
 		}
 	}
-	kubeAPI, err := createKubeAPI()
+	secretSourceConfig, err := lib.GetSecretSourceConfig(lib.GetEnv())
 	if err != nil {
+		log.Fatalf("invalid secret source configuration: %s", err.Error())
+	}
+
+	// the kubernetes client is only required if secrets are read from kubernetes, so that the webhook service
+	// can also be run outside of a cluster
+	var kubeAPI kubernetes.Interface
+	if clientset, err := createKubeAPI(); err == nil {
+		kubeAPI = clientset
+	} else if secretSourceConfig.Source == lib.SecretSourceKubernetes {
 		log.Fatalf("could not create kubernetes client: %s", err.Error())
+	} else {
+		log.Warnf("could not create kubernetes client, the deny list of the %s ConfigMap is not applied: %s", lib.WebhookConfigMap, err.Error())
+	}
+
+	secretReader, err := lib.NewSecretReader(secretSourceConfig, kubeAPI)
+	if err != nil {
+		log.Fatalf("could not create secret reader: %s", err.Error())
 	}
-	secretReader := lib.NewK8sSecretReader(kubeAPI)
+	log.Infof("reading secrets from %s", secretSourceConfig.Source)
 
 	curlExecutor := lib.NewCmdCurlExecutor(
 		&lib.OSCmdExecutor{},
@@ -37,9 +59,11 @@ func main() {
 	ipResolver := lib.NewIPResolver()
 	denyListProvider := lib.NewDenyListProvider(kubeAPI)
 	requestValidator := lib.NewRequestValidator(denyListProvider, ipResolver)
-	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, curlExecutor, requestValidator, secretReader)
+	httpExecutor := lib.NewHTTPExecutor(requestValidator)
+	callbackRegistry := lib.NewCallbackRegistry(getCallbackBaseURL(), createCallbackStore())
+	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, curlExecutor, httpExecutor, requestValidator, secretReader, handler.WithCallbackRegistry(callbackRegistry))
 
-	log.Fatal(sdk.NewKeptn(
+	keptn := sdk.NewKeptn(
 		serviceName,
 		sdk.WithTaskHandler(
 			eventTypeWildcard,
@@ -47,7 +71,47 @@ func main() {
 		),
 		sdk.WithAutomaticResponse(false),
 		sdk.WithLogger(log.StandardLogger()),
-	).Start())
+	)
+
+	if err := taskHandler.StartCallbacks(keptn); err != nil {
+		log.Fatalf("could not start callbacks of async webhooks: %s", err.Error())
+	}
+
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle(lib.CallbackPath, handler.NewCallbackHandler(callbackRegistry))
+		mux.Handle(handler.DryRunPath, handler.NewDryRunHandler(taskHandler, keptn.GetResourceHandler()))
+		if err := http.ListenAndServe(":"+getCallbackPort(), mux); err != nil {
+			log.WithError(err).Error("http endpoint stopped")
+		}
+	}()
+
+	log.Fatal(keptn.Start())
+}
+
+func getCallbackPort() string {
+	if port := os.Getenv(envVarCallbackPort); port != "" {
+		return port
+	}
+	return defaultCallbackPort
+}
+
+// getCallbackBaseURL returns the URL under which the callback endpoint can be reached by the systems called by async webhooks
+func getCallbackBaseURL() string {
+	if baseURL := os.Getenv(envVarCallbackBaseURL); baseURL != "" {
+		return baseURL
+	}
+	return fmt.Sprintf("http://%s.%s:%s", serviceName, lib.GetNamespaceFromEnvVar(), getCallbackPort())
+}
+
+// createCallbackStore returns the store of the pending callbacks of async webhooks, which are kept in MongoDB if it is
+// configured, so that the webhook service can also be run without MongoDB
+func createCallbackStore() lib.CallbackStore {
+	if _, _, err := keptnmongoutils.GetMongoConnectionStringFromEnv(); err != nil {
+		log.Warnf("pending callbacks of async webhooks are kept in memory and are lost on restarts: %s", err.Error())
+		return lib.NewInMemoryCallbackStore()
+	}
+	return lib.NewMongoDBCallbackStore()
 }
 
 func createKubeAPI() (*kubernetes.Clientset, error) {